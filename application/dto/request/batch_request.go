@@ -0,0 +1,41 @@
+package request
+
+import "github.com/google/uuid"
+
+// Operations a BatchRequest can carry
+const (
+	BatchCreateEntry    = "create_entry"
+	BatchAddMeaning     = "add_meaning"
+	BatchAddTranslation = "add_translation"
+	BatchLike           = "like"
+)
+
+// BatchRequest contains operations that are applied in order and all together,
+// or not at all
+type BatchRequest struct {
+	Operations []BatchOperation `json:"operations" binding:"required,min=1,dive"`
+	UserID     uuid.UUID        `json:"-"` // Set from authentication context, not from client
+}
+
+// BatchOperation is one mutation of a BatchRequest. Op selects which of the
+// other fields apply. An ID field takes either a UUID or "$" followed by the
+// ref of an earlier operation, whose created ID it then stands for
+type BatchOperation struct {
+	Op  string `json:"op" binding:"required,oneof=create_entry add_meaning add_translation like"`
+	Ref string `json:"ref" binding:"omitempty,max=64"` // Name later operations use for the created ID
+
+	// create_entry
+	Entry *CreateEntryRequest `json:"entry,omitempty"`
+
+	// add_meaning
+	EntryID string                `json:"entry_id,omitempty"`
+	Meaning *CreateMeaningRequest `json:"meaning,omitempty"`
+
+	// add_translation
+	MeaningID   string                    `json:"meaning_id,omitempty"`
+	Translation *CreateTranslationRequest `json:"translation,omitempty"`
+
+	// like
+	TargetType string `json:"target_type,omitempty" binding:"omitempty,oneof=meaning translation"`
+	TargetID   string `json:"target_id,omitempty"`
+}
@@ -4,16 +4,20 @@ import "github.com/google/uuid"
 
 // CreateEntryRequest contains data for creating a new dictionary entry
 type CreateEntryRequest struct {
-	Word          string `json:"word" binding:"required"`
-	Type          string `json:"type" binding:"required,oneof=WORD COMPOUND_WORD PHRASE"`
-	Pronunciation string `json:"pronunciation"`
+	Word             string `json:"word" binding:"required"`
+	Type             string `json:"type" binding:"required,oneof=WORD COMPOUND_WORD PHRASE"`
+	SourceLanguageID string `json:"source_language_id" binding:"omitempty,min=2,max=5"` // ISO 639-1 code
+	HomographIndex   int    `json:"homograph_index" binding:"omitempty,min=1"`          // Assigned automatically when omitted
+	Pronunciation    string `json:"pronunciation"`
 }
 
 // UpdateEntryRequest contains data for updating an existing dictionary entry
 type UpdateEntryRequest struct {
-	Word          string `json:"word"`
-	Type          string `json:"type" binding:"omitempty,oneof=WORD COMPOUND_WORD PHRASE"`
-	Pronunciation string `json:"pronunciation"`
+	Word           string `json:"word"`
+	Type           string `json:"type" binding:"omitempty,oneof=WORD COMPOUND_WORD PHRASE"`
+	HomographIndex int    `json:"homograph_index" binding:"omitempty,min=1"`
+	Pronunciation  string `json:"pronunciation"`
+	Version        int    `json:"-"` // Expected version from If-Match, 0 skips the check
 }
 
 // ListEntriesRequest contains filtering and pagination parameters
@@ -24,6 +28,9 @@ type ListEntriesRequest struct {
 	SortDesc   bool   `json:"sort_desc" form:"sort_desc"`
 	WordFilter string `json:"word_filter" form:"word_filter"`
 	Type       string `json:"type" form:"type" binding:"omitempty,oneof=WORD COMPOUND_WORD PHRASE"`
+	// AfterID pages by key instead of offset for exports: entries come in ID
+	// order, starting after this ID, or from the first when it is uuid.Nil
+	AfterID *uuid.UUID `json:"-" form:"-"`
 }
 
 // CreateMeaningRequest contains data for adding a new meaning to an entry
@@ -31,6 +38,7 @@ type CreateMeaningRequest struct {
 	PartOfSpeechID uuid.UUID `json:"part_of_speech_id" binding:"required"`
 	Description    string    `json:"description" binding:"required"`
 	Examples       []string  `json:"examples"`
+	Labels         []string  `json:"labels" binding:"omitempty,max=8,dive,min=1,max=30"` // Usage labels, e.g. "formal"
 }
 
 // UpdateMeaningRequest contains data for updating a meaning
@@ -38,6 +46,8 @@ type UpdateMeaningRequest struct {
 	PartOfSpeechID uuid.UUID `json:"part_of_speech_id"`
 	Description    string    `json:"description"`
 	Examples       []string  `json:"examples"`
+	Labels         []string  `json:"labels" binding:"omitempty,max=8,dive,min=1,max=30"` // Replaces the labels when given
+	Version        int       `json:"-"`                                                  // Expected version from If-Match, 0 skips the check
 }
 
 // CreateCommentRequest contains data for creating a comment
@@ -51,3 +61,17 @@ type FeedRequest struct {
 	Limit  int    `json:"limit" form:"limit" binding:"omitempty,min=1,max=50"`
 	Cursor string `json:"cursor" form:"cursor"` // For cursor-based pagination
 }
+
+// DuplicateReportRequest contains parameters for the duplicate entry report
+type DuplicateReportRequest struct {
+	Threshold  float64 `json:"threshold" form:"threshold" binding:"omitempty,gt=0,lte=1"` // Minimum similarity for near duplicates
+	Type       string  `json:"type" form:"type" binding:"omitempty,oneof=WORD COMPOUND_WORD PHRASE"`
+	LanguageID string  `json:"language_id" form:"language_id" binding:"omitempty,min=2,max=5"`
+	Limit      int     `json:"limit" form:"limit" binding:"omitempty,min=1,max=500"`
+}
+
+// MergeEntriesRequest contains data for merging one entry into another
+type MergeEntriesRequest struct {
+	TargetID uuid.UUID `json:"target_id" binding:"required"`
+	UserID   uuid.UUID `json:"-"` // Set from authentication context, not from client
+}
@@ -0,0 +1,42 @@
+package request
+
+import "github.com/google/uuid"
+
+// EntryTreeRequest contains a dictionary entry with everything beneath it. On
+// creation no IDs are given; on replacement children carrying an ID are
+// updated, those without one are created and stored ones left out are deleted
+type EntryTreeRequest struct {
+	Word             string               `json:"word" binding:"required"`
+	Type             string               `json:"type" binding:"required,oneof=WORD COMPOUND_WORD PHRASE"`
+	SourceLanguageID string               `json:"source_language_id" binding:"omitempty,min=2,max=5"` // ISO 639-1 code
+	HomographIndex   int                  `json:"homograph_index" binding:"omitempty,min=1"`          // Assigned automatically when omitted
+	Pronunciation    string               `json:"pronunciation"`
+	Etymology        string               `json:"etymology"`
+	Meanings         []MeaningTreeRequest `json:"meanings" binding:"omitempty,max=50,dive"`
+	UserID           uuid.UUID            `json:"-"` // Set from authentication context, not from client
+	Version          int                  `json:"-"` // Expected version from If-Match when replacing, 0 skips the check
+}
+
+// MeaningTreeRequest contains a meaning of an EntryTreeRequest
+type MeaningTreeRequest struct {
+	ID             uuid.UUID                `json:"id"` // Omitted for a new meaning
+	PartOfSpeechID uuid.UUID                `json:"part_of_speech_id" binding:"required"`
+	Description    string                   `json:"description" binding:"required"`
+	Labels         []string                 `json:"labels" binding:"omitempty,max=8,dive,min=1,max=30"`
+	Examples       []ExampleTreeRequest     `json:"examples" binding:"omitempty,max=20,dive"`
+	Translations   []TranslationTreeRequest `json:"translations" binding:"omitempty,max=50,dive"`
+}
+
+// ExampleTreeRequest contains a usage example of a MeaningTreeRequest
+type ExampleTreeRequest struct {
+	ID      uuid.UUID `json:"id"` // Omitted for a new example
+	Text    string    `json:"text" binding:"required"`
+	Context string    `json:"context"`
+}
+
+// TranslationTreeRequest contains a translation of a MeaningTreeRequest
+type TranslationTreeRequest struct {
+	ID         uuid.UUID `json:"id"` // Omitted for a new translation
+	LanguageID string    `json:"language_id" binding:"required,min=2,max=5"`
+	Text       string    `json:"text" binding:"required"`
+}
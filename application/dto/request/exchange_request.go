@@ -0,0 +1,36 @@
+package request
+
+// ImportRequest contains options for a bulk import
+type ImportRequest struct {
+	BatchSize  int `json:"batch_size" form:"batch_size" binding:"omitempty,min=1,max=5000"`
+	ResumeFrom int `json:"resume_from" form:"resume_from" binding:"omitempty,min=0"` // Checkpoint of an earlier, interrupted run
+
+	// OnCheckpoint is called after every committed batch with the number of
+	// source records dealt with so far. Returning an error stops the import
+	OnCheckpoint func(checkpoint int) error `json:"-" form:"-"`
+}
+
+// ExportRequest selects the entries included in an export
+type ExportRequest struct {
+	Type       string `json:"type" form:"type" binding:"omitempty,oneof=WORD COMPOUND_WORD PHRASE"`
+	LanguageID string `json:"language_id" form:"language_id" binding:"omitempty,min=2,max=5"` // Source language of the entries
+
+	// TargetLanguageID restricts translations to one language and leaves out
+	// entries that have none in it
+	TargetLanguageID string `json:"target_language_id" form:"target_language_id" binding:"omitempty,min=2,max=5"`
+
+	// Label restricts the export to meanings with this usage label
+	Label string `json:"label" form:"label" binding:"omitempty,max=30"`
+
+	// Words restricts the export to a word list, such as the words a user is
+	// studying
+	Words []string `json:"words" form:"word" binding:"omitempty,max=1000,dive,min=1,max=100"`
+}
+
+// AnkiExportRequest selects the entries of an Anki deck and how its cards
+// look
+type AnkiExportRequest struct {
+	ExportRequest
+	Deck  string   `json:"deck" form:"deck" binding:"omitempty,max=100"`
+	Cards []string `json:"cards" form:"card" binding:"omitempty,dive,oneof=forward reverse"`
+}
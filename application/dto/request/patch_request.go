@@ -0,0 +1,45 @@
+package request
+
+import "github.com/google/uuid"
+
+// Media types of the patch documents accepted by PATCH
+const (
+	MergePatchMediaType = "application/merge-patch+json" // RFC 7396
+	JSONPatchMediaType  = "application/json-patch+json"  // RFC 6902
+)
+
+// PatchRequest contains a patch document for an entry, meaning or translation.
+// The patch is applied to the resource as EntryPatch, MeaningPatch or
+// TranslationPatch, and the result is validated before it is saved
+type PatchRequest struct {
+	MediaType string    `json:"-"` // MergePatchMediaType or JSONPatchMediaType
+	Patch     []byte    `json:"-"`
+	UserID    uuid.UUID `json:"-"` // Set from authentication context, not from client
+	Version   int       `json:"-"` // Expected version from If-Match, 0 skips the check
+}
+
+// EntryPatch is the document a patch of an entry applies to. Unlike
+// UpdateEntryRequest, an empty string clears a field
+type EntryPatch struct {
+	Word             string `json:"word" binding:"required"`
+	Type             string `json:"type" binding:"required,oneof=WORD COMPOUND_WORD PHRASE"`
+	SourceLanguageID string `json:"source_language_id" binding:"omitempty,min=2,max=5"` // ISO 639-1 code
+	HomographIndex   int    `json:"homograph_index" binding:"min=1"`
+	Pronunciation    string `json:"pronunciation"`
+	Etymology        string `json:"etymology"`
+}
+
+// MeaningPatch is the document a patch of a meaning applies to. Examples
+// keep their IDs, so a single example can be changed by its position
+type MeaningPatch struct {
+	PartOfSpeechID uuid.UUID            `json:"part_of_speech_id" binding:"required"`
+	Description    string               `json:"description" binding:"required"`
+	Labels         []string             `json:"labels" binding:"omitempty,max=8,dive,min=1,max=30"`
+	Examples       []ExampleTreeRequest `json:"examples" binding:"omitempty,max=20,dive"`
+}
+
+// TranslationPatch is the document a patch of a translation applies to
+type TranslationPatch struct {
+	LanguageID string `json:"language_id" binding:"required,min=2,max=5"`
+	Text       string `json:"text" binding:"required"`
+}
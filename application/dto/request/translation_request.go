@@ -4,13 +4,16 @@ import "github.com/google/uuid"
 
 // CreateTranslationRequest contains data for creating a new translation
 type CreateTranslationRequest struct {
-	LanguageID string `json:"language_id" binding:"required,min=2,max=5"` // ISO 639-1 code
-	Text       string `json:"text" binding:"required"`
+	LanguageID string     `json:"language_id" binding:"required,min=2,max=5"` // ISO 639-1 code
+	Text       string     `json:"text" binding:"required"`
+	ReplacesID *uuid.UUID `json:"replaces_id,omitempty"` // Approved translation this proposal should supersede
+	UserID     uuid.UUID  `json:"-"`                     // Set from authentication context, not from client
 }
 
 // UpdateTranslationRequest contains data for updating an existing translation
 type UpdateTranslationRequest struct {
-	Text string `json:"text" binding:"required"`
+	Text    string `json:"text" binding:"required"`
+	Version int    `json:"-"` // Expected version from If-Match, 0 skips the check
 }
 
 // ListTranslationsRequest contains filtering and pagination parameters for translations
@@ -31,3 +34,23 @@ type TranslationCommentRequest struct {
 type TranslationLikeRequest struct {
 	UserID uuid.UUID `json:"-"` // Set from authentication context, not from client
 }
+
+// ReviewQueueRequest contains filtering and pagination parameters for the review queue
+type ReviewQueueRequest struct {
+	Limit      int    `json:"limit" form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset     int    `json:"offset" form:"offset" binding:"omitempty,min=0"`
+	LanguageID string `json:"language_id" form:"language_id" binding:"omitempty,min=2,max=5"`
+}
+
+// ReviewDecisionRequest contains data for approving or rejecting a translation
+type ReviewDecisionRequest struct {
+	Reason     string    `json:"reason" binding:"max=1000"`
+	ReviewerID uuid.UUID `json:"-"` // Set from authentication context, not from client
+}
+
+// ListNotificationsRequest contains pagination parameters for user notifications
+type ListNotificationsRequest struct {
+	Limit      int  `json:"limit" form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset     int  `json:"offset" form:"offset" binding:"omitempty,min=0"`
+	UnreadOnly bool `json:"unread_only" form:"unread_only"`
+}
@@ -0,0 +1,19 @@
+package response
+
+import "github.com/google/uuid"
+
+// BatchResponse represents the outcome of an applied batch, one result per
+// operation in request order
+type BatchResponse struct {
+	Results []BatchOperationResult `json:"results"`
+}
+
+// BatchOperationResult represents the outcome of one batch operation
+type BatchOperationResult struct {
+	Index   int        `json:"index"`
+	Op      string     `json:"op"`
+	Ref     string     `json:"ref,omitempty"`
+	ID      uuid.UUID  `json:"id"`                 // Entry, meaning, translation or like the operation created
+	EntryID *uuid.UUID `json:"entry_id,omitempty"` // Entry the operation changed, unless it was a like
+	Created bool       `json:"created"`            // False for a like the user had already given
+}
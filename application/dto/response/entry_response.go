@@ -11,8 +11,15 @@ type EntryResponse struct {
 	ID            uuid.UUID        `json:"id"`
 	Word          string           `json:"word"`
 	Type          string           `json:"type"`
+	SourceLanguageID string        `json:"source_language_id,omitempty"`
+	HomographIndex int             `json:"homograph_index"`
+	DisplayWord   string           `json:"display_word"` // Word with its homograph index when it has homographs, e.g. "bank²"
 	Pronunciation string           `json:"pronunciation,omitempty"`
+	Etymology     string           `json:"etymology,omitempty"`
+	Source        string           `json:"source,omitempty"`  // Dataset an imported entry came from
+	License       string           `json:"license,omitempty"` // Licence of that dataset, for attribution
 	Meanings      []MeaningResponse `json:"meanings,omitempty"`
+	Version       int              `json:"version"` // Also sent as the ETag of the entry
 	CreatedAt     time.Time        `json:"created_at"`
 	UpdatedAt     time.Time        `json:"updated_at"`
 }
@@ -31,19 +38,23 @@ type MeaningResponse struct {
 	EntryID        uuid.UUID             `json:"entry_id"`
 	PartOfSpeech   string                `json:"part_of_speech"`
 	Description    string                `json:"description"`
+	Labels         []string              `json:"labels,omitempty"`
 	Examples       []ExampleResponse      `json:"examples,omitempty"`
 	Translations   []TranslationResponse  `json:"translations,omitempty"`
 	Comments       []CommentResponse      `json:"comments,omitempty"`
 	LikesCount     int                   `json:"likes_count"`
 	CurrentUserLiked bool                `json:"current_user_liked,omitempty"`
+	Version        int                   `json:"version"`
 	CreatedAt      time.Time             `json:"created_at"`
 	UpdatedAt      time.Time             `json:"updated_at"`
 }
 
 // MeaningListResponse represents a list of meanings
 type MeaningListResponse struct {
-	Meanings []*MeaningResponse `json:"meanings"`
-	Total    int                `json:"total"`
+	Meanings       []*MeaningResponse `json:"meanings"`
+	Total          int                `json:"total"`
+	EntryVersion   int                `json:"entry_version"` // The list changes only with its entry
+	EntryUpdatedAt time.Time          `json:"entry_updated_at"`
 }
 
 // ExampleResponse represents a usage example in API responses
@@ -85,3 +96,29 @@ type FeedItem struct {
 	User      UserSummary  `json:"user"`
 	Timestamp time.Time    `json:"timestamp"`
 }
+
+// EntrySummary represents a compact version of an entry for embedding in reports
+type EntrySummary struct {
+	ID               uuid.UUID `json:"id"`
+	Word             string    `json:"word"`
+	Type             string    `json:"type"`
+	SourceLanguageID string    `json:"source_language_id,omitempty"`
+	HomographIndex   int       `json:"homograph_index"`
+	DisplayWord      string    `json:"display_word"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// DuplicateGroupResponse represents a set of entries that look like the same headword
+type DuplicateGroupResponse struct {
+	Reason  string         `json:"reason"` // "exact" or "similar"
+	Score   float64        `json:"score"`
+	Entries []EntrySummary `json:"entries"`
+}
+
+// DuplicateReportResponse represents the duplicate entry report
+type DuplicateReportResponse struct {
+	Groups    []DuplicateGroupResponse `json:"groups"`
+	Total     int                      `json:"total"`
+	Threshold float64                  `json:"threshold"`
+	Scanned   int                      `json:"scanned"`
+}
@@ -0,0 +1,25 @@
+package response
+
+// ImportReport summarises the outcome of a bulk import
+type ImportReport struct {
+	Processed       int              `json:"processed"` // Records read after the resume point
+	Imported        int              `json:"imported"`
+	Failed          int              `json:"failed"`
+	Skipped         int              `json:"skipped"`    // Records before the resume point
+	Checkpoint      int              `json:"checkpoint"` // Pass as resume_from to continue an interrupted import
+	Completed       bool             `json:"completed"`
+	Errors          []ImportRowError `json:"errors,omitempty"`
+	ErrorsTruncated bool             `json:"errors_truncated,omitempty"`
+}
+
+// ImportRowError describes why a record of the import source was rejected
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// ExportReport summarises a finished export
+type ExportReport struct {
+	Exported int `json:"exported"`
+}
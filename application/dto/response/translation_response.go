@@ -15,17 +15,23 @@ type TranslationResponse struct {
 	Comments       []CommentResponse  `json:"comments,omitempty"`
 	LikesCount     int                `json:"likes_count"`
 	CurrentUserLiked bool             `json:"current_user_liked,omitempty"`
+	Version        int                `json:"version"`
 	CreatedAt      time.Time          `json:"created_at"`
 	UpdatedAt      time.Time          `json:"updated_at"`
 	CreatedBy      *UserSummary       `json:"created_by,omitempty"` // Translation creator
+	Status         string             `json:"status,omitempty"`
+	ReviewReason   string             `json:"review_reason,omitempty"`
+	ReviewedAt     *time.Time         `json:"reviewed_at,omitempty"`
 }
 
 // TranslationListResponse represents a paginated list of translations
 type TranslationListResponse struct {
-	Translations []*TranslationResponse `json:"translations"`
-	Total        int                    `json:"total"`
-	Limit        int                    `json:"limit"`
-	Offset       int                    `json:"offset"`
+	Translations     []*TranslationResponse `json:"translations"`
+	Total            int                    `json:"total"`
+	Limit            int                    `json:"limit"`
+	Offset           int                    `json:"offset"`
+	MeaningVersion   int                    `json:"meaning_version,omitempty"` // Set when the list holds the translations of one meaning
+	MeaningUpdatedAt *time.Time             `json:"meaning_updated_at,omitempty"`
 }
 
 // TranslationSummary represents a compact version of translation for embedding in other responses
@@ -43,3 +49,22 @@ type LanguageInfo struct {
 	NativeName string `json:"native_name"` // Name in the language itself
 	RTL       bool   `json:"rtl"`       // Right-to-left writing
 }
+
+// NotificationResponse represents a user notification in API responses
+type NotificationResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	Type       string     `json:"type"`
+	TargetType string     `json:"target_type"`
+	TargetID   uuid.UUID  `json:"target_id"`
+	Message    string     `json:"message"`
+	ReadAt     *time.Time `json:"read_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// NotificationListResponse represents a paginated list of notifications
+type NotificationListResponse struct {
+	Notifications []*NotificationResponse `json:"notifications"`
+	Total         int                     `json:"total"`
+	Limit         int                     `json:"limit"`
+	Offset        int                     `json:"offset"`
+}
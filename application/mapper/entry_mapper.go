@@ -3,6 +3,7 @@ package mapper
 import (
 	"github.com/valpere/trytrago/application/dto/response"
 	"github.com/valpere/trytrago/domain/database"
+	"github.com/valpere/trytrago/domain/utils"
 )
 
 // EntryToResponse maps a domain Entry model to an EntryResponse DTO
@@ -12,12 +13,19 @@ func EntryToResponse(entry *database.Entry) *response.EntryResponse {
 	}
 
 	resp := &response.EntryResponse{
-		ID:            entry.ID,
-		Word:          entry.Word,
-		Type:          string(entry.Type),
-		Pronunciation: entry.Pronunciation,
-		CreatedAt:     entry.CreatedAt,
-		UpdatedAt:     entry.UpdatedAt,
+		ID:               entry.ID,
+		Word:             entry.Word,
+		Type:             string(entry.Type),
+		SourceLanguageID: entry.SourceLanguageID,
+		HomographIndex:   entry.HomographIndex,
+		DisplayWord:      utils.FormatHomograph(entry.Word, entry.HomographIndex, entry.Homographs),
+		Pronunciation:    entry.Pronunciation,
+		Etymology:        entry.Etymology,
+		Source:           entry.Source,
+		License:          entry.License,
+		Version:          entry.Version,
+		CreatedAt:        entry.CreatedAt,
+		UpdatedAt:        entry.UpdatedAt,
 	}
 
 	// Map meanings if available
@@ -42,6 +50,8 @@ func MeaningToResponse(meaning *database.Meaning) *response.MeaningResponse {
 		ID:          meaning.ID,
 		EntryID:     meaning.EntryID,
 		Description: meaning.Description,
+		Labels:      meaning.LabelList(),
+		Version:     meaning.Version,
 		CreatedAt:   meaning.CreatedAt,
 		UpdatedAt:   meaning.UpdatedAt,
 		LikesCount:  0, // To be implemented with actual count
@@ -62,12 +72,10 @@ func MeaningToResponse(meaning *database.Meaning) *response.MeaningResponse {
 		}
 	}
 
-	// Map translations if available
-	if len(meaning.Translations) > 0 {
-		resp.Translations = make([]response.TranslationResponse, len(meaning.Translations))
-		
-		for i, translation := range meaning.Translations {
-			resp.Translations[i] = *TranslationToResponse(&translation)
+	// Map translations if available, hiding those still under review
+	for _, translation := range meaning.Translations {
+		if translation.IsPublic() {
+			resp.Translations = append(resp.Translations, *TranslationToResponse(&translation))
 		}
 	}
 
@@ -88,3 +96,16 @@ func ExampleToResponse(example *database.Example) *response.ExampleResponse {
 		UpdatedAt: example.UpdatedAt,
 	}
 }
+
+// EntryToSummary maps a domain Entry model to an EntrySummary DTO
+func EntryToSummary(entry *database.Entry) response.EntrySummary {
+	return response.EntrySummary{
+		ID:               entry.ID,
+		Word:             entry.Word,
+		Type:             string(entry.Type),
+		SourceLanguageID: entry.SourceLanguageID,
+		HomographIndex:   entry.HomographIndex,
+		DisplayWord:      utils.FormatHomograph(entry.Word, entry.HomographIndex, entry.Homographs),
+		CreatedAt:        entry.CreatedAt,
+	}
+}
@@ -3,6 +3,7 @@ package mapper
 import (
 	"github.com/valpere/trytrago/application/dto/response"
 	"github.com/valpere/trytrago/domain/database"
+	"github.com/valpere/trytrago/domain/model"
 )
 
 // TranslationToResponse maps a domain Translation model to a TranslationResponse DTO
@@ -12,13 +13,17 @@ func TranslationToResponse(translation *database.Translation) *response.Translat
 	}
 
 	return &response.TranslationResponse{
-		ID:         translation.ID,
-		MeaningID:  translation.MeaningID,
-		LanguageID: translation.LanguageID,
-		Text:       translation.Text,
-		LikesCount: 0, // To be implemented with actual count
-		CreatedAt:  translation.CreatedAt,
-		UpdatedAt:  translation.UpdatedAt,
+		ID:           translation.ID,
+		MeaningID:    translation.MeaningID,
+		LanguageID:   translation.LanguageID,
+		Text:         translation.Text,
+		LikesCount:   0, // To be implemented with actual count
+		Version:      translation.Version,
+		CreatedAt:    translation.CreatedAt,
+		UpdatedAt:    translation.UpdatedAt,
+		Status:       string(translation.Status),
+		ReviewReason: translation.ReviewReason,
+		ReviewedAt:   translation.ReviewedAt,
 	}
 }
 
@@ -35,3 +40,20 @@ func TranslationListToResponse(translations []database.Translation) []*response.
 
 	return result
 }
+
+// NotificationToResponse maps a domain Notification model to a NotificationResponse DTO
+func NotificationToResponse(notification *model.Notification) *response.NotificationResponse {
+	if notification == nil {
+		return nil
+	}
+
+	return &response.NotificationResponse{
+		ID:         notification.ID,
+		Type:       string(notification.Type),
+		TargetType: notification.TargetType,
+		TargetID:   notification.TargetID,
+		Message:    notification.Message,
+		ReadAt:     notification.ReadAt,
+		CreatedAt:  notification.CreatedAt,
+	}
+}
@@ -0,0 +1,374 @@
+package service
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/valpere/trytrago/application/dto/request"
+	"github.com/valpere/trytrago/application/dto/response"
+	"github.com/valpere/trytrago/domain/database"
+	"github.com/valpere/trytrago/domain/database/repository"
+	"github.com/valpere/trytrago/domain/errors"
+	"github.com/valpere/trytrago/domain/logging"
+	"github.com/valpere/trytrago/domain/model"
+)
+
+// BatchOperationError reports the operation a batch stopped at. None of the
+// batch's operations are applied when it is returned
+type BatchOperationError struct {
+	Index int
+	Op    string
+	Err   error
+}
+
+// Error implements the error interface
+func (e *BatchOperationError) Error() string {
+	return fmt.Sprintf("operations[%d] (%s): %v", e.Index, e.Op, e.Err)
+}
+
+// Unwrap returns the error of the failed operation
+func (e *BatchOperationError) Unwrap() error {
+	return e.Err
+}
+
+// batchService implements the BatchService interface
+type batchService struct {
+	repo          repository.Repository
+	maxOperations int
+	logger        logging.Logger
+}
+
+// NewBatchService creates a new instance of BatchService. A batch may hold at
+// most maxOperations operations; zero or less leaves it unbounded
+func NewBatchService(repo repository.Repository, maxOperations int, logger logging.Logger) BatchService {
+	return &batchService{
+		repo:          repo,
+		maxOperations: maxOperations,
+		logger:        logger.With(logging.String("service", "batch")),
+	}
+}
+
+// ExecuteBatch implements BatchService.ExecuteBatch
+func (s *batchService) ExecuteBatch(ctx context.Context, req *request.BatchRequest) (*response.BatchResponse, error) {
+	s.logger.Debug("executing batch",
+		logging.Int("operations", len(req.Operations)),
+		logging.String("userID", req.UserID.String()),
+	)
+
+	if s.maxOperations > 0 && len(req.Operations) > s.maxOperations {
+		return nil, fmt.Errorf("%w: %d given, at most %d allowed", errors.ErrBatchTooLarge, len(req.Operations), s.maxOperations)
+	}
+
+	if err := s.validate(ctx, req); err != nil {
+		return nil, err
+	}
+
+	results := make([]response.BatchOperationResult, 0, len(req.Operations))
+	err := s.repo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		run := &batchRun{
+			repo:    s.repo.WithTx(tx),
+			userID:  req.UserID,
+			created: make(map[string]uuid.UUID),
+		}
+
+		for i := range req.Operations {
+			op := &req.Operations[i]
+
+			result, err := run.apply(ctx, op)
+			if err != nil {
+				return &BatchOperationError{Index: i, Op: op.Op, Err: err}
+			}
+
+			result.Index, result.Op, result.Ref = i, op.Op, op.Ref
+			if op.Ref != "" {
+				run.created[op.Ref] = result.ID
+			}
+			results = append(results, *result)
+		}
+
+		return nil
+	})
+	if err != nil {
+		var opErr *BatchOperationError
+		if stdErrors.As(err, &opErr) {
+			s.logger.Debug("batch rolled back",
+				logging.Int("index", opErr.Index),
+				logging.String("op", opErr.Op),
+				logging.Error(opErr.Err),
+			)
+			return nil, err
+		}
+		s.logger.Error("failed to execute batch", logging.Error(err))
+		return nil, fmt.Errorf("failed to execute batch: %w", err)
+	}
+
+	return &response.BatchResponse{Results: results}, nil
+}
+
+// validate checks every operation before anything is written, reporting all
+// problems at once. A reference must name the ref of an earlier operation that
+// creates the kind of record the field expects
+func (s *batchService) validate(ctx context.Context, req *request.BatchRequest) error {
+	var problems []string
+	refs := make(map[string]string) // ref -> op that defines it
+
+	checkID := func(path, value, wantOp string) {
+		if value == "" {
+			problems = append(problems, fmt.Sprintf("%s is required", path))
+			return
+		}
+		if ref, ok := strings.CutPrefix(value, "$"); ok {
+			op, defined := refs[ref]
+			switch {
+			case !defined:
+				problems = append(problems, fmt.Sprintf("%s: no earlier operation has ref %q", path, ref))
+			case op != wantOp:
+				problems = append(problems, fmt.Sprintf("%s: ref %q is from a %s operation, expected %s", path, ref, op, wantOp))
+			}
+			return
+		}
+		if _, err := uuid.Parse(value); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %q is neither a UUID nor a $ref", path, value))
+		}
+	}
+
+	checkParts := false
+	for i, op := range req.Operations {
+		path := fmt.Sprintf("operations[%d]", i)
+
+		switch op.Op {
+		case request.BatchCreateEntry:
+			if op.Entry == nil {
+				problems = append(problems, path+".entry is required")
+			}
+		case request.BatchAddMeaning:
+			checkID(path+".entry_id", op.EntryID, request.BatchCreateEntry)
+			if op.Meaning == nil {
+				problems = append(problems, path+".meaning is required")
+			} else {
+				checkParts = true
+			}
+		case request.BatchAddTranslation:
+			checkID(path+".meaning_id", op.MeaningID, request.BatchAddMeaning)
+			if op.Translation == nil {
+				problems = append(problems, path+".translation is required")
+			}
+		case request.BatchLike:
+			switch op.TargetType {
+			case "meaning":
+				checkID(path+".target_id", op.TargetID, request.BatchAddMeaning)
+			case "translation":
+				checkID(path+".target_id", op.TargetID, request.BatchAddTranslation)
+			default:
+				problems = append(problems, path+".target_type is required")
+			}
+		}
+
+		// Refs are defined after the operation's own fields are checked, so an
+		// operation cannot refer to itself
+		if op.Ref != "" {
+			if strings.HasPrefix(op.Ref, "$") {
+				problems = append(problems, fmt.Sprintf("%s.ref: %q must not start with $", path, op.Ref))
+			} else if _, taken := refs[op.Ref]; taken {
+				problems = append(problems, fmt.Sprintf("%s.ref: %q is already used", path, op.Ref))
+			} else {
+				refs[op.Ref] = op.Op
+			}
+		}
+	}
+
+	if checkParts {
+		parts, err := s.repo.ListPartsOfSpeech(ctx)
+		if err != nil {
+			s.logger.Error("failed to list parts of speech", logging.Error(err))
+			return fmt.Errorf("failed to list parts of speech: %w", err)
+		}
+		known := make(map[uuid.UUID]bool, len(parts))
+		for _, part := range parts {
+			known[part.ID] = true
+		}
+		for i, op := range req.Operations {
+			if op.Op == request.BatchAddMeaning && op.Meaning != nil && !known[op.Meaning.PartOfSpeechID] {
+				problems = append(problems, fmt.Sprintf("operations[%d].meaning: unknown part of speech %s", i, op.Meaning.PartOfSpeechID))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", errors.ErrValidation, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// batchRun applies the operations of one batch inside its transaction
+type batchRun struct {
+	repo    repository.Repository
+	userID  uuid.UUID
+	created map[string]uuid.UUID // ref -> ID created by the operation
+}
+
+// apply performs a single operation
+func (r *batchRun) apply(ctx context.Context, op *request.BatchOperation) (*response.BatchOperationResult, error) {
+	switch op.Op {
+	case request.BatchCreateEntry:
+		return r.createEntry(ctx, op.Entry)
+	case request.BatchAddMeaning:
+		return r.addMeaning(ctx, r.resolve(op.EntryID), op.Meaning)
+	case request.BatchAddTranslation:
+		return r.addTranslation(ctx, r.resolve(op.MeaningID), op.Translation)
+	case request.BatchLike:
+		return r.like(ctx, op.TargetType, r.resolve(op.TargetID))
+	default:
+		return nil, fmt.Errorf("%w: unknown operation %q", errors.ErrValidation, op.Op)
+	}
+}
+
+// resolve turns an ID field into the ID it stands for. Fields were validated
+// before the batch started
+func (r *batchRun) resolve(value string) uuid.UUID {
+	if ref, ok := strings.CutPrefix(value, "$"); ok {
+		return r.created[ref]
+	}
+	id, _ := uuid.Parse(value)
+	return id
+}
+
+func (r *batchRun) createEntry(ctx context.Context, req *request.CreateEntryRequest) (*response.BatchOperationResult, error) {
+	now := time.Now().UTC()
+	entry := &database.Entry{
+		ID:               uuid.New(),
+		Word:             req.Word,
+		Type:             database.EntryType(req.Type),
+		SourceLanguageID: req.SourceLanguageID,
+		HomographIndex:   req.HomographIndex,
+		Pronunciation:    req.Pronunciation,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	if err := r.repo.CreateEntry(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return &response.BatchOperationResult{ID: entry.ID, EntryID: &entry.ID, Created: true}, nil
+}
+
+func (r *batchRun) addMeaning(ctx context.Context, entryID uuid.UUID, req *request.CreateMeaningRequest) (*response.BatchOperationResult, error) {
+	entry, err := r.repo.GetEntryByID(ctx, entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	meaning := database.Meaning{
+		ID:             uuid.New(),
+		EntryID:        entry.ID,
+		PartOfSpeechId: req.PartOfSpeechID,
+		Description:    req.Description,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	meaning.SetLabels(req.Labels)
+	for _, text := range req.Examples {
+		meaning.Examples = append(meaning.Examples, database.Example{
+			ID:        uuid.New(),
+			MeaningID: meaning.ID,
+			Text:      text,
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+	}
+
+	entry.Meanings = append(entry.Meanings, meaning)
+	if err := r.repo.UpdateEntry(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return &response.BatchOperationResult{ID: meaning.ID, EntryID: &entry.ID, Created: true}, nil
+}
+
+func (r *batchRun) addTranslation(ctx context.Context, meaningID uuid.UUID, req *request.CreateTranslationRequest) (*response.BatchOperationResult, error) {
+	meaning, err := r.repo.GetMeaningByID(ctx, meaningID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkReplaces(ctx, r.repo, meaningID, req.ReplacesID); err != nil {
+		return nil, err
+	}
+
+	entry, err := r.repo.GetEntryByID(ctx, meaning.EntryID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Contributed translations stay hidden until a reviewer approves them
+	now := time.Now().UTC()
+	authorID := r.userID
+	translation := database.Translation{
+		ID:           uuid.New(),
+		MeaningID:    meaningID,
+		LanguageID:   req.LanguageID,
+		Text:         req.Text,
+		Status:       database.TranslationProposed,
+		CreatedByID:  &authorID,
+		SupersedesID: req.ReplacesID,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	for i := range entry.Meanings {
+		if entry.Meanings[i].ID == meaningID {
+			entry.Meanings[i].Translations = append(entry.Meanings[i].Translations, translation)
+		}
+	}
+	if err := r.repo.UpdateEntry(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return &response.BatchOperationResult{ID: translation.ID, EntryID: &entry.ID, Created: true}, nil
+}
+
+// like records the user's like of a meaning or translation. Liking something
+// twice keeps the first like, so a retried batch does not fail on it
+func (r *batchRun) like(ctx context.Context, targetType string, targetID uuid.UUID) (*response.BatchOperationResult, error) {
+	var err error
+	if targetType == "meaning" {
+		_, err = r.repo.GetMeaningByID(ctx, targetID)
+	} else {
+		_, err = r.repo.GetTranslationByID(ctx, targetID)
+	}
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return nil, fmt.Errorf("%s %s: %w", targetType, targetID, err)
+		}
+		return nil, err
+	}
+
+	existing, err := r.repo.GetLike(ctx, r.userID, targetType, targetID)
+	if err == nil {
+		return &response.BatchOperationResult{ID: existing.ID}, nil
+	}
+	if !database.IsNotFoundError(err) {
+		return nil, err
+	}
+
+	like := &model.Like{
+		ID:         uuid.New(),
+		UserID:     r.userID,
+		TargetType: targetType,
+		TargetID:   targetID,
+		CreatedAt:  time.Now().UTC(),
+	}
+	if err := r.repo.CreateLike(ctx, like); err != nil {
+		return nil, err
+	}
+
+	return &response.BatchOperationResult{ID: like.ID, Created: true}, nil
+}
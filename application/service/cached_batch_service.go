@@ -0,0 +1,85 @@
+// application/service/cached_batch_service.go
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/valpere/trytrago/application/dto/request"
+	"github.com/valpere/trytrago/application/dto/response"
+	"github.com/valpere/trytrago/domain/cache"
+	"github.com/valpere/trytrago/domain/logging"
+)
+
+// cachedBatchService implements the BatchService interface, invalidating the
+// caches of the entry and translation services for everything a batch changed
+type cachedBatchService struct {
+	baseService BatchService
+	cache       cache.CacheService
+	logger      logging.Logger
+}
+
+// NewCachedBatchService creates a new cached batch service
+func NewCachedBatchService(baseService BatchService, cacheService cache.CacheService, logger logging.Logger) BatchService {
+	return &cachedBatchService{
+		baseService: baseService,
+		cache:       cacheService,
+		logger:      logger.With(logging.String("service", "cached_batch_service")),
+	}
+}
+
+// ExecuteBatch implements BatchService.ExecuteBatch with cache invalidation
+func (s *cachedBatchService) ExecuteBatch(ctx context.Context, req *request.BatchRequest) (*response.BatchResponse, error) {
+	resp, err := s.baseService.ExecuteBatch(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	patterns := []string{"entries:list:*"}
+	entries := make(map[uuid.UUID]bool)
+	liked := false
+	for _, result := range resp.Results {
+		if result.EntryID != nil && !entries[*result.EntryID] {
+			entries[*result.EntryID] = true
+			patterns = append(patterns, fmt.Sprintf("entries:%s:meanings:*", result.EntryID.String()))
+		}
+		if result.Op == request.BatchLike {
+			liked = true
+		}
+	}
+	if len(entries) > 0 || liked {
+		patterns = append(patterns, "meanings:*", "translations:*")
+	}
+
+	for id := range entries {
+		cacheKey := s.cache.GenerateKey("entries", "id", id.String())
+		if err := s.cache.Delete(ctx, cacheKey); err != nil {
+			s.logger.Warn("failed to invalidate entry cache after batch",
+				logging.String("id", id.String()),
+				logging.Error(err),
+			)
+		}
+	}
+
+	if liked {
+		userLikesCacheKey := s.cache.GenerateKey("users", req.UserID.String(), "likes")
+		if err := s.cache.Delete(ctx, userLikesCacheKey); err != nil {
+			s.logger.Warn("failed to invalidate user likes cache",
+				logging.String("userId", req.UserID.String()),
+				logging.Error(err),
+			)
+		}
+	}
+
+	for _, pattern := range patterns {
+		if err := s.cache.Invalidate(ctx, pattern); err != nil {
+			s.logger.Warn("failed to invalidate cache after batch",
+				logging.String("pattern", pattern),
+				logging.Error(err),
+			)
+		}
+	}
+
+	return resp, nil
+}
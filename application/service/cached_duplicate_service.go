@@ -0,0 +1,57 @@
+// application/service/cached_duplicate_service.go
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/valpere/trytrago/application/dto/request"
+	"github.com/valpere/trytrago/application/dto/response"
+	"github.com/valpere/trytrago/domain/cache"
+	"github.com/valpere/trytrago/domain/logging"
+)
+
+// cachedDuplicateService implements the DuplicateService interface,
+// invalidating the caches of the entry and translation services after a merge.
+// Without it the cached source entry keeps being served instead of the
+// redirect, and the target entry and the lists keep their old contents
+type cachedDuplicateService struct {
+	baseService DuplicateService
+	cache       cache.CacheService
+	logger      logging.Logger
+}
+
+// NewCachedDuplicateService creates a new cached duplicate service
+func NewCachedDuplicateService(baseService DuplicateService, cacheService cache.CacheService, logger logging.Logger) DuplicateService {
+	return &cachedDuplicateService{
+		baseService: baseService,
+		cache:       cacheService,
+		logger:      logger.With(logging.String("service", "cached_duplicate_service")),
+	}
+}
+
+// FindDuplicates implements DuplicateService.FindDuplicates
+func (s *cachedDuplicateService) FindDuplicates(ctx context.Context, req *request.DuplicateReportRequest) (*response.DuplicateReportResponse, error) {
+	return s.baseService.FindDuplicates(ctx, req)
+}
+
+// MergeEntries implements DuplicateService.MergeEntries with cache invalidation
+func (s *cachedDuplicateService) MergeEntries(ctx context.Context, sourceID uuid.UUID, req *request.MergeEntriesRequest) (*response.EntryResponse, error) {
+	resp, err := s.baseService.MergeEntries(ctx, sourceID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Both entries, every list and the moved meanings with their translations
+	// have changed. The patterns cover the source and target entry keys too
+	for _, pattern := range []string{"entries:*", "meanings:*", "translations:*"} {
+		if err := s.cache.Invalidate(ctx, pattern); err != nil {
+			s.logger.Warn("failed to invalidate cache after merge",
+				logging.String("pattern", pattern),
+				logging.Error(err),
+			)
+		}
+	}
+
+	return resp, nil
+}
@@ -74,6 +74,12 @@ func (s *cachedEntryService) GetEntryByID(ctx context.Context, id uuid.UUID) (*r
 		return nil, err
 	}
 
+	// An entry reached through a merge redirect is cached under its own ID only,
+	// so that its later changes cannot leave a stale copy under the old one
+	if entry.ID != id {
+		return entry, nil
+	}
+
 	// Store in cache for future requests
 	if err := s.cache.Set(ctx, cacheKey, entry, defaultEntryTTL); err != nil {
 		s.logger.Warn("failed to cache entry",
@@ -113,9 +119,9 @@ func (s *cachedEntryService) UpdateEntry(ctx context.Context, id uuid.UUID, req
 }
 
 // DeleteEntry implements EntryService.DeleteEntry with cache invalidation
-func (s *cachedEntryService) DeleteEntry(ctx context.Context, id uuid.UUID) error {
+func (s *cachedEntryService) DeleteEntry(ctx context.Context, id uuid.UUID, version int) error {
 	// Call base service to delete the entry
-	if err := s.baseService.DeleteEntry(ctx, id); err != nil {
+	if err := s.baseService.DeleteEntry(ctx, id, version); err != nil {
 		return err
 	}
 
@@ -148,6 +154,11 @@ func (s *cachedEntryService) DeleteEntry(ctx context.Context, id uuid.UUID) erro
 
 // ListEntries implements EntryService.ListEntries with caching
 func (s *cachedEntryService) ListEntries(ctx context.Context, req *request.ListEntriesRequest) (*response.EntryListResponse, error) {
+	// Export pages are read once, so caching them would only crowd out other lists
+	if req.AfterID != nil {
+		return s.baseService.ListEntries(ctx, req)
+	}
+
 	// Generate a cache key based on the request parameters
 	cacheKey := s.generateListCacheKey(req)
 
@@ -212,6 +223,9 @@ func (s *cachedEntryService) AddMeaning(ctx context.Context, entryID uuid.UUID,
 		return nil, err
 	}
 
+	// The meaning may have gone to the entry a merged entry redirects to
+	entryID = resp.EntryID
+
 	// Invalidate entry cache since it includes meanings
 	entryCacheKey := s.cache.GenerateKey("entries", "id", entryID.String())
 	if err := s.cache.Delete(ctx, entryCacheKey); err != nil {
@@ -259,19 +273,28 @@ func (s *cachedEntryService) UpdateMeaning(ctx context.Context, id uuid.UUID, re
 				logging.Error(err),
 			)
 		}
+
+		// The listed meaning carries its version, which has moved on
+		meaningListCacheKey := s.cache.GenerateKey("entries", resp.EntryID.String(), "meanings", "list")
+		if err := s.cache.Delete(ctx, meaningListCacheKey); err != nil {
+			s.logger.Warn("failed to invalidate meanings list cache after meaning update",
+				logging.String("entryId", resp.EntryID.String()),
+				logging.Error(err),
+			)
+		}
 	}
 
 	return resp, nil
 }
 
 // DeleteMeaning implements EntryService.DeleteMeaning with cache invalidation
-func (s *cachedEntryService) DeleteMeaning(ctx context.Context, id uuid.UUID) error {
+func (s *cachedEntryService) DeleteMeaning(ctx context.Context, id uuid.UUID, version int) error {
 	// We need to find the entry ID before deleting the meaning for cache invalidation
 	// This requires an additional database query
 	// In a real implementation, you might want to get this information from the request context
 
 	// Call base service to delete the meaning
-	if err := s.baseService.DeleteMeaning(ctx, id); err != nil {
+	if err := s.baseService.DeleteMeaning(ctx, id, version); err != nil {
 		return err
 	}
 
@@ -284,8 +307,8 @@ func (s *cachedEntryService) DeleteMeaning(ctx context.Context, id uuid.UUID) er
 		)
 	}
 
-	// Invalidate all potential entry caches
-	if err := s.cache.Invalidate(ctx, "entries:id:*"); err != nil {
+	// Invalidate all potential entry caches, their meaning lists included
+	if err := s.cache.Invalidate(ctx, "entries:*"); err != nil {
 		s.logger.Warn("failed to invalidate entry caches after meaning delete",
 			logging.Error(err),
 		)
@@ -314,6 +337,11 @@ func (s *cachedEntryService) ListMeanings(ctx context.Context, entryID uuid.UUID
 		return nil, err
 	}
 
+	// Meanings of the entry a merged entry redirects to are not cached under the old ID
+	if len(list.Meanings) > 0 && list.Meanings[0].EntryID != entryID {
+		return list, nil
+	}
+
 	// Store in cache for future requests
 	if err := s.cache.Set(ctx, cacheKey, list, defaultListTTL); err != nil {
 		s.logger.Warn("failed to cache meaning list",
@@ -0,0 +1,82 @@
+// application/service/cached_entry_tree_service.go
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/valpere/trytrago/application/dto/request"
+	"github.com/valpere/trytrago/application/dto/response"
+	"github.com/valpere/trytrago/domain/cache"
+	"github.com/valpere/trytrago/domain/logging"
+)
+
+// cachedEntryTreeService implements the EntryTreeService interface, keeping
+// the caches of the entry and translation services in step with its writes
+type cachedEntryTreeService struct {
+	baseService EntryTreeService
+	cache       cache.CacheService
+	logger      logging.Logger
+}
+
+// NewCachedEntryTreeService creates a new cached entry tree service
+func NewCachedEntryTreeService(baseService EntryTreeService, cacheService cache.CacheService, logger logging.Logger) EntryTreeService {
+	return &cachedEntryTreeService{
+		baseService: baseService,
+		cache:       cacheService,
+		logger:      logger.With(logging.String("service", "cached_entry_tree_service")),
+	}
+}
+
+// CreateEntryTree implements EntryTreeService.CreateEntryTree with cache invalidation
+func (s *cachedEntryTreeService) CreateEntryTree(ctx context.Context, req *request.EntryTreeRequest) (*response.EntryResponse, error) {
+	resp, err := s.baseService.CreateEntryTree(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalidate any list caches that might contain the new entry
+	if err := s.cache.Invalidate(ctx, "entries:list:*"); err != nil {
+		s.logger.Warn("failed to invalidate entry list cache after create",
+			logging.Error(err),
+		)
+	}
+
+	return resp, nil
+}
+
+// ReplaceEntryTree implements EntryTreeService.ReplaceEntryTree with cache invalidation
+func (s *cachedEntryTreeService) ReplaceEntryTree(ctx context.Context, id uuid.UUID, req *request.EntryTreeRequest) (*response.EntryResponse, error) {
+	resp, err := s.baseService.ReplaceEntryTree(ctx, id, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalidate specific entry cache
+	cacheKey := s.cache.GenerateKey("entries", "id", id.String())
+	if err := s.cache.Delete(ctx, cacheKey); err != nil {
+		s.logger.Warn("failed to invalidate entry cache after replace",
+			logging.String("id", id.String()),
+			logging.Error(err),
+		)
+	}
+
+	// Any meaning or translation of the entry may have changed or gone
+	patterns := []string{
+		"entries:list:*",
+		fmt.Sprintf("entries:%s:meanings:*", id.String()),
+		"meanings:*",
+		"translations:*",
+	}
+	for _, pattern := range patterns {
+		if err := s.cache.Invalidate(ctx, pattern); err != nil {
+			s.logger.Warn("failed to invalidate cache after replace",
+				logging.String("pattern", pattern),
+				logging.Error(err),
+			)
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,94 @@
+// application/service/cached_patch_service.go
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/valpere/trytrago/application/dto/request"
+	"github.com/valpere/trytrago/application/dto/response"
+	"github.com/valpere/trytrago/domain/cache"
+	"github.com/valpere/trytrago/domain/logging"
+)
+
+// cachedPatchService implements the PatchService interface, keeping the caches
+// of the entry and translation services in step with its writes
+type cachedPatchService struct {
+	baseService PatchService
+	cache       cache.CacheService
+	logger      logging.Logger
+}
+
+// NewCachedPatchService creates a new cached patch service
+func NewCachedPatchService(baseService PatchService, cacheService cache.CacheService, logger logging.Logger) PatchService {
+	return &cachedPatchService{
+		baseService: baseService,
+		cache:       cacheService,
+		logger:      logger.With(logging.String("service", "cached_patch_service")),
+	}
+}
+
+// PatchEntry implements PatchService.PatchEntry with cache invalidation
+func (s *cachedPatchService) PatchEntry(ctx context.Context, id uuid.UUID, req *request.PatchRequest) (*response.EntryResponse, error) {
+	resp, err := s.baseService.PatchEntry(ctx, id, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidate(ctx, []string{"entries:list:*", fmt.Sprintf("entries:%s:meanings:*", id.String())},
+		s.cache.GenerateKey("entries", "id", id.String()))
+
+	return resp, nil
+}
+
+// PatchMeaning implements PatchService.PatchMeaning with cache invalidation
+func (s *cachedPatchService) PatchMeaning(ctx context.Context, id uuid.UUID, req *request.PatchRequest) (*response.MeaningResponse, error) {
+	resp, err := s.baseService.PatchMeaning(ctx, id, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidate(ctx, []string{fmt.Sprintf("entries:%s:meanings:*", resp.EntryID.String())},
+		s.cache.GenerateKey("meanings", "id", id.String()),
+		s.cache.GenerateKey("entries", "id", resp.EntryID.String()))
+
+	return resp, nil
+}
+
+// PatchTranslation implements PatchService.PatchTranslation with cache invalidation
+func (s *cachedPatchService) PatchTranslation(ctx context.Context, id uuid.UUID, req *request.PatchRequest) (*response.TranslationResponse, error) {
+	resp, err := s.baseService.PatchTranslation(ctx, id, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// The translation may have changed language, and its meaning and entry
+	// have moved on to new versions
+	s.invalidate(ctx, []string{fmt.Sprintf("meanings:%s:translations:*", resp.MeaningID.String()), "entries:*"},
+		s.cache.GenerateKey("translations", "id", id.String()),
+		s.cache.GenerateKey("meanings", "id", resp.MeaningID.String()))
+
+	return resp, nil
+}
+
+// invalidate deletes the given keys and every key matching the patterns
+func (s *cachedPatchService) invalidate(ctx context.Context, patterns []string, keys ...string) {
+	for _, key := range keys {
+		if err := s.cache.Delete(ctx, key); err != nil {
+			s.logger.Warn("failed to invalidate cache after patch",
+				logging.String("key", key),
+				logging.Error(err),
+			)
+		}
+	}
+
+	for _, pattern := range patterns {
+		if err := s.cache.Invalidate(ctx, pattern); err != nil {
+			s.logger.Warn("failed to invalidate cache after patch",
+				logging.String("pattern", pattern),
+				logging.Error(err),
+			)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+// application/service/cached_review_service.go
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/valpere/trytrago/application/dto/request"
+	"github.com/valpere/trytrago/application/dto/response"
+	"github.com/valpere/trytrago/domain/cache"
+	"github.com/valpere/trytrago/domain/logging"
+)
+
+// cachedReviewService implements the ReviewService interface, invalidating the
+// caches of the entry and translation services after a review decision. A
+// decision changes which translations are public and moves the meaning and
+// entry on to new versions, so cached lists and ETags would otherwise go stale
+type cachedReviewService struct {
+	baseService ReviewService
+	cache       cache.CacheService
+	logger      logging.Logger
+}
+
+// NewCachedReviewService creates a new cached review service
+func NewCachedReviewService(baseService ReviewService, cacheService cache.CacheService, logger logging.Logger) ReviewService {
+	return &cachedReviewService{
+		baseService: baseService,
+		cache:       cacheService,
+		logger:      logger.With(logging.String("service", "cached_review_service")),
+	}
+}
+
+// ListReviewQueue implements ReviewService.ListReviewQueue
+func (s *cachedReviewService) ListReviewQueue(ctx context.Context, req *request.ReviewQueueRequest) (*response.TranslationListResponse, error) {
+	return s.baseService.ListReviewQueue(ctx, req)
+}
+
+// ApproveTranslation implements ReviewService.ApproveTranslation with cache invalidation
+func (s *cachedReviewService) ApproveTranslation(ctx context.Context, id uuid.UUID, req *request.ReviewDecisionRequest) (*response.TranslationResponse, error) {
+	resp, err := s.baseService.ApproveTranslation(ctx, id, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidate(ctx)
+	return resp, nil
+}
+
+// RejectTranslation implements ReviewService.RejectTranslation with cache invalidation
+func (s *cachedReviewService) RejectTranslation(ctx context.Context, id uuid.UUID, req *request.ReviewDecisionRequest) (*response.TranslationResponse, error) {
+	resp, err := s.baseService.RejectTranslation(ctx, id, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidate(ctx)
+	return resp, nil
+}
+
+// ListNotifications implements ReviewService.ListNotifications
+func (s *cachedReviewService) ListNotifications(ctx context.Context, userID uuid.UUID, req *request.ListNotificationsRequest) (*response.NotificationListResponse, error) {
+	return s.baseService.ListNotifications(ctx, userID, req)
+}
+
+// invalidate drops every cached entry, meaning and translation. Approving a
+// proposal also retires the translation it supersedes, so the reviewed
+// translation is not the only one that changed
+func (s *cachedReviewService) invalidate(ctx context.Context) {
+	for _, pattern := range []string{"entries:*", "meanings:*", "translations:*"} {
+		if err := s.cache.Invalidate(ctx, pattern); err != nil {
+			s.logger.Warn("failed to invalidate cache after review",
+				logging.String("pattern", pattern),
+				logging.Error(err),
+			)
+		}
+	}
+}
@@ -77,6 +77,14 @@ func (s *cachedTranslationService) CreateTranslation(
 		)
 	}
 
+	// The entry holding the meaning has moved on to a new version, and so
+	// has its listed meanings
+	if err := s.cache.Invalidate(ctx, "entries:*"); err != nil {
+		s.logger.Warn("failed to invalidate entry caches after translation create",
+			logging.Error(err),
+		)
+	}
+
 	return resp, nil
 }
 
@@ -137,17 +145,25 @@ func (s *cachedTranslationService) UpdateTranslation(
 		}
 	}
 
+	// The entry holding the translation has moved on to a new version, and so
+	// has its listed meanings
+	if err := s.cache.Invalidate(ctx, "entries:*"); err != nil {
+		s.logger.Warn("failed to invalidate entry caches after translation update",
+			logging.Error(err),
+		)
+	}
+
 	return resp, nil
 }
 
 // DeleteTranslation implements TranslationService.DeleteTranslation with cache invalidation
-func (s *cachedTranslationService) DeleteTranslation(ctx context.Context, id uuid.UUID) error {
+func (s *cachedTranslationService) DeleteTranslation(ctx context.Context, id uuid.UUID, version int) error {
 	// First, get the translation to retrieve its meaning ID and language ID for cache invalidation
 	// In a real implementation, you might want to include this info in the request context
 	// Here we'll assume we don't have that information and proceed with broader invalidation
 
 	// Call base service to delete the translation
-	if err := s.baseService.DeleteTranslation(ctx, id); err != nil {
+	if err := s.baseService.DeleteTranslation(ctx, id, version); err != nil {
 		return err
 	}
 
@@ -175,6 +191,12 @@ func (s *cachedTranslationService) DeleteTranslation(ctx context.Context, id uui
 		)
 	}
 
+	if err := s.cache.Invalidate(ctx, "entries:*"); err != nil {
+		s.logger.Warn("failed to invalidate entry caches after translation delete",
+			logging.Error(err),
+		)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/valpere/trytrago/application/dto/request"
+	"github.com/valpere/trytrago/application/dto/response"
+	"github.com/valpere/trytrago/application/mapper"
+	"github.com/valpere/trytrago/domain/database"
+	"github.com/valpere/trytrago/domain/database/repository"
+	"github.com/valpere/trytrago/domain/logging"
+	"github.com/valpere/trytrago/domain/utils"
+)
+
+const (
+	// defaultDuplicateThreshold is the minimum similarity reported as a near duplicate
+	defaultDuplicateThreshold = 0.85
+
+	// defaultDuplicateLimit caps the number of groups in a report
+	defaultDuplicateLimit = 100
+
+	// similarityWindow is how many alphabetical neighbours each word is compared with
+	similarityWindow = 8
+)
+
+// duplicateCandidate is the lightweight view of an entry used while scanning
+type duplicateCandidate struct {
+	entry      database.Entry
+	normalized string
+}
+
+// duplicateService implements the DuplicateService interface
+type duplicateService struct {
+	repo   repository.Repository
+	logger logging.Logger
+}
+
+// NewDuplicateService creates a new instance of DuplicateService
+func NewDuplicateService(repo repository.Repository, logger logging.Logger) DuplicateService {
+	return &duplicateService{
+		repo:   repo,
+		logger: logger.With(logging.String("service", "duplicate")),
+	}
+}
+
+// FindDuplicates implements DuplicateService.FindDuplicates.
+// Entries sharing normalized word, type and source language are exact duplicates.
+// Within the same type and language, alphabetically close words whose similarity
+// reaches the threshold are reported as near duplicates.
+func (s *duplicateService) FindDuplicates(ctx context.Context, req *request.DuplicateReportRequest) (*response.DuplicateReportResponse, error) {
+	threshold := req.Threshold
+	if threshold <= 0 {
+		threshold = defaultDuplicateThreshold
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultDuplicateLimit
+	}
+
+	s.logger.Debug("scanning for duplicate entries",
+		logging.String("type", req.Type),
+		logging.String("languageID", req.LanguageID),
+	)
+
+	params := repository.IterateParams{
+		BatchSize: 1000,
+		Filters:   make(map[string]interface{}),
+	}
+	if req.Type != "" {
+		params.Filters["type = ?"] = req.Type
+	}
+	if req.LanguageID != "" {
+		params.Filters["source_language_id = ?"] = req.LanguageID
+	}
+
+	// Bucket entries by type and source language; duplicates never cross buckets
+	buckets := make(map[string][]duplicateCandidate)
+	scanned := 0
+	err := s.repo.IterateEntries(ctx, params, func(batch []database.Entry) error {
+		for _, entry := range batch {
+			key := string(entry.Type) + "|" + entry.SourceLanguageID
+			buckets[key] = append(buckets[key], duplicateCandidate{
+				entry:      entry,
+				normalized: utils.NormalizeWord(entry.Word),
+			})
+		}
+		scanned += len(batch)
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("failed to scan entries for duplicates", logging.Error(err))
+		return nil, fmt.Errorf("failed to scan entries: %w", err)
+	}
+
+	var groups []response.DuplicateGroupResponse
+	for _, candidates := range buckets {
+		sort.Slice(candidates, func(i, j int) bool {
+			return candidates[i].normalized < candidates[j].normalized
+		})
+		groups = append(groups, exactDuplicates(candidates)...)
+		groups = append(groups, similarDuplicates(candidates, threshold)...)
+	}
+
+	// Exact matches first, then the most similar pairs
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].Score != groups[j].Score {
+			return groups[i].Score > groups[j].Score
+		}
+		return groups[i].Entries[0].Word < groups[j].Entries[0].Word
+	})
+
+	total := len(groups)
+	if len(groups) > limit {
+		groups = groups[:limit]
+	}
+
+	return &response.DuplicateReportResponse{
+		Groups:    groups,
+		Total:     total,
+		Threshold: threshold,
+		Scanned:   scanned,
+	}, nil
+}
+
+// exactDuplicates groups runs of identical normalized words in a sorted bucket
+func exactDuplicates(candidates []duplicateCandidate) []response.DuplicateGroupResponse {
+	var groups []response.DuplicateGroupResponse
+
+	for start := 0; start < len(candidates); {
+		end := start + 1
+		for end < len(candidates) && candidates[end].normalized == candidates[start].normalized {
+			end++
+		}
+
+		if end-start > 1 {
+			group := response.DuplicateGroupResponse{Reason: "exact", Score: 1}
+			for _, c := range candidates[start:end] {
+				group.Entries = append(group.Entries, mapper.EntryToSummary(&c.entry))
+			}
+			groups = append(groups, group)
+		}
+
+		start = end
+	}
+
+	return groups
+}
+
+// similarDuplicates pairs each word with its close alphabetical neighbours
+func similarDuplicates(candidates []duplicateCandidate, threshold float64) []response.DuplicateGroupResponse {
+	var groups []response.DuplicateGroupResponse
+
+	for i := range candidates {
+		for j := i + 1; j < len(candidates) && j <= i+similarityWindow; j++ {
+			if candidates[i].normalized == candidates[j].normalized {
+				continue
+			}
+
+			score := utils.WordSimilarity(candidates[i].normalized, candidates[j].normalized)
+			if score < threshold {
+				continue
+			}
+
+			groups = append(groups, response.DuplicateGroupResponse{
+				Reason: "similar",
+				Score:  score,
+				Entries: []response.EntrySummary{
+					mapper.EntryToSummary(&candidates[i].entry),
+					mapper.EntryToSummary(&candidates[j].entry),
+				},
+			})
+		}
+	}
+
+	return groups
+}
+
+// MergeEntries implements DuplicateService.MergeEntries
+func (s *duplicateService) MergeEntries(ctx context.Context, sourceID uuid.UUID, req *request.MergeEntriesRequest) (*response.EntryResponse, error) {
+	s.logger.Info("merging entries",
+		logging.String("sourceID", sourceID.String()),
+		logging.String("targetID", req.TargetID.String()),
+		logging.String("userID", req.UserID.String()),
+	)
+
+	if err := s.repo.MergeEntries(ctx, sourceID, req.TargetID, req.UserID); err != nil {
+		s.logger.Error("failed to merge entries",
+			logging.Error(err),
+			logging.String("sourceID", sourceID.String()),
+			logging.String("targetID", req.TargetID.String()),
+		)
+		return nil, fmt.Errorf("failed to merge entries: %w", err)
+	}
+
+	entry, err := s.repo.GetEntryByID(ctx, req.TargetID)
+	if err != nil {
+		s.logger.Error("failed to get merged entry", logging.Error(err), logging.String("id", req.TargetID.String()))
+		return nil, fmt.Errorf("failed to get merged entry: %w", err)
+	}
+
+	return mapper.EntryToResponse(entry), nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/valpere/trytrago/domain/database/repository"
 	"github.com/valpere/trytrago/domain/logging"
 	"github.com/valpere/trytrago/domain/model"
+	"github.com/valpere/trytrago/domain/utils"
 )
 
 // entryService implements the EntryService interface
@@ -35,12 +36,14 @@ func (s *entryService) CreateEntry(ctx context.Context, req *request.CreateEntry
 
 	// Create domain model from request
 	entry := &database.Entry{
-		ID:            uuid.New(),
-		Word:          req.Word,
-		Type:          database.EntryType(req.Type),
-		Pronunciation: req.Pronunciation,
-		CreatedAt:     time.Now().UTC(),
-		UpdatedAt:     time.Now().UTC(),
+		ID:               uuid.New(),
+		Word:             req.Word,
+		Type:             database.EntryType(req.Type),
+		SourceLanguageID: req.SourceLanguageID,
+		HomographIndex:   req.HomographIndex,
+		Pronunciation:    req.Pronunciation,
+		CreatedAt:        time.Now().UTC(),
+		UpdatedAt:        time.Now().UTC(),
 	}
 
 	// Persist to database
@@ -58,8 +61,8 @@ func (s *entryService) CreateEntry(ctx context.Context, req *request.CreateEntry
 func (s *entryService) GetEntryByID(ctx context.Context, id uuid.UUID) (*response.EntryResponse, error) {
 	s.logger.Debug("getting entry by ID", logging.String("id", id.String()))
 
-	// Fetch entry from repository
-	entry, err := s.repo.GetEntryByID(ctx, id)
+	// Fetch entry from repository, following the redirect left by a merge
+	entry, err := s.resolveEntry(ctx, id)
 	if err != nil {
 		s.logger.Error("failed to get entry", logging.Error(err), logging.String("id", id.String()))
 		return nil, fmt.Errorf("failed to get entry: %w", err)
@@ -70,6 +73,22 @@ func (s *entryService) GetEntryByID(ctx context.Context, id uuid.UUID) (*respons
 	return resp, nil
 }
 
+// resolveEntry fetches an entry, following the redirect left by a merge so
+// that reads under the ID of a merged entry reach the entry it was merged into
+func (s *entryService) resolveEntry(ctx context.Context, id uuid.UUID) (*database.Entry, error) {
+	entry, err := s.repo.GetEntryByID(ctx, id)
+	if database.IsNotFoundError(err) {
+		if redirect, redirectErr := s.repo.GetEntryRedirect(ctx, id); redirectErr == nil {
+			s.logger.Debug("following entry redirect",
+				logging.String("from", id.String()),
+				logging.String("to", redirect.ToID.String()),
+			)
+			return s.repo.GetEntryByID(ctx, redirect.ToID)
+		}
+	}
+	return entry, err
+}
+
 // UpdateEntry implements EntryService.UpdateEntry
 func (s *entryService) UpdateEntry(ctx context.Context, id uuid.UUID, req *request.UpdateEntryRequest) (*response.EntryResponse, error) {
 	s.logger.Debug("updating entry", logging.String("id", id.String()))
@@ -82,6 +101,8 @@ func (s *entryService) UpdateEntry(ctx context.Context, id uuid.UUID, req *reque
 	}
 
 	// Update fields
+	previousKey, previousType := entry.NormalizedWord, entry.Type
+
 	if req.Word != "" {
 		entry.Word = req.Word
 	}
@@ -90,12 +111,26 @@ func (s *entryService) UpdateEntry(ctx context.Context, id uuid.UUID, req *reque
 		entry.Type = database.EntryType(req.Type)
 	}
 
+	// An entry that moves to another headword is numbered among its new
+	// homographs, unless the caller asked for a specific index
+	if req.HomographIndex > 0 {
+		entry.HomographIndex = req.HomographIndex
+	} else if utils.NormalizeWord(entry.Word) != previousKey || entry.Type != previousType {
+		entry.HomographIndex = 0
+	}
+
 	if req.Pronunciation != "" {
 		entry.Pronunciation = req.Pronunciation
 	}
 
 	entry.UpdatedAt = time.Now().UTC()
 
+	// The repository compares the version the client read, or else the one
+	// loaded above, with the stored one as it saves
+	if req.Version != 0 {
+		entry.Version = req.Version
+	}
+
 	// Save changes
 	if err := s.repo.UpdateEntry(ctx, entry); err != nil {
 		s.logger.Error("failed to update entry", logging.Error(err), logging.String("id", id.String()))
@@ -108,10 +143,10 @@ func (s *entryService) UpdateEntry(ctx context.Context, id uuid.UUID, req *reque
 }
 
 // DeleteEntry implements EntryService.DeleteEntry
-func (s *entryService) DeleteEntry(ctx context.Context, id uuid.UUID) error {
+func (s *entryService) DeleteEntry(ctx context.Context, id uuid.UUID, version int) error {
 	s.logger.Debug("deleting entry", logging.String("id", id.String()))
 
-	if err := s.repo.DeleteEntry(ctx, id); err != nil {
+	if err := s.repo.DeleteEntry(ctx, id, version); err != nil {
 		s.logger.Error("failed to delete entry", logging.Error(err), logging.String("id", id.String()))
 		return fmt.Errorf("failed to delete entry: %w", err)
 	}
@@ -144,6 +179,14 @@ func (s *entryService) ListEntries(ctx context.Context, req *request.ListEntries
 		params.Filters["type = ?"] = req.Type
 	}
 
+	// Keyset pages neither skip nor repeat entries when others are written meanwhile
+	if req.AfterID != nil {
+		params.Offset, params.SortBy, params.SortDesc = 0, "id", false
+		if *req.AfterID != uuid.Nil {
+			params.Filters["id > ?"] = *req.AfterID
+		}
+	}
+
 	// Execute query
 	entries, err := s.repo.ListEntries(ctx, params)
 	if err != nil {
@@ -173,8 +216,9 @@ func (s *entryService) AddMeaning(ctx context.Context, entryID uuid.UUID, req *r
 		logging.String("partOfSpeech", req.PartOfSpeechID.String()),
 	)
 
-	// Fetch the entry to ensure it exists
-	entry, err := s.repo.GetEntryByID(ctx, entryID)
+	// Fetch the entry to ensure it exists; meanings added under the ID of a
+	// merged entry go to the entry it was merged into
+	entry, err := s.resolveEntry(ctx, entryID)
 	if err != nil {
 		if database.IsNotFoundError(err) {
 			return nil, database.ErrEntryNotFound
@@ -190,12 +234,13 @@ func (s *entryService) AddMeaning(ctx context.Context, entryID uuid.UUID, req *r
 	now := time.Now().UTC()
 	meaning := database.Meaning{
 		ID:             uuid.New(),
-		EntryID:        entryID,
+		EntryID:        entry.ID,
 		PartOfSpeechId: req.PartOfSpeechID,
 		Description:    req.Description,
 		CreatedAt:      now,
 		UpdatedAt:      now,
 	}
+	meaning.SetLabels(req.Labels)
 
 	// Add examples if provided
 	if len(req.Examples) > 0 {
@@ -224,7 +269,7 @@ func (s *entryService) AddMeaning(ctx context.Context, entryID uuid.UUID, req *r
 	}
 
 	// Retrieve the updated entry to ensure we have the proper data
-	updatedEntry, err := s.repo.GetEntryByID(ctx, entryID)
+	updatedEntry, err := s.repo.GetEntryByID(ctx, entry.ID)
 	if err != nil {
 		s.logger.Error("failed to retrieve updated entry",
 			logging.Error(err),
@@ -258,162 +303,68 @@ func (s *entryService) AddMeaning(ctx context.Context, entryID uuid.UUID, req *r
 func (s *entryService) UpdateMeaning(ctx context.Context, id uuid.UUID, req *request.UpdateMeaningRequest) (*response.MeaningResponse, error) {
 	s.logger.Debug("updating meaning", logging.String("meaningID", id.String()))
 
-	// Find the meaning by ID
-	// Note: This approach searches across all entries to find the meaning
-	// In a real implementation, you would have a direct repository method to get a meaning by ID
-	params := repository.ListParams{
-		Limit: 100, // Reasonable limit to search through entries
-	}
-	entries, err := s.repo.ListEntries(ctx, params)
+	meaning, err := s.repo.GetMeaningByID(ctx, id)
 	if err != nil {
-		s.logger.Error("failed to list entries to find meaning",
+		if database.IsNotFoundError(err) {
+			return nil, err
+		}
+		s.logger.Error("failed to get meaning",
 			logging.Error(err),
 			logging.String("meaningID", id.String()),
 		)
 		return nil, fmt.Errorf("failed to find meaning: %w", err)
 	}
 
-	// Find the meaning and its parent entry
-	var foundMeaning *database.Meaning
-	var foundEntry *database.Entry
-
-	for i := range entries {
-		entry := &entries[i]
-		for j := range entry.Meanings {
-			meaning := &entry.Meanings[j]
-			if meaning.ID == id {
-				foundMeaning = meaning
-				foundEntry = entry
-				break
-			}
-		}
-		if foundMeaning != nil {
-			break
-		}
-	}
-
-	if foundMeaning == nil {
-		return nil, database.ErrEntryNotFound
-	}
-
 	// Update meaning fields
 	if req.PartOfSpeechID != uuid.Nil {
-		foundMeaning.PartOfSpeechId = req.PartOfSpeechID
+		meaning.PartOfSpeechId = req.PartOfSpeechID
 	}
 
 	if req.Description != "" {
-		foundMeaning.Description = req.Description
+		meaning.Description = req.Description
 	}
 
-	foundMeaning.UpdatedAt = time.Now().UTC()
+	if req.Labels != nil {
+		meaning.SetLabels(req.Labels)
+	}
 
 	// Handle examples if provided
 	if len(req.Examples) > 0 {
 		// For simplicity, we'll replace all examples
 		// In a real implementation, you might want to handle more granular updates
-		foundMeaning.Examples = make([]database.Example, len(req.Examples))
+		meaning.Examples = make([]database.Example, len(req.Examples))
 		for i, exampleText := range req.Examples {
-			foundMeaning.Examples[i] = database.Example{
-				ID:        uuid.New(), // New example gets a new ID
-				MeaningID: foundMeaning.ID,
+			meaning.Examples[i] = database.Example{
+				MeaningID: meaning.ID,
 				Text:      exampleText,
-				CreatedAt: time.Now().UTC(),
-				UpdatedAt: time.Now().UTC(),
 			}
 		}
 	}
 
-	// Save the updated entry
-	if err := s.repo.UpdateEntry(ctx, foundEntry); err != nil {
-		s.logger.Error("failed to update meaning",
-			logging.Error(err),
-			logging.String("meaningID", id.String()),
-		)
-		return nil, fmt.Errorf("failed to update meaning: %w", err)
+	if req.Version != 0 {
+		meaning.Version = req.Version
 	}
 
-	// Retrieve the updated entry to ensure we have the latest data
-	updatedEntry, err := s.repo.GetEntryByID(ctx, foundEntry.ID)
-	if err != nil {
-		s.logger.Error("failed to retrieve updated entry",
+	// Save the meaning on its own, leaving the rest of the entry alone
+	if err := s.repo.UpdateMeaning(ctx, meaning); err != nil {
+		s.logger.Error("failed to update meaning",
 			logging.Error(err),
-			logging.String("entryID", foundEntry.ID.String()),
-		)
-		return nil, fmt.Errorf("failed to retrieve updated entry: %w", err)
-	}
-
-	// Find the updated meaning
-	var updatedMeaning *database.Meaning
-	for i := range updatedEntry.Meanings {
-		if updatedEntry.Meanings[i].ID == id {
-			updatedMeaning = &updatedEntry.Meanings[i]
-			break
-		}
-	}
-
-	if updatedMeaning == nil {
-		s.logger.Error("updated meaning not found in entry",
 			logging.String("meaningID", id.String()),
 		)
-		return nil, fmt.Errorf("updated meaning not found in entry")
+		return nil, fmt.Errorf("failed to update meaning: %w", err)
 	}
 
 	// Map to response
-	resp := mapper.MeaningToResponse(updatedMeaning)
+	resp := mapper.MeaningToResponse(meaning)
 	return resp, nil
 }
 
 // DeleteMeaning implements EntryService.DeleteMeaning
-func (s *entryService) DeleteMeaning(ctx context.Context, id uuid.UUID) error {
+func (s *entryService) DeleteMeaning(ctx context.Context, id uuid.UUID, version int) error {
 	s.logger.Debug("deleting meaning", logging.String("meaningID", id.String()))
 
-	// Find the meaning by ID
-	params := repository.ListParams{
-		Limit: 100, // Reasonable limit to search through entries
-	}
-	entries, err := s.repo.ListEntries(ctx, params)
-	if err != nil {
-		s.logger.Error("failed to list entries to find meaning",
-			logging.Error(err),
-			logging.String("meaningID", id.String()),
-		)
-		return fmt.Errorf("failed to find meaning: %w", err)
-	}
-
-	// Find the meaning and its parent entry
-	var foundMeaning *database.Meaning
-	var foundEntry *database.Entry
-	var meaningIndex int
-
-	for i := range entries {
-		entry := &entries[i]
-		for j := range entry.Meanings {
-			meaning := &entry.Meanings[j]
-			if meaning.ID == id {
-				foundMeaning = meaning
-				foundEntry = entry
-				meaningIndex = j
-				break
-			}
-		}
-		if foundMeaning != nil {
-			break
-		}
-	}
-
-	if foundMeaning == nil {
-		return database.ErrEntryNotFound
-	}
-
-	// Remove the meaning from the entry
-	foundEntry.Meanings = append(
-		foundEntry.Meanings[:meaningIndex],
-		foundEntry.Meanings[meaningIndex+1:]...,
-	)
-
-	// Save the updated entry
-	if err := s.repo.UpdateEntry(ctx, foundEntry); err != nil {
-		s.logger.Error("failed to update entry after deleting meaning",
+	if err := s.repo.DeleteMeaning(ctx, id, version); err != nil {
+		s.logger.Error("failed to delete meaning",
 			logging.Error(err),
 			logging.String("meaningID", id.String()),
 		)
@@ -427,8 +378,8 @@ func (s *entryService) DeleteMeaning(ctx context.Context, id uuid.UUID) error {
 func (s *entryService) ListMeanings(ctx context.Context, entryID uuid.UUID) (*response.MeaningListResponse, error) {
 	s.logger.Debug("listing meanings for entry", logging.String("entryID", entryID.String()))
 
-	// Fetch the entry to get its meanings
-	entry, err := s.repo.GetEntryByID(ctx, entryID)
+	// Fetch the entry to get its meanings, following a merge redirect
+	entry, err := s.resolveEntry(ctx, entryID)
 	if err != nil {
 		if database.IsNotFoundError(err) {
 			return nil, database.ErrEntryNotFound
@@ -442,8 +393,10 @@ func (s *entryService) ListMeanings(ctx context.Context, entryID uuid.UUID) (*re
 
 	// Prepare response
 	resp := &response.MeaningListResponse{
-		Meanings: make([]*response.MeaningResponse, len(entry.Meanings)),
-		Total:    len(entry.Meanings),
+		Meanings:       make([]*response.MeaningResponse, len(entry.Meanings)),
+		Total:          len(entry.Meanings),
+		EntryVersion:   entry.Version,
+		EntryUpdatedAt: entry.UpdatedAt,
 	}
 
 	// Map meanings to response
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/valpere/trytrago/application/dto/request"
+	"github.com/valpere/trytrago/application/dto/response"
+	"github.com/valpere/trytrago/application/mapper"
+	"github.com/valpere/trytrago/domain/database"
+	"github.com/valpere/trytrago/domain/database/repository"
+	"github.com/valpere/trytrago/domain/errors"
+	"github.com/valpere/trytrago/domain/logging"
+	"github.com/valpere/trytrago/domain/utils"
+)
+
+// entryTreeService implements the EntryTreeService interface
+type entryTreeService struct {
+	repo   repository.Repository
+	logger logging.Logger
+}
+
+// NewEntryTreeService creates a new instance of EntryTreeService
+func NewEntryTreeService(repo repository.Repository, logger logging.Logger) EntryTreeService {
+	return &entryTreeService{
+		repo:   repo,
+		logger: logger.With(logging.String("service", "entry_tree")),
+	}
+}
+
+// CreateEntryTree implements EntryTreeService.CreateEntryTree
+func (s *entryTreeService) CreateEntryTree(ctx context.Context, req *request.EntryTreeRequest) (*response.EntryResponse, error) {
+	s.logger.Debug("creating entry tree",
+		logging.String("word", req.Word),
+		logging.Int("meanings", len(req.Meanings)),
+	)
+
+	if err := s.validate(ctx, req, nil); err != nil {
+		return nil, err
+	}
+
+	entry := &database.Entry{ID: uuid.New()}
+	applyEntryTree(entry, req)
+
+	// The repository creates the entry and everything under it in one transaction
+	if err := s.repo.CreateEntry(ctx, entry); err != nil {
+		s.logger.Error("failed to create entry tree", logging.Error(err))
+		return nil, fmt.Errorf("failed to create entry: %w", err)
+	}
+
+	return mapper.EntryToResponse(entry), nil
+}
+
+// ReplaceEntryTree implements EntryTreeService.ReplaceEntryTree
+func (s *entryTreeService) ReplaceEntryTree(ctx context.Context, id uuid.UUID, req *request.EntryTreeRequest) (*response.EntryResponse, error) {
+	s.logger.Debug("replacing entry tree",
+		logging.String("id", id.String()),
+		logging.Int("meanings", len(req.Meanings)),
+	)
+
+	entry, err := s.repo.GetEntryByID(ctx, id)
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return nil, database.ErrEntryNotFound
+		}
+		s.logger.Error("failed to get entry for replacement", logging.Error(err), logging.String("id", id.String()))
+		return nil, fmt.Errorf("failed to get entry: %w", err)
+	}
+
+	if err := s.validate(ctx, req, entry); err != nil {
+		return nil, err
+	}
+
+	// An entry that moves to another headword is numbered among its new
+	// homographs, unless the caller asked for a specific index
+	previousKey, previousType := entry.NormalizedWord, entry.Type
+	applyEntryTree(entry, req)
+	if req.HomographIndex == 0 && (utils.NormalizeWord(entry.Word) != previousKey || entry.Type != previousType) {
+		entry.HomographIndex = 0
+	}
+	if req.Version != 0 {
+		entry.Version = req.Version
+	}
+
+	if err := s.repo.ReplaceEntry(ctx, entry); err != nil {
+		s.logger.Error("failed to replace entry tree", logging.Error(err), logging.String("id", id.String()))
+		return nil, fmt.Errorf("failed to replace entry: %w", err)
+	}
+
+	// Reload so the response reflects what was stored, in stored order
+	updated, err := s.repo.GetEntryByID(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to retrieve replaced entry", logging.Error(err), logging.String("id", id.String()))
+		return nil, fmt.Errorf("failed to retrieve updated entry: %w", err)
+	}
+
+	return mapper.EntryToResponse(updated), nil
+}
+
+// validate checks the request as a whole, reporting every problem at once.
+// For a replacement, stored is the entry being replaced and every child ID in
+// the request must name one of its children of the same kind
+func (s *entryTreeService) validate(ctx context.Context, req *request.EntryTreeRequest, stored *database.Entry) error {
+	var problems []string
+
+	parts, err := s.repo.ListPartsOfSpeech(ctx)
+	if err != nil {
+		s.logger.Error("failed to list parts of speech", logging.Error(err))
+		return fmt.Errorf("failed to list parts of speech: %w", err)
+	}
+	knownParts := make(map[uuid.UUID]bool, len(parts))
+	for _, part := range parts {
+		knownParts[part.ID] = true
+	}
+
+	known := make(map[uuid.UUID]string)
+	if stored != nil {
+		for _, meaning := range stored.Meanings {
+			known[meaning.ID] = "meaning"
+			for _, example := range meaning.Examples {
+				known[example.ID] = "example"
+			}
+			for _, translation := range meaning.Translations {
+				known[translation.ID] = "translation"
+			}
+		}
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	checkID := func(path string, id uuid.UUID, kind string) {
+		switch {
+		case id == uuid.Nil:
+		case stored == nil:
+			problems = append(problems, fmt.Sprintf("%s: id is not allowed when creating an entry", path))
+		case known[id] != kind:
+			problems = append(problems, fmt.Sprintf("%s: %s %s does not belong to the entry", path, kind, id))
+		case seen[id]:
+			problems = append(problems, fmt.Sprintf("%s: %s %s appears more than once", path, kind, id))
+		}
+		seen[id] = true
+	}
+
+	for i, meaning := range req.Meanings {
+		path := fmt.Sprintf("meanings[%d]", i)
+		checkID(path, meaning.ID, "meaning")
+		if !knownParts[meaning.PartOfSpeechID] {
+			problems = append(problems, fmt.Sprintf("%s: unknown part of speech %s", path, meaning.PartOfSpeechID))
+		}
+		for j, example := range meaning.Examples {
+			checkID(fmt.Sprintf("%s.examples[%d]", path, j), example.ID, "example")
+		}
+		for j, translation := range meaning.Translations {
+			checkID(fmt.Sprintf("%s.translations[%d]", path, j), translation.ID, "translation")
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", errors.ErrValidation, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// applyEntryTree copies the request onto the entry. Stored children named by
+// ID are updated in place and keep what the request does not carry, such as
+// the review state of an unchanged translation. New and changed translations
+// are proposals awaiting review
+func applyEntryTree(entry *database.Entry, req *request.EntryTreeRequest) {
+	now := time.Now().UTC()
+
+	entry.Word = req.Word
+	entry.Type = database.EntryType(req.Type)
+	entry.SourceLanguageID = req.SourceLanguageID
+	if req.HomographIndex > 0 {
+		entry.HomographIndex = req.HomographIndex
+	}
+	entry.Pronunciation = req.Pronunciation
+	entry.Etymology = req.Etymology
+	entry.UpdatedAt = now
+
+	// Translations under review are not shown to clients, so a tree that
+	// leaves them out keeps them rather than deleting them
+	named := make(map[uuid.UUID]bool)
+	for _, m := range req.Meanings {
+		for _, t := range m.Translations {
+			named[t.ID] = true
+		}
+	}
+
+	meanings := make(map[uuid.UUID]database.Meaning)
+	examples := make(map[uuid.UUID]database.Example)
+	translations := make(map[uuid.UUID]database.Translation)
+	for _, meaning := range entry.Meanings {
+		meanings[meaning.ID] = meaning
+		for _, example := range meaning.Examples {
+			examples[example.ID] = example
+		}
+		for _, translation := range meaning.Translations {
+			translations[translation.ID] = translation
+		}
+	}
+
+	entry.Meanings = make([]database.Meaning, len(req.Meanings))
+	for i, m := range req.Meanings {
+		meaning := meanings[m.ID]
+		meaning.ID = m.ID
+		meaning.PartOfSpeechId = m.PartOfSpeechID
+		meaning.Description = m.Description
+		meaning.SetLabels(m.Labels)
+
+		meaning.Examples = make([]database.Example, len(m.Examples))
+		for j, e := range m.Examples {
+			example := examples[e.ID]
+			example.ID = e.ID
+			example.Text = e.Text
+			example.Context = e.Context
+			meaning.Examples[j] = example
+		}
+
+		meaning.Translations = make([]database.Translation, 0, len(m.Translations))
+		for _, t := range m.Translations {
+			translation, ok := translations[t.ID]
+			if ok {
+				// Changed text goes back to review instead of going public unchecked
+				translation.Revise(t.LanguageID, t.Text)
+			} else {
+				authorID := req.UserID
+				translation = database.Translation{
+					ID:          t.ID,
+					LanguageID:  t.LanguageID,
+					Text:        t.Text,
+					Status:      database.TranslationProposed,
+					CreatedByID: &authorID,
+				}
+			}
+			meaning.Translations = append(meaning.Translations, translation)
+		}
+		if stored, ok := meanings[m.ID]; ok {
+			for _, translation := range stored.Translations {
+				if !translation.IsPublic() && !named[translation.ID] {
+					meaning.Translations = append(meaning.Translations, translation)
+				}
+			}
+		}
+
+		entry.Meanings[i] = meaning
+	}
+}
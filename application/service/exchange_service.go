@@ -0,0 +1,422 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/valpere/trytrago/application/dto/request"
+	"github.com/valpere/trytrago/application/dto/response"
+	"github.com/valpere/trytrago/domain/database"
+	"github.com/valpere/trytrago/domain/database/repository"
+	"github.com/valpere/trytrago/domain/logging"
+	domainValidator "github.com/valpere/trytrago/domain/validator"
+	"github.com/valpere/trytrago/infrastructure/exchange"
+)
+
+const (
+	defaultImportBatchSize = 500
+	maxReportedRowErrors   = 1000
+
+	// Entries are exported with all their meanings, so batches stay small
+	exportBatchSize = 200
+)
+
+// exchangeService implements the ExchangeService interface
+type exchangeService struct {
+	repo     repository.Repository
+	logger   logging.Logger
+	validate *validator.Validate
+}
+
+// NewExchangeService creates a new instance of ExchangeService
+func NewExchangeService(repo repository.Repository, logger logging.Logger) ExchangeService {
+	validate := validator.New()
+	domainValidator.RegisterCustomValidators(validate)
+
+	// Report fields by their JSON names, which is what import authors know
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+
+	return &exchangeService{
+		repo:     repo,
+		logger:   logger.With(logging.String("service", "exchange")),
+		validate: validate,
+	}
+}
+
+// pendingEntry is a validated record waiting for its batch to be written
+type pendingEntry struct {
+	position int
+	entry    *database.Entry
+}
+
+// importRun holds the state of a single import
+type importRun struct {
+	report        *response.ImportReport
+	partsOfSpeech map[string]uuid.UUID
+	batch         []pendingEntry
+	lastFailed    int // Position of the most recently rejected record
+}
+
+// Import implements ExchangeService.Import
+func (s *exchangeService) Import(ctx context.Context, reader exchange.RecordReader, req *request.ImportRequest) (*response.ImportReport, error) {
+	batchSize := req.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultImportBatchSize
+	}
+
+	s.logger.Info("starting import",
+		logging.Int("batchSize", batchSize),
+		logging.Int("resumeFrom", req.ResumeFrom),
+	)
+
+	run := &importRun{
+		report:        &response.ImportReport{Checkpoint: req.ResumeFrom},
+		partsOfSpeech: make(map[string]uuid.UUID),
+	}
+
+	// consumed counts source records from the very start, including skipped ones
+	consumed := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return run.report, err
+		}
+
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		var rowErr *exchange.RowError
+		if err != nil && !errors.As(err, &rowErr) {
+			s.logger.Error("failed to read import source", logging.Error(err))
+			return run.report, fmt.Errorf("failed to read import source: %w", err)
+		}
+
+		consumed++
+		if consumed <= req.ResumeFrom {
+			run.report.Skipped++
+			continue
+		}
+		run.report.Processed++
+
+		if rowErr != nil {
+			run.fail(rowErr.Position, rowErr.Field, rowErr.Message)
+			continue
+		}
+
+		entry, ok := s.prepareRecord(ctx, run, record)
+		if !ok {
+			continue
+		}
+		run.batch = append(run.batch, pendingEntry{position: record.Position, entry: entry})
+
+		if len(run.batch) >= batchSize {
+			if err := s.flush(ctx, run, consumed, req); err != nil {
+				return run.report, err
+			}
+		}
+	}
+
+	if err := s.flush(ctx, run, consumed, req); err != nil {
+		return run.report, err
+	}
+	run.report.Completed = true
+
+	s.logger.Info("import finished",
+		logging.Int("processed", run.report.Processed),
+		logging.Int("imported", run.report.Imported),
+		logging.Int("failed", run.report.Failed),
+	)
+
+	return run.report, nil
+}
+
+// prepareRecord validates a record and converts it into an entry. Problems
+// are added to the report and reported as false
+func (s *exchangeService) prepareRecord(ctx context.Context, run *importRun, record *exchange.Record) (*database.Entry, bool) {
+	if err := s.validate.Struct(record); err != nil {
+		var fieldErrs validator.ValidationErrors
+		if !errors.As(err, &fieldErrs) {
+			run.fail(record.Position, "", err.Error())
+			return nil, false
+		}
+		for _, fe := range fieldErrs {
+			run.fail(record.Position, importFieldName(fe), fmt.Sprintf("failed %q validation", fe.Tag()))
+		}
+		return nil, false
+	}
+
+	entry := &database.Entry{
+		Word:             record.Word,
+		Type:             database.EntryType(record.Type),
+		SourceLanguageID: record.SourceLanguageID,
+		Pronunciation:    record.Pronunciation,
+		Etymology:        record.Etymology,
+		Source:           record.Source,
+		License:          record.License,
+	}
+
+	for _, rm := range record.Meanings {
+		posID, err := s.partOfSpeechID(ctx, run, rm.PartOfSpeech)
+		if err != nil {
+			run.fail(record.Position, "part_of_speech", "unknown part of speech")
+			return nil, false
+		}
+
+		meaning := database.Meaning{
+			PartOfSpeechId: posID,
+			Description:    rm.Description,
+		}
+		meaning.SetLabels(rm.Labels)
+		for _, text := range rm.Examples {
+			meaning.Examples = append(meaning.Examples, database.Example{Text: text})
+		}
+		for _, rt := range rm.Translations {
+			// Imports are curated in bulk and skip the review queue
+			meaning.Translations = append(meaning.Translations, database.Translation{
+				LanguageID: rt.LanguageID,
+				Text:       rt.Text,
+				Status:     database.TranslationApproved,
+			})
+		}
+		entry.Meanings = append(entry.Meanings, meaning)
+	}
+
+	return entry, true
+}
+
+// partOfSpeechID resolves a part of speech name, caching lookups for the run
+func (s *exchangeService) partOfSpeechID(ctx context.Context, run *importRun, name string) (uuid.UUID, error) {
+	if id, ok := run.partsOfSpeech[name]; ok {
+		return id, nil
+	}
+
+	pos, err := s.repo.GetOrCreatePartOfSpeech(ctx, name)
+	if err != nil {
+		s.logger.Warn("failed to resolve part of speech",
+			logging.Error(err),
+			logging.String("name", name),
+		)
+		return uuid.Nil, err
+	}
+
+	run.partsOfSpeech[name] = pos.ID
+	return pos.ID, nil
+}
+
+// flush writes the pending batch and advances the checkpoint. A batch that
+// fails as a whole is retried entry by entry to find the rows at fault
+func (s *exchangeService) flush(ctx context.Context, run *importRun, consumed int, req *request.ImportRequest) error {
+	if len(run.batch) > 0 {
+		entries := make([]*database.Entry, len(run.batch))
+		for i := range run.batch {
+			entries[i] = run.batch[i].entry
+		}
+
+		if err := s.repo.CreateEntries(ctx, entries); err != nil {
+			s.logger.Warn("batch insert failed, retrying entries one by one",
+				logging.Error(err),
+				logging.Int("size", len(entries)),
+			)
+			for _, pending := range run.batch {
+				// Let the repository pick the homograph index again
+				pending.entry.HomographIndex = 0
+				if err := s.repo.CreateEntry(ctx, pending.entry); err != nil {
+					if ctx.Err() != nil {
+						return ctx.Err()
+					}
+					s.logger.Debug("failed to import entry",
+						logging.Error(err),
+						logging.Int("row", pending.position),
+					)
+					run.fail(pending.position, "", importStoreMessage(err))
+					continue
+				}
+				run.report.Imported++
+			}
+		} else {
+			run.report.Imported += len(entries)
+		}
+		run.batch = run.batch[:0]
+	}
+
+	if consumed <= run.report.Checkpoint {
+		return nil
+	}
+	run.report.Checkpoint = consumed
+
+	if req.OnCheckpoint != nil {
+		if err := req.OnCheckpoint(consumed); err != nil {
+			return fmt.Errorf("failed to save import checkpoint: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Export implements ExchangeService.Export. Entries are streamed in batches,
+// so the export never holds the whole dictionary in memory
+func (s *exchangeService) Export(ctx context.Context, writer exchange.RecordWriter, req *request.ExportRequest) (*response.ExportReport, error) {
+	parts, err := s.repo.ListPartsOfSpeech(ctx)
+	if err != nil {
+		s.logger.Error("failed to load parts of speech", logging.Error(err))
+		return nil, fmt.Errorf("failed to load parts of speech: %w", err)
+	}
+	partNames := make(map[uuid.UUID]string, len(parts))
+	for _, part := range parts {
+		partNames[part.ID] = part.Name
+	}
+
+	params := repository.IterateParams{
+		BatchSize:     exportBatchSize,
+		Filters:       make(map[string]interface{}),
+		WithRelations: true,
+	}
+	if req.Type != "" {
+		params.Filters["type = ?"] = req.Type
+	}
+	if req.LanguageID != "" {
+		params.Filters["source_language_id = ?"] = req.LanguageID
+	}
+	if len(req.Words) > 0 {
+		params.Filters["word IN ?"] = req.Words
+	}
+
+	report := &response.ExportReport{}
+	err = s.repo.IterateEntries(ctx, params, func(batch []database.Entry) error {
+		for i := range batch {
+			record := exportRecord(&batch[i], partNames, req)
+			if req.TargetLanguageID != "" && !hasTranslations(record) {
+				continue
+			}
+			if req.Label != "" && len(record.Meanings) == 0 {
+				continue
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+			report.Exported++
+		}
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("export failed",
+			logging.Error(err),
+			logging.Int("exported", report.Exported),
+		)
+		return report, fmt.Errorf("failed to export entries: %w", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		return report, fmt.Errorf("failed to finish export: %w", err)
+	}
+
+	s.logger.Info("export finished", logging.Int("exported", report.Exported))
+	return report, nil
+}
+
+// exportRecord converts an entry into a record. Only approved translations
+// leave the system; proposals are still under review. A target language, if
+// given, drops translations into any other language, and a label drops the
+// meanings without it
+func exportRecord(entry *database.Entry, partNames map[uuid.UUID]string, req *request.ExportRequest) *exchange.Record {
+	record := &exchange.Record{
+		ID:               entry.ID.String(),
+		Word:             entry.Word,
+		Type:             string(entry.Type),
+		SourceLanguageID: entry.SourceLanguageID,
+		Pronunciation:    entry.Pronunciation,
+		Etymology:        entry.Etymology,
+		Source:           entry.Source,
+		License:          entry.License,
+	}
+
+	for _, m := range entry.Meanings {
+		if req.Label != "" && !m.HasLabel(req.Label) {
+			continue
+		}
+
+		meaning := exchange.RecordMeaning{
+			PartOfSpeech: partNames[m.PartOfSpeechId],
+			Description:  m.Description,
+			Labels:       m.LabelList(),
+		}
+		for _, example := range m.Examples {
+			meaning.Examples = append(meaning.Examples, example.Text)
+		}
+		for _, t := range m.Translations {
+			if !t.IsPublic() {
+				continue
+			}
+			if req.TargetLanguageID != "" && t.LanguageID != req.TargetLanguageID {
+				continue
+			}
+			meaning.Translations = append(meaning.Translations, exchange.RecordTranslation{
+				LanguageID: t.LanguageID,
+				Text:       t.Text,
+			})
+		}
+		record.Meanings = append(record.Meanings, meaning)
+	}
+
+	return record
+}
+
+// hasTranslations reports whether any meaning of the record has a translation
+func hasTranslations(record *exchange.Record) bool {
+	for _, meaning := range record.Meanings {
+		if len(meaning.Translations) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// fail records a rejected row in the report
+func (r *importRun) fail(position int, field, message string) {
+	// A record with several invalid fields still counts once
+	if r.report.Failed == 0 || r.lastFailed != position {
+		r.report.Failed++
+		r.lastFailed = position
+	}
+
+	if len(r.report.Errors) >= maxReportedRowErrors {
+		r.report.ErrorsTruncated = true
+		return
+	}
+	r.report.Errors = append(r.report.Errors, response.ImportRowError{
+		Row:     position,
+		Field:   field,
+		Message: message,
+	})
+}
+
+// importFieldName turns a validator namespace such as "Record.meanings[0].description"
+// into the field path used in import reports
+func importFieldName(fe validator.FieldError) string {
+	ns := fe.Namespace()
+	if i := strings.Index(ns, "."); i >= 0 {
+		ns = ns[i+1:]
+	}
+	return ns
+}
+
+// importStoreMessage explains why the database refused an entry
+func importStoreMessage(err error) string {
+	if database.IsDuplicateError(err) {
+		return "entry already exists"
+	}
+	return "failed to store entry"
+}
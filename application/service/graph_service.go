@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/valpere/trytrago/application/dto/request"
+	"github.com/valpere/trytrago/application/dto/response"
+	"github.com/valpere/trytrago/application/mapper"
+	"github.com/valpere/trytrago/domain/database"
+	"github.com/valpere/trytrago/domain/database/repository"
+	"github.com/valpere/trytrago/domain/logging"
+)
+
+// graphService implements the GraphService interface
+type graphService struct {
+	repo   repository.Repository
+	logger logging.Logger
+}
+
+// NewGraphService creates a new instance of GraphService
+func NewGraphService(repo repository.Repository, logger logging.Logger) GraphService {
+	return &graphService{
+		repo:   repo,
+		logger: logger.With(logging.String("service", "graph")),
+	}
+}
+
+// ListEntries implements GraphService.ListEntries
+func (s *graphService) ListEntries(ctx context.Context, req *request.ListEntriesRequest) ([]*response.EntryResponse, error) {
+	s.logger.Debug("listing entries for graph",
+		logging.Int("limit", req.Limit),
+		logging.Int("offset", req.Offset),
+	)
+
+	params := repository.ListParams{
+		Offset:           req.Offset,
+		Limit:            req.Limit,
+		SortBy:           req.SortBy,
+		SortDesc:         req.SortDesc,
+		Filters:          make(map[string]interface{}),
+		WithoutRelations: true,
+	}
+	if req.WordFilter != "" {
+		params.Filters["word LIKE ?"] = "%" + req.WordFilter + "%"
+	}
+	if req.Type != "" {
+		params.Filters["type = ?"] = req.Type
+	}
+
+	entries, err := s.repo.ListEntries(ctx, params)
+	if err != nil {
+		s.logger.Error("failed to list entries", logging.Error(err))
+		return nil, fmt.Errorf("failed to list entries: %w", err)
+	}
+
+	resp := make([]*response.EntryResponse, len(entries))
+	for i := range entries {
+		resp[i] = mapper.EntryToResponse(&entries[i])
+	}
+	return resp, nil
+}
+
+// EntriesByID implements GraphService.EntriesByID
+func (s *graphService) EntriesByID(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*response.EntryResponse, error) {
+	entries, err := s.repo.GetEntriesByIDs(ctx, ids)
+	if err != nil {
+		s.logger.Error("failed to load entries", logging.Error(err), logging.Int("count", len(ids)))
+		return nil, fmt.Errorf("failed to load entries: %w", err)
+	}
+
+	resp := make(map[uuid.UUID]*response.EntryResponse, len(ids))
+	for i := range entries {
+		resp[entries[i].ID] = mapper.EntryToResponse(&entries[i])
+	}
+
+	// Entries merged into others are found under the entry that absorbed
+	// them, as EntryService.GetEntryByID does
+	redirects := make(map[uuid.UUID]uuid.UUID)
+	var targets []uuid.UUID
+	for _, id := range ids {
+		if _, ok := resp[id]; ok {
+			continue
+		}
+		redirect, err := s.repo.GetEntryRedirect(ctx, id)
+		if err != nil {
+			if database.IsNotFoundError(err) {
+				continue
+			}
+			s.logger.Error("failed to get entry redirect", logging.Error(err), logging.String("id", id.String()))
+			return nil, fmt.Errorf("failed to load entries: %w", err)
+		}
+		redirects[id] = redirect.ToID
+		if _, ok := resp[redirect.ToID]; !ok {
+			targets = append(targets, redirect.ToID)
+		}
+	}
+	if len(redirects) == 0 {
+		return resp, nil
+	}
+
+	if len(targets) > 0 {
+		merged, err := s.repo.GetEntriesByIDs(ctx, targets)
+		if err != nil {
+			s.logger.Error("failed to load merge targets", logging.Error(err), logging.Int("count", len(targets)))
+			return nil, fmt.Errorf("failed to load entries: %w", err)
+		}
+		for i := range merged {
+			resp[merged[i].ID] = mapper.EntryToResponse(&merged[i])
+		}
+	}
+	for from, to := range redirects {
+		if entry, ok := resp[to]; ok {
+			resp[from] = entry
+		}
+	}
+	return resp, nil
+}
+
+// GetMeaning implements GraphService.GetMeaning
+func (s *graphService) GetMeaning(ctx context.Context, id uuid.UUID) (*response.MeaningResponse, error) {
+	meaning, err := s.repo.GetMeaningByID(ctx, id)
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return nil, err
+		}
+		s.logger.Error("failed to get meaning", logging.Error(err), logging.String("meaningID", id.String()))
+		return nil, fmt.Errorf("failed to get meaning: %w", err)
+	}
+
+	names, err := s.partOfSpeechNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Translations are resolved through TranslationsByMeaning like any others
+	meaning.Translations = nil
+	resp := mapper.MeaningToResponse(meaning)
+	resp.PartOfSpeech = names[meaning.PartOfSpeechId]
+	return resp, nil
+}
+
+// MeaningsByEntry implements GraphService.MeaningsByEntry
+func (s *graphService) MeaningsByEntry(ctx context.Context, entryIDs []uuid.UUID) (map[uuid.UUID][]*response.MeaningResponse, error) {
+	meanings, err := s.repo.ListMeaningsByEntryIDs(ctx, entryIDs)
+	if err != nil {
+		s.logger.Error("failed to load meanings", logging.Error(err), logging.Int("entries", len(entryIDs)))
+		return nil, fmt.Errorf("failed to load meanings: %w", err)
+	}
+
+	names, err := s.partOfSpeechNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make(map[uuid.UUID][]*response.MeaningResponse, len(entryIDs))
+	for i := range meanings {
+		meaning := mapper.MeaningToResponse(&meanings[i])
+		meaning.PartOfSpeech = names[meanings[i].PartOfSpeechId]
+		resp[meaning.EntryID] = append(resp[meaning.EntryID], meaning)
+	}
+	return resp, nil
+}
+
+// TranslationsByMeaning implements GraphService.TranslationsByMeaning
+func (s *graphService) TranslationsByMeaning(ctx context.Context, meaningIDs []uuid.UUID) (map[uuid.UUID][]*response.TranslationResponse, error) {
+	translations, err := s.repo.ListTranslationsByMeaningIDs(ctx, meaningIDs)
+	if err != nil {
+		s.logger.Error("failed to load translations", logging.Error(err), logging.Int("meanings", len(meaningIDs)))
+		return nil, fmt.Errorf("failed to load translations: %w", err)
+	}
+
+	// Only approved translations are public
+	resp := make(map[uuid.UUID][]*response.TranslationResponse, len(meaningIDs))
+	for i := range translations {
+		if !translations[i].IsPublic() {
+			continue
+		}
+		translation := mapper.TranslationToResponse(&translations[i])
+		resp[translation.MeaningID] = append(resp[translation.MeaningID], translation)
+	}
+	return resp, nil
+}
+
+// CommentsByTarget implements GraphService.CommentsByTarget
+func (s *graphService) CommentsByTarget(ctx context.Context, targetType string, targetIDs []uuid.UUID) (map[uuid.UUID][]*response.CommentResponse, error) {
+	comments, err := s.repo.ListCommentsByTargets(ctx, targetType, targetIDs)
+	if err != nil {
+		s.logger.Error("failed to load comments", logging.Error(err), logging.String("targetType", targetType))
+		return nil, fmt.Errorf("failed to load comments: %w", err)
+	}
+
+	resp := make(map[uuid.UUID][]*response.CommentResponse, len(targetIDs))
+	for i := range comments {
+		comment := mapper.CommentToResponse(&comments[i])
+		comment.User.ID = comments[i].UserID
+		resp[comments[i].TargetID] = append(resp[comments[i].TargetID], comment)
+	}
+	return resp, nil
+}
+
+// LikeCounts implements GraphService.LikeCounts
+func (s *graphService) LikeCounts(ctx context.Context, targetType string, targetIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	counts, err := s.repo.CountLikesByTargets(ctx, targetType, targetIDs)
+	if err != nil {
+		s.logger.Error("failed to count likes", logging.Error(err), logging.String("targetType", targetType))
+		return nil, fmt.Errorf("failed to count likes: %w", err)
+	}
+
+	resp := make(map[uuid.UUID]int, len(targetIDs))
+	for _, id := range targetIDs {
+		resp[id] = int(counts[id])
+	}
+	return resp, nil
+}
+
+// UsersByID implements GraphService.UsersByID
+func (s *graphService) UsersByID(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*response.UserSummary, error) {
+	users, err := s.repo.GetUsersByIDs(ctx, ids)
+	if err != nil {
+		s.logger.Error("failed to load users", logging.Error(err), logging.Int("count", len(ids)))
+		return nil, fmt.Errorf("failed to load users: %w", err)
+	}
+
+	resp := make(map[uuid.UUID]*response.UserSummary, len(users))
+	for i := range users {
+		resp[users[i].ID] = mapper.UserToSummary(&users[i])
+	}
+	return resp, nil
+}
+
+// ListLanguages implements GraphService.ListLanguages
+func (s *graphService) ListLanguages(ctx context.Context) ([]*response.LanguageInfo, error) {
+	languages, err := s.repo.ListLanguages(ctx)
+	if err != nil {
+		s.logger.Error("failed to list languages", logging.Error(err))
+		return nil, fmt.Errorf("failed to list languages: %w", err)
+	}
+
+	resp := make([]*response.LanguageInfo, len(languages))
+	for i, language := range languages {
+		resp[i] = &response.LanguageInfo{
+			Code:       language.Code,
+			Name:       language.Name,
+			NativeName: language.NativeName,
+			RTL:        language.RTL,
+		}
+	}
+	return resp, nil
+}
+
+// partOfSpeechNames maps part of speech IDs to their names
+func (s *graphService) partOfSpeechNames(ctx context.Context) (map[uuid.UUID]string, error) {
+	parts, err := s.repo.ListPartsOfSpeech(ctx)
+	if err != nil {
+		s.logger.Error("failed to list parts of speech", logging.Error(err))
+		return nil, fmt.Errorf("failed to list parts of speech: %w", err)
+	}
+
+	names := make(map[uuid.UUID]string, len(parts))
+	for _, part := range parts {
+		names[part.ID] = part.Name
+	}
+	return names, nil
+}
@@ -0,0 +1,294 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	stdErrors "errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/valpere/trytrago/application/dto/request"
+	"github.com/valpere/trytrago/application/dto/response"
+	"github.com/valpere/trytrago/application/mapper"
+	"github.com/valpere/trytrago/domain/database"
+	"github.com/valpere/trytrago/domain/database/repository"
+	"github.com/valpere/trytrago/domain/errors"
+	"github.com/valpere/trytrago/domain/logging"
+	"github.com/valpere/trytrago/domain/utils"
+)
+
+// patchService implements the PatchService interface
+type patchService struct {
+	repo     repository.Repository
+	logger   logging.Logger
+	validate *validator.Validate
+}
+
+// NewPatchService creates a new instance of PatchService
+func NewPatchService(repo repository.Repository, logger logging.Logger) PatchService {
+	// Patched documents are checked against the same binding rules as request
+	// bodies, and problems are reported by the JSON names the patch used
+	validate := validator.New()
+	validate.SetTagName("binding")
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+
+	return &patchService{
+		repo:     repo,
+		logger:   logger.With(logging.String("service", "patch")),
+		validate: validate,
+	}
+}
+
+// PatchEntry implements PatchService.PatchEntry
+func (s *patchService) PatchEntry(ctx context.Context, id uuid.UUID, req *request.PatchRequest) (*response.EntryResponse, error) {
+	s.logger.Debug("patching entry", logging.String("id", id.String()), logging.String("mediaType", req.MediaType))
+
+	entry, err := s.repo.GetEntryByID(ctx, id)
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return nil, database.ErrEntryNotFound
+		}
+		s.logger.Error("failed to get entry for patch", logging.Error(err), logging.String("id", id.String()))
+		return nil, fmt.Errorf("failed to get entry: %w", err)
+	}
+
+	current := request.EntryPatch{
+		Word:             entry.Word,
+		Type:             string(entry.Type),
+		SourceLanguageID: entry.SourceLanguageID,
+		HomographIndex:   entry.HomographIndex,
+		Pronunciation:    entry.Pronunciation,
+		Etymology:        entry.Etymology,
+	}
+	var patched request.EntryPatch
+	if err := s.apply(req, &current, &patched); err != nil {
+		return nil, err
+	}
+
+	// An entry that moves to another headword is numbered among its new
+	// homographs, unless the patch also set its index
+	previousKey, previousType := entry.NormalizedWord, entry.Type
+	entry.Word = utils.SanitizeString(patched.Word)
+	entry.Type = database.EntryType(patched.Type)
+	entry.SourceLanguageID = patched.SourceLanguageID
+	entry.HomographIndex = patched.HomographIndex
+	if patched.HomographIndex == current.HomographIndex &&
+		(utils.NormalizeWord(entry.Word) != previousKey || entry.Type != previousType) {
+		entry.HomographIndex = 0
+	}
+	entry.Pronunciation = utils.SanitizeString(patched.Pronunciation)
+	entry.Etymology = patched.Etymology
+	entry.UpdatedAt = time.Now().UTC()
+
+	// Without If-Match the version loaded above is compared, so a change made
+	// since it was read fails instead of being overwritten
+	if req.Version != 0 {
+		entry.Version = req.Version
+	}
+
+	if err := s.repo.UpdateEntry(ctx, entry); err != nil {
+		s.logger.Error("failed to save patched entry", logging.Error(err), logging.String("id", id.String()))
+		return nil, fmt.Errorf("failed to update entry: %w", err)
+	}
+
+	return mapper.EntryToResponse(entry), nil
+}
+
+// PatchMeaning implements PatchService.PatchMeaning
+func (s *patchService) PatchMeaning(ctx context.Context, id uuid.UUID, req *request.PatchRequest) (*response.MeaningResponse, error) {
+	s.logger.Debug("patching meaning", logging.String("meaningID", id.String()), logging.String("mediaType", req.MediaType))
+
+	meaning, err := s.repo.GetMeaningByID(ctx, id)
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return nil, err
+		}
+		s.logger.Error("failed to get meaning for patch", logging.Error(err), logging.String("meaningID", id.String()))
+		return nil, fmt.Errorf("failed to find meaning: %w", err)
+	}
+
+	current := request.MeaningPatch{
+		PartOfSpeechID: meaning.PartOfSpeechId,
+		Description:    meaning.Description,
+		Labels:         meaning.LabelList(),
+		Examples:       make([]request.ExampleTreeRequest, len(meaning.Examples)),
+	}
+	// JSON Patch appends to lists with "/labels/-", which needs a list to append to
+	if current.Labels == nil {
+		current.Labels = []string{}
+	}
+	for i, example := range meaning.Examples {
+		current.Examples[i] = request.ExampleTreeRequest{ID: example.ID, Text: example.Text, Context: example.Context}
+	}
+	var patched request.MeaningPatch
+	if err := s.apply(req, &current, &patched); err != nil {
+		return nil, err
+	}
+	if err := s.checkMeaning(ctx, meaning, &patched); err != nil {
+		return nil, err
+	}
+
+	// Examples named by ID are changed in place; the repository deletes those
+	// the patch removed and creates those without an ID
+	stored := make(map[uuid.UUID]database.Example, len(meaning.Examples))
+	for _, example := range meaning.Examples {
+		stored[example.ID] = example
+	}
+	examples := make([]database.Example, len(patched.Examples))
+	for i, e := range patched.Examples {
+		example := stored[e.ID]
+		example.ID = e.ID
+		example.MeaningID = meaning.ID
+		example.Text = e.Text
+		example.Context = e.Context
+		examples[i] = example
+	}
+
+	meaning.PartOfSpeechId = patched.PartOfSpeechID
+	meaning.Description = patched.Description
+	meaning.SetLabels(patched.Labels)
+	meaning.Examples = examples
+	if req.Version != 0 {
+		meaning.Version = req.Version
+	}
+
+	if err := s.repo.UpdateMeaning(ctx, meaning); err != nil {
+		s.logger.Error("failed to save patched meaning", logging.Error(err), logging.String("meaningID", id.String()))
+		return nil, fmt.Errorf("failed to update meaning: %w", err)
+	}
+
+	return mapper.MeaningToResponse(meaning), nil
+}
+
+// PatchTranslation implements PatchService.PatchTranslation
+func (s *patchService) PatchTranslation(ctx context.Context, id uuid.UUID, req *request.PatchRequest) (*response.TranslationResponse, error) {
+	s.logger.Debug("patching translation", logging.String("id", id.String()), logging.String("mediaType", req.MediaType))
+
+	translation, err := s.repo.GetTranslationByID(ctx, id)
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return nil, err
+		}
+		s.logger.Error("failed to get translation for patch", logging.Error(err), logging.String("translationID", id.String()))
+		return nil, fmt.Errorf("failed to find translation: %w", err)
+	}
+
+	current := request.TranslationPatch{LanguageID: translation.LanguageID, Text: translation.Text}
+	var patched request.TranslationPatch
+	if err := s.apply(req, &current, &patched); err != nil {
+		return nil, err
+	}
+
+	// Changed text goes back to review instead of going public unchecked
+	translation.Revise(patched.LanguageID, patched.Text)
+	if req.Version != 0 {
+		translation.Version = req.Version
+	}
+
+	if err := s.repo.UpdateTranslation(ctx, translation); err != nil {
+		s.logger.Error("failed to save patched translation", logging.Error(err), logging.String("translationID", id.String()))
+		return nil, fmt.Errorf("failed to update translation: %w", err)
+	}
+
+	return mapper.TranslationToResponse(translation), nil
+}
+
+// apply patches the current document into patched and validates the result.
+// A patch that adds a member the document does not have, or gives a member a
+// value of the wrong type, fails like one that names a missing path
+func (s *patchService) apply(req *request.PatchRequest, current, patched interface{}) error {
+	document, err := json.Marshal(current)
+	if err != nil {
+		return fmt.Errorf("failed to encode document: %w", err)
+	}
+
+	switch req.MediaType {
+	case request.MergePatchMediaType:
+		document, err = utils.ApplyMergePatch(document, req.Patch)
+	case request.JSONPatchMediaType:
+		document, err = utils.ApplyJSONPatch(document, req.Patch)
+	default:
+		return fmt.Errorf("%w: %q", errors.ErrUnsupportedPatch, req.MediaType)
+	}
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(patched); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if stdErrors.As(err, &typeErr) {
+			return fmt.Errorf("%w: %s must be a %s", errors.ErrPatchNotApplicable, typeErr.Field, typeErr.Type)
+		}
+		return fmt.Errorf("%w: %s", errors.ErrPatchNotApplicable, strings.TrimPrefix(err.Error(), "json: "))
+	}
+
+	if err := s.validate.Struct(patched); err != nil {
+		var fieldErrs validator.ValidationErrors
+		if !stdErrors.As(err, &fieldErrs) {
+			return fmt.Errorf("%w: %v", errors.ErrValidation, err)
+		}
+		problems := make([]string, len(fieldErrs))
+		for i, fe := range fieldErrs {
+			problems[i] = fmt.Sprintf("%s: failed %q validation", importFieldName(fe), fe.Tag())
+		}
+		return fmt.Errorf("%w: %s", errors.ErrValidation, strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// checkMeaning checks what binding rules cannot: the part of speech must
+// exist and every example ID must name one of the meaning's own examples
+func (s *patchService) checkMeaning(ctx context.Context, meaning *database.Meaning, patched *request.MeaningPatch) error {
+	var problems []string
+
+	if patched.PartOfSpeechID != meaning.PartOfSpeechId {
+		parts, err := s.repo.ListPartsOfSpeech(ctx)
+		if err != nil {
+			s.logger.Error("failed to list parts of speech", logging.Error(err))
+			return fmt.Errorf("failed to list parts of speech: %w", err)
+		}
+		known := false
+		for _, part := range parts {
+			known = known || part.ID == patched.PartOfSpeechID
+		}
+		if !known {
+			problems = append(problems, fmt.Sprintf("part_of_speech_id: unknown part of speech %s", patched.PartOfSpeechID))
+		}
+	}
+
+	own := make(map[uuid.UUID]bool, len(meaning.Examples))
+	for _, example := range meaning.Examples {
+		own[example.ID] = true
+	}
+	seen := make(map[uuid.UUID]bool)
+	for i, example := range patched.Examples {
+		switch {
+		case example.ID == uuid.Nil:
+		case !own[example.ID]:
+			problems = append(problems, fmt.Sprintf("examples[%d]: example %s does not belong to the meaning", i, example.ID))
+		case seen[example.ID]:
+			problems = append(problems, fmt.Sprintf("examples[%d]: example %s appears more than once", i, example.ID))
+		}
+		seen[example.ID] = true
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", errors.ErrValidation, strings.Join(problems, "; "))
+	}
+	return nil
+}
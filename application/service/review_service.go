@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/valpere/trytrago/application/dto/request"
+	"github.com/valpere/trytrago/application/dto/response"
+	"github.com/valpere/trytrago/application/mapper"
+	"github.com/valpere/trytrago/domain/database"
+	"github.com/valpere/trytrago/domain/database/repository"
+	"github.com/valpere/trytrago/domain/errors"
+	"github.com/valpere/trytrago/domain/logging"
+	"github.com/valpere/trytrago/domain/model"
+)
+
+// reviewService implements the ReviewService interface
+type reviewService struct {
+	repo   repository.Repository
+	logger logging.Logger
+}
+
+// NewReviewService creates a new instance of ReviewService
+func NewReviewService(repo repository.Repository, logger logging.Logger) ReviewService {
+	return &reviewService{
+		repo:   repo,
+		logger: logger.With(logging.String("service", "review")),
+	}
+}
+
+// ListReviewQueue implements ReviewService.ListReviewQueue
+func (s *reviewService) ListReviewQueue(ctx context.Context, req *request.ReviewQueueRequest) (*response.TranslationListResponse, error) {
+	s.logger.Debug("listing review queue",
+		logging.Int("limit", req.Limit),
+		logging.Int("offset", req.Offset),
+	)
+
+	params := repository.ListParams{
+		Limit:   req.Limit,
+		Offset:  req.Offset,
+		Filters: make(map[string]interface{}),
+	}
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+	if req.LanguageID != "" {
+		params.Filters["language_id = ?"] = req.LanguageID
+	}
+
+	translations, err := s.repo.ListTranslationsByStatus(ctx, database.TranslationProposed, params)
+	if err != nil {
+		s.logger.Error("failed to list review queue", logging.Error(err))
+		return nil, fmt.Errorf("failed to list review queue: %w", err)
+	}
+
+	total, err := s.repo.CountTranslationsByStatus(ctx, database.TranslationProposed, params.Filters)
+	if err != nil {
+		s.logger.Error("failed to count review queue", logging.Error(err))
+		return nil, fmt.Errorf("failed to count review queue: %w", err)
+	}
+
+	return &response.TranslationListResponse{
+		Translations: mapper.TranslationListToResponse(translations),
+		Total:        int(total),
+		Limit:        params.Limit,
+		Offset:       params.Offset,
+	}, nil
+}
+
+// ApproveTranslation implements ReviewService.ApproveTranslation
+func (s *reviewService) ApproveTranslation(ctx context.Context, id uuid.UUID, req *request.ReviewDecisionRequest) (*response.TranslationResponse, error) {
+	return s.review(ctx, id, database.TranslationApproved, req)
+}
+
+// RejectTranslation implements ReviewService.RejectTranslation
+func (s *reviewService) RejectTranslation(ctx context.Context, id uuid.UUID, req *request.ReviewDecisionRequest) (*response.TranslationResponse, error) {
+	if strings.TrimSpace(req.Reason) == "" {
+		return nil, errors.ErrReviewReasonRequired
+	}
+	return s.review(ctx, id, database.TranslationRejected, req)
+}
+
+// review records a decision on a proposed translation and notifies its author
+func (s *reviewService) review(ctx context.Context, id uuid.UUID, status database.TranslationStatus, req *request.ReviewDecisionRequest) (*response.TranslationResponse, error) {
+	s.logger.Debug("reviewing translation",
+		logging.String("id", id.String()),
+		logging.String("status", string(status)),
+		logging.String("reviewerID", req.ReviewerID.String()),
+	)
+
+	translation, err := s.repo.GetTranslationByID(ctx, id)
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return nil, err
+		}
+		s.logger.Error("failed to get translation for review",
+			logging.Error(err),
+			logging.String("id", id.String()),
+		)
+		return nil, fmt.Errorf("failed to get translation: %w", err)
+	}
+
+	if translation.Status != database.TranslationProposed {
+		return nil, errors.ErrTranslationAlreadyReviewed
+	}
+
+	now := time.Now().UTC()
+	reviewerID := req.ReviewerID
+	translation.Status = status
+	translation.ReviewedByID = &reviewerID
+	translation.ReviewedAt = &now
+	translation.ReviewReason = strings.TrimSpace(req.Reason)
+
+	if err := s.repo.ReviewTranslation(ctx, translation); err != nil {
+		s.logger.Error("failed to save review decision",
+			logging.Error(err),
+			logging.String("id", id.String()),
+		)
+		return nil, fmt.Errorf("failed to save review decision: %w", err)
+	}
+
+	s.notifyAuthor(ctx, translation)
+
+	return mapper.TranslationToResponse(translation), nil
+}
+
+// notifyAuthor tells the contributor about the outcome of a review. Failing to
+// deliver a notification does not undo the decision.
+func (s *reviewService) notifyAuthor(ctx context.Context, translation *database.Translation) {
+	if translation.CreatedByID == nil || *translation.CreatedByID == uuid.Nil {
+		return
+	}
+
+	notification := &model.Notification{
+		UserID:     *translation.CreatedByID,
+		TargetType: "translation",
+		TargetID:   translation.ID,
+	}
+
+	switch translation.Status {
+	case database.TranslationApproved:
+		notification.Type = model.NotificationTranslationApproved
+		notification.Message = fmt.Sprintf("Your translation %q was approved", translation.Text)
+	case database.TranslationRejected:
+		notification.Type = model.NotificationTranslationRejected
+		notification.Message = fmt.Sprintf("Your translation %q was rejected: %s", translation.Text, translation.ReviewReason)
+	default:
+		return
+	}
+
+	if translation.ReviewReason != "" && translation.Status == database.TranslationApproved {
+		notification.Message = fmt.Sprintf("%s: %s", notification.Message, translation.ReviewReason)
+	}
+
+	if err := s.repo.CreateNotification(ctx, notification); err != nil {
+		s.logger.Warn("failed to notify translation author",
+			logging.Error(err),
+			logging.String("translationID", translation.ID.String()),
+			logging.String("userID", translation.CreatedByID.String()),
+		)
+	}
+}
+
+// ListNotifications implements ReviewService.ListNotifications
+func (s *reviewService) ListNotifications(ctx context.Context, userID uuid.UUID, req *request.ListNotificationsRequest) (*response.NotificationListResponse, error) {
+	s.logger.Debug("listing notifications", logging.String("userID", userID.String()))
+
+	params := repository.ListParams{
+		Limit:   req.Limit,
+		Offset:  req.Offset,
+		Filters: make(map[string]interface{}),
+	}
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+	if req.UnreadOnly {
+		params.Filters["read_at IS NULL"] = nil
+	}
+
+	notifications, err := s.repo.ListNotifications(ctx, userID, params)
+	if err != nil {
+		s.logger.Error("failed to list notifications",
+			logging.Error(err),
+			logging.String("userID", userID.String()),
+		)
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	resp := &response.NotificationListResponse{
+		Notifications: make([]*response.NotificationResponse, len(notifications)),
+		Total:         len(notifications),
+		Limit:         params.Limit,
+		Offset:        params.Offset,
+	}
+	for i := range notifications {
+		resp.Notifications[i] = mapper.NotificationToResponse(&notifications[i])
+	}
+
+	return resp, nil
+}
@@ -6,21 +6,24 @@ import (
 	"github.com/google/uuid"
 	"github.com/valpere/trytrago/application/dto/request"
 	"github.com/valpere/trytrago/application/dto/response"
+	"github.com/valpere/trytrago/infrastructure/exchange"
 )
 
-// EntryService defines operations for dictionary entries
+// EntryService defines operations for dictionary entries. Updates and deletes
+// take the version the caller last read and fail with ErrVersionConflict when
+// the stored one has moved on; version 0 skips the check
 type EntryService interface {
 	// Entry operations
 	CreateEntry(ctx context.Context, req *request.CreateEntryRequest) (*response.EntryResponse, error)
 	GetEntryByID(ctx context.Context, id uuid.UUID) (*response.EntryResponse, error)
 	UpdateEntry(ctx context.Context, id uuid.UUID, req *request.UpdateEntryRequest) (*response.EntryResponse, error)
-	DeleteEntry(ctx context.Context, id uuid.UUID) error
+	DeleteEntry(ctx context.Context, id uuid.UUID, version int) error
 	ListEntries(ctx context.Context, req *request.ListEntriesRequest) (*response.EntryListResponse, error)
 
 	// Meaning operations
 	AddMeaning(ctx context.Context, entryID uuid.UUID, req *request.CreateMeaningRequest) (*response.MeaningResponse, error)
 	UpdateMeaning(ctx context.Context, id uuid.UUID, req *request.UpdateMeaningRequest) (*response.MeaningResponse, error)
-	DeleteMeaning(ctx context.Context, id uuid.UUID) error
+	DeleteMeaning(ctx context.Context, id uuid.UUID, version int) error
 	ListMeanings(ctx context.Context, entryID uuid.UUID) (*response.MeaningListResponse, error)
 
 	// Social operations for meanings
@@ -28,12 +31,50 @@ type EntryService interface {
 	ToggleMeaningLike(ctx context.Context, meaningID uuid.UUID, userID uuid.UUID) error
 }
 
+// EntryTreeService defines operations on an entry together with its meanings,
+// examples and translations, each applied as a single unit
+type EntryTreeService interface {
+	CreateEntryTree(ctx context.Context, req *request.EntryTreeRequest) (*response.EntryResponse, error)
+	ReplaceEntryTree(ctx context.Context, id uuid.UUID, req *request.EntryTreeRequest) (*response.EntryResponse, error)
+}
+
+// PatchService defines partial updates of entries, meanings and translations
+// by RFC 7396 merge patches and RFC 6902 JSON Patch documents. Like updates,
+// patches fail with ErrVersionConflict when the stored version has moved on
+type PatchService interface {
+	PatchEntry(ctx context.Context, id uuid.UUID, req *request.PatchRequest) (*response.EntryResponse, error)
+	PatchMeaning(ctx context.Context, id uuid.UUID, req *request.PatchRequest) (*response.MeaningResponse, error)
+	PatchTranslation(ctx context.Context, id uuid.UUID, req *request.PatchRequest) (*response.TranslationResponse, error)
+}
+
+// GraphService defines reads for resolvers that walk the dictionary graph one
+// level at a time. Each call loads what every parent on a level needs with a
+// single query, keyed by parent ID. Entries and meanings come without their
+// children, and comments carry only their author's ID, to be loaded with UsersByID.
+// EntriesByID follows merge redirects, keying the absorbing entry by the merged ID
+type GraphService interface {
+	ListEntries(ctx context.Context, req *request.ListEntriesRequest) ([]*response.EntryResponse, error)
+	EntriesByID(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*response.EntryResponse, error)
+	GetMeaning(ctx context.Context, id uuid.UUID) (*response.MeaningResponse, error)
+	MeaningsByEntry(ctx context.Context, entryIDs []uuid.UUID) (map[uuid.UUID][]*response.MeaningResponse, error)
+	TranslationsByMeaning(ctx context.Context, meaningIDs []uuid.UUID) (map[uuid.UUID][]*response.TranslationResponse, error)
+	CommentsByTarget(ctx context.Context, targetType string, targetIDs []uuid.UUID) (map[uuid.UUID][]*response.CommentResponse, error)
+	LikeCounts(ctx context.Context, targetType string, targetIDs []uuid.UUID) (map[uuid.UUID]int, error)
+	UsersByID(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*response.UserSummary, error)
+	ListLanguages(ctx context.Context) ([]*response.LanguageInfo, error)
+}
+
+// BatchService defines operations that apply many mutations as a single unit
+type BatchService interface {
+	ExecuteBatch(ctx context.Context, req *request.BatchRequest) (*response.BatchResponse, error)
+}
+
 // TranslationService defines operations for translations
 type TranslationService interface {
 	// Translation operations
 	CreateTranslation(ctx context.Context, meaningID uuid.UUID, req *request.CreateTranslationRequest) (*response.TranslationResponse, error)
 	UpdateTranslation(ctx context.Context, id uuid.UUID, req *request.UpdateTranslationRequest) (*response.TranslationResponse, error)
-	DeleteTranslation(ctx context.Context, id uuid.UUID) error
+	DeleteTranslation(ctx context.Context, id uuid.UUID, version int) error
 	ListTranslations(ctx context.Context, meaningID uuid.UUID, langID string) (*response.TranslationListResponse, error)
 
 	// Social operations for translations
@@ -41,6 +82,29 @@ type TranslationService interface {
 	ToggleTranslationLike(ctx context.Context, translationID uuid.UUID, userID uuid.UUID) error
 }
 
+// ReviewService defines moderation operations for contributed translations
+type ReviewService interface {
+	// Review queue operations
+	ListReviewQueue(ctx context.Context, req *request.ReviewQueueRequest) (*response.TranslationListResponse, error)
+	ApproveTranslation(ctx context.Context, id uuid.UUID, req *request.ReviewDecisionRequest) (*response.TranslationResponse, error)
+	RejectTranslation(ctx context.Context, id uuid.UUID, req *request.ReviewDecisionRequest) (*response.TranslationResponse, error)
+
+	// Review outcome notifications
+	ListNotifications(ctx context.Context, userID uuid.UUID, req *request.ListNotificationsRequest) (*response.NotificationListResponse, error)
+}
+
+// DuplicateService defines operations for finding and merging duplicate entries
+type DuplicateService interface {
+	FindDuplicates(ctx context.Context, req *request.DuplicateReportRequest) (*response.DuplicateReportResponse, error)
+	MergeEntries(ctx context.Context, sourceID uuid.UUID, req *request.MergeEntriesRequest) (*response.EntryResponse, error)
+}
+
+// ExchangeService defines operations for moving entries in and out of external formats
+type ExchangeService interface {
+	Import(ctx context.Context, reader exchange.RecordReader, req *request.ImportRequest) (*response.ImportReport, error)
+	Export(ctx context.Context, writer exchange.RecordWriter, req *request.ExportRequest) (*response.ExportReport, error)
+}
+
 // UserService defines operations for user management
 type UserService interface {
 	// User operations
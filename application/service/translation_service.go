@@ -11,6 +11,7 @@ import (
     "github.com/valpere/trytrago/application/mapper"
     "github.com/valpere/trytrago/domain/database"
     "github.com/valpere/trytrago/domain/database/repository"
+    "github.com/valpere/trytrago/domain/errors"
     "github.com/valpere/trytrago/domain/logging"
     "github.com/valpere/trytrago/domain/model"
 )
@@ -36,51 +37,31 @@ func (s *translationService) CreateTranslation(ctx context.Context, meaningID uu
         logging.String("languageID", req.LanguageID),
     )
 
-    // Verify the meaning exists - first get all entries
-    params := repository.ListParams{
-        Limit: 100, // Reasonable limit
-    }
-    entries, err := s.repo.ListEntries(ctx, params)
+    // Load the entry holding the meaning, which is saved with the translation
+    entry, meaning, err := s.findMeaning(ctx, meaningID)
     if err != nil {
-        s.logger.Error("failed to list entries to find meaning",
-            logging.Error(err),
-            logging.String("meaningID", meaningID.String()),
-        )
-        return nil, fmt.Errorf("failed to find meaning: %w", err)
-    }
-
-    // Find the meaning
-    var meaning *database.Meaning
-    var entry *database.Entry
-
-    for i := range entries {
-        e := &entries[i]
-        for j := range e.Meanings {
-            m := &e.Meanings[j]
-            if m.ID == meaningID {
-                meaning = m
-                entry = e
-                break
-            }
-        }
-        if meaning != nil {
-            break
-        }
+        return nil, err
     }
 
-    if meaning == nil {
-        return nil, database.ErrEntryNotFound
+    if err := checkReplaces(ctx, s.repo, meaningID, req.ReplacesID); err != nil {
+        return nil, err
     }
 
     // Create translation
     now := time.Now().UTC()
+    authorID := req.UserID
+    // Contributed translations stay hidden until a reviewer approves them
     translation := &database.Translation{
-        ID:         uuid.New(),
-        MeaningID:  meaningID,
-        LanguageID: req.LanguageID,
-        Text:       req.Text,
-        CreatedAt:  now,
-        UpdatedAt:  now,
+        ID:           uuid.New(),
+        MeaningID:    meaningID,
+        LanguageID:   req.LanguageID,
+        Text:         req.Text,
+        Status:       database.TranslationProposed,
+        CreatedByID:  &authorID,
+        SupersedesID: req.ReplacesID,
+        Version:      1,
+        CreatedAt:    now,
+        UpdatedAt:    now,
     }
 
     // Add to meaning's translations
@@ -100,135 +81,120 @@ func (s *translationService) CreateTranslation(ctx context.Context, meaningID uu
     return resp, nil
 }
 
-// UpdateTranslation implements TranslationService.UpdateTranslation
-func (s *translationService) UpdateTranslation(ctx context.Context, id uuid.UUID, req *request.UpdateTranslationRequest) (*response.TranslationResponse, error) {
-    s.logger.Debug("updating translation", logging.String("id", id.String()))
-
-    // Find the translation by checking all meanings
-    // This is inefficient but necessary with the current model structure
-    // In a real implementation, we would need a direct repository method to get a translation
-
-    // Get all entries that might contain this translation
-    params := repository.ListParams{
-        Limit: 100, // Reasonable limit, adjust based on actual requirements
-    }
-    entries, err := s.repo.ListEntries(ctx, params)
+// findMeaning loads a meaning together with the entry holding it, returning
+// the meaning as it appears within the entry so that changes to it are saved
+// with the entry
+func (s *translationService) findMeaning(ctx context.Context, meaningID uuid.UUID) (*database.Entry, *database.Meaning, error) {
+    meaning, err := s.repo.GetMeaningByID(ctx, meaningID)
     if err != nil {
-        s.logger.Error("failed to list entries to find translation",
+        if database.IsNotFoundError(err) {
+            return nil, nil, err
+        }
+        s.logger.Error("failed to get meaning",
             logging.Error(err),
-            logging.String("translationID", id.String()),
+            logging.String("meaningID", meaningID.String()),
         )
-        return nil, fmt.Errorf("failed to find translation: %w", err)
+        return nil, nil, fmt.Errorf("failed to find meaning: %w", err)
     }
 
-    // Search for the translation
-    var foundTranslation *database.Translation
-    var foundEntry *database.Entry
-
-    for i := range entries {
-        entry := &entries[i]
-        for j := range entry.Meanings {
-            meaning := &entry.Meanings[j]
-            for k := range meaning.Translations {
-                translation := &meaning.Translations[k]
-                if translation.ID == id {
-                    foundTranslation = translation
-                    foundEntry = entry
-                    break
-                }
-            }
-            if foundTranslation != nil {
-                break
-            }
-        }
-        if foundTranslation != nil {
-            break
+    entry, err := s.repo.GetEntryByID(ctx, meaning.EntryID)
+    if err != nil {
+        if database.IsNotFoundError(err) {
+            return nil, nil, err
         }
+        s.logger.Error("failed to get entry of meaning",
+            logging.Error(err),
+            logging.String("meaningID", meaningID.String()),
+        )
+        return nil, nil, fmt.Errorf("failed to find meaning: %w", err)
     }
 
-    if foundTranslation == nil {
-        return nil, database.ErrEntryNotFound
+    for i := range entry.Meanings {
+        if entry.Meanings[i].ID == meaningID {
+            return entry, &entry.Meanings[i], nil
+        }
     }
+    return nil, nil, database.ErrMeaningNotFound
+}
 
-    // Update the translation fields
-    foundTranslation.Text = req.Text
-    foundTranslation.UpdatedAt = time.Now().UTC()
-
-    // Save the updated entry
-    if err := s.repo.UpdateEntry(ctx, foundEntry); err != nil {
-        s.logger.Error("failed to update translation",
+// findTranslation checks that a translation exists
+func (s *translationService) findTranslation(ctx context.Context, translationID uuid.UUID) error {
+    if _, err := s.repo.GetTranslationByID(ctx, translationID); err != nil {
+        if database.IsNotFoundError(err) {
+            return err
+        }
+        s.logger.Error("failed to get translation",
             logging.Error(err),
-            logging.String("translationID", id.String()),
+            logging.String("translationID", translationID.String()),
         )
-        return nil, fmt.Errorf("failed to update translation: %w", err)
+        return fmt.Errorf("failed to find translation: %w", err)
     }
-
-    // Create response
-    resp := mapper.TranslationToResponse(foundTranslation)
-    return resp, nil
+    return nil
 }
 
-// DeleteTranslation implements TranslationService.DeleteTranslation
-func (s *translationService) DeleteTranslation(ctx context.Context, id uuid.UUID) error {
-    s.logger.Debug("deleting translation", logging.String("id", id.String()))
-
-    // Similar to UpdateTranslation, we need to find the translation first
-    // Then remove it from its parent meaning
+// checkReplaces verifies that the translation a proposal is to replace exists
+// and belongs to the same meaning, so that approving the proposal cannot
+// retire a translation of another word
+func checkReplaces(ctx context.Context, repo repository.Repository, meaningID uuid.UUID, replacesID *uuid.UUID) error {
+    if replacesID == nil {
+        return nil
+    }
 
-    // Get all entries that might contain this translation
-    params := repository.ListParams{
-        Limit: 100, // Reasonable limit, adjust based on actual requirements
+    replaced, err := repo.GetTranslationByID(ctx, *replacesID)
+    if err != nil {
+        if database.IsNotFoundError(err) {
+            return fmt.Errorf("%w: translation %s to replace does not exist", errors.ErrValidation, *replacesID)
+        }
+        return fmt.Errorf("failed to find translation to replace: %w", err)
     }
-    entries, err := s.repo.ListEntries(ctx, params)
+    if replaced.MeaningID != meaningID {
+        return errors.ErrReplacesOtherMeaning
+    }
+
+    return nil
+}
+
+// UpdateTranslation implements TranslationService.UpdateTranslation
+func (s *translationService) UpdateTranslation(ctx context.Context, id uuid.UUID, req *request.UpdateTranslationRequest) (*response.TranslationResponse, error) {
+    s.logger.Debug("updating translation", logging.String("id", id.String()))
+
+    translation, err := s.repo.GetTranslationByID(ctx, id)
     if err != nil {
-        s.logger.Error("failed to list entries to find translation for deletion",
+        if database.IsNotFoundError(err) {
+            return nil, err
+        }
+        s.logger.Error("failed to get translation",
             logging.Error(err),
             logging.String("translationID", id.String()),
         )
-        return fmt.Errorf("failed to find translation: %w", err)
+        return nil, fmt.Errorf("failed to find translation: %w", err)
     }
 
-    // Search for the translation
-    var foundTranslation *database.Translation
-    var foundMeaning *database.Meaning
-    var foundEntry *database.Entry
-    var translationIndex int
-
-    for i := range entries {
-        entry := &entries[i]
-        for j := range entry.Meanings {
-            meaning := &entry.Meanings[j]
-            for k := range meaning.Translations {
-                translation := &meaning.Translations[k]
-                if translation.ID == id {
-                    foundTranslation = translation
-                    foundMeaning = meaning
-                    foundEntry = entry
-                    translationIndex = k
-                    break
-                }
-            }
-            if foundTranslation != nil {
-                break
-            }
-        }
-        if foundTranslation != nil {
-            break
-        }
+    // Changed text goes back to review instead of going public unchecked
+    translation.Revise(translation.LanguageID, req.Text)
+    if req.Version != 0 {
+        translation.Version = req.Version
     }
 
-    if foundTranslation == nil {
-        return database.ErrEntryNotFound
+    // Save the translation on its own, leaving the rest of the entry alone
+    if err := s.repo.UpdateTranslation(ctx, translation); err != nil {
+        s.logger.Error("failed to update translation",
+            logging.Error(err),
+            logging.String("translationID", id.String()),
+        )
+        return nil, fmt.Errorf("failed to update translation: %w", err)
     }
 
-    // Remove the translation from the meaning
-    foundMeaning.Translations = append(
-        foundMeaning.Translations[:translationIndex],
-        foundMeaning.Translations[translationIndex+1:]...,
-    )
+    // Create response
+    resp := mapper.TranslationToResponse(translation)
+    return resp, nil
+}
 
-    // Save the updated entry
-    if err := s.repo.UpdateEntry(ctx, foundEntry); err != nil {
+// DeleteTranslation implements TranslationService.DeleteTranslation
+func (s *translationService) DeleteTranslation(ctx context.Context, id uuid.UUID, version int) error {
+    s.logger.Debug("deleting translation", logging.String("id", id.String()))
+
+    if err := s.repo.DeleteTranslation(ctx, id, version); err != nil {
         s.logger.Error("failed to delete translation",
             logging.Error(err),
             logging.String("translationID", id.String()),
@@ -246,58 +212,39 @@ func (s *translationService) ListTranslations(ctx context.Context, meaningID uui
         logging.String("languageID", langID),
     )
 
-    // Get the meaning with its translations - first get all entries
-    params := repository.ListParams{
-        Limit: 100, // Reasonable limit
-    }
-    entries, err := s.repo.ListEntries(ctx, params)
+    // Get the meaning with its translations
+    meaning, err := s.repo.GetMeaningByID(ctx, meaningID)
     if err != nil {
-        s.logger.Error("failed to list entries to find meaning",
+        if database.IsNotFoundError(err) {
+            return nil, err
+        }
+        s.logger.Error("failed to get meaning",
             logging.Error(err),
             logging.String("meaningID", meaningID.String()),
         )
         return nil, fmt.Errorf("failed to find meaning: %w", err)
     }
 
-    // Find the meaning
-    var meaning *database.Meaning
-
-    for i := range entries {
-        e := &entries[i]
-        for j := range e.Meanings {
-            m := &e.Meanings[j]
-            if m.ID == meaningID {
-                meaning = m
-                break
-            }
-        }
-        if meaning != nil {
-            break
-        }
-    }
-
-    if meaning == nil {
-        return nil, database.ErrEntryNotFound
-    }
-
-    // Filter translations by language if specified
+    // Only approved translations are public; filter by language if specified
     var translations []database.Translation
-    if langID != "" {
-        for _, t := range meaning.Translations {
-            if t.LanguageID == langID {
-                translations = append(translations, t)
-            }
+    for _, t := range meaning.Translations {
+        if !t.IsPublic() {
+            continue
         }
-    } else {
-        translations = meaning.Translations
+        if langID != "" && t.LanguageID != langID {
+            continue
+        }
+        translations = append(translations, t)
     }
 
     // Create response
     resp := &response.TranslationListResponse{
-        Translations: make([]*response.TranslationResponse, len(translations)),
-        Total:        len(translations),
-        Limit:        100,
-        Offset:       0,
+        Translations:     make([]*response.TranslationResponse, len(translations)),
+        Total:            len(translations),
+        Limit:            100,
+        Offset:           0,
+        MeaningVersion:   meaning.Version,
+        MeaningUpdatedAt: &meaning.UpdatedAt,
     }
 
     for i, t := range translations {
@@ -314,47 +261,9 @@ func (s *translationService) AddTranslationComment(ctx context.Context, translat
         logging.String("userID", req.UserID.String()),
     )
 
-    // Find the translation by ID
-    // Note: In a real implementation, you would have a direct repository method to get a translation by ID
-
-    // Get all entries that might contain this translation
-    params := repository.ListParams{
-        Limit: 100, // Reasonable limit
-    }
-    entries, err := s.repo.ListEntries(ctx, params)
-    if err != nil {
-        s.logger.Error("failed to list entries to find translation",
-            logging.Error(err),
-            logging.String("translationID", translationID.String()),
-        )
-        return nil, fmt.Errorf("failed to find translation: %w", err)
-    }
-
-    // Search for the translation
-    var foundTranslation *database.Translation
-
-    for i := range entries {
-        entry := entries[i]
-        for j := range entry.Meanings {
-            meaning := entry.Meanings[j]
-            for k := range meaning.Translations {
-                translation := &meaning.Translations[k]
-                if translation.ID == translationID {
-                    foundTranslation = translation
-                    break
-                }
-            }
-            if foundTranslation != nil {
-                break
-            }
-        }
-        if foundTranslation != nil {
-            break
-        }
-    }
-
-    if foundTranslation == nil {
-        return nil, database.ErrEntryNotFound
+    // The translation must exist to be commented on
+    if err := s.findTranslation(ctx, translationID); err != nil {
+        return nil, err
     }
 
     // Create a new comment
@@ -399,44 +308,9 @@ func (s *translationService) ToggleTranslationLike(ctx context.Context, translat
         logging.String("userID", userID.String()),
     )
 
-    // Find the translation by ID
-    params := repository.ListParams{
-        Limit: 100, // Reasonable limit
-    }
-    entries, err := s.repo.ListEntries(ctx, params)
-    if err != nil {
-        s.logger.Error("failed to list entries to find translation",
-            logging.Error(err),
-            logging.String("translationID", translationID.String()),
-        )
-        return fmt.Errorf("failed to find translation: %w", err)
-    }
-
-    // Search for the translation
-    var foundTranslation *database.Translation
-
-    for i := range entries {
-        entry := entries[i]
-        for j := range entry.Meanings {
-            meaning := entry.Meanings[j]
-            for k := range meaning.Translations {
-                translation := &meaning.Translations[k]
-                if translation.ID == translationID {
-                    foundTranslation = translation
-                    break
-                }
-            }
-            if foundTranslation != nil {
-                break
-            }
-        }
-        if foundTranslation != nil {
-            break
-        }
-    }
-
-    if foundTranslation == nil {
-        return database.ErrEntryNotFound
+    // The translation must exist to be liked
+    if err := s.findTranslation(ctx, translationID); err != nil {
+        return err
     }
 
     // In a real implementation, you would:
@@ -203,10 +203,10 @@ func (s *entryServiceImpl) UpdateEntry(ctx context.Context, id uuid.UUID, req *r
 }
 
 // DeleteEntry implements EntryService.DeleteEntry
-func (s *entryServiceImpl) DeleteEntry(ctx context.Context, id uuid.UUID) error {
+func (s *entryServiceImpl) DeleteEntry(ctx context.Context, id uuid.UUID, version int) error {
 	s.logger.Debug("deleting entry", logging.String("id", id.String()))
 
-	if err := s.repo.DeleteEntry(ctx, id); err != nil {
+	if err := s.repo.DeleteEntry(ctx, id, version); err != nil {
 		s.logger.Error("failed to delete entry",
 			logging.Error(err),
 			logging.String("id", id.String()),
@@ -268,6 +268,14 @@ func (s *entryServiceImpl) ListEntries(ctx context.Context, req *request.ListEnt
 		params.Filters["type = ?"] = req.Type
 	}
 
+	// Keyset pages neither skip nor repeat entries when others are written meanwhile
+	if req.AfterID != nil {
+		params.Offset, params.SortBy, params.SortDesc = 0, "id", false
+		if *req.AfterID != uuid.Nil {
+			params.Filters["id > ?"] = *req.AfterID
+		}
+	}
+
 	// Execute query
 	entries, err := s.repo.ListEntries(ctx, params)
 	if err != nil {
@@ -571,7 +579,7 @@ func (s *entryServiceImpl) UpdateMeaning(ctx context.Context, id uuid.UUID, req
 }
 
 // DeleteMeaning implements EntryService.DeleteMeaning
-func (s *entryServiceImpl) DeleteMeaning(ctx context.Context, id uuid.UUID) error {
+func (s *entryServiceImpl) DeleteMeaning(ctx context.Context, id uuid.UUID, version int) error {
 	s.logger.Debug("deleting meaning", logging.String("meaningID", id.String()))
 
 	// Find the meaning by ID
@@ -592,10 +600,8 @@ func (s *entryServiceImpl) DeleteMeaning(ctx context.Context, id uuid.UUID) erro
 		)
 	}
 
-	// Find the meaning and its parent entry
+	// Find the meaning
 	var foundMeaning *database.Meaning
-	var foundEntry *database.Entry
-	var meaningIndex int
 
 	for i := range entries {
 		entry := &entries[i]
@@ -603,8 +609,6 @@ func (s *entryServiceImpl) DeleteMeaning(ctx context.Context, id uuid.UUID) erro
 			meaning := &entry.Meanings[j]
 			if meaning.ID == id {
 				foundMeaning = meaning
-				foundEntry = entry
-				meaningIndex = j
 				break
 			}
 		}
@@ -622,15 +626,9 @@ func (s *entryServiceImpl) DeleteMeaning(ctx context.Context, id uuid.UUID) erro
 		)
 	}
 
-	// Remove the meaning from the entry
-	foundEntry.Meanings = append(
-		foundEntry.Meanings[:meaningIndex],
-		foundEntry.Meanings[meaningIndex+1:]...,
-	)
-
-	// Save the updated entry
-	if err := s.repo.UpdateEntry(ctx, foundEntry); err != nil {
-		s.logger.Error("failed to update entry after deleting meaning",
+	// Delete the meaning with its examples and translations
+	if err := s.repo.DeleteMeaning(ctx, foundMeaning.ID, version); err != nil {
+		s.logger.Error("failed to delete meaning",
 			logging.Error(err),
 			logging.String("meaningID", id.String()),
 		)
@@ -674,8 +672,10 @@ func (s *entryServiceImpl) ListMeanings(ctx context.Context, entryID uuid.UUID)
 
 	// Prepare response
 	resp := &response.MeaningListResponse{
-		Meanings: make([]*response.MeaningResponse, len(entry.Meanings)),
-		Total:    len(entry.Meanings),
+		Meanings:       make([]*response.MeaningResponse, len(entry.Meanings)),
+		Total:          len(entry.Meanings),
+		EntryVersion:   entry.Version,
+		EntryUpdatedAt: entry.UpdatedAt,
 	}
 
 	// Map meanings to response
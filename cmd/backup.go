@@ -1,29 +1,146 @@
 package cmd
 
-import "github.com/spf13/cobra"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/valpere/trytrago/domain/logging"
+	"github.com/valpere/trytrago/infrastructure/backup"
+)
 
 var (
-	backupPath string
-	compress   bool
+	backupPath        string
+	compress          bool
+	backupIncremental bool
+	backupSince       string
 )
 
 var backupCmd = &cobra.Command{
 	Use:   "backup",
 	Short: "Backup dictionary content",
-	Long:  `Create a backup of the dictionary content in JSON format`,
+	Long: `Create a backup of the dictionary content in JSON format.
+
+A full backup holds every entry with its meanings, examples and translations.
+With --incremental only the rows changed since an earlier backup are captured,
+together with the entries deleted or merged since. --since names that backup
+by ID, or gives an RFC 3339 timestamp; without it the latest backup in the
+output directory is used. The manifest of an incremental backup records its
+parent, so that restore can replay the whole chain.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runBackup()
 	},
 }
 
 func init() {
-	backupCmd.Flags().StringVar(&backupPath, "output", "backup.json", "Output file path")
+	backupCmd.Flags().StringVar(&backupPath, "output", "", "Output file or directory (default backup-<id>.json in the current directory)")
 	backupCmd.Flags().BoolVar(&compress, "compress", false, "Compress backup file")
+	backupCmd.Flags().BoolVar(&backupIncremental, "incremental", false, "Only capture changes since an earlier backup")
+	backupCmd.Flags().StringVar(&backupSince, "since", "", "Backup ID or RFC 3339 timestamp an incremental backup starts from")
 
 	rootCmd.AddCommand(backupCmd)
 }
 
 func runBackup() error {
-	// TODO: Implement backup logic
+	if backupSince != "" && !backupIncremental {
+		return fmt.Errorf("--since requires --incremental")
+	}
+
+	// Earlier backups are looked up next to the new one
+	dir, file := ".", ""
+	if backupPath != "" {
+		info, err := os.Stat(backupPath)
+		if (err == nil && info.IsDir()) || strings.HasSuffix(backupPath, string(os.PathSeparator)) {
+			dir = backupPath
+		} else {
+			dir, file = filepath.Dir(backupPath), backupPath
+		}
+	}
+
+	opts := backup.Options{}
+	if backupIncremental {
+		since, parentID, err := resolveBackupSince(dir, backupSince)
+		if err != nil {
+			return err
+		}
+		opts.Since = &since
+		opts.ParentID = parentID
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	repo, err := initializeRepository(loadConfiguration())
+	if err != nil {
+		log.Error("failed to initialize repository", logging.Error(err))
+		return fmt.Errorf("failed to initialize repository: %w", err)
+	}
+	defer repo.Close()
+
+	b, err := backup.NewManager(repo, log).Create(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("failed to create backup: %w", err)
+	}
+
+	path := file
+	if path == "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create backup directory: %w", err)
+		}
+		path = filepath.Join(dir, "backup-"+b.Manifest.ID+".json")
+	}
+	if compress && !strings.HasSuffix(path, ".gz") {
+		path += ".gz"
+	}
+	if err := backup.WriteFile(path, b, compress); err != nil {
+		return err
+	}
+
+	fmt.Printf("Backup %s (%s) written to %s\n", b.Manifest.ID, b.Manifest.Type, path)
+	if b.Manifest.ParentID != "" {
+		fmt.Printf("Based on:  %s\n", b.Manifest.ParentID)
+	}
+	fmt.Printf("Entries:   %d\n", len(b.Entries))
+	fmt.Printf("Meanings:  %d\n", len(b.Meanings))
+	if b.Manifest.Type == backup.TypeIncremental {
+		fmt.Printf("Deletions: %d\n", len(b.Deletions))
+	}
 	return nil
 }
+
+// resolveBackupSince works out where an incremental backup starts and which
+// backup it builds on. A backup ID or file starts it at that backup, less
+// backup.SinceOverlap; a timestamp links it to the latest backup in dir if
+// that backup is not older
+func resolveBackupSince(dir, since string) (time.Time, string, error) {
+	if since == "" {
+		_, manifest, err := backup.LatestManifest(dir)
+		if err != nil {
+			return time.Time{}, "", fmt.Errorf("no backup to build on, pass --since: %w", err)
+		}
+		return manifest.CreatedAt.Add(-backup.SinceOverlap), manifest.ID, nil
+	}
+
+	if ts, err := time.Parse(time.RFC3339, since); err == nil {
+		_, manifest, err := backup.LatestManifest(dir)
+		if err == nil && !manifest.CreatedAt.Before(ts) {
+			return ts, manifest.ID, nil
+		}
+		log.Warn("incremental backup is not linked to a parent backup", logging.String("since", since))
+		return ts, "", nil
+	}
+
+	manifest, err := backup.ReadManifest(since)
+	if errors.Is(err, os.ErrNotExist) {
+		_, manifest, err = backup.FindManifest(dir, since)
+	}
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	return manifest.CreatedAt.Add(-backup.SinceOverlap), manifest.ID, nil
+}
@@ -0,0 +1,330 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/valpere/trytrago/application/dto/request"
+	"github.com/valpere/trytrago/application/service"
+	"github.com/valpere/trytrago/domain/logging"
+	"github.com/valpere/trytrago/infrastructure/exchange"
+)
+
+var (
+	exportOutput         string
+	exportType           string
+	exportLanguage       string
+	exportTargetLanguage string
+	exportLabel          string
+	exportWords          []string
+	exportWordsFile      string
+	exportDeck           string
+	exportCards          []string
+	exportAudioDir       string
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export dictionary entries",
+	Long: `Export dictionary entries to files in other dictionary formats. Only approved
+translations are exported. With --target-language only translations into
+that language are kept, and entries without any are left out. With --label
+only meanings carrying that usage label are kept.`,
+}
+
+var exportTEICmd = &cobra.Command{
+	Use:   "tei",
+	Short: "Export entries as a TEI Lex-0 document",
+	Long: `Export entries as a TEI Lex-0 document. Entries are streamed from the
+database in batches, so the size of the dictionary is not limited by memory.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		req, err := exportRequest()
+		if err != nil {
+			return err
+		}
+
+		out := os.Stdout
+		if exportOutput != "-" {
+			out, err = os.Create(exportOutput)
+			if err != nil {
+				return fmt.Errorf("failed to create output file: %w", err)
+			}
+			defer out.Close()
+		}
+
+		buffered := bufio.NewWriter(out)
+		if err := runExport(exchange.NewTEIWriter(buffered, exchange.DefaultTitle), req); err != nil {
+			return err
+		}
+		if err := buffered.Flush(); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+		return nil
+	},
+}
+
+var exportStarDictCmd = &cobra.Command{
+	Use:   "stardict",
+	Short: "Export entries as a StarDict dictionary",
+	Long: `Export entries as a StarDict dictionary for offline readers. --output is the
+path without extension; <output>.ifo, <output>.idx and <output>.dict.dz are
+written. Select the language pair with --language and --target-language.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		req, err := exportRequest()
+		if err != nil {
+			return err
+		}
+		base, err := exportBase(".ifo", ".idx", ".dict", ".dict.dz")
+		if err != nil {
+			return err
+		}
+
+		writer, err := exchange.NewStarDictWriter(base, exportBookName(req))
+		if err != nil {
+			return err
+		}
+		return runExport(writer, req)
+	},
+}
+
+var exportDictdCmd = &cobra.Command{
+	Use:   "dictd",
+	Short: "Export entries as a dictd database",
+	Long: `Export entries as a DICT protocol (dictd) database. --output is the path
+without extension; <output>.index and <output>.dict are written. Select the
+language pair with --language and --target-language.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		req, err := exportRequest()
+		if err != nil {
+			return err
+		}
+		base, err := exportBase(".index", ".dict")
+		if err != nil {
+			return err
+		}
+
+		writer, err := exchange.NewDictdWriter(base, exportBookName(req))
+		if err != nil {
+			return err
+		}
+		return runExport(writer, req)
+	},
+}
+
+var exportTBXCmd = &cobra.Command{
+	Use:   "tbx",
+	Short: "Export entries as a TBX termbase",
+	Long: `Export entries as a TBX-Basic termbase for CAT tools. Each meaning becomes a
+concept entry holding the word with its part of speech, usage labels and
+examples, the definition, and the translations grouped by language. Select a
+language pair with --language and --target-language and a subject with --label.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		req, err := exportRequest()
+		if err != nil {
+			return err
+		}
+
+		out := os.Stdout
+		if exportOutput != "-" {
+			out, err = os.Create(exportOutput)
+			if err != nil {
+				return fmt.Errorf("failed to create output file: %w", err)
+			}
+			defer out.Close()
+		}
+
+		buffered := bufio.NewWriter(out)
+		if err := runExport(exchange.NewTBXWriter(buffered, exportBookName(req)), req); err != nil {
+			return err
+		}
+		if err := buffered.Flush(); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+		return nil
+	},
+}
+
+var exportAnkiCmd = &cobra.Command{
+	Use:   "anki",
+	Short: "Export entries as an Anki flashcard deck",
+	Long: `Export entries as an Anki deck package (.apkg). Each entry becomes a note with
+the word, its pronunciation, translations and meanings. --cards chooses the
+card types: forward (word to translation), reverse (translation to word), or
+both. Pronunciation recordings found in --audio-dir as <language>/<word>.mp3
+are included. Use --word or --words-file to build a deck from a word list.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		req, err := exportRequest()
+		if err != nil {
+			return err
+		}
+
+		audioDir := exportAudioDir
+		if !cmd.Flags().Changed("audio-dir") {
+			audioDir = loadConfiguration().Exchange.AudioDir
+		}
+
+		out := os.Stdout
+		if exportOutput != "-" {
+			out, err = os.Create(exportOutput)
+			if err != nil {
+				return fmt.Errorf("failed to create output file: %w", err)
+			}
+			defer out.Close()
+		}
+
+		buffered := bufio.NewWriter(out)
+		writer, err := exchange.NewAnkiWriter(buffered, exchange.AnkiOptions{
+			DeckName:  exportDeck,
+			Templates: exportCards,
+			AudioDir:  audioDir,
+		})
+		if err != nil {
+			return err
+		}
+		if err := runExport(writer, req); err != nil {
+			return err
+		}
+		if err := buffered.Flush(); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	exportCmd.PersistentFlags().StringVarP(&exportOutput, "output", "o", "-", "Output file, or - for standard output")
+	exportCmd.PersistentFlags().StringVar(&exportType, "type", "", "Only export entries of this type (WORD, COMPOUND_WORD, PHRASE)")
+	exportCmd.PersistentFlags().StringVar(&exportLanguage, "language", "", "Only export entries in this source language")
+	exportCmd.PersistentFlags().StringVar(&exportTargetLanguage, "target-language", "", "Only export translations into this language")
+	exportCmd.PersistentFlags().StringVar(&exportLabel, "label", "", "Only export meanings with this usage label")
+	exportCmd.PersistentFlags().StringArrayVar(&exportWords, "word", nil, "Only export these words (repeatable)")
+	exportCmd.PersistentFlags().StringVar(&exportWordsFile, "words-file", "", "Only export the words listed in this file, one per line")
+
+	exportAnkiCmd.Flags().StringVar(&exportDeck, "deck", "", "Deck name (default \""+exchange.DefaultTitle+"\")")
+	exportAnkiCmd.Flags().StringSliceVar(&exportCards, "cards", []string{exchange.AnkiForward, exchange.AnkiReverse}, "Card types to generate: forward, reverse")
+	exportAnkiCmd.Flags().StringVar(&exportAudioDir, "audio-dir", "", "Directory of pronunciation recordings (default from exchange.audio_dir)")
+
+	exportCmd.AddCommand(exportTEICmd)
+	exportCmd.AddCommand(exportStarDictCmd)
+	exportCmd.AddCommand(exportDictdCmd)
+	exportCmd.AddCommand(exportTBXCmd)
+	exportCmd.AddCommand(exportAnkiCmd)
+	rootCmd.AddCommand(exportCmd)
+}
+
+// exportRequest builds the entry selection from the command line
+func exportRequest() (*request.ExportRequest, error) {
+	switch exportType {
+	case "", "WORD", "COMPOUND_WORD", "PHRASE":
+	default:
+		return nil, fmt.Errorf("invalid entry type %q", exportType)
+	}
+
+	words, err := exportWordList()
+	if err != nil {
+		return nil, err
+	}
+
+	return &request.ExportRequest{
+		Type:             exportType,
+		LanguageID:       strings.ToLower(exportLanguage),
+		TargetLanguageID: strings.ToLower(exportTargetLanguage),
+		Label:            strings.ToLower(strings.TrimSpace(exportLabel)),
+		Words:            words,
+	}, nil
+}
+
+// exportWordList collects the words given with --word and --words-file
+func exportWordList() ([]string, error) {
+	var words []string
+	for _, word := range exportWords {
+		if word = strings.TrimSpace(word); word != "" {
+			words = append(words, word)
+		}
+	}
+	if exportWordsFile == "" {
+		return words, nil
+	}
+
+	file, err := os.Open(exportWordsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open word list: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if word := strings.TrimSpace(scanner.Text()); word != "" && !strings.HasPrefix(word, "#") {
+			words = append(words, word)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read word list: %w", err)
+	}
+	if len(words) == 0 {
+		return nil, fmt.Errorf("word list %s is empty", exportWordsFile)
+	}
+	return words, nil
+}
+
+// exportBase returns the output path of a multi-file format without any of
+// the format's own extensions
+func exportBase(extensions ...string) (string, error) {
+	if exportOutput == "-" || exportOutput == "" {
+		return "", fmt.Errorf("this format writes several files; set --output to their path without extension")
+	}
+	for _, ext := range extensions {
+		if base, ok := strings.CutSuffix(exportOutput, ext); ok {
+			return base, nil
+		}
+	}
+	return exportOutput, nil
+}
+
+// exportBookName names the dictionary after its language pair, if any
+func exportBookName(req *request.ExportRequest) string {
+	if req.LanguageID != "" && req.TargetLanguageID != "" {
+		return fmt.Sprintf("%s (%s-%s)", exchange.DefaultTitle, req.LanguageID, req.TargetLanguageID)
+	}
+	return exchange.DefaultTitle
+}
+
+// runExport streams the selected entries into writer
+func runExport(writer exchange.RecordWriter, req *request.ExportRequest) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Writers that stage files clean up after themselves when the export fails
+	abort := func() {
+		if aborter, ok := writer.(exchange.Aborter); ok {
+			if err := aborter.Abort(); err != nil {
+				log.Warn("failed to discard partial export", logging.Error(err))
+			}
+		}
+	}
+
+	repo, err := initializeRepository(loadConfiguration())
+	if err != nil {
+		abort()
+		log.Error("failed to initialize repository", logging.Error(err))
+		return fmt.Errorf("failed to initialize repository: %w", err)
+	}
+	defer repo.Close()
+
+	exchangeService := service.NewExchangeService(repo, log)
+	report, err := exchangeService.Export(ctx, writer, req)
+	if err != nil {
+		abort()
+		return err
+	}
+
+	// Standard output may hold the export itself
+	fmt.Fprintf(os.Stderr, "Exported %d entries\n", report.Exported)
+	return nil
+}
@@ -0,0 +1,335 @@
+package cmd
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/valpere/trytrago/application/dto/request"
+	"github.com/valpere/trytrago/application/dto/response"
+	"github.com/valpere/trytrago/application/service"
+	"github.com/valpere/trytrago/domain/logging"
+	"github.com/valpere/trytrago/infrastructure/exchange"
+)
+
+var (
+	importFile       string
+	importBatchSize  int
+	importCheckpoint string
+	importResume     bool
+	importMapping    string
+	importLang       string
+	importTargetLang string
+	importDefaultPOS string
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import dictionary entries",
+	Long:  `Import dictionary entries in bulk from spreadsheets and other dictionary formats`,
+}
+
+var importCSVCmd = &cobra.Command{
+	Use:   "csv",
+	Short: "Import entries from a CSV or TSV file",
+	Long: `Import entries from a CSV or TSV file. The optional mapping is a JSON file that
+maps columns to entry fields (word, type, pronunciation, source_language_id,
+part_of_speech, description, examples, translation.<lang>). Without it, header
+names equal to field names are used. Consecutive rows with the same word and
+type become one entry with several meanings.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runImportCSV()
+	},
+}
+
+var importTEICmd = &cobra.Command{
+	Use:   "tei",
+	Short: "Import entries from a TEI Lex-0 document",
+	Long: `Import entries from a TEI Lex-0 document. Entries are read one at a time,
+so documents larger than memory can be imported. Senses become meanings,
+cit elements become examples and translations, and gramGrp supplies the part
+of speech; subsenses are flattened into meanings of their entry.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := os.Open(importFile)
+		if err != nil {
+			return fmt.Errorf("failed to open import file: %w", err)
+		}
+		defer file.Close()
+
+		return runImport(exchange.NewTEIReader(bufio.NewReader(file)))
+	},
+}
+
+var importStarDictCmd = &cobra.Command{
+	Use:   "stardict",
+	Short: "Import entries from a StarDict dictionary",
+	Long: `Import entries from a StarDict dictionary; --file is the .ifo file. Articles
+are free text, so they are split into meanings by their numbering ("1.", "2)"),
+or line by line when they are not numbered, and lines following a numbered
+meaning become its examples. With --target-lang meanings are read as
+translations separated by semicolons; otherwise they are descriptions.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		reader, err := exchange.NewStarDictReader(importFile, exchange.StarDictImportOptions{
+			SourceLanguageID:    strings.ToLower(importLang),
+			TargetLanguageID:    strings.ToLower(importTargetLang),
+			DefaultPartOfSpeech: importDefaultPOS,
+		})
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+
+		return runImport(reader)
+	},
+}
+
+var importTBXCmd = &cobra.Command{
+	Use:   "tbx",
+	Short: "Import entries from a TBX termbase",
+	Long: `Import entries from a TermBase eXchange (TBX) file, as exported by CAT tools.
+Both TBX 2019 (tbx, conceptEntry, langSec) and TBX 2008 (martif, termEntry,
+langSet) are read. Every term in the source language becomes an entry whose
+meaning is the concept: its definition, subject field and usage notes as
+labels, and the terms of the other languages as translations. The source
+language is --lang, or the language of the document.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := os.Open(importFile)
+		if err != nil {
+			return fmt.Errorf("failed to open import file: %w", err)
+		}
+		defer file.Close()
+
+		return runImport(exchange.NewTBXReader(bufio.NewReader(file), exchange.TBXImportOptions{
+			SourceLanguageID:    importLang,
+			DefaultPartOfSpeech: importDefaultPOS,
+		}))
+	},
+}
+
+var importWiktionaryCmd = &cobra.Command{
+	Use:   "wiktionary",
+	Short: "Import entries from a Wiktionary JSONL extraction",
+	Long: `Import entries from the machine-readable Wiktionary extraction published by
+kaikki.org (JSONL, optionally gzip-compressed). Only entries in the language
+given by --lang are imported; each becomes an entry with its glosses as
+meanings, usage examples, translations, IPA pronunciation and etymology.
+Imported entries are marked with Wiktionary as their source and its licence.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := os.Open(importFile)
+		if err != nil {
+			return fmt.Errorf("failed to open import file: %w", err)
+		}
+		defer file.Close()
+
+		var source io.Reader = file
+		if strings.EqualFold(filepath.Ext(importFile), ".gz") {
+			gz, err := gzip.NewReader(file)
+			if err != nil {
+				return fmt.Errorf("failed to decompress import file: %w", err)
+			}
+			defer gz.Close()
+			source = gz
+		}
+
+		return runImport(exchange.NewWiktionaryReader(source, importLang))
+	},
+}
+
+func init() {
+	importCmd.PersistentFlags().StringVar(&importFile, "file", "", "File to import")
+	importCmd.PersistentFlags().IntVar(&importBatchSize, "batch-size", 500, "Number of entries written per transaction")
+	importCmd.PersistentFlags().StringVar(&importCheckpoint, "checkpoint", "", "Checkpoint file (default is <file>.checkpoint)")
+	importCmd.PersistentFlags().BoolVar(&importResume, "resume", false, "Continue from the last checkpoint")
+	importCmd.MarkPersistentFlagRequired("file")
+
+	importCSVCmd.Flags().StringVar(&importMapping, "mapping", "", "JSON column mapping file")
+
+	importStarDictCmd.Flags().StringVar(&importLang, "lang", "", "Language of the headwords")
+	importStarDictCmd.Flags().StringVar(&importTargetLang, "target-lang", "", "Language the articles translate into")
+	importStarDictCmd.Flags().StringVar(&importDefaultPOS, "default-pos", "unspecified", "Part of speech for meanings that do not name one")
+
+	importTBXCmd.Flags().StringVar(&importLang, "lang", "", "Language whose terms become entries (default is the document language)")
+	importTBXCmd.Flags().StringVar(&importDefaultPOS, "default-pos", "unspecified", "Part of speech for terms that do not name one")
+
+	importWiktionaryCmd.Flags().StringVar(&importLang, "lang", "", "Language code of the entries to import, e.g. en")
+	importWiktionaryCmd.MarkFlagRequired("lang")
+
+	importCmd.AddCommand(importCSVCmd)
+	importCmd.AddCommand(importTEICmd)
+	importCmd.AddCommand(importStarDictCmd)
+	importCmd.AddCommand(importTBXCmd)
+	importCmd.AddCommand(importWiktionaryCmd)
+	rootCmd.AddCommand(importCmd)
+}
+
+func runImportCSV() error {
+	mapping := &exchange.CSVMapping{}
+	if importMapping != "" {
+		f, err := os.Open(importMapping)
+		if err != nil {
+			return fmt.Errorf("failed to open mapping: %w", err)
+		}
+		mapping, err = exchange.LoadCSVMapping(f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+	}
+	if mapping.Delimiter == "" && strings.EqualFold(filepath.Ext(importFile), ".tsv") {
+		mapping.Delimiter = "\t"
+	}
+
+	file, err := os.Open(importFile)
+	if err != nil {
+		return fmt.Errorf("failed to open import file: %w", err)
+	}
+	defer file.Close()
+
+	reader, err := exchange.NewCSVReader(file, mapping)
+	if err != nil {
+		return err
+	}
+
+	return runImport(reader)
+}
+
+// importCheckpointState is what the checkpoint file records about a run
+type importCheckpointState struct {
+	File      string    `json:"file"`
+	Records   int       `json:"records"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// runImport feeds records into the exchange service, keeping the checkpoint
+// file up to date so that an interrupted import can be resumed
+func runImport(reader exchange.RecordReader) error {
+	// Interrupting the import still leaves a usable checkpoint behind
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	source, err := filepath.Abs(importFile)
+	if err != nil {
+		return fmt.Errorf("failed to resolve import file: %w", err)
+	}
+
+	checkpointPath := importCheckpoint
+	if checkpointPath == "" {
+		checkpointPath = importFile + ".checkpoint"
+	}
+
+	req := &request.ImportRequest{
+		BatchSize: importBatchSize,
+		OnCheckpoint: func(records int) error {
+			return saveImportCheckpoint(checkpointPath, importCheckpointState{
+				File:      source,
+				Records:   records,
+				UpdatedAt: time.Now().UTC(),
+			})
+		},
+	}
+
+	if importResume {
+		state, err := loadImportCheckpoint(checkpointPath)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			log.Info("no checkpoint found, starting from the beginning", logging.String("checkpoint", checkpointPath))
+		case err != nil:
+			return err
+		case state.File != source:
+			return fmt.Errorf("checkpoint %s belongs to %s", checkpointPath, state.File)
+		default:
+			req.ResumeFrom = state.Records
+			log.Info("resuming import", logging.Int("records", state.Records))
+		}
+	}
+
+	repo, err := initializeRepository(loadConfiguration())
+	if err != nil {
+		log.Error("failed to initialize repository", logging.Error(err))
+		return fmt.Errorf("failed to initialize repository: %w", err)
+	}
+	defer repo.Close()
+
+	exchangeService := service.NewExchangeService(repo, log)
+	report, err := exchangeService.Import(ctx, reader, req)
+	printImportReport(report)
+	if err != nil {
+		fmt.Printf("\nImport stopped: %v\nRun again with --resume to continue from record %d\n", err, report.Checkpoint)
+		return err
+	}
+
+	if err := os.Remove(checkpointPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Warn("failed to remove checkpoint", logging.Error(err))
+	}
+
+	return nil
+}
+
+// loadImportCheckpoint reads a checkpoint file
+func loadImportCheckpoint(path string) (*importCheckpointState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var state importCheckpointState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint %s: %w", path, err)
+	}
+	return &state, nil
+}
+
+// saveImportCheckpoint writes a checkpoint file atomically
+func saveImportCheckpoint(path string, state importCheckpointState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// printImportReport writes a human readable summary of an import
+func printImportReport(report *response.ImportReport) {
+	if report == nil {
+		return
+	}
+
+	fmt.Println("Import Summary:")
+	fmt.Println("===============")
+	fmt.Printf("Processed: %d\n", report.Processed)
+	fmt.Printf("Imported:  %d\n", report.Imported)
+	fmt.Printf("Failed:    %d\n", report.Failed)
+	if report.Skipped > 0 {
+		fmt.Printf("Skipped:   %d (before checkpoint)\n", report.Skipped)
+	}
+
+	if len(report.Errors) > 0 {
+		fmt.Println("\nErrors:")
+		for _, e := range report.Errors {
+			if e.Field != "" {
+				fmt.Printf("  row %d: %s: %s\n", e.Row, e.Field, e.Message)
+			} else {
+				fmt.Printf("  row %d: %s\n", e.Row, e.Message)
+			}
+		}
+		if report.ErrorsTruncated {
+			fmt.Println("  ... more errors not shown")
+		}
+	}
+}
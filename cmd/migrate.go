@@ -3,6 +3,9 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io/fs"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -11,6 +14,7 @@ import (
 	"github.com/valpere/trytrago/domain/database/repository"
 	"github.com/valpere/trytrago/domain/logging"
 	"github.com/valpere/trytrago/infrastructure/migration"
+	"github.com/valpere/trytrago/infrastructure/transfer"
 	"github.com/valpere/trytrago/migrations"
 )
 
@@ -19,33 +23,155 @@ var (
 	migrateAutoApply bool
 	migrateRollback  bool
 	migrateVersion   int64
+	migrateChecksum  string
+	migrateTo        int64
+	migrateDryRun    bool
+	migrateLockWait  time.Duration
 )
 
 var migrateCmd = &cobra.Command{
 	Use:   "migrate",
 	Short: "Manage database migrations",
-	Long:  `Apply, rollback, or check the status of database migrations`,
+	Long: `Apply, rollback, or check the status of database migrations.
+
+The migration files are built into the binary. --path uses the files in a
+directory instead, laid out the same way with one subdirectory per dialect.
+
+--rollback rolls back the last applied migration by running its R file.
+With --rollback --version N the R file of version N is only printed.
+
+With --to N the database is moved to version N: applied migrations above it
+are rolled back with their R files, newest first, then pending migrations up
+to it are applied. The steps are listed before anything runs; --dry-run stops
+there. --to 0 rolls back every migration.
+
+Applying or rolling back takes a database-wide migration lock, so instances
+started together do not run the same migration twice. --lock-timeout bounds
+how long to wait for another instance holding it.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if cmd.Flags().Changed("to") {
+			return runMigrateTo(migrateTo, migrateDryRun)
+		}
 		return runMigrate(migrateAutoApply, migrateRollback, migrateVersion)
 	},
 }
 
+var migrateVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Check applied migrations against the migration files",
+	Long: `Compare the migrations recorded in the database with the migration files and
+report applied migrations whose file is missing or has been modified since,
+and pending migrations numbered below the latest applied one. Exits with an
+error when any are found.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runMigrateVerify()
+	},
+}
+
+var migrateCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create the next numbered migration and rollback files",
+	Long: `Create empty V and R files numbered one above the latest migration, for
+every dialect. Files are written under --path, the repository's migrations
+directory by default; rebuild the binary to embed them.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runMigrateCreate(args[0])
+	},
+}
+
+var migrateDiffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare the database schema with the models",
+	Long: `Compare the tables, columns and indexes of the connected database with the
+GORM models the application uses, and list what either side lacks. Exits with
+an error when they differ.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runMigrateDiff()
+	},
+}
+
 func init() {
-	migrateCmd.Flags().StringVar(&migrationPath, "path", "migrations", "Path to migration files")
+	migrateCmd.PersistentFlags().StringVar(&migrationPath, "path", "", "Directory of migration files to use instead of the ones built into the binary")
 	migrateCmd.Flags().BoolVar(&migrateAutoApply, "apply", false, "Automatically apply pending migrations")
-	migrateCmd.Flags().BoolVar(&migrateRollback, "rollback", false, "Rollback the last migration or a specific version")
-	migrateCmd.Flags().Int64Var(&migrateVersion, "version", 0, "Specific migration version to rollback (0 for last applied)")
+	migrateCmd.Flags().BoolVar(&migrateRollback, "rollback", false, "Roll back the last applied migration with its R file")
+	migrateCmd.Flags().Int64Var(&migrateVersion, "version", 0, "With --rollback, print the rollback script of this version instead of running one (0 for none)")
+	migrateCmd.Flags().Int64Var(&migrateTo, "to", 0, "Apply or roll back migrations until the database is at this version")
+	migrateCmd.Flags().BoolVar(&migrateDryRun, "dry-run", false, "With --to, only show the steps")
+	migrateCmd.Flags().DurationVar(&migrateLockWait, "lock-timeout", 0, "How long to wait for the migration lock held by another instance (default database.migration_lock_timeout, else 2m)")
+	migrateCmd.Flags().StringVar(&migrateChecksum, "on-checksum-mismatch", "", "Reaction to modified applied migrations: refuse or warn (default database.migration_checksum, else refuse)")
 
+	migrateCmd.AddCommand(migrateVerifyCmd)
+	migrateCmd.AddCommand(migrateCreateCmd)
+	migrateCmd.AddCommand(migrateDiffCmd)
 	rootCmd.AddCommand(migrateCmd)
 }
 
 func runMigrate(autoApply, rollback bool, version int64) error {
 	log.Info("initializing database migration")
 
-	// Set up context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	// Set up context with timeout, leaving room to wait for the lock
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second+lockTimeout())
 	defer cancel()
 
+	// Create migrator
+	migrator, err := newMigrator(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Check status or apply migrations based on flags
+	if rollback {
+		return handleRollback(ctx, migrator, version)
+	}
+
+	if autoApply {
+		return migrations.RunMigrations(ctx, migrator, migrationRoot, log)
+	}
+
+	return showMigrationStatus(ctx, migrator)
+}
+
+// migrationRoot is the directory the migrator's methods are given: the root of
+// the migration files, wherever they come from
+const migrationRoot = "."
+
+// migrationFiles returns the migration files built into the binary, or those
+// in the directory given with --path
+func migrationFiles() fs.FS {
+	if migrationPath == "" {
+		return migrations.Files
+	}
+	return os.DirFS(migrationPath)
+}
+
+// lockTimeout is how long migrate waits for another instance's migration lock
+func lockTimeout() time.Duration {
+	if migrateLockWait > 0 {
+		return migrateLockWait
+	}
+	if viper.IsSet("database.migration_lock_timeout") {
+		return viper.GetDuration("database.migration_lock_timeout")
+	}
+	return migration.DefaultLockTimeout
+}
+
+// newMigrator connects to the configured database and sets up a migrator
+func newMigrator(ctx context.Context) (*migration.Migrator, error) {
+	mode := viper.GetString("database.migration_checksum")
+	if migrateChecksum != "" {
+		mode = migrateChecksum
+	}
+	checksumMode, err := migration.ParseChecksumMode(mode)
+	if err != nil {
+		return nil, err
+	}
+	if migrationPath != "" {
+		if info, err := os.Stat(migrationPath); err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("migrations directory %s not found", migrationPath)
+		}
+	}
+
 	// Initialize repository
 	opts := repository.Options{
 		Driver:   "postgres", // Default to PostgreSQL, could be configured
@@ -82,22 +208,14 @@ func runMigrate(autoApply, rollback bool, version int64) error {
 	repo, err := domain.NewRepository(ctx, opts)
 	if err != nil {
 		log.Error("failed to create repository", logging.Error(err))
-		return fmt.Errorf("failed to create repository: %w", err)
+		return nil, fmt.Errorf("failed to create repository: %w", err)
 	}
 
-	// Create migrator
 	migrator := migration.NewMigrator(repo, log)
-
-	// Check status or apply migrations based on flags
-	if rollback {
-		return handleRollback(ctx, migrator, version)
-	}
-
-	if autoApply {
-		return migrations.RunMigrations(ctx, migrator, log)
-	}
-
-	return showMigrationStatus(ctx, migrator)
+	migrator.SetChecksumMode(checksumMode)
+	migrator.SetLockTimeout(lockTimeout())
+	migrator.SetFS(migrationFiles())
+	return migrator, nil
 }
 
 func showMigrationStatus(ctx context.Context, migrator *migration.Migrator) error {
@@ -108,7 +226,7 @@ func showMigrationStatus(ctx context.Context, migrator *migration.Migrator) erro
 	}
 
 	// Get migration status
-	status, err := migrator.Status(ctx, migrationPath)
+	status, err := migrator.Status(ctx, migrationRoot)
 	if err != nil {
 		log.Error("failed to get migration status", logging.Error(err))
 		return fmt.Errorf("failed to get migration status: %w", err)
@@ -146,11 +264,112 @@ func showMigrationStatus(ctx context.Context, migrator *migration.Migrator) erro
 	return nil
 }
 
+func runMigrateTo(target int64, dryRun bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second+lockTimeout())
+	defer cancel()
+
+	migrator, err := newMigrator(ctx)
+	if err != nil {
+		return err
+	}
+
+	steps, err := migrator.Plan(ctx, migrationRoot, target)
+	if err != nil {
+		log.Error("failed to plan migrations", logging.Error(err))
+		return fmt.Errorf("failed to plan migrations: %w", err)
+	}
+
+	fmt.Printf("Migration Plan to V%d:\n", target)
+	fmt.Println("====================")
+	if len(steps) == 0 {
+		fmt.Println("Nothing to do, the database is at the target version")
+		return nil
+	}
+	for i, step := range steps {
+		action := "apply"
+		if step.Direction == migration.Down {
+			action = "roll back"
+		}
+		fmt.Printf("%2d. %-9s V%d - %s\n", i+1, action, step.Migration.Version, step.Migration.Description)
+	}
+	if !migrator.TransactionalDDL() {
+		fmt.Printf("\nNote: %s commits schema changes immediately, a failing step is not undone\n", migrator.Dialect())
+	}
+
+	if dryRun {
+		return nil
+	}
+
+	fmt.Println()
+	if err := migrator.MigrateTo(ctx, migrationRoot, target); err != nil {
+		log.Error("failed to migrate", logging.Int64("target", target), logging.Error(err))
+		return fmt.Errorf("failed to migrate to version %d: %w", target, err)
+	}
+
+	fmt.Printf("Database is at version %d\n", target)
+	return nil
+}
+
+func runMigrateVerify() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	migrator, err := newMigrator(ctx)
+	if err != nil {
+		return err
+	}
+
+	report, err := migrator.Verify(ctx, migrationRoot)
+	if err != nil {
+		log.Error("failed to verify migrations", logging.Error(err))
+		return fmt.Errorf("failed to verify migrations: %w", err)
+	}
+
+	fmt.Println("Migration Verification:")
+	fmt.Println("=======================")
+	for _, m := range report.Missing {
+		fmt.Printf("❓ V%d - %s (applied at %s, file missing)\n",
+			m.Version,
+			m.Description,
+			m.AppliedAt.Format(time.RFC3339),
+		)
+	}
+	for _, m := range report.Modified {
+		fmt.Printf("✏️  V%d - %s (modified: applied %s, file %s)\n",
+			m.Version,
+			m.Description,
+			m.AppliedChecksum[:12],
+			m.FileChecksum[:12],
+		)
+	}
+	for _, m := range report.OutOfOrder {
+		fmt.Printf("↩️  V%d - %s (pending, older than the latest applied migration)\n",
+			m.Version,
+			m.Description,
+		)
+	}
+	for _, m := range report.Unrecorded {
+		fmt.Printf("ℹ️  V%d - %s (applied without a checksum, recorded on the next migrate)\n",
+			m.Version,
+			m.Description,
+		)
+	}
+
+	fmt.Printf("\nSummary: %d applied, %d pending, %d missing, %d modified, %d out of order\n",
+		report.Applied, report.Pending, len(report.Missing), len(report.Modified), len(report.OutOfOrder))
+
+	if !report.OK() {
+		return fmt.Errorf("migrations do not match the applied history")
+	}
+	fmt.Println("All applied migrations match their files")
+	return nil
+}
+
 func handleRollback(ctx context.Context, migrator *migration.Migrator, version int64) error {
 	if version > 0 {
 		// Generate rollback script for specific version
 		log.Info("generating rollback script", logging.Int64("version", version))
-		script, err := migrations.GenerateRollbackScript(version)
+		script, err := migrations.GenerateRollbackScript(migrationFiles(), version, migrator.Dialect())
 		if err != nil {
 			log.Error("failed to generate rollback script", logging.Error(err))
 			return fmt.Errorf("failed to generate rollback script: %w", err)
@@ -169,7 +388,7 @@ func handleRollback(ctx context.Context, migrator *migration.Migrator, version i
 
 	// Rollback the last migration
 	log.Info("rolling back the last migration")
-	err := migrator.Rollback(ctx)
+	err := migrator.Rollback(ctx, migrationRoot)
 	if err != nil {
 		log.Error("failed to rollback migration", logging.Error(err))
 		return fmt.Errorf("failed to rollback migration: %w", err)
@@ -178,3 +397,72 @@ func handleRollback(ctx context.Context, migrator *migration.Migrator, version i
 	log.Info("successfully rolled back the last migration")
 	return nil
 }
+
+func runMigrateCreate(name string) error {
+	dir := migrationPath
+	if dir == "" {
+		dir = "migrations"
+	}
+
+	paths, err := migration.CreateMigration(dir, name)
+	for _, path := range paths {
+		fmt.Println("Created", path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create migration: %w", err)
+	}
+	return nil
+}
+
+func runMigrateDiff() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	migrator, err := newMigrator(ctx)
+	if err != nil {
+		return err
+	}
+
+	models := make([]interface{}, 0, len(transfer.Tables))
+	for _, table := range transfer.Tables {
+		models = append(models, table.Model)
+	}
+
+	diff, err := migrator.Diff(ctx, models)
+	if err != nil {
+		log.Error("failed to compare schema", logging.Error(err))
+		return fmt.Errorf("failed to compare schema: %w", err)
+	}
+
+	fmt.Printf("Schema Diff (%s):\n", migrator.Dialect())
+	fmt.Println("=============")
+	for _, table := range diff.MissingTables {
+		fmt.Printf("➕ table %s (in the models, not in the database)\n", table)
+	}
+	for _, table := range diff.UnmodeledTables {
+		fmt.Printf("➖ table %s (in the database, no model)\n", table)
+	}
+	for _, c := range diff.MissingColumns {
+		fmt.Printf("➕ column %s.%s %s (in the models, not in the database)\n", c.Table, c.Column, c.Type)
+	}
+	for _, c := range diff.UnmodeledColumns {
+		fmt.Printf("➖ column %s.%s %s (in the database, not in the models)\n", c.Table, c.Column, c.Type)
+	}
+	for _, i := range diff.MissingIndexes {
+		kind := "index"
+		if i.Unique {
+			kind = "unique index"
+		}
+		fmt.Printf("➕ %s %s on %s(%s) (in the models, not in the database)\n", kind, i.Name, i.Table, strings.Join(i.Columns, ", "))
+	}
+
+	fmt.Printf("\nSummary: %d tables, %d columns, %d indexes missing from the database; %d tables, %d columns without a model\n",
+		len(diff.MissingTables), len(diff.MissingColumns), len(diff.MissingIndexes),
+		len(diff.UnmodeledTables), len(diff.UnmodeledColumns))
+
+	if !diff.Empty() {
+		return fmt.Errorf("database schema differs from the models")
+	}
+	fmt.Println("Database schema matches the models")
+	return nil
+}
@@ -1,23 +1,36 @@
 package cmd
 
-import "github.com/spf13/cobra"
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/valpere/trytrago/domain/logging"
+	"github.com/valpere/trytrago/infrastructure/backup"
+)
 
 var (
-	restorePath string
-	dryRun      bool
+	restorePaths []string
+	dryRun       bool
 )
 
 var restoreCmd = &cobra.Command{
 	Use:   "restore",
 	Short: "Restore dictionary content",
-	Long:  `Restore dictionary content from a JSON backup file`,
+	Long: `Restore dictionary content from JSON backup files.
+
+Pass a full backup followed by the incremental backups taken on top of it,
+repeating --input once per file, in any order. The files are ordered by the
+parent links in their manifests and replayed one transaction per backup;
+rows are upserted by ID, so an interrupted restore can be run again.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runRestore()
 	},
 }
 
 func init() {
-	restoreCmd.Flags().StringVar(&restorePath, "input", "", "Input backup file path")
+	restoreCmd.Flags().StringArrayVar(&restorePaths, "input", nil, "Input backup file path, repeat for a chain of backups")
 	restoreCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Validate backup without restoring")
 	restoreCmd.MarkFlagRequired("input")
 
@@ -25,6 +38,49 @@ func init() {
 }
 
 func runRestore() error {
-	// TODO: Implement restore logic
+	backups := make([]*backup.Backup, 0, len(restorePaths))
+	for _, path := range restorePaths {
+		b, err := backup.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		backups = append(backups, b)
+	}
+
+	chain, err := backup.Chain(backups)
+	if err != nil {
+		return fmt.Errorf("invalid backup chain: %w", err)
+	}
+	if chain[0].Manifest.Type == backup.TypeIncremental {
+		log.Warn("backup chain does not start with a full backup, restoring on top of the existing content",
+			logging.String("id", chain[0].Manifest.ID))
+	}
+
+	fmt.Println("Backup chain:")
+	for _, b := range chain {
+		fmt.Printf("  %s  %-11s  %s  entries: %d, deletions: %d\n",
+			b.Manifest.ID, b.Manifest.Type, b.Manifest.CreatedAt.Format(time.RFC3339),
+			len(b.Entries), len(b.Deletions))
+	}
+	if dryRun {
+		fmt.Println("Dry run, nothing restored")
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	repo, err := initializeRepository(loadConfiguration())
+	if err != nil {
+		log.Error("failed to initialize repository", logging.Error(err))
+		return fmt.Errorf("failed to initialize repository: %w", err)
+	}
+	defer repo.Close()
+
+	if err := backup.NewManager(repo, log).Restore(ctx, chain); err != nil {
+		return err
+	}
+
+	fmt.Printf("Restored %d backups\n", len(chain))
 	return nil
 }
@@ -65,16 +65,30 @@ func runServer(cmd *cobra.Command, args []string) error {
 
 	// Initialize services
 	entryService := service.NewEntryService(repo, logger)
+	entryTreeService := service.NewEntryTreeService(repo, logger)
 	translationService := service.NewTranslationService(repo, logger)
 	userService := service.NewUserService(repo, logger)
+	reviewService := service.NewReviewService(repo, logger)
+	duplicateService := service.NewDuplicateService(repo, logger)
+	exchangeService := service.NewExchangeService(repo, logger)
+	batchService := service.NewBatchService(repo, config.Server.MaxBatchOperations, logger)
+	patchService := service.NewPatchService(repo, logger)
+	graphService := service.NewGraphService(repo, logger)
 
 	// Start server
 	srv := server.NewServer(
 		config,
 		logger,
 		entryService,
+		entryTreeService,
 		translationService,
 		userService,
+		reviewService,
+		duplicateService,
+		exchangeService,
+		batchService,
+		patchService,
+		graphService,
 	)
 
 	// Set up graceful shutdown
@@ -116,6 +130,10 @@ func loadConfiguration() domain.Config {
 	config.Server.Timeout = 30 * time.Second
 	config.Server.ReadTimeout = 15 * time.Second
 	config.Server.WriteTimeout = 15 * time.Second
+	config.Server.MaxBatchOperations = 100
+	config.Server.GraphQL.MaxDepth = 8
+	config.Server.GraphQL.MaxComplexity = 10000
+	config.Server.GRPC.Port = 9090
 
 	config.Database.Type = "postgres"
 	config.Database.Host = "localhost"
@@ -145,6 +163,30 @@ func loadConfiguration() domain.Config {
 	if viper.IsSet("server.write_timeout") {
 		config.Server.WriteTimeout = viper.GetDuration("server.write_timeout")
 	}
+	if viper.IsSet("server.max_batch_operations") {
+		config.Server.MaxBatchOperations = viper.GetInt("server.max_batch_operations")
+	}
+	if viper.IsSet("server.require_if_match") {
+		config.Server.RequireIfMatch = viper.GetBool("server.require_if_match")
+	}
+	if viper.IsSet("server.cache_control.entries") {
+		config.Server.CacheControl.Entries = viper.GetString("server.cache_control.entries")
+	}
+	if viper.IsSet("server.cache_control.meanings") {
+		config.Server.CacheControl.Meanings = viper.GetString("server.cache_control.meanings")
+	}
+	if viper.IsSet("server.graphql.max_depth") {
+		config.Server.GraphQL.MaxDepth = viper.GetInt("server.graphql.max_depth")
+	}
+	if viper.IsSet("server.graphql.max_complexity") {
+		config.Server.GraphQL.MaxComplexity = viper.GetInt("server.graphql.max_complexity")
+	}
+	if viper.IsSet("server.grpc.enabled") {
+		config.Server.GRPC.Enabled = viper.GetBool("server.grpc.enabled")
+	}
+	if viper.IsSet("server.grpc.port") {
+		config.Server.GRPC.Port = viper.GetInt("server.grpc.port")
+	}
 
 	if viper.IsSet("database.type") {
 		config.Database.Type = viper.GetString("database.type")
@@ -182,6 +224,10 @@ func loadConfiguration() domain.Config {
 		config.Auth.RefreshTokenDuration = viper.GetDuration("auth.refresh_token_duration")
 	}
 
+	if viper.IsSet("exchange.audio_dir") {
+		config.Exchange.AudioDir = viper.GetString("exchange.audio_dir")
+	}
+
 	if viper.IsSet("environment") {
 		config.Environment = viper.GetString("environment")
 	} else {
@@ -12,14 +12,32 @@ type Config struct {
 	// Server configuration
 	// Server configuration
 	Server struct {
-		Port           int           `mapstructure:"port" yaml:"port"`
-		Timeout        time.Duration `mapstructure:"timeout" yaml:"timeout"`
-		ReadTimeout    time.Duration `mapstructure:"read_timeout" yaml:"read_timeout"`
-		WriteTimeout   time.Duration `mapstructure:"write_timeout" yaml:"write_timeout"`
-		AllowedOrigins []string      `mapstructure:"allowed_origins" yaml:"allowed_origins"`
-		AllowedMethods []string      `mapstructure:"allowed_methods" yaml:"allowed_methods"`
-		MaxRequestSize int64         `mapstructure:"max_request_size" yaml:"max_request_size"`
-		TLS            struct {
+		Port               int           `mapstructure:"port" yaml:"port"`
+		Timeout            time.Duration `mapstructure:"timeout" yaml:"timeout"`
+		ReadTimeout        time.Duration `mapstructure:"read_timeout" yaml:"read_timeout"`
+		WriteTimeout       time.Duration `mapstructure:"write_timeout" yaml:"write_timeout"`
+		AllowedOrigins     []string      `mapstructure:"allowed_origins" yaml:"allowed_origins"`
+		AllowedMethods     []string      `mapstructure:"allowed_methods" yaml:"allowed_methods"`
+		MaxRequestSize     int64         `mapstructure:"max_request_size" yaml:"max_request_size"`
+		MaxBatchOperations int           `mapstructure:"max_batch_operations" yaml:"max_batch_operations"`
+		RequireIfMatch     bool          `mapstructure:"require_if_match" yaml:"require_if_match"` // Reject writes to entries, meanings and translations without If-Match
+		// CacheControl is the Cache-Control policy sent with successful reads
+		// of each public route group, e.g. "public, max-age=60". Empty sends none
+		CacheControl struct {
+			Entries  string `mapstructure:"entries" yaml:"entries"`   // /api/v1/entries
+			Meanings string `mapstructure:"meanings" yaml:"meanings"` // /api/v1/meaning-details
+		} `mapstructure:"cache_control" yaml:"cache_control"`
+		// GraphQL bounds the queries POST /graphql runs; 0 lifts a limit
+		GraphQL struct {
+			MaxDepth      int `mapstructure:"max_depth" yaml:"max_depth"`           // Deepest nesting of fields
+			MaxComplexity int `mapstructure:"max_complexity" yaml:"max_complexity"` // Highest estimated number of objects resolved
+		} `mapstructure:"graphql" yaml:"graphql"`
+		// GRPC serves the entry, translation and user services over gRPC on its own port
+		GRPC struct {
+			Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+			Port    int  `mapstructure:"port" yaml:"port"`
+		} `mapstructure:"grpc" yaml:"grpc"`
+		TLS struct {
 			Enabled  bool   `mapstructure:"enabled" yaml:"enabled"`
 			CertFile string `mapstructure:"cert_file" yaml:"cert_file"`
 			KeyFile  string `mapstructure:"key_file" yaml:"key_file"`
@@ -47,6 +65,12 @@ type Config struct {
 		MaxOpenConns int           `mapstructure:"max_open_conns" yaml:"max_open_conns"`
 		MaxIdleConns int           `mapstructure:"max_idle_conns" yaml:"max_idle_conns"`
 		ConnLifetime time.Duration `mapstructure:"conn_lifetime" yaml:"conn_lifetime"`
+		// MigrationChecksum is "refuse" or "warn": what migrate does when an
+		// applied migration's file has been modified since
+		MigrationChecksum string `mapstructure:"migration_checksum" yaml:"migration_checksum"`
+		// MigrationLockTimeout is how long to wait for another instance
+		// holding the migration lock
+		MigrationLockTimeout time.Duration `mapstructure:"migration_lock_timeout" yaml:"migration_lock_timeout"`
 	} `mapstructure:"database" yaml:"database"`
 
 	// Logging configuration
@@ -82,6 +106,13 @@ type Config struct {
 		TranslationTTL time.Duration `mapstructure:"translation_ttl" yaml:"translation_ttl"`
 	} `mapstructure:"cache" yaml:"cache"`
 
+	// Import and export configuration
+	Exchange struct {
+		// AudioDir holds pronunciation recordings as <language>/<word>.mp3,
+		// attached to exported flashcards
+		AudioDir string `mapstructure:"audio_dir" yaml:"audio_dir"`
+	} `mapstructure:"exchange" yaml:"exchange"`
+
 	// Environment and version information
 	Environment string `mapstructure:"environment" yaml:"environment"`
 	Version     string `mapstructure:"version" yaml:"version"`
@@ -10,6 +10,8 @@ package database
 import (
 	"errors"
 	"fmt"
+
+	"gorm.io/gorm"
 )
 
 // Standard error types that can be used for error comparisons
@@ -20,9 +22,15 @@ var (
 	// ErrEntryNotFound indicates that a dictionary entry wasn't found
 	ErrEntryNotFound = fmt.Errorf("%w: entry not found", ErrNotFound)
 
+	// ErrMeaningNotFound indicates that a meaning wasn't found
+	ErrMeaningNotFound = fmt.Errorf("%w: meaning not found", ErrNotFound)
+
 	// ErrDuplicateEntry indicates that an entry with the same key already exists
 	ErrDuplicateEntry = errors.New("duplicate entry")
 
+	// ErrVersionConflict indicates that a row changed since the caller read it
+	ErrVersionConflict = errors.New("version conflict")
+
 	// ErrInvalidInput indicates that the provided input is invalid
 	ErrInvalidInput = errors.New("invalid input")
 
@@ -98,9 +106,15 @@ func IsNotFoundError(err error) bool {
 	return errors.Is(err, ErrNotFound)
 }
 
-// IsDuplicateError checks if the error is a duplicate entry error
+// IsDuplicateError checks if the error is a duplicate entry error, including
+// unique constraint violations reported by the database driver
 func IsDuplicateError(err error) bool {
-	return errors.Is(err, ErrDuplicateEntry)
+	return errors.Is(err, ErrDuplicateEntry) || errors.Is(err, gorm.ErrDuplicatedKey)
+}
+
+// IsVersionConflictError checks if the error is a failed version check
+func IsVersionConflictError(err error) bool {
+	return errors.Is(err, ErrVersionConflict)
 }
 
 // IsDatabaseConnectionError checks if the error is a connection-related error
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,27 +17,72 @@ const (
 	PhraseType       EntryType = "PHRASE"
 )
 
+// TranslationStatus represents the review state of a translation
+type TranslationStatus string
+
+const (
+	TranslationProposed   TranslationStatus = "PROPOSED"
+	TranslationApproved   TranslationStatus = "APPROVED"
+	TranslationRejected   TranslationStatus = "REJECTED"
+	TranslationSuperseded TranslationStatus = "SUPERSEDED"
+)
+
 type Product struct {
 	gorm.Model
 	Code  string
 	Price uint
 }
 
+// PartOfSpeech is a grammatical category referenced by meanings
 type PartOfSpeech struct {
-	ID           uint      `gorm:"primarykey"`
-	PartOfSpeech string    `gorm:"type:varchar(50)"`
-	Meanings     []Meaning `gorm:"foreignKey:PartOfSpeechID"`
+	ID        uuid.UUID `gorm:"type:uuid;primary_key"`
+	Name      string    `gorm:"type:varchar(50);uniqueIndex;not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TableName matches the table created by the SQL migrations
+func (PartOfSpeech) TableName() string {
+	return "parts_of_speech"
+}
+
+// Language is a reference row for the languages entries and translations use
+type Language struct {
+	Code       string `gorm:"type:varchar(5);primary_key"` // ISO 639-1 code
+	Name       string `gorm:"type:varchar(100);not null"`
+	NativeName string `gorm:"type:varchar(100);not null"`
+	RTL        bool   `gorm:"column:rtl;not null;default:false"`
+	Active     bool   `gorm:"not null;default:true"`
 }
 
 // Entry represents a dictionary entry
 type Entry struct {
-	ID            uuid.UUID `gorm:"type:uuid;primary_key"`
-	Word          string    `gorm:"index:idx_word;not null"`
-	Type          EntryType `gorm:"type:varchar(20);not null"`
-	Pronunciation string
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
-	Meanings      []Meaning `gorm:"foreignKey:EntryID"`
+	ID               uuid.UUID `gorm:"type:uuid;primary_key"`
+	Word             string    `gorm:"index:idx_word;not null"`
+	NormalizedWord   string    `gorm:"type:varchar(255);not null;default:'';uniqueIndex:idx_entries_homograph,priority:1"`
+	Type             EntryType `gorm:"type:varchar(20);not null;uniqueIndex:idx_entries_homograph,priority:2"`
+	SourceLanguageID string    `gorm:"type:varchar(5);index;uniqueIndex:idx_entries_homograph,priority:3"` // ISO 639-1 code
+	HomographIndex   int       `gorm:"not null;default:1;uniqueIndex:idx_entries_homograph,priority:4"`    // 1-based position among entries sharing the same normalized word
+	Pronunciation    string
+	Etymology        string `gorm:"type:text"`
+	Source           string `gorm:"type:varchar(100)"`  // Dataset the entry was imported from, kept for attribution
+	License          string `gorm:"type:varchar(100)"`  // Licence the imported content is distributed under
+	Version          int    `gorm:"not null;default:1"` // Moves on with every change to the entry or anything beneath it
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	Meanings         []Meaning `gorm:"foreignKey:EntryID"`
+
+	// Homographs counts the entries sharing the headword, type and source
+	// language, this one included. Filled in by the repository when it loads
+	// or saves the entry, 0 when unknown
+	Homographs int `gorm:"-"`
+}
+
+// EntryRedirect points the ID of a merged entry at the entry that absorbed it
+type EntryRedirect struct {
+	FromID    uuid.UUID `gorm:"type:uuid;primary_key"`
+	ToID      uuid.UUID `gorm:"type:uuid;index;not null"`
+	CreatedAt time.Time
 }
 
 // Meaning represents a specific meaning of a dictionary entry
@@ -45,12 +91,74 @@ type Meaning struct {
 	EntryID        uuid.UUID     `gorm:"type:uuid;index"`
 	PartOfSpeechId uuid.UUID     `gorm:"type:uuid;index"`
 	Description    string        `gorm:"type:text"`
+	Labels         string        `gorm:"type:varchar(255)"` // Comma separated usage labels, see LabelList
 	Examples       []Example     `gorm:"foreignKey:MeaningID"`
 	Translations   []Translation `gorm:"foreignKey:MeaningID"`
+	Version        int           `gorm:"not null;default:1"` // Moves on when the meaning or its examples change
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
 }
 
+// SameContent reports whether two copies of a meaning hold the same fields and
+// examples. Translations are versioned on their own and are not compared
+func (m *Meaning) SameContent(other *Meaning) bool {
+	if m.EntryID != other.EntryID || m.PartOfSpeechId != other.PartOfSpeechId ||
+		m.Description != other.Description || m.Labels != other.Labels ||
+		len(m.Examples) != len(other.Examples) {
+		return false
+	}
+
+	examples := make(map[uuid.UUID]Example, len(other.Examples))
+	for _, example := range other.Examples {
+		examples[example.ID] = example
+	}
+	for _, example := range m.Examples {
+		stored, ok := examples[example.ID]
+		if !ok || stored.Text != example.Text || stored.Context != example.Context {
+			return false
+		}
+	}
+	return true
+}
+
+// LabelList returns the usage labels of the meaning, such as "formal" or
+// "medicine"
+func (m *Meaning) LabelList() []string {
+	var labels []string
+	for _, label := range strings.Split(m.Labels, ",") {
+		if label = strings.TrimSpace(label); label != "" {
+			labels = append(labels, label)
+		}
+	}
+	return labels
+}
+
+// SetLabels replaces the usage labels of the meaning. Labels are stored in
+// lower case without duplicates
+func (m *Meaning) SetLabels(labels []string) {
+	var kept []string
+	seen := make(map[string]bool)
+	for _, label := range labels {
+		label = strings.ToLower(strings.Join(strings.Fields(strings.ReplaceAll(label, ",", " ")), " "))
+		if label == "" || seen[label] {
+			continue
+		}
+		seen[label] = true
+		kept = append(kept, label)
+	}
+	m.Labels = strings.Join(kept, ",")
+}
+
+// HasLabel reports whether the meaning carries the usage label
+func (m *Meaning) HasLabel(label string) bool {
+	for _, l := range m.LabelList() {
+		if strings.EqualFold(l, label) {
+			return true
+		}
+	}
+	return false
+}
+
 // Example represents usage examples for a meaning
 type Example struct {
 	ID        uuid.UUID `gorm:"type:uuid;primary_key"`
@@ -63,12 +171,59 @@ type Example struct {
 
 // Translation represents a translation of a meaning
 type Translation struct {
-	ID         uuid.UUID `gorm:"type:uuid;primary_key"`
-	MeaningID  uuid.UUID `gorm:"type:uuid;index"`
-	LanguageID string    `gorm:"type:varchar(5);index"` // ISO 639-1 code
-	Text       string    `gorm:"type:text"`
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
+	ID           uuid.UUID         `gorm:"type:uuid;primary_key"`
+	MeaningID    uuid.UUID         `gorm:"type:uuid;index"`
+	LanguageID   string            `gorm:"type:varchar(5);index"` // ISO 639-1 code
+	Text         string            `gorm:"type:text"`
+	Status       TranslationStatus `gorm:"type:varchar(20);not null;default:'APPROVED';index"`
+	CreatedByID  *uuid.UUID        `gorm:"type:uuid;index"` // Nil for imported and legacy rows
+	SupersedesID *uuid.UUID        `gorm:"type:uuid"`       // Translation replaced when this one is approved
+	ReviewedByID *uuid.UUID        `gorm:"type:uuid"`
+	ReviewedAt   *time.Time
+	ReviewReason string `gorm:"type:text"`
+	Version      int    `gorm:"not null;default:1"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// SameContent reports whether two copies of a translation hold the same fields
+func (t *Translation) SameContent(other *Translation) bool {
+	return t.MeaningID == other.MeaningID && t.LanguageID == other.LanguageID &&
+		t.Text == other.Text && t.Status == other.Status && t.ReviewReason == other.ReviewReason &&
+		sameID(t.CreatedByID, other.CreatedByID) && sameID(t.SupersedesID, other.SupersedesID) &&
+		sameID(t.ReviewedByID, other.ReviewedByID)
+}
+
+// sameID compares optional references by value
+func sameID(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// IsPublic reports whether the translation is visible to anonymous readers.
+// Rows created before the review workflow have no status and count as approved.
+func (t *Translation) IsPublic() bool {
+	return t.Status == "" || t.Status == TranslationApproved
+}
+
+// Revise changes the language and text of a translation. Changed text has not
+// been reviewed, so a translation that was public, rejected or superseded goes
+// back to the review queue and stays off the public lists until it is approved
+func (t *Translation) Revise(languageID, text string) {
+	if t.LanguageID == languageID && t.Text == text {
+		return
+	}
+	t.LanguageID = languageID
+	t.Text = text
+
+	if t.Status != TranslationProposed {
+		t.Status = TranslationProposed
+		t.ReviewedByID = nil
+		t.ReviewedAt = nil
+		t.ReviewReason = ""
+	}
 }
 
 // ChangeHistory tracks changes to dictionary entries
@@ -80,3 +235,24 @@ type ChangeHistory struct {
 	UserID    uuid.UUID `gorm:"type:uuid"`
 	CreatedAt time.Time
 }
+
+// TableName matches the table created by the SQL migrations
+func (ChangeHistory) TableName() string {
+	return "change_history"
+}
+
+// Actions recorded in the change history
+const (
+	ChangeMerge  = "MERGE"  // Data holds merged_entry_id, the entry that was absorbed
+	ChangeDelete = "DELETE" // Data holds deleted_entry_id
+	ChangeRemove = "REMOVE" // Data holds the RemovedChildren of an entry that stays
+)
+
+// RemovedChildren lists the meanings, examples and translations deleted from
+// an entry. Examples and translations of a removed meaning go with it and are
+// not listed separately
+type RemovedChildren struct {
+	MeaningIDs     []uuid.UUID `json:"meaning_ids,omitempty"`
+	ExampleIDs     []uuid.UUID `json:"example_ids,omitempty"`
+	TranslationIDs []uuid.UUID `json:"translation_ids,omitempty"`
+}
@@ -0,0 +1,217 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/google/uuid"
+	"github.com/valpere/trytrago/domain/database"
+)
+
+// UpdateMeaning saves a meaning and its examples without going through the
+// rest of the entry. Examples the meaning no longer holds are deleted and its
+// translations are left as they are. A non-zero Version must match the stored one
+func (r *dbrepo) UpdateMeaning(ctx context.Context, meaning *database.Meaning) error {
+	now := time.Now().UTC()
+	meaning.UpdatedAt = now
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		found, err := touchEntry(tx, entryOfMeaning(tx, meaning.ID), now)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return database.ErrMeaningNotFound
+		}
+
+		var stored database.Meaning
+		if err := tx.First(&stored, "id = ?", meaning.ID).Error; err != nil {
+			return err
+		}
+		version, err := claimVersion(tx, &database.Meaning{}, meaning.ID, meaning.Version)
+		if err != nil {
+			return err
+		}
+		meaning.Version = version
+		meaning.EntryID = stored.EntryID
+		meaning.CreatedAt = stored.CreatedAt
+
+		kept := make([]uuid.UUID, 0, len(meaning.Examples))
+		for i := range meaning.Examples {
+			example := &meaning.Examples[i]
+			if example.ID == uuid.Nil {
+				example.ID = uuid.New()
+				example.CreatedAt = now
+			}
+			example.MeaningID = meaning.ID
+			example.UpdatedAt = now
+			kept = append(kept, example.ID)
+		}
+
+		var dropped []uuid.UUID
+		query := tx.Model(&database.Example{}).Where("meaning_id = ?", meaning.ID)
+		if len(kept) > 0 {
+			query = query.Where("id NOT IN ?", kept)
+		}
+		if err := query.Pluck("id", &dropped).Error; err != nil {
+			return err
+		}
+		if len(dropped) > 0 {
+			if err := tx.Where("id IN ?", dropped).Delete(&database.Example{}).Error; err != nil {
+				return err
+			}
+			if err := recordRemoval(tx, stored.EntryID, database.RemovedChildren{ExampleIDs: dropped}); err != nil {
+				return err
+			}
+		}
+		for i := range meaning.Examples {
+			if err := tx.Save(&meaning.Examples[i]).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Omit(clause.Associations).Save(meaning).Error
+	})
+
+	if err != nil {
+		if errors.Is(err, database.ErrMeaningNotFound) || errors.Is(err, database.ErrVersionConflict) {
+			return err
+		}
+		return database.NewDatabaseError(err, "update", "meanings")
+	}
+
+	return nil
+}
+
+// DeleteMeaning deletes a meaning with its examples and translations. A
+// non-zero version must match the stored one
+func (r *dbrepo) DeleteMeaning(ctx context.Context, id uuid.UUID, version int) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		found, err := touchEntry(tx, entryOfMeaning(tx, id), time.Now().UTC())
+		if err != nil {
+			return err
+		}
+		if !found {
+			return database.ErrMeaningNotFound
+		}
+
+		// Claiming the version fails for a writer that got in first
+		if _, err := claimVersion(tx, &database.Meaning{}, id, version); err != nil {
+			return err
+		}
+
+		var stored database.Meaning
+		if err := tx.Select("entry_id").First(&stored, "id = ?", id).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("meaning_id = ?", id).Delete(&database.Translation{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("meaning_id = ?", id).Delete(&database.Example{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&database.Meaning{}, "id = ?", id).Error; err != nil {
+			return err
+		}
+		return recordRemoval(tx, stored.EntryID, database.RemovedChildren{MeaningIDs: []uuid.UUID{id}})
+	})
+
+	if err != nil {
+		if errors.Is(err, database.ErrMeaningNotFound) || errors.Is(err, database.ErrVersionConflict) {
+			return err
+		}
+		return database.NewDatabaseError(err, "delete", "meanings")
+	}
+
+	return nil
+}
+
+// UpdateTranslation saves a translation without going through the rest of the
+// entry. The translation stays with its meaning, which moves on to its next
+// version along with the entry. A non-zero Version must match the stored one
+func (r *dbrepo) UpdateTranslation(ctx context.Context, translation *database.Translation) error {
+	now := time.Now().UTC()
+	translation.UpdatedAt = now
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		found, err := touchEntry(tx, entryOfTranslation(tx, translation.ID), now)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return database.ErrNotFound
+		}
+		if err := touchMeaning(tx, meaningOfTranslation(tx, translation.ID), now); err != nil {
+			return err
+		}
+
+		var stored database.Translation
+		if err := tx.First(&stored, "id = ?", translation.ID).Error; err != nil {
+			return err
+		}
+		version, err := claimVersion(tx, &database.Translation{}, translation.ID, translation.Version)
+		if err != nil {
+			return err
+		}
+		translation.Version = version
+		translation.MeaningID = stored.MeaningID
+		translation.CreatedAt = stored.CreatedAt
+
+		return tx.Save(translation).Error
+	})
+
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) || errors.Is(err, database.ErrVersionConflict) {
+			return err
+		}
+		return database.NewDatabaseError(err, "update", "translations")
+	}
+
+	return nil
+}
+
+// DeleteTranslation deletes a translation. A non-zero version must match the
+// stored one
+func (r *dbrepo) DeleteTranslation(ctx context.Context, id uuid.UUID, version int) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now().UTC()
+		found, err := touchEntry(tx, entryOfTranslation(tx, id), now)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return database.ErrNotFound
+		}
+		if err := touchMeaning(tx, meaningOfTranslation(tx, id), now); err != nil {
+			return err
+		}
+
+		if _, err := claimVersion(tx, &database.Translation{}, id, version); err != nil {
+			return err
+		}
+
+		var meaning database.Meaning
+		if err := tx.Select("entry_id").Where("id = (?)", meaningOfTranslation(tx, id)).First(&meaning).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&database.Translation{}, "id = ?", id).Error; err != nil {
+			return err
+		}
+		return recordRemoval(tx, meaning.EntryID, database.RemovedChildren{TranslationIDs: []uuid.UUID{id}})
+	})
+
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) || errors.Is(err, database.ErrVersionConflict) {
+			return err
+		}
+		return database.NewDatabaseError(err, "delete", "translations")
+	}
+
+	return nil
+}
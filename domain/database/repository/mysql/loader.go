@@ -0,0 +1,135 @@
+package mysql
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/valpere/trytrago/domain/database"
+	"github.com/valpere/trytrago/domain/model"
+)
+
+// GetEntriesByIDs loads the entries with the given IDs, without their meanings.
+// IDs that name no entry are left out
+func (r *dbrepo) GetEntriesByIDs(ctx context.Context, ids []uuid.UUID) ([]database.Entry, error) {
+	var entries []database.Entry
+	if len(ids) == 0 {
+		return entries, nil
+	}
+
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&entries).Error; err != nil {
+		return nil, database.NewDatabaseError(err, "query", "entries")
+	}
+
+	if err := countHomographs(r.db.WithContext(ctx), entryRefs(entries)...); err != nil {
+		return nil, database.NewDatabaseError(err, "query", "entries")
+	}
+
+	return entries, nil
+}
+
+// ListMeaningsByEntryIDs loads the meanings of all the given entries with
+// their examples, in the order they were added
+func (r *dbrepo) ListMeaningsByEntryIDs(ctx context.Context, entryIDs []uuid.UUID) ([]database.Meaning, error) {
+	var meanings []database.Meaning
+	if len(entryIDs) == 0 {
+		return meanings, nil
+	}
+
+	if err := r.db.WithContext(ctx).
+		Preload("Examples").
+		Where("entry_id IN ?", entryIDs).
+		Order("created_at ASC, id ASC").
+		Find(&meanings).Error; err != nil {
+		return nil, database.NewDatabaseError(err, "list", "meanings")
+	}
+
+	return meanings, nil
+}
+
+// ListTranslationsByMeaningIDs loads the translations of all the given
+// meanings, whatever their review status
+func (r *dbrepo) ListTranslationsByMeaningIDs(ctx context.Context, meaningIDs []uuid.UUID) ([]database.Translation, error) {
+	var translations []database.Translation
+	if len(meaningIDs) == 0 {
+		return translations, nil
+	}
+
+	if err := r.db.WithContext(ctx).
+		Where("meaning_id IN ?", meaningIDs).
+		Order("created_at ASC, id ASC").
+		Find(&translations).Error; err != nil {
+		return nil, database.NewDatabaseError(err, "list", "translations")
+	}
+
+	return translations, nil
+}
+
+// ListCommentsByTargets loads the comments on all the given targets of one
+// type, newest first like ListComments
+func (r *dbrepo) ListCommentsByTargets(ctx context.Context, targetType string, targetIDs []uuid.UUID) ([]model.Comment, error) {
+	var comments []model.Comment
+	if len(targetIDs) == 0 {
+		return comments, nil
+	}
+
+	if err := r.db.WithContext(ctx).
+		Where("target_type = ? AND target_id IN ?", targetType, targetIDs).
+		Order("created_at DESC").
+		Find(&comments).Error; err != nil {
+		return nil, database.NewDatabaseError(err, "list", "comments")
+	}
+
+	return comments, nil
+}
+
+// CountLikesByTargets counts the likes of all the given targets of one type.
+// Targets nobody likes are missing from the result
+func (r *dbrepo) CountLikesByTargets(ctx context.Context, targetType string, targetIDs []uuid.UUID) (map[uuid.UUID]int64, error) {
+	counts := make(map[uuid.UUID]int64, len(targetIDs))
+	if len(targetIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		TargetID uuid.UUID
+		Count    int64
+	}
+	if err := r.db.WithContext(ctx).
+		Model(&model.Like{}).
+		Select("target_id, COUNT(*) AS count").
+		Where("target_type = ? AND target_id IN ? AND deleted_at IS NULL", targetType, targetIDs).
+		Group("target_id").
+		Scan(&rows).Error; err != nil {
+		return nil, database.NewDatabaseError(err, "count", "likes")
+	}
+
+	for _, row := range rows {
+		counts[row.TargetID] = row.Count
+	}
+	return counts, nil
+}
+
+// GetUsersByIDs loads the users with the given IDs. IDs that name no user are left out
+func (r *dbrepo) GetUsersByIDs(ctx context.Context, ids []uuid.UUID) ([]model.User, error) {
+	var users []model.User
+	if len(ids) == 0 {
+		return users, nil
+	}
+
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&users).Error; err != nil {
+		return nil, database.NewDatabaseError(err, "query", "users")
+	}
+
+	return users, nil
+}
+
+// ListLanguages loads the active languages, ordered by code
+func (r *dbrepo) ListLanguages(ctx context.Context) ([]database.Language, error) {
+	var languages []database.Language
+
+	if err := r.db.WithContext(ctx).Where("active = ?", true).Order("code ASC").Find(&languages).Error; err != nil {
+		return nil, database.NewDatabaseError(err, "list", "languages")
+	}
+
+	return languages, nil
+}
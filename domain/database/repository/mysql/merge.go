@@ -0,0 +1,163 @@
+package mysql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/google/uuid"
+	"github.com/valpere/trytrago/domain/database"
+	"github.com/valpere/trytrago/domain/database/repository"
+)
+
+// IterateEntries walks through entries in primary key order, handing each batch to fn
+func (r *dbrepo) IterateEntries(ctx context.Context, params repository.IterateParams, fn func(batch []database.Entry) error) error {
+	batchSize := params.BatchSize
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+
+	query := r.db.WithContext(ctx).Model(&database.Entry{})
+	for key, value := range params.Filters {
+		query = query.Where(key, value)
+	}
+	if params.WithRelations {
+		query = query.
+			Preload("Meanings.Examples").
+			Preload("Meanings.Translations")
+	}
+
+	// Errors returned by fn are passed through untouched
+	var fnErr error
+	var batch []database.Entry
+	result := query.FindInBatches(&batch, batchSize, func(tx *gorm.DB, _ int) error {
+		if err := countHomographs(r.db.WithContext(ctx), entryRefs(batch)...); err != nil {
+			return err
+		}
+		if err := fn(batch); err != nil {
+			fnErr = err
+			return err
+		}
+		return nil
+	})
+
+	if fnErr != nil {
+		return fnErr
+	}
+	if result.Error != nil {
+		return database.NewDatabaseError(result.Error, "list", "entries")
+	}
+
+	return nil
+}
+
+// MergeEntries moves everything attached to the source entry into the target
+// entry and replaces the source with a redirect. Comments and likes belong to
+// meanings and translations, so they follow the meanings they are attached to.
+func (r *dbrepo) MergeEntries(ctx context.Context, sourceID, targetID, userID uuid.UUID) error {
+	if sourceID == targetID {
+		return database.ErrInvalidInput
+	}
+
+	now := time.Now().UTC()
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var source database.Entry
+		if err := tx.First(&source, "id = ?", sourceID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return database.ErrEntryNotFound
+			}
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&database.Entry{}).Where("id = ?", targetID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return database.ErrEntryNotFound
+		}
+
+		// Re-parent meanings; examples and translations follow automatically
+		if err := tx.Model(&database.Meaning{}).
+			Where("entry_id = ?", sourceID).
+			Updates(map[string]interface{}{"entry_id": targetID, "version": gorm.Expr("version + 1"), "updated_at": now}).Error; err != nil {
+			return err
+		}
+
+		// Keep the audit trail with the surviving entry
+		if err := tx.Model(&database.ChangeHistory{}).
+			Where("entry_id = ?", sourceID).
+			Update("entry_id", targetID).Error; err != nil {
+			return err
+		}
+
+		// Collapse redirect chains so every old ID resolves in one hop
+		if err := tx.Model(&database.EntryRedirect{}).
+			Where("to_id = ?", sourceID).
+			Update("to_id", targetID).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&database.EntryRedirect{FromID: sourceID, ToID: targetID, CreatedAt: now}).Error; err != nil {
+			return err
+		}
+
+		data, err := json.Marshal(map[string]interface{}{
+			"merged_entry_id": sourceID,
+			"word":            source.Word,
+			"type":            source.Type,
+		})
+		if err != nil {
+			return err
+		}
+		if err := tx.Create(&database.ChangeHistory{
+			ID:        uuid.New(),
+			EntryID:   targetID,
+			Action:    database.ChangeMerge,
+			Data:      data,
+			UserID:    userID,
+			CreatedAt: now,
+		}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&database.Entry{}, "id = ?", sourceID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&database.Entry{}).
+			Where("id = ?", targetID).
+			Updates(map[string]interface{}{"version": gorm.Expr("version + 1"), "updated_at": now}).Error; err != nil {
+			return err
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		if errors.Is(err, database.ErrEntryNotFound) {
+			return err
+		}
+		return database.NewDatabaseError(err, "merge", "entries")
+	}
+
+	return nil
+}
+
+// GetEntryRedirect returns the redirect left behind by a merged entry
+func (r *dbrepo) GetEntryRedirect(ctx context.Context, id uuid.UUID) (*database.EntryRedirect, error) {
+	var redirect database.EntryRedirect
+
+	result := r.db.WithContext(ctx).First(&redirect, "from_id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, database.ErrNotFound
+		}
+		return nil, database.NewDatabaseError(result.Error, "query", "entry_redirects")
+	}
+
+	return &redirect, nil
+}
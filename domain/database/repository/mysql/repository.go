@@ -2,6 +2,7 @@ package mysql
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -22,8 +23,8 @@ type dbrepo struct {
 
 // NewRepository creates a new MySQL repository instance
 func NewRepository(ctx context.Context, opts repository.Options) (repository.Repository, error) {
-	// Construct MySQL DSN
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+	// Construct MySQL DSN; migration files hold several statements each
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=utf8mb4&parseTime=True&loc=Local&multiStatements=true",
 		opts.Username,
 		opts.Password,
 		opts.Host,
@@ -37,6 +38,7 @@ func NewRepository(ctx context.Context, opts repository.Options) (repository.Rep
 		NowFunc: func() time.Time {
 			return time.Now().UTC()
 		},
+		TranslateError: true,
 	}
 
 	if opts.Debug {
@@ -88,56 +90,34 @@ func (r *dbrepo) Close() error {
 }
 
 func (r *dbrepo) CreateEntry(ctx context.Context, entry *database.Entry) error {
-	if entry.ID == uuid.Nil {
-		entry.ID = uuid.New()
-	}
-
-	// Set creation timestamps
-	now := time.Now().UTC()
-	entry.CreatedAt = now
-	entry.UpdatedAt = now
-
-	// Handle meanings and their related items
-	for i := range entry.Meanings {
-		if entry.Meanings[i].ID == uuid.Nil {
-			entry.Meanings[i].ID = uuid.New()
+	prepareNewEntry(entry, time.Now().UTC())
+
+	// Use transaction to ensure all data is created atomically. The homograph
+	// index is picked inside it; if a concurrent insert takes the same index,
+	// the unique index rejects ours and the next attempt picks again
+	autoIndex := entry.HomographIndex <= 0
+	var err error
+	for attempt := 0; attempt < maxHomographAttempts; attempt++ {
+		if autoIndex {
+			entry.HomographIndex = 0
 		}
-		entry.Meanings[i].EntryID = entry.ID
-		entry.Meanings[i].CreatedAt = now
-		entry.Meanings[i].UpdatedAt = now
-
-		// Handle examples
-		for j := range entry.Meanings[i].Examples {
-			if entry.Meanings[i].Examples[j].ID == uuid.Nil {
-				entry.Meanings[i].Examples[j].ID = uuid.New()
+		err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := assignHomographIndex(tx, entry); err != nil {
+				return err
 			}
-			entry.Meanings[i].Examples[j].MeaningID = entry.Meanings[i].ID
-			entry.Meanings[i].Examples[j].CreatedAt = now
-			entry.Meanings[i].Examples[j].UpdatedAt = now
-		}
-
-		// Handle translations
-		for j := range entry.Meanings[i].Translations {
-			if entry.Meanings[i].Translations[j].ID == uuid.Nil {
-				entry.Meanings[i].Translations[j].ID = uuid.New()
+			if err := tx.Create(entry).Error; err != nil {
+				if database.IsDuplicateError(err) {
+					return database.ErrDuplicateEntry
+				}
+				return err
 			}
-			entry.Meanings[i].Translations[j].MeaningID = entry.Meanings[i].ID
-			entry.Meanings[i].Translations[j].CreatedAt = now
-			entry.Meanings[i].Translations[j].UpdatedAt = now
+			return countHomographs(tx, entry)
+		})
+		if !autoIndex || !database.IsDuplicateError(err) {
+			break
 		}
 	}
 
-	// Use transaction to ensure all data is created atomically
-	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		if err := tx.Create(entry).Error; err != nil {
-			if database.IsDuplicateError(err) {
-				return database.ErrDuplicateEntry
-			}
-			return err
-		}
-		return nil
-	})
-
 	if err != nil {
 		return database.NewDatabaseError(err, "create", "entries")
 	}
@@ -160,9 +140,32 @@ func (r *dbrepo) GetEntryByID(ctx context.Context, id uuid.UUID) (*database.Entr
 		return nil, database.NewDatabaseError(result.Error, "query", "entries")
 	}
 
+	if err := countHomographs(r.db.WithContext(ctx), &entry); err != nil {
+		return nil, database.NewDatabaseError(err, "query", "entries")
+	}
+
 	return &entry, nil
 }
 
+// GetMeaningByID loads a single meaning with its examples and translations
+func (r *dbrepo) GetMeaningByID(ctx context.Context, id uuid.UUID) (*database.Meaning, error) {
+	var meaning database.Meaning
+
+	result := r.db.WithContext(ctx).
+		Preload("Examples").
+		Preload("Translations").
+		First(&meaning, "id = ?", id)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, database.ErrMeaningNotFound
+		}
+		return nil, database.NewDatabaseError(result.Error, "query", "meanings")
+	}
+
+	return &meaning, nil
+}
+
 func (r *dbrepo) UpdateEntry(ctx context.Context, entry *database.Entry) error {
 	// Set update timestamp
 	entry.UpdatedAt = time.Now().UTC()
@@ -178,56 +181,7 @@ func (r *dbrepo) UpdateEntry(ctx context.Context, entry *database.Entry) error {
 			return database.ErrEntryNotFound
 		}
 
-		// Update entry
-		if err := tx.Save(entry).Error; err != nil {
-			return err
-		}
-
-		// Handle meanings - more complex as we need to handle relationships
-		for i := range entry.Meanings {
-			// Ensure IDs are set
-			if entry.Meanings[i].ID == uuid.Nil {
-				entry.Meanings[i].ID = uuid.New()
-				entry.Meanings[i].CreatedAt = entry.UpdatedAt
-			}
-			entry.Meanings[i].EntryID = entry.ID
-			entry.Meanings[i].UpdatedAt = entry.UpdatedAt
-
-			// Update the meaning
-			if err := tx.Save(&entry.Meanings[i]).Error; err != nil {
-				return err
-			}
-
-			// Update examples
-			for j := range entry.Meanings[i].Examples {
-				if entry.Meanings[i].Examples[j].ID == uuid.Nil {
-					entry.Meanings[i].Examples[j].ID = uuid.New()
-					entry.Meanings[i].Examples[j].CreatedAt = entry.UpdatedAt
-				}
-				entry.Meanings[i].Examples[j].MeaningID = entry.Meanings[i].ID
-				entry.Meanings[i].Examples[j].UpdatedAt = entry.UpdatedAt
-
-				if err := tx.Save(&entry.Meanings[i].Examples[j]).Error; err != nil {
-					return err
-				}
-			}
-
-			// Update translations
-			for j := range entry.Meanings[i].Translations {
-				if entry.Meanings[i].Translations[j].ID == uuid.Nil {
-					entry.Meanings[i].Translations[j].ID = uuid.New()
-					entry.Meanings[i].Translations[j].CreatedAt = entry.UpdatedAt
-				}
-				entry.Meanings[i].Translations[j].MeaningID = entry.Meanings[i].ID
-				entry.Meanings[i].Translations[j].UpdatedAt = entry.UpdatedAt
-
-				if err := tx.Save(&entry.Meanings[i].Translations[j]).Error; err != nil {
-					return err
-				}
-			}
-		}
-
-		return nil
+		return saveEntryTree(tx, entry)
 	})
 
 	if err != nil {
@@ -237,17 +191,13 @@ func (r *dbrepo) UpdateEntry(ctx context.Context, entry *database.Entry) error {
 	return nil
 }
 
-func (r *dbrepo) DeleteEntry(ctx context.Context, id uuid.UUID) error {
+func (r *dbrepo) DeleteEntry(ctx context.Context, id uuid.UUID, version int) error {
 	// Use a transaction to delete the entry and all related records
 	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// First check if the entry exists
-		var count int64
-		if err := tx.Model(&database.Entry{}).Where("id = ?", id).Count(&count).Error; err != nil {
+		// First check that the entry exists and is still the version the caller read
+		if err := claimEntryVersion(tx, &database.Entry{ID: id, Version: version}); err != nil {
 			return err
 		}
-		if count == 0 {
-			return database.ErrEntryNotFound
-		}
 
 		// Find meanings to get their IDs for deleting examples and translations
 		var meanings []database.Meaning
@@ -277,11 +227,27 @@ func (r *dbrepo) DeleteEntry(ctx context.Context, id uuid.UUID) error {
 			return err
 		}
 
+		// Incremental backups learn about deletions from the history. The
+		// deletion is not attributed to anyone, so user_id is left NULL
+		data, err := json.Marshal(map[string]interface{}{"deleted_entry_id": id})
+		if err != nil {
+			return err
+		}
+		if err := tx.Omit("UserID").Create(&database.ChangeHistory{
+			ID:        uuid.New(),
+			EntryID:   id,
+			Action:    database.ChangeDelete,
+			Data:      data,
+			CreatedAt: time.Now().UTC(),
+		}).Error; err != nil {
+			return err
+		}
+
 		return nil
 	})
 
 	if err != nil {
-		if errors.Is(err, database.ErrEntryNotFound) {
+		if errors.Is(err, database.ErrEntryNotFound) || errors.Is(err, database.ErrVersionConflict) {
 			return err
 		}
 		return database.NewDatabaseError(err, "delete", "entries")
@@ -306,6 +272,10 @@ func (r *dbrepo) ListEntries(ctx context.Context, params repository.ListParams)
 			direction = "DESC"
 		}
 		query = query.Order(fmt.Sprintf("%s %s", params.SortBy, direction))
+		if params.SortBy == "word" {
+			// Keep homographs in numbering order
+			query = query.Order("homograph_index ASC")
+		}
 	} else {
 		// Default sorting by updated_at
 		query = query.Order("updated_at DESC")
@@ -331,7 +301,7 @@ func (r *dbrepo) ListEntries(ctx context.Context, params repository.ListParams)
 	}
 
 	// If we need full entry data, preload related data
-	if len(entries) > 0 {
+	if len(entries) > 0 && !params.WithoutRelations {
 		// For performance with large datasets, only preload for specific entries
 		entryIDs := make([]uuid.UUID, len(entries))
 		for i, entry := range entries {
@@ -348,6 +318,10 @@ func (r *dbrepo) ListEntries(ctx context.Context, params repository.ListParams)
 		}
 	}
 
+	if err := countHomographs(r.db.WithContext(ctx), entryRefs(entries)...); err != nil {
+		return nil, database.NewDatabaseError(err, "list", "entries")
+	}
+
 	return entries, nil
 }
 
@@ -565,6 +539,12 @@ func (r *dbrepo) GetDB() (*gorm.DB, error) {
 	return r.db, nil
 }
 
+// WithTx returns a repository whose operations run in the given transaction,
+// as handed to the callback of WithTransaction. It must not be closed
+func (r *dbrepo) WithTx(tx *gorm.DB) repository.Repository {
+	return &dbrepo{db: tx}
+}
+
 // ListUserEntries lists entries created by a specific user
 func (r *dbrepo) ListUserEntries(ctx context.Context, userID uuid.UUID, params repository.ListParams) ([]database.Entry, error) {
 	var entries []database.Entry
@@ -603,6 +583,10 @@ func (r *dbrepo) ListUserEntries(ctx context.Context, userID uuid.UUID, params r
 		return nil, database.NewDatabaseError(result.Error, "list", "entries")
 	}
 
+	if err := countHomographs(r.db.WithContext(ctx), entryRefs(entries)...); err != nil {
+		return nil, database.NewDatabaseError(err, "list", "entries")
+	}
+
 	return entries, nil
 }
 
@@ -0,0 +1,187 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/google/uuid"
+	"github.com/valpere/trytrago/domain/database"
+	"github.com/valpere/trytrago/domain/database/repository"
+	"github.com/valpere/trytrago/domain/model"
+)
+
+// GetTranslationByID loads a single translation regardless of its review status
+func (r *dbrepo) GetTranslationByID(ctx context.Context, id uuid.UUID) (*database.Translation, error) {
+	var translation database.Translation
+
+	result := r.db.WithContext(ctx).First(&translation, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, database.ErrNotFound
+		}
+		return nil, database.NewDatabaseError(result.Error, "query", "translations")
+	}
+
+	return &translation, nil
+}
+
+// ListTranslationsByStatus returns translations in the given review state, oldest first
+func (r *dbrepo) ListTranslationsByStatus(ctx context.Context, status database.TranslationStatus, params repository.ListParams) ([]database.Translation, error) {
+	var translations []database.Translation
+	query := r.db.WithContext(ctx).Where("status = ?", status)
+
+	for key, value := range params.Filters {
+		query = query.Where(key, value)
+	}
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	offset := params.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	result := query.Order("created_at ASC").Limit(limit).Offset(offset).Find(&translations)
+	if result.Error != nil {
+		return nil, database.NewDatabaseError(result.Error, "list", "translations")
+	}
+
+	return translations, nil
+}
+
+// CountTranslationsByStatus counts the translations in the given review state
+// that match the filters, ignoring any paging
+func (r *dbrepo) CountTranslationsByStatus(ctx context.Context, status database.TranslationStatus, filters map[string]interface{}) (int64, error) {
+	var count int64
+	query := r.db.WithContext(ctx).Model(&database.Translation{}).Where("status = ?", status)
+
+	for key, value := range filters {
+		query = query.Where(key, value)
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return 0, database.NewDatabaseError(err, "count", "translations")
+	}
+
+	return count, nil
+}
+
+// ReviewTranslation stores a review decision. Approving a translation that
+// supersedes another one of the same meaning retires the older translation in
+// the same transaction.
+// A non-zero Version must match the stored one, as for any other change.
+func (r *dbrepo) ReviewTranslation(ctx context.Context, translation *database.Translation) error {
+	now := time.Now().UTC()
+	translation.UpdatedAt = now
+	if translation.ReviewedAt == nil {
+		translation.ReviewedAt = &now
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		found, err := touchEntry(tx, entryOfTranslation(tx, translation.ID), now)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return database.ErrNotFound
+		}
+		if err := touchMeaning(tx, meaningOfTranslation(tx, translation.ID), now); err != nil {
+			return err
+		}
+
+		version, err := claimVersion(tx, &database.Translation{}, translation.ID, translation.Version)
+		if err != nil {
+			return err
+		}
+		translation.Version = version
+
+		if err := tx.Model(&database.Translation{}).
+			Where("id = ?", translation.ID).
+			Updates(map[string]interface{}{
+				"status":         translation.Status,
+				"reviewed_by_id": translation.ReviewedByID,
+				"reviewed_at":    translation.ReviewedAt,
+				"review_reason":  translation.ReviewReason,
+				"updated_at":     translation.UpdatedAt,
+			}).Error; err != nil {
+			return err
+		}
+
+		if translation.Status == database.TranslationApproved && translation.SupersedesID != nil {
+			if err := tx.Model(&database.Translation{}).
+				Where("id = ? AND meaning_id = ? AND status = ?", *translation.SupersedesID, translation.MeaningID, database.TranslationApproved).
+				Updates(map[string]interface{}{
+					"status":     database.TranslationSuperseded,
+					"version":    gorm.Expr("version + 1"),
+					"updated_at": now,
+				}).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) || errors.Is(err, database.ErrVersionConflict) {
+			return err
+		}
+		return database.NewDatabaseError(err, "update", "translations")
+	}
+
+	return nil
+}
+
+// CreateNotification stores a notification for a user
+func (r *dbrepo) CreateNotification(ctx context.Context, notification *model.Notification) error {
+	if notification.ID == uuid.Nil {
+		notification.ID = uuid.New()
+	}
+
+	if notification.CreatedAt.IsZero() {
+		notification.CreatedAt = time.Now().UTC()
+	}
+
+	result := r.db.WithContext(ctx).Create(notification)
+	if result.Error != nil {
+		return database.NewDatabaseError(result.Error, "create", "notifications")
+	}
+
+	return nil
+}
+
+// ListNotifications returns a user's notifications, newest first
+func (r *dbrepo) ListNotifications(ctx context.Context, userID uuid.UUID, params repository.ListParams) ([]model.Notification, error) {
+	var notifications []model.Notification
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+
+	// Filters without a value (e.g. "read_at IS NULL") are applied as-is
+	for key, value := range params.Filters {
+		if value == nil {
+			query = query.Where(key)
+			continue
+		}
+		query = query.Where(key, value)
+	}
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	offset := params.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	result := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&notifications)
+	if result.Error != nil {
+		return nil, database.NewDatabaseError(result.Error, "list", "notifications")
+	}
+
+	return notifications, nil
+}
@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/google/uuid"
+	"github.com/valpere/trytrago/domain/database"
+)
+
+// CreateEntries inserts a batch of entries with their meanings in a single
+// transaction. Either every entry is created or none is
+func (r *dbrepo) CreateEntries(ctx context.Context, entries []*database.Entry) error {
+	now := time.Now().UTC()
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, entry := range entries {
+			prepareNewEntry(entry, now)
+			if err := assignHomographIndex(tx, entry); err != nil {
+				return err
+			}
+			if err := tx.Create(entry).Error; err != nil {
+				if database.IsDuplicateError(err) {
+					return database.ErrDuplicateEntry
+				}
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		return database.NewDatabaseError(err, "create", "entries")
+	}
+
+	return nil
+}
+
+// GetOrCreatePartOfSpeech looks up a part of speech by name, creating it if
+// it does not exist yet. Names are stored in lower case
+func (r *dbrepo) GetOrCreatePartOfSpeech(ctx context.Context, name string) (*database.PartOfSpeech, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return nil, database.ErrInvalidInput
+	}
+
+	var pos database.PartOfSpeech
+	err := r.db.WithContext(ctx).Where("name = ?", name).First(&pos).Error
+	if err == nil {
+		return &pos, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, database.NewDatabaseError(err, "query", "parts_of_speech")
+	}
+
+	now := time.Now().UTC()
+	pos = database.PartOfSpeech{
+		ID:        uuid.New(),
+		Name:      name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := r.db.WithContext(ctx).Create(&pos).Error; err != nil {
+		// Another writer may have created it in the meantime
+		if database.IsDuplicateError(err) {
+			if err := r.db.WithContext(ctx).Where("name = ?", name).First(&pos).Error; err == nil {
+				return &pos, nil
+			}
+		}
+		return nil, database.NewDatabaseError(err, "create", "parts_of_speech")
+	}
+
+	return &pos, nil
+}
+
+// ListPartsOfSpeech returns every part of speech ordered by name
+func (r *dbrepo) ListPartsOfSpeech(ctx context.Context) ([]database.PartOfSpeech, error) {
+	var parts []database.PartOfSpeech
+	if err := r.db.WithContext(ctx).Order("name ASC").Find(&parts).Error; err != nil {
+		return nil, database.NewDatabaseError(err, "list", "parts_of_speech")
+	}
+	return parts, nil
+}
+
+// prepareNewEntry assigns missing IDs, creation timestamps and the first version
+// to an entry and everything nested under it
+func prepareNewEntry(entry *database.Entry, now time.Time) {
+	if entry.ID == uuid.Nil {
+		entry.ID = uuid.New()
+	}
+
+	// Set creation timestamps
+	entry.CreatedAt = now
+	entry.UpdatedAt = now
+	entry.Version = 1
+
+	// Handle meanings and their related items
+	for i := range entry.Meanings {
+		if entry.Meanings[i].ID == uuid.Nil {
+			entry.Meanings[i].ID = uuid.New()
+		}
+		entry.Meanings[i].EntryID = entry.ID
+		entry.Meanings[i].CreatedAt = now
+		entry.Meanings[i].UpdatedAt = now
+		entry.Meanings[i].Version = 1
+
+		// Handle examples
+		for j := range entry.Meanings[i].Examples {
+			if entry.Meanings[i].Examples[j].ID == uuid.Nil {
+				entry.Meanings[i].Examples[j].ID = uuid.New()
+			}
+			entry.Meanings[i].Examples[j].MeaningID = entry.Meanings[i].ID
+			entry.Meanings[i].Examples[j].CreatedAt = now
+			entry.Meanings[i].Examples[j].UpdatedAt = now
+		}
+
+		// Handle translations
+		for j := range entry.Meanings[i].Translations {
+			if entry.Meanings[i].Translations[j].ID == uuid.Nil {
+				entry.Meanings[i].Translations[j].ID = uuid.New()
+			}
+			entry.Meanings[i].Translations[j].MeaningID = entry.Meanings[i].ID
+			entry.Meanings[i].Translations[j].CreatedAt = now
+			entry.Meanings[i].Translations[j].UpdatedAt = now
+			entry.Meanings[i].Translations[j].Version = 1
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/valpere/trytrago/domain/database"
+	"github.com/valpere/trytrago/domain/utils"
+)
+
+// maxHomographAttempts bounds how often entry creation is retried when a
+// concurrent insert claims the same homograph index first
+const maxHomographAttempts = 3
+
+// assignHomographIndex fills in the normalized word of the entry and, unless an
+// index was set explicitly, gives it the next free index among its homographs
+func assignHomographIndex(tx *gorm.DB, entry *database.Entry) error {
+	entry.NormalizedWord = utils.NormalizeWord(entry.Word)
+	if entry.HomographIndex > 0 {
+		return nil
+	}
+
+	var highest int
+	err := tx.Model(&database.Entry{}).
+		Select("COALESCE(MAX(homograph_index), 0)").
+		Where("normalized_word = ? AND type = ? AND COALESCE(source_language_id, '') = ?",
+			entry.NormalizedWord, entry.Type, entry.SourceLanguageID).
+		Where("id <> ?", entry.ID).
+		Scan(&highest).Error
+	if err != nil {
+		return err
+	}
+
+	entry.HomographIndex = highest + 1
+	return nil
+}
+
+// homographKey identifies the entries that are homographs of each other
+type homographKey struct {
+	word      string
+	entryType database.EntryType
+	language  string
+}
+
+// countHomographs tells each entry how many entries share its headword, type
+// and source language, itself included, so that only entries with homographs
+// are shown with their index
+func countHomographs(db *gorm.DB, entries ...*database.Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	words := make([]string, len(entries))
+	for i, entry := range entries {
+		words[i] = entry.NormalizedWord
+	}
+
+	var rows []struct {
+		NormalizedWord   string
+		Type             database.EntryType
+		SourceLanguageID string
+		Homographs       int
+	}
+	err := db.Model(&database.Entry{}).
+		Select("normalized_word, type, COALESCE(source_language_id, '') AS source_language_id, COUNT(*) AS homographs").
+		Where("normalized_word IN ?", words).
+		Group("normalized_word, type, COALESCE(source_language_id, '')").
+		Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	counts := make(map[homographKey]int, len(rows))
+	for _, row := range rows {
+		counts[homographKey{row.NormalizedWord, row.Type, row.SourceLanguageID}] = row.Homographs
+	}
+	for _, entry := range entries {
+		entry.Homographs = counts[homographKey{entry.NormalizedWord, entry.Type, entry.SourceLanguageID}]
+	}
+	return nil
+}
+
+// entryRefs returns pointers to the entries of a slice
+func entryRefs(entries []database.Entry) []*database.Entry {
+	refs := make([]*database.Entry, len(entries))
+	for i := range entries {
+		refs[i] = &entries[i]
+	}
+	return refs
+}
@@ -16,11 +16,35 @@ type Repository interface {
 	CreateEntry(ctx context.Context, entry *database.Entry) error
 	GetEntryByID(ctx context.Context, id uuid.UUID) (*database.Entry, error)
 	UpdateEntry(ctx context.Context, entry *database.Entry) error
-	DeleteEntry(ctx context.Context, id uuid.UUID) error
+	ReplaceEntry(ctx context.Context, entry *database.Entry) error
+	GetMeaningByID(ctx context.Context, id uuid.UUID) (*database.Meaning, error)
+	DeleteEntry(ctx context.Context, id uuid.UUID, version int) error
 	ListEntries(ctx context.Context, params ListParams) ([]database.Entry, error)
+	IterateEntries(ctx context.Context, params IterateParams, fn func(batch []database.Entry) error) error
+	CreateEntries(ctx context.Context, entries []*database.Entry) error
+
+	// Part of speech operations
+	GetOrCreatePartOfSpeech(ctx context.Context, name string) (*database.PartOfSpeech, error)
+	ListPartsOfSpeech(ctx context.Context) ([]database.PartOfSpeech, error)
+
+	// Merge operations
+	MergeEntries(ctx context.Context, sourceID, targetID, userID uuid.UUID) error
+	GetEntryRedirect(ctx context.Context, id uuid.UUID) (*database.EntryRedirect, error)
+
+	// Meaning operations; a non-zero version is checked against the stored one
+	UpdateMeaning(ctx context.Context, meaning *database.Meaning) error
+	DeleteMeaning(ctx context.Context, id uuid.UUID, version int) error
 
 	// Translation operations
 	FindTranslations(ctx context.Context, word string, langID string) ([]database.Translation, error)
+	UpdateTranslation(ctx context.Context, translation *database.Translation) error
+	DeleteTranslation(ctx context.Context, id uuid.UUID, version int) error
+
+	// Review operations
+	GetTranslationByID(ctx context.Context, id uuid.UUID) (*database.Translation, error)
+	ListTranslationsByStatus(ctx context.Context, status database.TranslationStatus, params ListParams) ([]database.Translation, error)
+	CountTranslationsByStatus(ctx context.Context, status database.TranslationStatus, filters map[string]interface{}) (int64, error)
+	ReviewTranslation(ctx context.Context, translation *database.Translation) error
 
 	// History operations
 	RecordChange(ctx context.Context, change *database.ChangeHistory) error
@@ -45,6 +69,19 @@ type Repository interface {
 	GetLike(ctx context.Context, userID uuid.UUID, targetType string, targetID uuid.UUID) (*model.Like, error)
 	CountLikes(ctx context.Context, targetType string, targetID uuid.UUID) (int64, error)
 
+	// Batch loading operations, each one query for any number of keys
+	GetEntriesByIDs(ctx context.Context, ids []uuid.UUID) ([]database.Entry, error)
+	ListMeaningsByEntryIDs(ctx context.Context, entryIDs []uuid.UUID) ([]database.Meaning, error)
+	ListTranslationsByMeaningIDs(ctx context.Context, meaningIDs []uuid.UUID) ([]database.Translation, error)
+	ListCommentsByTargets(ctx context.Context, targetType string, targetIDs []uuid.UUID) ([]model.Comment, error)
+	CountLikesByTargets(ctx context.Context, targetType string, targetIDs []uuid.UUID) (map[uuid.UUID]int64, error)
+	GetUsersByIDs(ctx context.Context, ids []uuid.UUID) ([]model.User, error)
+	ListLanguages(ctx context.Context) ([]database.Language, error)
+
+	// Notification operations
+	CreateNotification(ctx context.Context, notification *model.Notification) error
+	ListNotifications(ctx context.Context, userID uuid.UUID, params ListParams) ([]model.Notification, error)
+
 	// Maintenance operations
 	Ping(ctx context.Context) error
 	Close() error
@@ -52,15 +89,24 @@ type Repository interface {
 	// Access to the underlying database
 	GetDB() (*gorm.DB, error)
 	WithTransaction(ctx context.Context, fn func(tx *gorm.DB) error) error
+	WithTx(tx *gorm.DB) Repository
 }
 
 // ListParams defines parameters for listing entries
 type ListParams struct {
-	Offset   int
-	Limit    int
-	SortBy   string
-	SortDesc bool
-	Filters  map[string]interface{}
+	Offset           int
+	Limit            int
+	SortBy           string
+	SortDesc         bool
+	Filters          map[string]interface{}
+	WithoutRelations bool // Leave meanings unloaded, for callers that load them on their own
+}
+
+// IterateParams defines parameters for walking through all entries in batches
+type IterateParams struct {
+	BatchSize     int
+	Filters       map[string]interface{}
+	WithRelations bool // Preload meanings with their examples and translations
 }
 
 // Options defines database connection options
@@ -0,0 +1,384 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/google/uuid"
+	"github.com/valpere/trytrago/domain/database"
+)
+
+// ReplaceEntry saves the entry with its whole tree of meanings, examples and
+// translations, and deletes the children the tree no longer holds. Children
+// keep their IDs and those without one are created. A child ID that does not
+// belong to the entry is rejected with ErrInvalidInput
+func (r *dbrepo) ReplaceEntry(ctx context.Context, entry *database.Entry) error {
+	entry.UpdatedAt = time.Now().UTC()
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&database.Entry{}).Where("id = ?", entry.ID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return database.ErrEntryNotFound
+		}
+
+		if err := pruneEntryTree(tx, entry); err != nil {
+			return err
+		}
+
+		return saveEntryTree(tx, entry)
+	})
+
+	if err != nil {
+		if errors.Is(err, database.ErrEntryNotFound) || errors.Is(err, database.ErrInvalidInput) ||
+			errors.Is(err, database.ErrVersionConflict) {
+			return err
+		}
+		return database.NewDatabaseError(err, "replace", "entries")
+	}
+
+	return nil
+}
+
+// saveEntryTree saves an existing entry and upserts its meanings, examples and
+// translations, giving new children an ID. A non-zero entry version must match
+// the stored one, or ErrVersionConflict is returned and nothing is written
+func saveEntryTree(tx *gorm.DB, entry *database.Entry) error {
+	if err := claimEntryVersion(tx, entry); err != nil {
+		return err
+	}
+
+	// Keep the homograph key in step with the word
+	if err := assignHomographIndex(tx, entry); err != nil {
+		return err
+	}
+
+	// Saving the entry cascades to its children, so every child needs its ID
+	// and parent before anything is written
+	for i := range entry.Meanings {
+		meaning := &entry.Meanings[i]
+		if meaning.ID == uuid.Nil {
+			meaning.ID = uuid.New()
+			meaning.CreatedAt = entry.UpdatedAt
+		}
+		meaning.EntryID = entry.ID
+		meaning.UpdatedAt = entry.UpdatedAt
+
+		for j := range meaning.Examples {
+			if meaning.Examples[j].ID == uuid.Nil {
+				meaning.Examples[j].ID = uuid.New()
+				meaning.Examples[j].CreatedAt = entry.UpdatedAt
+			}
+			meaning.Examples[j].MeaningID = meaning.ID
+			meaning.Examples[j].UpdatedAt = entry.UpdatedAt
+		}
+
+		for j := range meaning.Translations {
+			if meaning.Translations[j].ID == uuid.Nil {
+				meaning.Translations[j].ID = uuid.New()
+				meaning.Translations[j].CreatedAt = entry.UpdatedAt
+			}
+			meaning.Translations[j].MeaningID = meaning.ID
+			meaning.Translations[j].UpdatedAt = entry.UpdatedAt
+		}
+	}
+
+	if err := versionChildren(tx, entry); err != nil {
+		return err
+	}
+
+	// Update entry
+	if err := tx.Save(entry).Error; err != nil {
+		if database.IsDuplicateError(err) {
+			return database.ErrDuplicateEntry
+		}
+		return err
+	}
+
+	// The cascade only links existing children, so their fields are saved one by one
+	for i := range entry.Meanings {
+		meaning := &entry.Meanings[i]
+		if err := tx.Save(meaning).Error; err != nil {
+			return err
+		}
+		for j := range meaning.Examples {
+			if err := tx.Save(&meaning.Examples[j]).Error; err != nil {
+				return err
+			}
+		}
+		for j := range meaning.Translations {
+			if err := tx.Save(&meaning.Translations[j]).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	return countHomographs(tx, entry)
+}
+
+// pruneEntryTree deletes the stored meanings, examples and translations of the
+// entry that its new tree leaves out. Examples and translations may move
+// between meanings of the same entry, but not in from another entry
+func pruneEntryTree(tx *gorm.DB, entry *database.Entry) error {
+	var meaningIDs, exampleIDs, translationIDs []uuid.UUID
+	if err := tx.Model(&database.Meaning{}).Where("entry_id = ?", entry.ID).Pluck("id", &meaningIDs).Error; err != nil {
+		return err
+	}
+	if len(meaningIDs) > 0 {
+		if err := tx.Model(&database.Example{}).Where("meaning_id IN ?", meaningIDs).Pluck("id", &exampleIDs).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&database.Translation{}).Where("meaning_id IN ?", meaningIDs).Pluck("id", &translationIDs).Error; err != nil {
+			return err
+		}
+	}
+
+	meanings, examples, translations := storedIDs(meaningIDs), storedIDs(exampleIDs), storedIDs(translationIDs)
+	for _, meaning := range entry.Meanings {
+		if err := meanings.keep(meaning.ID, "meaning"); err != nil {
+			return err
+		}
+		for _, example := range meaning.Examples {
+			if err := examples.keep(example.ID, "example"); err != nil {
+				return err
+			}
+		}
+		for _, translation := range meaning.Translations {
+			if err := translations.keep(translation.ID, "translation"); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Children go first so no row is left pointing at a deleted meaning
+	removed := database.RemovedChildren{
+		MeaningIDs:     meanings.dropped(),
+		ExampleIDs:     examples.dropped(),
+		TranslationIDs: translations.dropped(),
+	}
+	if ids := removed.TranslationIDs; len(ids) > 0 {
+		if err := tx.Where("id IN ?", ids).Delete(&database.Translation{}).Error; err != nil {
+			return err
+		}
+	}
+	if ids := removed.ExampleIDs; len(ids) > 0 {
+		if err := tx.Where("id IN ?", ids).Delete(&database.Example{}).Error; err != nil {
+			return err
+		}
+	}
+	if ids := removed.MeaningIDs; len(ids) > 0 {
+		if err := tx.Where("id IN ?", ids).Delete(&database.Meaning{}).Error; err != nil {
+			return err
+		}
+	}
+
+	return recordRemoval(tx, entry.ID, removed)
+}
+
+// recordRemoval leaves the children deleted from an entry in its change
+// history, so that incremental backups can replay the deletion. The removal
+// is not attributed to anyone, so user_id is left NULL
+func recordRemoval(tx *gorm.DB, entryID uuid.UUID, removed database.RemovedChildren) error {
+	if len(removed.MeaningIDs)+len(removed.ExampleIDs)+len(removed.TranslationIDs) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(removed)
+	if err != nil {
+		return err
+	}
+	return tx.Omit("UserID").Create(&database.ChangeHistory{
+		ID:        uuid.New(),
+		EntryID:   entryID,
+		Action:    database.ChangeRemove,
+		Data:      data,
+		CreatedAt: time.Now().UTC(),
+	}).Error
+}
+
+// childIDs tracks which stored children of an entry its new tree keeps
+type childIDs map[uuid.UUID]bool
+
+// storedIDs returns the tracker for children already in the database
+func storedIDs(ids []uuid.UUID) childIDs {
+	children := make(childIDs, len(ids))
+	for _, id := range ids {
+		children[id] = false
+	}
+	return children
+}
+
+// keep marks a child as part of the new tree. New children have no ID yet
+func (c childIDs) keep(id uuid.UUID, kind string) error {
+	if id == uuid.Nil {
+		return nil
+	}
+	kept, ok := c[id]
+	if !ok {
+		return fmt.Errorf("%w: %s %s does not belong to the entry", database.ErrInvalidInput, kind, id)
+	}
+	if kept {
+		return fmt.Errorf("%w: %s %s appears more than once", database.ErrInvalidInput, kind, id)
+	}
+	c[id] = true
+	return nil
+}
+
+// dropped returns the stored children the new tree leaves out
+func (c childIDs) dropped() []uuid.UUID {
+	var ids []uuid.UUID
+	for id, kept := range c {
+		if !kept {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// claimEntryVersion moves an existing entry on to its next version
+func claimEntryVersion(tx *gorm.DB, entry *database.Entry) error {
+	version, err := claimVersion(tx, &database.Entry{}, entry.ID, entry.Version)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return database.ErrEntryNotFound
+		}
+		return err
+	}
+	entry.Version = version
+	return nil
+}
+
+// claimVersion moves a row on to its next version and returns it. The update
+// only matches the version read just before it, so of two writers holding the
+// same version one fails with ErrVersionConflict instead of overwriting the
+// other. An expected version of 0 skips the comparison with the caller's copy
+func claimVersion(tx *gorm.DB, model interface{}, id uuid.UUID, expected int) (int, error) {
+	var versions []int
+	if err := tx.Model(model).Where("id = ?", id).Pluck("version", &versions).Error; err != nil {
+		return 0, err
+	}
+	if len(versions) == 0 {
+		return 0, gorm.ErrRecordNotFound
+	}
+	if expected != 0 && expected != versions[0] {
+		return 0, database.ErrVersionConflict
+	}
+
+	result := tx.Model(model).Where("id = ? AND version = ?", id, versions[0]).Update("version", versions[0]+1)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return 0, database.ErrVersionConflict
+	}
+	return versions[0] + 1, nil
+}
+
+// touchEntry moves the entry selected by the subquery on to its next version
+// before one of its children is written on its own. Every write path takes the
+// entry row first, so writers of the same tree queue up behind each other.
+// It reports whether the entry was found
+func touchEntry(tx *gorm.DB, entryID *gorm.DB, now time.Time) (bool, error) {
+	result := tx.Model(&database.Entry{}).
+		Where("id = (?)", entryID).
+		Updates(map[string]interface{}{"version": gorm.Expr("version + 1"), "updated_at": now})
+	return result.RowsAffected > 0, result.Error
+}
+
+// touchMeaning moves the meaning selected by the subquery on to its next
+// version when one of its translations is written on its own, since a meaning
+// is read together with its translations
+func touchMeaning(tx *gorm.DB, meaningID *gorm.DB, now time.Time) error {
+	return tx.Model(&database.Meaning{}).
+		Where("id = (?)", meaningID).
+		Updates(map[string]interface{}{"version": gorm.Expr("version + 1"), "updated_at": now}).Error
+}
+
+// entryOfMeaning selects the entry a meaning belongs to
+func entryOfMeaning(tx *gorm.DB, meaningID uuid.UUID) *gorm.DB {
+	return tx.Model(&database.Meaning{}).Select("entry_id").Where("id = ?", meaningID)
+}
+
+// entryOfTranslation selects the entry a translation belongs to
+func entryOfTranslation(tx *gorm.DB, translationID uuid.UUID) *gorm.DB {
+	return tx.Model(&database.Meaning{}).Select("entry_id").Where("id = (?)", meaningOfTranslation(tx, translationID))
+}
+
+// meaningOfTranslation selects the meaning a translation belongs to
+func meaningOfTranslation(tx *gorm.DB, translationID uuid.UUID) *gorm.DB {
+	return tx.Model(&database.Translation{}).Select("meaning_id").Where("id = ?", translationID)
+}
+
+// versionChildren gives the meanings and translations of the tree the version
+// they are saved with. New children start at 1, changed ones move on and
+// unchanged ones keep the stored version. A meaning also moves on when one of
+// its translations is added, changed or taken away
+func versionChildren(tx *gorm.DB, entry *database.Entry) error {
+	var meaningIDs, translationIDs []uuid.UUID
+	for _, meaning := range entry.Meanings {
+		meaningIDs = append(meaningIDs, meaning.ID)
+		for _, translation := range meaning.Translations {
+			translationIDs = append(translationIDs, translation.ID)
+		}
+	}
+
+	var meanings []database.Meaning
+	if len(meaningIDs) > 0 {
+		if err := tx.Preload("Examples").Preload("Translations").Where("id IN ?", meaningIDs).Find(&meanings).Error; err != nil {
+			return err
+		}
+	}
+	var translations []database.Translation
+	if len(translationIDs) > 0 {
+		if err := tx.Where("id IN ?", translationIDs).Find(&translations).Error; err != nil {
+			return err
+		}
+	}
+
+	storedMeanings := make(map[uuid.UUID]*database.Meaning, len(meanings))
+	for i := range meanings {
+		storedMeanings[meanings[i].ID] = &meanings[i]
+	}
+	storedTranslations := make(map[uuid.UUID]*database.Translation, len(translations))
+	for i := range translations {
+		storedTranslations[translations[i].ID] = &translations[i]
+	}
+
+	for i := range entry.Meanings {
+		meaning := &entry.Meanings[i]
+		stored, existing := storedMeanings[meaning.ID]
+		changed := !existing || !meaning.SameContent(stored) || len(meaning.Translations) != len(stored.Translations)
+
+		for j := range meaning.Translations {
+			translation := &meaning.Translations[j]
+			translation.Version = 1
+			storedTranslation, ok := storedTranslations[translation.ID]
+			if !ok {
+				changed = true
+				continue
+			}
+			translation.Version = storedTranslation.Version
+			if !translation.SameContent(storedTranslation) {
+				translation.Version++
+				changed = true
+			}
+		}
+
+		meaning.Version = 1
+		if existing {
+			meaning.Version = stored.Version
+			if changed {
+				meaning.Version++
+			}
+		}
+	}
+
+	return nil
+}
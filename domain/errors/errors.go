@@ -46,6 +46,9 @@ var (
 
 	// Translation errors
 	ErrTranslationNotFound = fmt.Errorf("%w: translation not found", ErrNotFound)
+	ErrTranslationAlreadyReviewed = fmt.Errorf("%w: translation has already been reviewed", ErrDuplicate)
+	ErrReviewReasonRequired = fmt.Errorf("%w: a reason is required to reject a translation", ErrValidation)
+	ErrReplacesOtherMeaning = fmt.Errorf("%w: a translation can only replace one of the same meaning", ErrValidation)
 
 	// User errors
 	ErrUserNotFound = fmt.Errorf("%w: user not found", ErrNotFound)
@@ -59,6 +62,14 @@ var (
 	// Comment/social errors
 	ErrCommentNotFound = fmt.Errorf("%w: comment not found", ErrNotFound)
 	ErrLikeNotFound = fmt.Errorf("%w: like not found", ErrNotFound)
+
+	// Batch errors
+	ErrBatchTooLarge = fmt.Errorf("%w: batch has too many operations", ErrValidation)
+
+	// Patch errors
+	ErrInvalidPatch = fmt.Errorf("%w: invalid patch document", ErrBadRequest)
+	ErrPatchNotApplicable = fmt.Errorf("%w: patch cannot be applied", ErrValidation)
+	ErrUnsupportedPatch = fmt.Errorf("%w: unsupported patch media type", ErrBadRequest)
 )
 
 // AppError represents a structured application error
@@ -0,0 +1,28 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationType identifies what a notification is about
+type NotificationType string
+
+// Available notification types
+const (
+	NotificationTranslationApproved NotificationType = "TRANSLATION_APPROVED"
+	NotificationTranslationRejected NotificationType = "TRANSLATION_REJECTED"
+)
+
+// Notification represents a message delivered to a user about their content
+type Notification struct {
+	ID         uuid.UUID        `gorm:"type:uuid;primary_key"`
+	UserID     uuid.UUID        `gorm:"type:uuid;index;not null"`
+	Type       NotificationType `gorm:"type:varchar(50);not null"`
+	TargetType string           `gorm:"type:varchar(20);not null"` // "meaning" or "translation"
+	TargetID   uuid.UUID        `gorm:"type:uuid;index;not null"`
+	Message    string           `gorm:"type:text"`
+	ReadAt     *time.Time
+	CreatedAt  time.Time
+}
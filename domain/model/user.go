@@ -29,8 +29,9 @@ type UserRole string
 
 // Available user roles
 const (
-	RoleUser  UserRole = "USER"
-	RoleAdmin UserRole = "ADMIN"
+	RoleUser     UserRole = "USER"
+	RoleReviewer UserRole = "REVIEWER"
+	RoleAdmin    UserRole = "ADMIN"
 )
 
 // AuthToken represents an authentication token for a user
@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeWord returns the canonical form of a headword used for comparisons:
+// Unicode NFKC, lower case, trimmed, with internal whitespace collapsed
+func NormalizeWord(word string) string {
+	if word == "" {
+		return word
+	}
+
+	normalized := norm.NFKC.String(word)
+	normalized = strings.ToLower(normalized)
+	normalized = multiSpaceRegex.ReplaceAllString(normalized, " ")
+
+	return strings.TrimSpace(normalized)
+}
+
+// superscriptDigits maps ASCII digits to their Unicode superscript forms
+var superscriptDigits = strings.NewReplacer(
+	"0", "⁰", "1", "¹", "2", "²", "3", "³", "4", "⁴",
+	"5", "⁵", "6", "⁶", "7", "⁷", "8", "⁸", "9", "⁹",
+)
+
+// FormatHomograph renders a headword with its homograph index as a
+// superscript, e.g. "bank²". Words without an index, and words that are the
+// only entry of their headword, are returned unchanged
+func FormatHomograph(word string, index, homographs int) string {
+	if index <= 0 || homographs <= 1 {
+		return word
+	}
+	return word + superscriptDigits.Replace(strconv.Itoa(index))
+}
+
+// WordSimilarity returns a score between 0 and 1 based on the Levenshtein
+// distance of the normalized words, where 1 means identical
+func WordSimilarity(a, b string) float64 {
+	a, b = NormalizeWord(a), NormalizeWord(b)
+	if a == b {
+		return 1
+	}
+
+	longest := utf8.RuneCountInString(a)
+	if n := utf8.RuneCountInString(b); n > longest {
+		longest = n
+	}
+	if longest == 0 {
+		return 1
+	}
+
+	return 1 - float64(levenshtein([]rune(a), []rune(b)))/float64(longest)
+}
+
+// levenshtein computes the edit distance between two rune slices
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
@@ -0,0 +1,364 @@
+package utils
+
+import (
+	"encoding/json"
+	stdErrors "errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/valpere/trytrago/domain/errors"
+)
+
+// ApplyMergePatch applies an RFC 7396 merge patch to a JSON document. Members
+// of the patch replace those of the document, objects are merged recursively
+// and null removes a member
+func ApplyMergePatch(document, patch []byte) ([]byte, error) {
+	var doc, p interface{}
+	if err := json.Unmarshal(document, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode document: %w", err)
+	}
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrInvalidPatch, err)
+	}
+
+	return json.Marshal(mergePatch(doc, p))
+}
+
+// mergePatch merges a decoded patch into a decoded document
+func mergePatch(doc, patch interface{}) interface{} {
+	members, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	target, ok := doc.(map[string]interface{})
+	if !ok {
+		target = make(map[string]interface{})
+	}
+	for name, value := range members {
+		if value == nil {
+			delete(target, name)
+			continue
+		}
+		target[name] = mergePatch(target[name], value)
+	}
+	return target
+}
+
+// patchOperation is one operation of an RFC 6902 JSON Patch document
+type patchOperation struct {
+	Op    string           `json:"op"`
+	Path  *string          `json:"path"`
+	From  *string          `json:"from"`
+	Value *json.RawMessage `json:"value"`
+}
+
+// ApplyJSONPatch applies an RFC 6902 JSON Patch to a JSON document. The
+// operations are applied in order and the first one that fails stops the
+// patch; the error names that operation and its path
+func ApplyJSONPatch(document, patch []byte) ([]byte, error) {
+	var doc interface{}
+	if err := json.Unmarshal(document, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode document: %w", err)
+	}
+
+	var ops []patchOperation
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		switch {
+		case stdErrors.As(err, &typeErr) && typeErr.Field == "":
+			return nil, fmt.Errorf("%w: a JSON Patch is an array of operations", errors.ErrInvalidPatch)
+		case stdErrors.As(err, &typeErr):
+			return nil, fmt.Errorf("%w: %s must be a %s", errors.ErrInvalidPatch, typeErr.Field, typeErr.Type)
+		default:
+			return nil, fmt.Errorf("%w: %v", errors.ErrInvalidPatch, err)
+		}
+	}
+
+	for i, op := range ops {
+		var err error
+		if doc, err = applyOperation(doc, op); err != nil {
+			path := ""
+			if op.Path != nil {
+				path = " " + *op.Path
+			}
+			return nil, fmt.Errorf("operation %d (%s%s): %w", i, op.Op, path, err)
+		}
+	}
+
+	return json.Marshal(doc)
+}
+
+// applyOperation applies a single JSON Patch operation and returns the new document
+func applyOperation(doc interface{}, op patchOperation) (interface{}, error) {
+	if op.Path == nil {
+		return nil, fmt.Errorf("%w: path is required", errors.ErrInvalidPatch)
+	}
+	path, err := parsePointer(*op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	value := func() (interface{}, error) {
+		if op.Value == nil {
+			return nil, fmt.Errorf("%w: value is required", errors.ErrInvalidPatch)
+		}
+		var v interface{}
+		if err := json.Unmarshal(*op.Value, &v); err != nil {
+			return nil, fmt.Errorf("%w: %v", errors.ErrInvalidPatch, err)
+		}
+		return v, nil
+	}
+	from := func() ([]string, error) {
+		if op.From == nil {
+			return nil, fmt.Errorf("%w: from is required", errors.ErrInvalidPatch)
+		}
+		return parsePointer(*op.From)
+	}
+
+	switch op.Op {
+	case "add":
+		v, err := value()
+		if err != nil {
+			return nil, err
+		}
+		return addValue(doc, path, v)
+
+	case "remove":
+		doc, _, err := removeValue(doc, path)
+		return doc, err
+
+	case "replace":
+		v, err := value()
+		if err != nil {
+			return nil, err
+		}
+		if len(path) == 0 {
+			return v, nil
+		}
+		if doc, _, err = removeValue(doc, path); err != nil {
+			return nil, err
+		}
+		return addValue(doc, path, v)
+
+	case "move":
+		source, err := from()
+		if err != nil {
+			return nil, err
+		}
+		if len(path) > len(source) && reflect.DeepEqual(path[:len(source)], source) {
+			return nil, fmt.Errorf("%w: a value cannot be moved into itself", errors.ErrPatchNotApplicable)
+		}
+		doc, v, err := removeValue(doc, source)
+		if err != nil {
+			return nil, err
+		}
+		return addValue(doc, path, v)
+
+	case "copy":
+		source, err := from()
+		if err != nil {
+			return nil, err
+		}
+		v, err := getValue(doc, source)
+		if err != nil {
+			return nil, err
+		}
+		return addValue(doc, path, deepCopy(v))
+
+	case "test":
+		want, err := value()
+		if err != nil {
+			return nil, err
+		}
+		got, err := getValue(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(got, want) {
+			return nil, fmt.Errorf("%w: value does not match", errors.ErrPatchNotApplicable)
+		}
+		return doc, nil
+
+	default:
+		return nil, fmt.Errorf("%w: unknown operation %q", errors.ErrInvalidPatch, op.Op)
+	}
+}
+
+// parsePointer splits an RFC 6901 JSON Pointer into its unescaped reference tokens
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: path %q must start with /", errors.ErrInvalidPatch, pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+	return tokens, nil
+}
+
+// getValue returns the value a pointer refers to
+func getValue(doc interface{}, path []string) (interface{}, error) {
+	for i, token := range path {
+		switch node := doc.(type) {
+		case map[string]interface{}:
+			v, ok := node[token]
+			if !ok {
+				return nil, notFound(path[:i+1])
+			}
+			doc = v
+		case []interface{}:
+			index, err := arrayIndex(token, len(node)-1, path[:i+1])
+			if err != nil {
+				return nil, err
+			}
+			doc = node[index]
+		default:
+			return nil, notFound(path[:i+1])
+		}
+	}
+	return doc, nil
+}
+
+// addValue adds a value at a pointer. An object member is created or
+// replaced; an array element is inserted, with "-" appending it
+func addValue(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	parent, err := getValue(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := path[len(path)-1]
+
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		node[last] = value
+		return doc, nil
+	case []interface{}:
+		index := len(node)
+		if last != "-" {
+			if index, err = arrayIndex(last, len(node), path); err != nil {
+				return nil, err
+			}
+		}
+		node = append(node, nil)
+		copy(node[index+1:], node[index:])
+		node[index] = value
+		return setValue(doc, path[:len(path)-1], node)
+	default:
+		return nil, notFound(path)
+	}
+}
+
+// removeValue removes the value at a pointer and returns it with the new document
+func removeValue(doc interface{}, path []string) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, nil, fmt.Errorf("%w: the whole document cannot be removed", errors.ErrPatchNotApplicable)
+	}
+
+	parent, err := getValue(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, nil, err
+	}
+	last := path[len(path)-1]
+
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		value, ok := node[last]
+		if !ok {
+			return nil, nil, notFound(path)
+		}
+		delete(node, last)
+		return doc, value, nil
+	case []interface{}:
+		index, err := arrayIndex(last, len(node)-1, path)
+		if err != nil {
+			return nil, nil, err
+		}
+		value := node[index]
+		node = append(node[:index:index], node[index+1:]...)
+		doc, err = setValue(doc, path[:len(path)-1], node)
+		return doc, value, err
+	default:
+		return nil, nil, notFound(path)
+	}
+}
+
+// setValue stores an array that has changed length back in its parent
+func setValue(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	parent, err := getValue(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := path[len(path)-1]
+
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		node[last] = value
+	case []interface{}:
+		index, _ := strconv.Atoi(last)
+		node[index] = value
+	}
+	return doc, nil
+}
+
+// arrayIndex parses an array index, which may be at most max
+func arrayIndex(token string, max int, path []string) (int, error) {
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("%w: %s is not an array index", errors.ErrPatchNotApplicable, formatPointer(path))
+	}
+	if index > max {
+		return 0, fmt.Errorf("%w: index %d at %s is out of range", errors.ErrPatchNotApplicable, index, formatPointer(path))
+	}
+	return index, nil
+}
+
+// notFound reports a pointer that names nothing in the document
+func notFound(path []string) error {
+	return fmt.Errorf("%w: %s does not exist", errors.ErrPatchNotApplicable, formatPointer(path))
+}
+
+// formatPointer joins reference tokens back into a JSON Pointer
+func formatPointer(path []string) string {
+	var b strings.Builder
+	for _, token := range path {
+		b.WriteString("/")
+		b.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(token))
+	}
+	return b.String()
+}
+
+// deepCopy copies a decoded JSON value, so a copied value can be changed
+// without changing its source
+func deepCopy(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		c := make(map[string]interface{}, len(v))
+		for name, member := range v {
+			c[name] = deepCopy(member)
+		}
+		return c
+	case []interface{}:
+		c := make([]interface{}, len(v))
+		for i, element := range v {
+			c[i] = deepCopy(element)
+		}
+		return c
+	default:
+		return v
+	}
+}
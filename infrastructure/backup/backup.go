@@ -0,0 +1,505 @@
+package backup
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/valpere/trytrago/domain/database"
+	"github.com/valpere/trytrago/domain/database/repository"
+	"github.com/valpere/trytrago/domain/logging"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Backup types
+const (
+	TypeFull        = "full"
+	TypeIncremental = "incremental"
+)
+
+// FormatVersion is the version of the backup file layout
+const FormatVersion = 1
+
+// restoreBatchSize bounds the number of rows written per statement
+const restoreBatchSize = 200
+
+// SinceOverlap is how far before a backup an incremental backup taken on top
+// of it starts. Rows get their updated_at before their transaction commits, so
+// a row committed while a backup was being read may be stamped earlier than
+// the backup. Rows in the overlap are captured twice, which restore tolerates
+const SinceOverlap = 5 * time.Minute
+
+// snapshot makes every table of a backup come from the same point in time,
+// so that no row is captured without the rows it refers to
+var snapshot = &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+
+// Manifest describes a backup and links an incremental backup to the backup
+// it was taken on top of
+type Manifest struct {
+	ID        string         `json:"id"`
+	Type      string         `json:"type"`
+	Format    int            `json:"format"`
+	ParentID  string         `json:"parent_id,omitempty"`
+	Since     *time.Time     `json:"since,omitempty"` // Rows changed at or after this time are included
+	CreatedAt time.Time      `json:"created_at"`
+	Counts    map[string]int `json:"counts"`
+}
+
+// Deletion is an entry that disappeared after the previous backup, either
+// deleted or merged into another entry
+type Deletion struct {
+	EntryID    uuid.UUID  `json:"entry_id"`
+	MergedInto *uuid.UUID `json:"merged_into,omitempty"`
+	DeletedAt  time.Time  `json:"deleted_at"`
+}
+
+// Removal lists the meanings, examples and translations deleted from an
+// entry after the previous backup, while the entry itself stayed
+type Removal struct {
+	EntryID        uuid.UUID   `json:"entry_id"`
+	MeaningIDs     []uuid.UUID `json:"meaning_ids,omitempty"`
+	ExampleIDs     []uuid.UUID `json:"example_ids,omitempty"`
+	TranslationIDs []uuid.UUID `json:"translation_ids,omitempty"`
+	RemovedAt      time.Time   `json:"removed_at"`
+}
+
+// Backup holds the dictionary content captured by a backup. The manifest is
+// kept first so that it can be read without decoding the whole file
+type Backup struct {
+	Manifest      Manifest                 `json:"manifest"`
+	PartsOfSpeech []database.PartOfSpeech  `json:"parts_of_speech"`
+	Entries       []database.Entry         `json:"entries"`
+	Meanings      []database.Meaning       `json:"meanings"`
+	Examples      []database.Example       `json:"examples"`
+	Translations  []database.Translation   `json:"translations"`
+	Redirects     []database.EntryRedirect `json:"entry_redirects"`
+	Deletions     []Deletion               `json:"deletions,omitempty"`
+	Removals      []Removal                `json:"removals,omitempty"`
+}
+
+// Options selects what a backup captures
+type Options struct {
+	// Since makes the backup incremental: only rows changed at or after this
+	// time are captured, together with the entries and children deleted since
+	Since *time.Time
+	// ParentID is the backup the incremental backup builds on
+	ParentID string
+}
+
+// Manager creates and restores backups of the dictionary content
+type Manager struct {
+	repo   repository.Repository
+	logger logging.Logger
+}
+
+// NewManager creates a new Manager instance
+func NewManager(repo repository.Repository, logger logging.Logger) *Manager {
+	return &Manager{
+		repo:   repo,
+		logger: logger.With(logging.String("component", "backup")),
+	}
+}
+
+// Create captures the dictionary content. All tables are read in a single
+// read-only snapshot. Rows are compared against their updated_at column,
+// redirects against created_at, and deletions are taken from the change history
+func (m *Manager) Create(ctx context.Context, opts Options) (*Backup, error) {
+	db, err := m.repo.GetDB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	// Taken before the snapshot so that rows written while the backup runs
+	// are picked up again by the next incremental backup
+	createdAt := time.Now().UTC()
+
+	b := &Backup{
+		Manifest: Manifest{
+			ID:        newID(createdAt),
+			Type:      TypeFull,
+			Format:    FormatVersion,
+			CreatedAt: createdAt,
+		},
+	}
+	if opts.Since != nil {
+		since := opts.Since.UTC()
+		b.Manifest.Type = TypeIncremental
+		b.Manifest.ParentID = opts.ParentID
+		b.Manifest.Since = &since
+	}
+
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return b.read(tx)
+	}, snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	b.Manifest.Counts = b.counts()
+	m.logger.Info("backup created",
+		logging.String("id", b.Manifest.ID),
+		logging.String("type", b.Manifest.Type),
+		logging.Int("entries", len(b.Entries)),
+		logging.Int("deletions", len(b.Deletions)),
+		logging.Int("removals", len(b.Removals)),
+	)
+
+	return b, nil
+}
+
+// read fills the backup with the rows selected by its manifest
+func (b *Backup) read(db *gorm.DB) error {
+	tables := []struct {
+		name   string
+		column string
+		order  string
+		dest   interface{}
+	}{
+		// Always complete, restore needs every name to match meanings against
+		{"parts_of_speech", "", "id", &b.PartsOfSpeech},
+		{"entries", "updated_at", "id", &b.Entries},
+		{"meanings", "updated_at", "id", &b.Meanings},
+		{"examples", "updated_at", "id", &b.Examples},
+		// Superseded translations are older than the ones replacing them
+		{"translations", "updated_at", "created_at, id", &b.Translations},
+		{"entry_redirects", "created_at", "from_id", &b.Redirects},
+	}
+	for _, table := range tables {
+		query := db.Table(table.name).Order(table.order)
+		if b.Manifest.Since != nil && table.column != "" {
+			query = query.Where(table.column+" >= ?", *b.Manifest.Since)
+		}
+		if err := query.Find(table.dest).Error; err != nil {
+			return database.NewDatabaseError(err, "backup", table.name)
+		}
+	}
+
+	if b.Manifest.Since != nil {
+		var err error
+		if b.Deletions, err = deletionsSince(db, *b.Manifest.Since); err != nil {
+			return err
+		}
+		if b.Removals, err = removalsSince(db, *b.Manifest.Since); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deletionsSince reads the entries deleted or merged away since the given time
+func deletionsSince(db *gorm.DB, since time.Time) ([]Deletion, error) {
+	var history []database.ChangeHistory
+	if err := db.
+		Where("action IN ? AND created_at >= ?", []string{database.ChangeDelete, database.ChangeMerge}, since).
+		Order("created_at").
+		Find(&history).Error; err != nil {
+		return nil, database.NewDatabaseError(err, "backup", "change_history")
+	}
+
+	deletions := make([]Deletion, 0, len(history))
+	for _, change := range history {
+		var data struct {
+			DeletedEntryID *uuid.UUID `json:"deleted_entry_id"`
+			MergedEntryID  *uuid.UUID `json:"merged_entry_id"`
+		}
+		if err := json.Unmarshal(change.Data, &data); err != nil {
+			return nil, fmt.Errorf("invalid change history %s: %w", change.ID, err)
+		}
+
+		deletion := Deletion{DeletedAt: change.CreatedAt.UTC()}
+		switch {
+		case change.Action == database.ChangeDelete && data.DeletedEntryID != nil:
+			deletion.EntryID = *data.DeletedEntryID
+		case change.Action == database.ChangeMerge && data.MergedEntryID != nil:
+			target := change.EntryID
+			deletion.EntryID = *data.MergedEntryID
+			deletion.MergedInto = &target
+		default:
+			return nil, fmt.Errorf("change history %s does not name the removed entry", change.ID)
+		}
+		deletions = append(deletions, deletion)
+	}
+
+	return deletions, nil
+}
+
+// removalsSince reads the meanings, examples and translations deleted since
+// the given time from entries that were not deleted themselves
+func removalsSince(db *gorm.DB, since time.Time) ([]Removal, error) {
+	var history []database.ChangeHistory
+	if err := db.
+		Where("action = ? AND created_at >= ?", database.ChangeRemove, since).
+		Order("created_at").
+		Find(&history).Error; err != nil {
+		return nil, database.NewDatabaseError(err, "backup", "change_history")
+	}
+
+	removals := make([]Removal, 0, len(history))
+	for _, change := range history {
+		var data database.RemovedChildren
+		if err := json.Unmarshal(change.Data, &data); err != nil {
+			return nil, fmt.Errorf("invalid change history %s: %w", change.ID, err)
+		}
+
+		removals = append(removals, Removal{
+			EntryID:        change.EntryID,
+			MeaningIDs:     data.MeaningIDs,
+			ExampleIDs:     data.ExampleIDs,
+			TranslationIDs: data.TranslationIDs,
+			RemovedAt:      change.CreatedAt.UTC(),
+		})
+	}
+
+	return removals, nil
+}
+
+// counts tallies the rows held by the backup
+func (b *Backup) counts() map[string]int {
+	return map[string]int{
+		"parts_of_speech": len(b.PartsOfSpeech),
+		"entries":         len(b.Entries),
+		"meanings":        len(b.Meanings),
+		"examples":        len(b.Examples),
+		"translations":    len(b.Translations),
+		"entry_redirects": len(b.Redirects),
+		"deletions":       len(b.Deletions),
+		"removals":        len(b.Removals),
+	}
+}
+
+// newID derives a sortable, unique backup ID from the creation time
+func newID(createdAt time.Time) string {
+	return createdAt.Format("20060102T150405Z") + "-" + uuid.NewString()[:8]
+}
+
+// Chain orders backups so that every incremental backup follows its parent.
+// The chain starts with its only backup whose parent is not part of it,
+// normally a full backup, and must not branch or leave backups out
+func Chain(backups []*Backup) ([]*Backup, error) {
+	if len(backups) == 0 {
+		return nil, fmt.Errorf("no backups to restore")
+	}
+
+	byID := make(map[string]*Backup, len(backups))
+	for _, b := range backups {
+		if _, ok := byID[b.Manifest.ID]; ok {
+			return nil, fmt.Errorf("backup %s is listed twice", b.Manifest.ID)
+		}
+		byID[b.Manifest.ID] = b
+	}
+
+	var root *Backup
+	children := make(map[string]*Backup)
+	for _, b := range backups {
+		parent := b.Manifest.ParentID
+		if b.Manifest.Type == TypeFull || byID[parent] == nil {
+			if root != nil {
+				return nil, fmt.Errorf("backups %s and %s both start a chain", root.Manifest.ID, b.Manifest.ID)
+			}
+			root = b
+			continue
+		}
+		if sibling, ok := children[parent]; ok {
+			return nil, fmt.Errorf("backups %s and %s are both based on %s", sibling.Manifest.ID, b.Manifest.ID, parent)
+		}
+		children[parent] = b
+	}
+	if root == nil {
+		return nil, fmt.Errorf("backups form a cycle")
+	}
+
+	chain := []*Backup{root}
+	for next := children[root.Manifest.ID]; next != nil; next = children[next.Manifest.ID] {
+		chain = append(chain, next)
+	}
+	if len(chain) != len(backups) {
+		return nil, fmt.Errorf("backups do not form a single chain")
+	}
+
+	return chain, nil
+}
+
+// Restore replays a chain of backups, ordered with Chain, one transaction per
+// backup. Rows are upserted by primary key, so a restore that stopped half
+// way can simply be run again
+func (m *Manager) Restore(ctx context.Context, chain []*Backup) error {
+	for _, b := range chain {
+		err := m.repo.WithTransaction(ctx, func(tx *gorm.DB) error {
+			return restore(tx, b)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to restore backup %s: %w", b.Manifest.ID, err)
+		}
+
+		m.logger.Info("backup restored",
+			logging.String("id", b.Manifest.ID),
+			logging.String("type", b.Manifest.Type),
+			logging.Int("entries", len(b.Entries)),
+			logging.Int("deletions", len(b.Deletions)),
+			logging.Int("removals", len(b.Removals)),
+		)
+	}
+
+	return nil
+}
+
+// restore writes a single backup in dependency order and applies its
+// removals and deletions last, after meanings of merged entries have been moved
+func restore(tx *gorm.DB, b *Backup) error {
+	// Parts of speech are looked up by name and seeded by the migrations, so
+	// the target database may know them under other IDs
+	partOfSpeech := make(map[uuid.UUID]uuid.UUID)
+	for i := range b.PartsOfSpeech {
+		pos := b.PartsOfSpeech[i]
+		var existing database.PartOfSpeech
+		err := tx.Where("name = ?", pos.Name).Limit(1).Find(&existing).Error
+		if err != nil {
+			return database.NewDatabaseError(err, "restore", "parts_of_speech")
+		}
+		if existing.ID != uuid.Nil {
+			partOfSpeech[pos.ID] = existing.ID
+			continue
+		}
+		if err := upsert(tx, &pos, 1); err != nil {
+			return database.NewDatabaseError(err, "restore", "parts_of_speech")
+		}
+	}
+	for i := range b.Meanings {
+		if id, ok := partOfSpeech[b.Meanings[i].PartOfSpeechId]; ok {
+			b.Meanings[i].PartOfSpeechId = id
+		}
+	}
+
+	if err := upsert(tx, b.Entries, len(b.Entries)); err != nil {
+		return database.NewDatabaseError(err, "restore", "entries")
+	}
+	if err := upsert(tx, b.Meanings, len(b.Meanings)); err != nil {
+		return database.NewDatabaseError(err, "restore", "meanings")
+	}
+	if err := upsert(tx, b.Examples, len(b.Examples)); err != nil {
+		return database.NewDatabaseError(err, "restore", "examples")
+	}
+	if err := forgetReviewers(tx, b.Translations); err != nil {
+		return err
+	}
+	if err := upsert(tx, b.Translations, len(b.Translations)); err != nil {
+		return database.NewDatabaseError(err, "restore", "translations")
+	}
+	if err := upsert(tx, b.Redirects, len(b.Redirects)); err != nil {
+		return database.NewDatabaseError(err, "restore", "entry_redirects")
+	}
+
+	for _, removal := range b.Removals {
+		if err := removeChildren(tx, removal); err != nil {
+			return database.NewDatabaseError(err, "restore", "meanings")
+		}
+	}
+	for _, deletion := range b.Deletions {
+		if err := deleteEntry(tx, deletion); err != nil {
+			return database.NewDatabaseError(err, "restore", "entries")
+		}
+	}
+
+	return nil
+}
+
+// upsert inserts rows, overwriting those that already exist
+func upsert(tx *gorm.DB, rows interface{}, n int) error {
+	if n == 0 {
+		return nil
+	}
+	return tx.Omit(clause.Associations).
+		Clauses(clause.OnConflict{UpdateAll: true}).
+		CreateInBatches(rows, restoreBatchSize).Error
+}
+
+// forgetReviewers clears references to reviewers that do not exist in the
+// target database, since user accounts are not part of a backup
+func forgetReviewers(tx *gorm.DB, translations []database.Translation) error {
+	var ids []uuid.UUID
+	for _, translation := range translations {
+		if translation.ReviewedByID != nil {
+			ids = append(ids, *translation.ReviewedByID)
+		}
+	}
+	if len(ids) == 0 || !tx.Migrator().HasTable("users") {
+		return nil
+	}
+
+	var known []uuid.UUID
+	if err := tx.Table("users").Where("id IN ?", ids).Pluck("id", &known).Error; err != nil {
+		return database.NewDatabaseError(err, "restore", "users")
+	}
+	exists := make(map[uuid.UUID]bool, len(known))
+	for _, id := range known {
+		exists[id] = true
+	}
+
+	for i := range translations {
+		if id := translations[i].ReviewedByID; id != nil && !exists[*id] {
+			translations[i].ReviewedByID = nil
+		}
+	}
+	return nil
+}
+
+// removeChildren deletes the meanings, examples and translations removed from
+// an entry, together with the examples and translations of removed meanings
+func removeChildren(tx *gorm.DB, removal Removal) error {
+	if len(removal.TranslationIDs) > 0 {
+		if err := tx.Where("id IN ?", removal.TranslationIDs).Delete(&database.Translation{}).Error; err != nil {
+			return err
+		}
+	}
+	if len(removal.ExampleIDs) > 0 {
+		if err := tx.Where("id IN ?", removal.ExampleIDs).Delete(&database.Example{}).Error; err != nil {
+			return err
+		}
+	}
+	if len(removal.MeaningIDs) > 0 {
+		if err := tx.Where("meaning_id IN ?", removal.MeaningIDs).Delete(&database.Translation{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("meaning_id IN ?", removal.MeaningIDs).Delete(&database.Example{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("id IN ?", removal.MeaningIDs).Delete(&database.Meaning{}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteEntry removes an entry together with everything attached to it.
+// Redirects pointing at a merged entry are moved on to the entry that
+// absorbed it, as MergeEntries does
+func deleteEntry(tx *gorm.DB, deletion Deletion) error {
+	id := deletion.EntryID
+
+	if deletion.MergedInto != nil {
+		if err := tx.Model(&database.EntryRedirect{}).
+			Where("to_id = ?", id).
+			Update("to_id", *deletion.MergedInto).Error; err != nil {
+			return err
+		}
+	} else if err := tx.Where("to_id = ?", id).Delete(&database.EntryRedirect{}).Error; err != nil {
+		return err
+	}
+
+	meanings := tx.Model(&database.Meaning{}).Select("id").Where("entry_id = ?", id)
+	if err := tx.Where("meaning_id IN (?)", meanings).Delete(&database.Translation{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("meaning_id IN (?)", meanings).Delete(&database.Example{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("entry_id = ?", id).Delete(&database.Meaning{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("id = ?", id).Delete(&database.Entry{}).Error
+}
@@ -0,0 +1,196 @@
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// gzipMagic starts every gzip stream
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// ErrNotFound is returned when no backup with the requested ID exists
+var ErrNotFound = errors.New("backup not found")
+
+// WriteFile stores a backup as JSON, gzip compressed if requested. The file is
+// written next to its final path and renamed once complete
+func WriteFile(path string, b *Backup, compress bool) error {
+	tmp := path + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("failed to create backup file: %w", err)
+	}
+
+	err = func() error {
+		buffered := bufio.NewWriter(file)
+		var w io.Writer = buffered
+
+		var gz *gzip.Writer
+		if compress {
+			gz = gzip.NewWriter(buffered)
+			w = gz
+		}
+		if err := json.NewEncoder(w).Encode(b); err != nil {
+			return err
+		}
+		if gz != nil {
+			if err := gz.Close(); err != nil {
+				return err
+			}
+		}
+		if err := buffered.Flush(); err != nil {
+			return err
+		}
+		return file.Close()
+	}()
+	if err != nil {
+		file.Close()
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write backup file: %w", err)
+	}
+
+	return os.Rename(tmp, path)
+}
+
+// ReadFile loads a backup written by WriteFile
+func ReadFile(path string) (*Backup, error) {
+	var b Backup
+	err := decodeFile(path, func(dec *json.Decoder) error {
+		return dec.Decode(&b)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := checkManifest(path, &b.Manifest); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// ReadManifest reads only the manifest of a backup, leaving the content
+// undecoded
+func ReadManifest(path string) (*Manifest, error) {
+	var manifest Manifest
+	err := decodeFile(path, func(dec *json.Decoder) error {
+		if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+			return fmt.Errorf("not a backup")
+		}
+		if tok, err := dec.Token(); err != nil || tok != "manifest" {
+			return fmt.Errorf("not a backup")
+		}
+		return dec.Decode(&manifest)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := checkManifest(path, &manifest); err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+// FindManifest looks up the backup with the given ID in dir
+func FindManifest(dir, id string) (string, *Manifest, error) {
+	paths, manifests, err := listManifests(dir)
+	if err != nil {
+		return "", nil, err
+	}
+	for i, manifest := range manifests {
+		if manifest.ID == id {
+			return paths[i], manifest, nil
+		}
+	}
+	return "", nil, fmt.Errorf("%w: %s in %s", ErrNotFound, id, dir)
+}
+
+// LatestManifest returns the most recent backup in dir
+func LatestManifest(dir string) (string, *Manifest, error) {
+	paths, manifests, err := listManifests(dir)
+	if err != nil {
+		return "", nil, err
+	}
+
+	latest := -1
+	for i, manifest := range manifests {
+		if latest < 0 || manifest.CreatedAt.After(manifests[latest].CreatedAt) {
+			latest = i
+		}
+	}
+	if latest < 0 {
+		return "", nil, fmt.Errorf("%w in %s", ErrNotFound, dir)
+	}
+	return paths[latest], manifests[latest], nil
+}
+
+// listManifests reads the manifests of the backups in dir. Other JSON files
+// are skipped
+func listManifests(dir string) ([]string, []*Manifest, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read backup directory: %w", err)
+	}
+
+	var paths []string
+	var manifests []*Manifest
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() || !(strings.HasSuffix(name, ".json") || strings.HasSuffix(name, ".json.gz")) {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		manifest, err := ReadManifest(path)
+		if err != nil {
+			continue
+		}
+		paths = append(paths, path)
+		manifests = append(manifests, manifest)
+	}
+	return paths, manifests, nil
+}
+
+// decodeFile opens a backup, compressed or not, and hands a decoder for its
+// content to fn
+func decodeFile(path string, fn func(dec *json.Decoder) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open backup file: %w", err)
+	}
+	defer file.Close()
+
+	buffered := bufio.NewReader(file)
+	var r io.Reader = buffered
+	if magic, _ := buffered.Peek(len(gzipMagic)); bytes.Equal(magic, gzipMagic) {
+		gz, err := gzip.NewReader(buffered)
+		if err != nil {
+			return fmt.Errorf("invalid backup %s: %w", path, err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	if err := fn(json.NewDecoder(r)); err != nil {
+		return fmt.Errorf("invalid backup %s: %w", path, err)
+	}
+	return nil
+}
+
+// checkManifest rejects backups this version cannot restore
+func checkManifest(path string, manifest *Manifest) error {
+	if manifest.ID == "" {
+		return fmt.Errorf("invalid backup %s: manifest has no ID", path)
+	}
+	if manifest.Format != FormatVersion {
+		return fmt.Errorf("backup %s has unsupported format %d", path, manifest.Format)
+	}
+	if manifest.Type != TypeFull && manifest.Type != TypeIncremental {
+		return fmt.Errorf("backup %s has unknown type %q", path, manifest.Type)
+	}
+	return nil
+}
@@ -0,0 +1,536 @@
+package exchange
+
+import (
+	"archive/zip"
+	"crypto/sha1"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	// Registers the "sqlite3" driver the collection is written with
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// Card templates an Anki deck can be built with
+const (
+	AnkiForward = "forward" // Word on the front, translation on the back
+	AnkiReverse = "reverse" // Translation on the front, word on the back
+)
+
+// ankiTemplate describes one card type of the note type
+type ankiTemplate struct {
+	name  string
+	front string
+	back  string
+}
+
+// The word side of a card shows the pronunciation and plays the recording,
+// if any
+const ankiWordSide = `<div class="word">{{Word}}</div>` +
+	`{{#Pronunciation}}<div class="pronunciation">[{{Pronunciation}}]</div>{{/Pronunciation}}{{Audio}}`
+
+var ankiTemplates = map[string]ankiTemplate{
+	AnkiForward: {
+		name:  "Word → Translation",
+		front: ankiWordSide,
+		back:  `{{FrontSide}}<hr id="answer"><div class="translation">{{Translation}}</div>{{Meaning}}`,
+	},
+	AnkiReverse: {
+		name:  "Translation → Word",
+		front: `<div class="translation">{{Translation}}</div>`,
+		back:  `{{FrontSide}}<hr id="answer">` + ankiWordSide + `{{Meaning}}`,
+	},
+}
+
+// ankiFields are the fields of a note, in order
+var ankiFields = []string{"Word", "Pronunciation", "Translation", "Meaning", "Audio"}
+
+const ankiCSS = `.card { font-family: sans-serif; font-size: 22px; text-align: center; color: black; background-color: white; }
+.word { font-size: 32px; font-weight: bold; }
+.pronunciation { color: #666; }
+.meaning { display: inline-block; text-align: left; font-size: 18px; }
+.example { color: #555; font-style: italic; }`
+
+// ankiDeckConfig is the default deck options group every collection has
+const ankiDeckConfig = `{"1": {"id": 1, "name": "Default", "mod": 0, "usn": 0, "maxTaken": 60,
+"autoplay": true, "timer": 0, "replayq": true, "dyn": false,
+"new": {"bury": false, "delays": [1, 10], "initialFactor": 2500, "ints": [1, 4, 0], "order": 1, "perDay": 20},
+"rev": {"bury": false, "ease4": 1.3, "ivlFct": 1, "maxIvl": 36500, "perDay": 200, "hardFactor": 1.2},
+"lapse": {"delays": [10], "leechAction": 1, "leechFails": 8, "minInt": 1, "mult": 0}}}`
+
+// ankiSchema is the schema of an Anki 2.1 collection (schema version 11),
+// which every Anki release can import
+const ankiSchema = `
+CREATE TABLE col (id integer primary key, crt integer not null, mod integer not null, scm integer not null,
+	ver integer not null, dty integer not null, usn integer not null, ls integer not null, conf text not null,
+	models text not null, decks text not null, dconf text not null, tags text not null);
+CREATE TABLE notes (id integer primary key, guid text not null, mid integer not null, mod integer not null,
+	usn integer not null, tags text not null, flds text not null, sfld integer not null, csum integer not null,
+	flags integer not null, data text not null);
+CREATE TABLE cards (id integer primary key, nid integer not null, did integer not null, ord integer not null,
+	mod integer not null, usn integer not null, type integer not null, queue integer not null, due integer not null,
+	ivl integer not null, factor integer not null, reps integer not null, lapses integer not null, left integer not null,
+	odue integer not null, odid integer not null, flags integer not null, data text not null);
+CREATE TABLE revlog (id integer primary key, cid integer not null, usn integer not null, ivl integer not null,
+	lastIvl integer not null, factor integer not null, time integer not null, type integer not null);
+CREATE TABLE graves (usn integer not null, oid integer not null, type integer not null);
+CREATE INDEX ix_notes_usn ON notes (usn);
+CREATE INDEX ix_cards_usn ON cards (usn);
+CREATE INDEX ix_revlog_usn ON revlog (usn);
+CREATE INDEX ix_cards_nid ON cards (nid);
+CREATE INDEX ix_cards_sched ON cards (did, queue, due);
+CREATE INDEX ix_revlog_cid ON revlog (cid);
+CREATE INDEX ix_notes_csum ON notes (csum);`
+
+// ankiAudioExtensions are the recording formats looked for, in order
+var ankiAudioExtensions = []string{".mp3", ".ogg", ".wav"}
+
+var ankiMarkup = regexp.MustCompile(`<[^>]*>`)
+
+// AnkiOptions configures the deck an AnkiWriter produces
+type AnkiOptions struct {
+	// DeckName names the deck; DefaultTitle if empty
+	DeckName string
+
+	// Templates lists the card types generated for each entry, AnkiForward
+	// and/or AnkiReverse. Both if empty
+	Templates []string
+
+	// AudioDir holds pronunciation recordings as <language>/<word>.mp3 (or
+	// .ogg, .wav). Entries with a recording get it attached to their cards
+	AudioDir string
+}
+
+// AnkiWriter writes records as an Anki deck package (.apkg): a zip archive
+// holding an SQLite collection and the media it refers to. The collection is
+// built in a temporary file and the package is written to w by Close
+type AnkiWriter struct {
+	out       io.Writer
+	options   AnkiOptions
+	templates []ankiTemplate
+	path      string
+	db        *sql.DB
+	tx        *sql.Tx
+	notes     *sql.Stmt
+	cards     *sql.Stmt
+
+	now     time.Time
+	nextID  int64
+	count   int
+	deckID  int64
+	modelID int64
+	media   []ankiMedia
+}
+
+// ankiMedia is a recording added to the package
+type ankiMedia struct {
+	path string // Location of the recording
+	name string // Name the notes refer to it by
+}
+
+// NewAnkiWriter creates a writer producing a deck package on w
+func NewAnkiWriter(w io.Writer, options AnkiOptions) (*AnkiWriter, error) {
+	if options.DeckName == "" {
+		options.DeckName = DefaultTitle
+	}
+	if len(options.Templates) == 0 {
+		options.Templates = []string{AnkiForward, AnkiReverse}
+	}
+
+	var templates []ankiTemplate
+	for _, name := range options.Templates {
+		template, ok := ankiTemplates[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown card template %q", name)
+		}
+		templates = append(templates, template)
+	}
+
+	file, err := os.CreateTemp("", "trytrago-anki-*.anki2")
+	if err != nil {
+		return nil, err
+	}
+	file.Close()
+
+	now := time.Now()
+	writer := &AnkiWriter{
+		out:       w,
+		options:   options,
+		templates: templates,
+		path:      file.Name(),
+		now:       now,
+		nextID:    now.UnixMilli(),
+		// Stable identifiers let a deck exported again update the cards
+		// already studied instead of adding a second copy
+		deckID:  ankiID("deck:" + options.DeckName),
+		modelID: ankiID("model:" + strings.Join(options.Templates, ",")),
+	}
+	if err := writer.open(); err != nil {
+		writer.Abort()
+		return nil, err
+	}
+	return writer, nil
+}
+
+// open creates the collection and prepares the statements adding notes
+func (w *AnkiWriter) open() error {
+	db, err := sql.Open("sqlite3", w.path)
+	if err != nil {
+		return err
+	}
+	w.db = db
+
+	if _, err := db.Exec(ankiSchema); err != nil {
+		return fmt.Errorf("failed to create Anki collection: %w", err)
+	}
+
+	if w.tx, err = db.Begin(); err != nil {
+		return err
+	}
+	w.notes, err = w.tx.Prepare(`INSERT INTO notes (id, guid, mid, mod, usn, tags, flds, sfld, csum, flags, data)
+		VALUES (?, ?, ?, ?, -1, ?, ?, ?, ?, 0, '')`)
+	if err != nil {
+		return err
+	}
+	// New cards are due in the order the entries were exported
+	w.cards, err = w.tx.Prepare(`INSERT INTO cards (id, nid, did, ord, mod, usn, type, queue, due, ivl, factor, reps,
+		lapses, left, odue, odid, flags, data) VALUES (?, ?, ?, ?, ?, -1, 0, 0, ?, 0, 0, 0, 0, 0, 0, 0, 0, '')`)
+	return err
+}
+
+// Write implements RecordWriter
+func (w *AnkiWriter) Write(record *Record) error {
+	guid := record.ID
+	if guid == "" {
+		sum := sha1.Sum([]byte(record.SourceLanguageID + "\x00" + record.Word))
+		guid = hex.EncodeToString(sum[:8])
+	}
+
+	audio, err := w.recording(record, guid)
+	if err != nil {
+		return err
+	}
+
+	translation := ankiTranslations(record)
+	fields := []string{
+		html.EscapeString(record.Word),
+		html.EscapeString(record.Pronunciation),
+		translation,
+		ankiMeanings(record),
+		audio,
+	}
+
+	w.count++
+	noteID := w.id()
+	mod := w.now.Unix()
+	_, err = w.notes.Exec(noteID, guid, w.modelID, mod, ankiTags(record), strings.Join(fields, "\x1f"),
+		record.Word, ankiChecksum(fields[0]))
+	if err != nil {
+		return fmt.Errorf("failed to add note for %q: %w", record.Word, err)
+	}
+
+	for ord, template := range w.options.Templates {
+		// A card asking for the translation of an entry without any would
+		// have an empty front
+		if template == AnkiReverse && translation == "" {
+			continue
+		}
+		if _, err := w.cards.Exec(w.id(), noteID, w.deckID, ord, mod, w.count); err != nil {
+			return fmt.Errorf("failed to add card for %q: %w", record.Word, err)
+		}
+	}
+	return nil
+}
+
+// id returns a fresh note or card identifier. Anki uses creation times in
+// milliseconds, so identifiers count up from the time of the export
+func (w *AnkiWriter) id() int64 {
+	w.nextID++
+	return w.nextID
+}
+
+// recording looks for the pronunciation recording of a record and returns
+// the sound reference for the Audio field, or "" if there is none
+func (w *AnkiWriter) recording(record *Record, guid string) (string, error) {
+	word := strings.TrimSpace(record.Word)
+	if w.options.AudioDir == "" || word == "" || word != filepath.Base(word) || word == ".." ||
+		record.SourceLanguageID != filepath.Base(record.SourceLanguageID) {
+		return "", nil
+	}
+
+	for _, ext := range ankiAudioExtensions {
+		path := filepath.Join(w.options.AudioDir, record.SourceLanguageID, word+ext)
+		info, err := os.Stat(path)
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+
+		// Media names are shared by the whole collection, so they carry the
+		// note identity rather than the word
+		name := "trytrago-" + guid + ext
+		w.media = append(w.media, ankiMedia{path: path, name: name})
+		return "[sound:" + name + "]", nil
+	}
+	return "", nil
+}
+
+// Close implements RecordWriter. It completes the collection and writes the
+// package
+func (w *AnkiWriter) Close() error {
+	defer w.Abort()
+
+	if err := w.finish(); err != nil {
+		return err
+	}
+
+	archive := zip.NewWriter(w.out)
+	if err := addZipFile(archive, "collection.anki2", w.path); err != nil {
+		return err
+	}
+
+	// The media manifest maps the numbered files of the package to the names
+	// notes refer to
+	manifest := make(map[string]string, len(w.media))
+	for i, media := range w.media {
+		name := strconv.Itoa(i)
+		manifest[name] = media.name
+		if err := addZipFile(archive, name, media.path); err != nil {
+			return err
+		}
+	}
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	entry, err := archive.Create("media")
+	if err != nil {
+		return err
+	}
+	if _, err := entry.Write(data); err != nil {
+		return err
+	}
+
+	return archive.Close()
+}
+
+// finish stores the deck and note type and closes the collection
+func (w *AnkiWriter) finish() error {
+	mod := w.now.UnixMilli()
+
+	type field struct {
+		Name   string        `json:"name"`
+		Ord    int           `json:"ord"`
+		Sticky bool          `json:"sticky"`
+		RTL    bool          `json:"rtl"`
+		Font   string        `json:"font"`
+		Size   int           `json:"size"`
+		Media  []interface{} `json:"media"`
+	}
+	type template struct {
+		Name  string      `json:"name"`
+		Ord   int         `json:"ord"`
+		QFmt  string      `json:"qfmt"`
+		AFmt  string      `json:"afmt"`
+		DID   interface{} `json:"did"`
+		BQFmt string      `json:"bqfmt"`
+		BAFmt string      `json:"bafmt"`
+	}
+
+	fields := make([]field, len(ankiFields))
+	for i, name := range ankiFields {
+		fields[i] = field{Name: name, Ord: i, Font: "Arial", Size: 20, Media: []interface{}{}}
+	}
+	templates := make([]template, len(w.templates))
+	required := make([]interface{}, len(w.templates))
+	for i, t := range w.templates {
+		templates[i] = template{Name: t.name, Ord: i, QFmt: t.front, AFmt: t.back}
+
+		// Older Anki versions only generate a card when the fields on its
+		// front are filled: Word for forward cards, Translation for reverse
+		front := 0
+		if w.options.Templates[i] == AnkiReverse {
+			front = 2
+		}
+		required[i] = []interface{}{i, "all", []int{front}}
+	}
+
+	models := map[string]interface{}{
+		strconv.FormatInt(w.modelID, 10): map[string]interface{}{
+			"id":        w.modelID,
+			"name":      "TryTraGo vocabulary",
+			"type":      0,
+			"mod":       w.now.Unix(),
+			"usn":       -1,
+			"sortf":     0,
+			"did":       w.deckID,
+			"tmpls":     templates,
+			"flds":      fields,
+			"css":       ankiCSS,
+			"latexPre":  "\\documentclass[12pt]{article}\n\\special{papersize=3in,5in}\n\\usepackage{amssymb,amsmath}\n\\pagestyle{empty}\n\\begin{document}\n",
+			"latexPost": "\\end{document}",
+			"latexsvg":  false,
+			"tags":      []string{},
+			"vers":      []interface{}{},
+			"req":       required,
+		},
+	}
+
+	deck := func(id int64, name string) map[string]interface{} {
+		return map[string]interface{}{
+			"id": id, "name": name, "desc": "", "mod": w.now.Unix(), "usn": -1,
+			"collapsed": false, "browserCollapsed": false, "dyn": 0, "conf": 1,
+			"newToday": []int{0, 0}, "revToday": []int{0, 0}, "lrnToday": []int{0, 0}, "timeToday": []int{0, 0},
+			"extendNew": 10, "extendRev": 50,
+		}
+	}
+	decks := map[string]interface{}{
+		"1":                             deck(1, "Default"),
+		strconv.FormatInt(w.deckID, 10): deck(w.deckID, w.options.DeckName),
+	}
+
+	conf := map[string]interface{}{
+		"nextPos": w.count + 1, "estTimes": true, "activeDecks": []int64{w.deckID}, "sortType": "noteFld",
+		"timeLim": 0, "sortBackwards": false, "addToCur": true, "curDeck": w.deckID, "newBury": true,
+		"newSpread": 0, "dueCounts": true, "curModel": strconv.FormatInt(w.modelID, 10), "collapseTime": 1200,
+	}
+
+	var encoded [3][]byte
+	for i, value := range []interface{}{conf, models, decks} {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		encoded[i] = data
+	}
+
+	// The collection was created at the start of the day of the export
+	year, month, day := w.now.Date()
+	created := time.Date(year, month, day, 0, 0, 0, 0, w.now.Location()).Unix()
+	_, err := w.tx.Exec(`INSERT INTO col (id, crt, mod, scm, ver, dty, usn, ls, conf, models, decks, dconf, tags)
+		VALUES (1, ?, ?, ?, 11, 0, 0, 0, ?, ?, ?, ?, '{}')`,
+		created, mod, mod, string(encoded[0]), string(encoded[1]), string(encoded[2]), ankiDeckConfig)
+	if err != nil {
+		return fmt.Errorf("failed to store Anki deck: %w", err)
+	}
+
+	if err := w.tx.Commit(); err != nil {
+		return err
+	}
+	w.tx = nil
+	return w.db.Close()
+}
+
+// Abort implements Aborter
+func (w *AnkiWriter) Abort() error {
+	if w.tx != nil {
+		w.tx.Rollback()
+		w.tx = nil
+	}
+	if w.db != nil {
+		w.db.Close()
+	}
+	err := os.Remove(w.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// addZipFile copies the file at path into the archive under name
+func addZipFile(archive *zip.Writer, name, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	entry, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(entry, file)
+	return err
+}
+
+// ankiTranslations renders the translations of a record, prefixed with their
+// language when there is more than one
+func ankiTranslations(record *Record) string {
+	var languages []string
+	byLanguage := make(map[string][]string)
+	seen := make(map[string]bool)
+	for _, meaning := range record.Meanings {
+		for _, t := range meaning.Translations {
+			key := t.LanguageID + "\x00" + t.Text
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			if _, ok := byLanguage[t.LanguageID]; !ok {
+				languages = append(languages, t.LanguageID)
+			}
+			byLanguage[t.LanguageID] = append(byLanguage[t.LanguageID], html.EscapeString(t.Text))
+		}
+	}
+
+	if len(languages) == 1 {
+		return strings.Join(byLanguage[languages[0]], "; ")
+	}
+	lines := make([]string, len(languages))
+	for i, lang := range languages {
+		lines[i] = lang + ": " + strings.Join(byLanguage[lang], "; ")
+	}
+	return strings.Join(lines, "<br>")
+}
+
+// ankiMeanings renders the meanings of a record as a numbered list
+func ankiMeanings(record *Record) string {
+	var b strings.Builder
+	for _, meaning := range record.Meanings {
+		if meaning.Description == "" && meaning.PartOfSpeech == "" && len(meaning.Examples) == 0 {
+			continue
+		}
+		b.WriteString("<li>")
+		if meaning.PartOfSpeech != "" {
+			b.WriteString("<i>" + html.EscapeString(meaning.PartOfSpeech) + "</i> ")
+		}
+		b.WriteString(html.EscapeString(meaning.Description))
+		for _, example := range meaning.Examples {
+			b.WriteString(`<div class="example">` + html.EscapeString(example) + "</div>")
+		}
+		b.WriteString("</li>")
+	}
+	if b.Len() == 0 {
+		return ""
+	}
+	return `<ol class="meaning">` + b.String() + "</ol>"
+}
+
+// ankiTags tags notes with their origin and entry type
+func ankiTags(record *Record) string {
+	return " trytrago " + strings.ToLower(record.Type) + " "
+}
+
+// ankiChecksum is the duplicate check value Anki keeps for the first field:
+// the first 8 hex digits of the SHA-1 of its text without markup
+func ankiChecksum(field string) int64 {
+	sum := sha1.Sum([]byte(html.UnescapeString(ankiMarkup.ReplaceAllString(field, ""))))
+	value, _ := strconv.ParseInt(hex.EncodeToString(sum[:4]), 16, 64)
+	return value
+}
+
+// ankiID derives a stable deck or note type identifier from a name
+func ankiID(name string) int64 {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	return int64(h.Sum32()) | 1<<40
+}
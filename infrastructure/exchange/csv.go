@@ -0,0 +1,326 @@
+package exchange
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/valpere/trytrago/domain/utils"
+)
+
+// Field names that CSV columns can be mapped to. Translations use one column
+// per language, named "translation.<language code>"
+const (
+	FieldWord             = "word"
+	FieldType             = "type"
+	FieldPronunciation    = "pronunciation"
+	FieldSourceLanguageID = "source_language_id"
+	FieldPartOfSpeech     = "part_of_speech"
+	FieldDescription      = "description"
+	FieldExamples         = "examples"
+	FieldTranslation      = "translation."
+)
+
+// defaultListSeparator splits several examples or translations held in one cell
+const defaultListSeparator = "|"
+
+// CSVMapping describes how the columns of a CSV or TSV file map onto record fields
+type CSVMapping struct {
+	Delimiter     string            `json:"delimiter"`      // Defaults to ","; use "\t" for TSV
+	NoHeader      bool              `json:"no_header"`      // The first row holds data rather than column names
+	ListSeparator string            `json:"list_separator"` // Defaults to "|"
+	Columns       map[string]string `json:"columns"`        // Field name -> column header, or 1-based column number
+	Defaults      map[string]string `json:"defaults"`       // Field name -> value used when the cell is empty
+}
+
+// LoadCSVMapping reads a JSON column mapping
+func LoadCSVMapping(r io.Reader) (*CSVMapping, error) {
+	var mapping CSVMapping
+	if err := json.NewDecoder(r).Decode(&mapping); err != nil {
+		return nil, fmt.Errorf("invalid column mapping: %w", err)
+	}
+	if err := mapping.Validate(); err != nil {
+		return nil, err
+	}
+	return &mapping, nil
+}
+
+// Validate checks that the mapping only refers to known fields
+func (m *CSVMapping) Validate() error {
+	if m.Delimiter != "" && utf8.RuneCountInString(m.Delimiter) != 1 {
+		return fmt.Errorf("invalid column mapping: delimiter must be a single character")
+	}
+	for field := range m.Columns {
+		if !isKnownField(field) {
+			return fmt.Errorf("invalid column mapping: unknown field %q", field)
+		}
+	}
+	for field := range m.Defaults {
+		if !isKnownField(field) {
+			return fmt.Errorf("invalid column mapping: unknown default field %q", field)
+		}
+	}
+	if m.NoHeader && m.Columns[FieldWord] == "" {
+		return fmt.Errorf("invalid column mapping: a column for %q is required when there is no header", FieldWord)
+	}
+	return nil
+}
+
+// isKnownField reports whether a mapping key names a record field
+func isKnownField(field string) bool {
+	switch field {
+	case FieldWord, FieldType, FieldPronunciation, FieldSourceLanguageID,
+		FieldPartOfSpeech, FieldDescription, FieldExamples:
+		return true
+	}
+	lang, ok := strings.CutPrefix(field, FieldTranslation)
+	return ok && lang != ""
+}
+
+// csvRow is a parsed row together with its line number in the file
+type csvRow struct {
+	line   int
+	fields []string
+}
+
+// CSVReader reads records from a CSV or TSV file. Consecutive rows with the
+// same word and type become one record with several meanings
+type CSVReader struct {
+	reader    *csv.Reader
+	mapping   CSVMapping
+	columns   map[string]int // Field name -> 0-based column index
+	languages []string       // Translation languages in a stable order
+
+	next    *csvRow
+	nextErr error
+}
+
+// NewCSVReader creates a reader for a CSV or TSV source. Without explicit
+// columns in the mapping, header names equal to field names are used
+func NewCSVReader(r io.Reader, mapping *CSVMapping) (*CSVReader, error) {
+	if mapping == nil {
+		mapping = &CSVMapping{}
+	}
+	if err := mapping.Validate(); err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	if mapping.Delimiter != "" {
+		reader.Comma, _ = utf8.DecodeRuneInString(mapping.Delimiter)
+	}
+	if reader.Comma == '\t' {
+		// Spreadsheet TSV exports do not escape stray quotes
+		reader.LazyQuotes = true
+	}
+
+	cr := &CSVReader{
+		reader:  reader,
+		mapping: *mapping,
+		columns: make(map[string]int),
+	}
+	if cr.mapping.ListSeparator == "" {
+		cr.mapping.ListSeparator = defaultListSeparator
+	}
+
+	var header []string
+	if !mapping.NoHeader {
+		var err error
+		header, err = reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, fmt.Errorf("empty import file")
+			}
+			return nil, fmt.Errorf("failed to read header: %w", err)
+		}
+	}
+
+	if err := cr.resolveColumns(header); err != nil {
+		return nil, err
+	}
+
+	return cr, nil
+}
+
+// resolveColumns turns the mapping into column indexes
+func (r *CSVReader) resolveColumns(header []string) error {
+	byName := make(map[string]int, len(header))
+	for i, name := range header {
+		byName[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	columns := r.mapping.Columns
+	if len(columns) == 0 {
+		// Use header names that match field names directly
+		columns = make(map[string]string)
+		for name := range byName {
+			if isKnownField(name) {
+				columns[name] = name
+			}
+		}
+	}
+
+	for field, ref := range columns {
+		if i, ok := byName[strings.ToLower(strings.TrimSpace(ref))]; ok {
+			r.columns[field] = i
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(ref))
+		if err != nil || n < 1 {
+			return fmt.Errorf("column %q for field %q not found", ref, field)
+		}
+		r.columns[field] = n - 1
+	}
+
+	if _, ok := r.columns[FieldWord]; !ok {
+		return fmt.Errorf("no column is mapped to %q", FieldWord)
+	}
+
+	for field := range r.columns {
+		if lang, ok := strings.CutPrefix(field, FieldTranslation); ok {
+			r.languages = append(r.languages, lang)
+		}
+	}
+	for field := range r.mapping.Defaults {
+		if lang, ok := strings.CutPrefix(field, FieldTranslation); ok {
+			if _, mapped := r.columns[field]; !mapped {
+				r.languages = append(r.languages, lang)
+			}
+		}
+	}
+	sort.Strings(r.languages)
+
+	return nil
+}
+
+// Read implements RecordReader
+func (r *CSVReader) Read() (*Record, error) {
+	var record *Record
+	var key string
+
+	for {
+		row, err := r.peek()
+		if err != nil {
+			if record != nil {
+				// Return what we have; the error surfaces on the next call
+				return record, nil
+			}
+			r.next, r.nextErr = nil, nil
+			if errors.Is(err, io.EOF) {
+				return nil, io.EOF
+			}
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				return nil, &RowError{Position: parseErr.StartLine, Message: parseErr.Err.Error()}
+			}
+			return nil, err
+		}
+
+		word := r.value(row, FieldWord)
+		entryType := strings.ToUpper(r.value(row, FieldType))
+		if entryType == "" {
+			entryType = "WORD"
+		}
+
+		rowKey := utils.NormalizeWord(word) + "\x00" + entryType
+		if record != nil && (word == "" || rowKey != key) {
+			return record, nil
+		}
+		r.next = nil
+
+		if record == nil {
+			key = rowKey
+			record = &Record{
+				Position:         row.line,
+				Word:             word,
+				Type:             entryType,
+				SourceLanguageID: strings.ToLower(r.value(row, FieldSourceLanguageID)),
+				Pronunciation:    r.value(row, FieldPronunciation),
+			}
+		}
+
+		if meaning := r.meaning(row); meaning != nil {
+			record.Meanings = append(record.Meanings, *meaning)
+		}
+
+		if word == "" {
+			// Rows without a word cannot be grouped; let validation report them
+			return record, nil
+		}
+	}
+}
+
+// peek returns the next row without consuming it
+func (r *CSVReader) peek() (*csvRow, error) {
+	if r.next != nil || r.nextErr != nil {
+		return r.next, r.nextErr
+	}
+
+	fields, err := r.reader.Read()
+	if err != nil {
+		r.nextErr = err
+		return nil, err
+	}
+
+	line, _ := r.reader.FieldPos(0)
+	r.next = &csvRow{line: line, fields: fields}
+	return r.next, nil
+}
+
+// value returns the trimmed cell mapped to field, falling back to its default
+func (r *CSVReader) value(row *csvRow, field string) string {
+	if i, ok := r.columns[field]; ok && i < len(row.fields) {
+		if v := strings.TrimSpace(row.fields[i]); v != "" {
+			return v
+		}
+	}
+	return strings.TrimSpace(r.mapping.Defaults[field])
+}
+
+// list splits a cell holding several values
+func (r *CSVReader) list(row *csvRow, field string) []string {
+	raw := r.value(row, field)
+	if raw == "" {
+		return nil
+	}
+
+	var values []string
+	for _, v := range strings.Split(raw, r.mapping.ListSeparator) {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// meaning builds the meaning described by a row, or nil if the row has none
+func (r *CSVReader) meaning(row *csvRow) *RecordMeaning {
+	meaning := &RecordMeaning{
+		PartOfSpeech: strings.ToLower(r.value(row, FieldPartOfSpeech)),
+		Description:  r.value(row, FieldDescription),
+		Examples:     r.list(row, FieldExamples),
+	}
+
+	for _, lang := range r.languages {
+		for _, text := range r.list(row, FieldTranslation+lang) {
+			meaning.Translations = append(meaning.Translations, RecordTranslation{
+				LanguageID: lang,
+				Text:       text,
+			})
+		}
+	}
+
+	// A part of speech alone, e.g. from a default, does not make a meaning
+	if meaning.Description == "" && len(meaning.Examples) == 0 && len(meaning.Translations) == 0 {
+		return nil
+	}
+	return meaning
+}
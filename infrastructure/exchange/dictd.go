@@ -0,0 +1,147 @@
+package exchange
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// dictdBase64 is the digit alphabet dictd uses for offsets and lengths
+const dictdBase64 = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+
+// dictdNumber encodes n the way dictd index files expect: base 64, most
+// significant digit first, without padding
+func dictdNumber(n uint64) string {
+	if n == 0 {
+		return dictdBase64[:1]
+	}
+	var digits []byte
+	for ; n > 0; n /= 64 {
+		digits = append(digits, dictdBase64[n%64])
+	}
+	for i, j := 0, len(digits)-1; i < j; i, j = i+1, j-1 {
+		digits[i], digits[j] = digits[j], digits[i]
+	}
+	return string(digits)
+}
+
+// dictdIndexEntry locates the article of a headword in the .dict file
+type dictdIndexEntry struct {
+	word   string
+	offset uint64
+	size   uint64
+}
+
+// DictdWriter writes records as a dictd database: base.index and base.dict.
+// Articles go straight to the .dict file, so only the index is held in memory
+type DictdWriter struct {
+	base   string
+	title  string
+	file   *os.File
+	dict   *bufio.Writer
+	offset uint64
+	index  []dictdIndexEntry
+}
+
+// NewDictdWriter creates a writer for the database at base, a path without
+// extension
+func NewDictdWriter(base, title string) (*DictdWriter, error) {
+	file, err := os.Create(base + ".dict")
+	if err != nil {
+		return nil, err
+	}
+
+	return &DictdWriter{
+		base:  base,
+		title: title,
+		file:  file,
+		dict:  bufio.NewWriter(file),
+	}, nil
+}
+
+// Write implements RecordWriter
+func (w *DictdWriter) Write(record *Record) error {
+	// dictd shows articles as they are, so they start with the headword
+	return w.article(record.Word, record.Word+"\n"+indent(renderPlainText(record))+"\n")
+}
+
+// article appends text to the .dict file and indexes it under word
+func (w *DictdWriter) article(word, text string) error {
+	if _, err := w.dict.WriteString(text); err != nil {
+		return err
+	}
+
+	// Tabs and newlines would break the index line
+	word = strings.Join(strings.Fields(word), " ")
+	w.index = append(w.index, dictdIndexEntry{word: word, offset: w.offset, size: uint64(len(text))})
+	w.offset += uint64(len(text))
+	return nil
+}
+
+// Close implements RecordWriter. It adds the database information entries
+// and writes the index
+func (w *DictdWriter) Close() error {
+	defer w.file.Close()
+
+	// 00-database-utf8 and 00-database-allchars make dictd treat headwords as
+	// UTF-8 and match them with punctuation intact
+	info := []struct{ word, text string }{
+		{"00-database-short", w.title},
+		{"00-database-utf8", ""},
+		{"00-database-allchars", ""},
+	}
+	for _, entry := range info {
+		if err := w.article(entry.word, entry.word+"\n"+indent(entry.text)+"\n"); err != nil {
+			return err
+		}
+	}
+
+	if err := w.dict.Flush(); err != nil {
+		return err
+	}
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+
+	// dictd binary searches the index ignoring case
+	sort.SliceStable(w.index, func(i, j int) bool {
+		a, b := strings.ToLower(w.index[i].word), strings.ToLower(w.index[j].word)
+		if a != b {
+			return a < b
+		}
+		return w.index[i].word < w.index[j].word
+	})
+
+	file, err := os.Create(w.base + ".index")
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	out := bufio.NewWriter(file)
+	for _, entry := range w.index {
+		if _, err := fmt.Fprintf(out, "%s\t%s\t%s\n", entry.word, dictdNumber(entry.offset), dictdNumber(entry.size)); err != nil {
+			return err
+		}
+	}
+	if err := out.Flush(); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// Abort implements Aborter
+func (w *DictdWriter) Abort() error {
+	w.file.Close()
+	return os.Remove(w.file.Name())
+}
+
+// indent shifts every line of text to the right, as dictfmt does
+func indent(text string) string {
+	if text == "" {
+		return ""
+	}
+	return "   " + strings.ReplaceAll(text, "\n", "\n   ")
+}
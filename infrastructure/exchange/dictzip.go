@@ -0,0 +1,113 @@
+package exchange
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"os"
+	"time"
+)
+
+const (
+	// dictzipChunkLength is the uncompressed size of a chunk. It is the value
+	// dictzip itself uses, small enough that a compressed chunk always fits
+	// the 16-bit size slots of the header
+	dictzipChunkLength = 58315
+
+	// The chunk table lives in the 16-bit gzip extra field
+	dictzipMaxChunks = (0xffff - 10) / 2
+)
+
+// writeDictzip compresses src into dst using dictzip, a gzip variant that
+// flushes the compressor between fixed-size chunks and lists the chunk sizes
+// in the header, so that readers can seek without inflating from the start.
+// The result is still a valid gzip file
+func writeDictzip(dst io.Writer, src io.Reader) error {
+	// The header needs every chunk size, so chunks are staged on disk first
+	staged, err := os.CreateTemp("", "trytrago-dictzip-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(staged.Name())
+	defer staged.Close()
+
+	var sizes []uint16
+	var total uint32
+	crc := crc32.NewIEEE()
+	chunk := make([]byte, dictzipChunkLength)
+	var compressed bytes.Buffer
+
+	for last := false; !last; {
+		n, err := io.ReadFull(src, chunk)
+		switch {
+		case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+			last = true
+		case err != nil:
+			return err
+		}
+
+		crc.Write(chunk[:n])
+		total += uint32(n)
+
+		// A fresh compressor per chunk keeps chunks independent of each other
+		compressed.Reset()
+		fw, err := flate.NewWriter(&compressed, flate.BestCompression)
+		if err != nil {
+			return err
+		}
+		if _, err := fw.Write(chunk[:n]); err != nil {
+			return err
+		}
+		if last {
+			err = fw.Close()
+		} else {
+			err = fw.Flush()
+		}
+		if err != nil {
+			return err
+		}
+
+		if compressed.Len() > 0xffff || len(sizes) == dictzipMaxChunks {
+			return errors.New("dictionary data too large for dictzip")
+		}
+		sizes = append(sizes, uint16(compressed.Len()))
+		if _, err := staged.Write(compressed.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	// gzip header with the "RA" (random access) extra subfield
+	extra := make([]byte, 0, 10+2*len(sizes))
+	extra = append(extra, 'R', 'A')
+	extra = binary.LittleEndian.AppendUint16(extra, uint16(6+2*len(sizes)))
+	extra = binary.LittleEndian.AppendUint16(extra, 1) // Version
+	extra = binary.LittleEndian.AppendUint16(extra, dictzipChunkLength)
+	extra = binary.LittleEndian.AppendUint16(extra, uint16(len(sizes)))
+	for _, size := range sizes {
+		extra = binary.LittleEndian.AppendUint16(extra, size)
+	}
+
+	header := []byte{0x1f, 0x8b, 8, 0x04} // Magic, deflate, FEXTRA
+	header = binary.LittleEndian.AppendUint32(header, uint32(time.Now().Unix()))
+	header = append(header, 2, 3) // Maximum compression, Unix
+	header = binary.LittleEndian.AppendUint16(header, uint16(len(extra)))
+	header = append(header, extra...)
+	if _, err := dst.Write(header); err != nil {
+		return err
+	}
+
+	if _, err := staged.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, staged); err != nil {
+		return err
+	}
+
+	trailer := binary.LittleEndian.AppendUint32(nil, crc.Sum32())
+	trailer = binary.LittleEndian.AppendUint32(trailer, total)
+	_, err = dst.Write(trailer)
+	return err
+}
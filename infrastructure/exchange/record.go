@@ -0,0 +1,69 @@
+// Package exchange converts dictionary entries to and from external file formats
+package exchange
+
+import "fmt"
+
+// DefaultTitle names exported dictionaries in formats that carry a title
+const DefaultTitle = "TryTraGo dictionary"
+
+// Record is a dictionary entry in a format-neutral shape, as read from an
+// import source or written to an export. Validation tags use the rules
+// registered by domain/validator
+type Record struct {
+	ID               string          `json:"id,omitempty" validate:"-"` // Stored entry ID on export; ignored on import
+	Position         int             `json:"-" validate:"-"`            // Row or line where the record starts in its source
+	Word             string          `json:"word" validate:"required,max=255,no_html"`
+	Type             string          `json:"type" validate:"required,entry_type"`
+	SourceLanguageID string          `json:"source_language_id,omitempty" validate:"omitempty,language_code"`
+	Pronunciation    string          `json:"pronunciation,omitempty" validate:"max=255,no_html"`
+	Etymology        string          `json:"etymology,omitempty" validate:"no_html"`
+	Source           string          `json:"source,omitempty" validate:"max=100,no_html"`  // Dataset the record comes from, for attribution
+	License          string          `json:"license,omitempty" validate:"max=100,no_html"` // Licence of the source dataset
+	Meanings         []RecordMeaning `json:"meanings,omitempty" validate:"dive"`
+}
+
+// RecordMeaning is one sense of a record
+type RecordMeaning struct {
+	PartOfSpeech string              `json:"part_of_speech" validate:"required,max=50,no_html"`
+	Description  string              `json:"description" validate:"no_html"`
+	Labels       []string            `json:"labels,omitempty" validate:"max=8,dive,min=1,max=30,no_html"` // Usage labels
+	Examples     []string            `json:"examples,omitempty" validate:"dive,no_html"`
+	Translations []RecordTranslation `json:"translations,omitempty" validate:"dive"`
+}
+
+// RecordTranslation is a translation of a meaning into another language
+type RecordTranslation struct {
+	LanguageID string `json:"language_id" validate:"required,language_code"`
+	Text       string `json:"text" validate:"required,no_html"`
+}
+
+// RecordReader reads records from an import source one at a time
+type RecordReader interface {
+	// Read returns the next record, or io.EOF once the source is exhausted.
+	// A *RowError means that only the current record was unreadable and
+	// reading can continue; any other error is fatal
+	Read() (*Record, error)
+}
+
+// RecordWriter writes records to an export destination one at a time
+type RecordWriter interface {
+	Write(record *Record) error
+
+	// Close completes the document. It does not close the underlying writer
+	Close() error
+}
+
+// RowError describes a problem with a single record of an import source
+type RowError struct {
+	Position int
+	Field    string
+	Message  string
+}
+
+// Error implements the error interface
+func (e *RowError) Error() string {
+	if e.Field != "" {
+		return fmt.Sprintf("row %d: %s: %s", e.Position, e.Field, e.Message)
+	}
+	return fmt.Sprintf("row %d: %s", e.Position, e.Message)
+}
@@ -0,0 +1,47 @@
+package exchange
+
+import (
+	"fmt"
+	"strings"
+)
+
+// renderPlainText lays a record out as plain text for offline dictionary
+// readers. The headword line is left to the caller because some formats
+// show the headword themselves
+func renderPlainText(record *Record) string {
+	var sb strings.Builder
+
+	if record.Pronunciation != "" {
+		fmt.Fprintf(&sb, "[%s]\n", record.Pronunciation)
+	}
+
+	for i, meaning := range record.Meanings {
+		fmt.Fprintf(&sb, "%d.", i+1)
+		if meaning.PartOfSpeech != "" {
+			fmt.Fprintf(&sb, " (%s)", meaning.PartOfSpeech)
+		}
+		if meaning.Description != "" {
+			fmt.Fprintf(&sb, " %s", meaning.Description)
+		}
+		sb.WriteByte('\n')
+
+		// Group translations by language, keeping the order they came in
+		var languages []string
+		texts := make(map[string][]string)
+		for _, translation := range meaning.Translations {
+			if _, ok := texts[translation.LanguageID]; !ok {
+				languages = append(languages, translation.LanguageID)
+			}
+			texts[translation.LanguageID] = append(texts[translation.LanguageID], translation.Text)
+		}
+		for _, lang := range languages {
+			fmt.Fprintf(&sb, "   %s: %s\n", lang, strings.Join(texts[lang], "; "))
+		}
+
+		for _, example := range meaning.Examples {
+			fmt.Fprintf(&sb, "   * %s\n", example)
+		}
+	}
+
+	return strings.TrimRight(sb.String(), "\n")
+}
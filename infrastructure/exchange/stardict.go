@@ -0,0 +1,621 @@
+package exchange
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	stardictMagic = "StarDict's dict ifo file"
+
+	// stardictMaxWordLength is the limit on headwords, in bytes, set by the format
+	stardictMaxWordLength = 255
+)
+
+// Aborter is implemented by writers that stage their output in temporary
+// files. Abort discards the partial output of a failed export
+type Aborter interface {
+	Abort() error
+}
+
+// stardictIndexEntry locates the article of a headword in the .dict file
+type stardictIndexEntry struct {
+	word   string
+	offset uint64
+	size   uint32
+}
+
+// stardictLess is the order StarDict readers binary search the index in:
+// ASCII case-insensitive first, then byte order
+func stardictLess(a, b string) bool {
+	if c := asciiCaseCompare(a, b); c != 0 {
+		return c < 0
+	}
+	return a < b
+}
+
+// asciiCaseCompare compares strings folding ASCII letters only, like
+// g_ascii_strcasecmp
+func asciiCaseCompare(a, b string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		ca, cb := asciiLower(a[i]), asciiLower(b[i])
+		if ca != cb {
+			return int(ca) - int(cb)
+		}
+	}
+	return len(a) - len(b)
+}
+
+func asciiLower(c byte) byte {
+	if 'A' <= c && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
+
+// truncateUTF8 shortens s to at most n bytes without splitting a character
+func truncateUTF8(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// StarDictWriter writes records as a StarDict dictionary: base.ifo, base.idx
+// and a dictzip-compressed base.dict.dz. Articles are staged in a temporary
+// file, so only the index is held in memory
+type StarDictWriter struct {
+	base     string
+	title    string
+	articles *os.File
+	buffered *bufio.Writer
+	offset   uint64
+	index    []stardictIndexEntry
+}
+
+// NewStarDictWriter creates a writer for the dictionary at base, a path
+// without extension
+func NewStarDictWriter(base, title string) (*StarDictWriter, error) {
+	articles, err := os.CreateTemp("", "trytrago-stardict-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create staging file: %w", err)
+	}
+
+	return &StarDictWriter{
+		base:     base,
+		title:    title,
+		articles: articles,
+		buffered: bufio.NewWriter(articles),
+	}, nil
+}
+
+// Write implements RecordWriter
+func (w *StarDictWriter) Write(record *Record) error {
+	article := renderPlainText(record)
+	if len(article) > math.MaxUint32 {
+		return fmt.Errorf("article for %q is too large", record.Word)
+	}
+
+	if _, err := w.buffered.WriteString(article); err != nil {
+		return err
+	}
+	w.index = append(w.index, stardictIndexEntry{
+		word:   truncateUTF8(record.Word, stardictMaxWordLength),
+		offset: w.offset,
+		size:   uint32(len(article)),
+	})
+	w.offset += uint64(len(article))
+	return nil
+}
+
+// Close implements RecordWriter. It writes the three dictionary files
+func (w *StarDictWriter) Close() error {
+	defer w.Abort()
+
+	if err := w.buffered.Flush(); err != nil {
+		return err
+	}
+	if _, err := w.articles.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	dict, err := os.Create(w.base + ".dict.dz")
+	if err != nil {
+		return err
+	}
+	defer dict.Close()
+	if err := writeDictzip(dict, w.articles); err != nil {
+		return fmt.Errorf("failed to write %s: %w", dict.Name(), err)
+	}
+	if err := dict.Close(); err != nil {
+		return err
+	}
+
+	sort.SliceStable(w.index, func(i, j int) bool {
+		return stardictLess(w.index[i].word, w.index[j].word)
+	})
+
+	// Offsets beyond 4GB need the 64-bit index variant
+	wide := w.offset > math.MaxUint32
+
+	idx, err := os.Create(w.base + ".idx")
+	if err != nil {
+		return err
+	}
+	defer idx.Close()
+
+	out := bufio.NewWriter(idx)
+	var idxSize int64
+	for _, entry := range w.index {
+		record := append([]byte(entry.word), 0)
+		if wide {
+			record = binary.BigEndian.AppendUint64(record, entry.offset)
+		} else {
+			record = binary.BigEndian.AppendUint32(record, uint32(entry.offset))
+		}
+		record = binary.BigEndian.AppendUint32(record, entry.size)
+		if _, err := out.Write(record); err != nil {
+			return err
+		}
+		idxSize += int64(len(record))
+	}
+	if err := out.Flush(); err != nil {
+		return err
+	}
+	if err := idx.Close(); err != nil {
+		return err
+	}
+
+	// Version 3.0.0 is only needed for the 64-bit index
+	version := "2.4.2"
+	if wide {
+		version = "3.0.0"
+	}
+
+	var ifo strings.Builder
+	fmt.Fprintf(&ifo, "%s\nversion=%s\n", stardictMagic, version)
+	fmt.Fprintf(&ifo, "bookname=%s\n", strings.ReplaceAll(w.title, "\n", " "))
+	fmt.Fprintf(&ifo, "wordcount=%d\n", len(w.index))
+	fmt.Fprintf(&ifo, "idxfilesize=%d\n", idxSize)
+	if wide {
+		fmt.Fprintf(&ifo, "idxoffsetbits=64\n")
+	}
+	fmt.Fprintf(&ifo, "date=%s\n", time.Now().UTC().Format("2006.01.02"))
+	fmt.Fprintf(&ifo, "sametypesequence=m\n")
+
+	return os.WriteFile(w.base+".ifo", []byte(ifo.String()), 0o644)
+}
+
+// Abort implements Aborter
+func (w *StarDictWriter) Abort() error {
+	w.articles.Close()
+	if err := os.Remove(w.articles.Name()); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// StarDictImportOptions says how the free text of StarDict articles maps
+// onto meanings
+type StarDictImportOptions struct {
+	SourceLanguageID string // Language of the headwords
+	TargetLanguageID string // If set, articles are read as translations into this language
+
+	// DefaultPartOfSpeech is used for senses that do not start with a
+	// recognised part of speech such as "n." or "adj."
+	DefaultPartOfSpeech string
+}
+
+// stardictInfo is the content of an .ifo file
+type stardictInfo struct {
+	idxOffsetBits    int
+	sameTypeSequence string
+}
+
+// StarDictReader reads records from a StarDict dictionary. The index is
+// streamed and articles are fetched by offset, so neither is loaded whole
+type StarDictReader struct {
+	info     stardictInfo
+	options  StarDictImportOptions
+	idx      *bufio.Reader
+	idxFile  io.Closer
+	dict     io.ReaderAt
+	dictFile *os.File
+	tempDict bool // dictFile is an inflated copy of a .dict.dz
+	position int
+}
+
+// NewStarDictReader opens the dictionary described by the .ifo file at path.
+// The .idx (or .idx.gz) and .dict (or .dict.dz) files must sit next to it
+func NewStarDictReader(path string, options StarDictImportOptions) (*StarDictReader, error) {
+	base := strings.TrimSuffix(path, ".ifo")
+
+	info, err := readStarDictInfo(base + ".ifo")
+	if err != nil {
+		return nil, err
+	}
+
+	r := &StarDictReader{info: *info, options: options}
+
+	idxFile, err := os.Open(base + ".idx")
+	if errors.Is(err, os.ErrNotExist) {
+		idxFile, err = os.Open(base + ".idx.gz")
+		if err == nil {
+			gz, gzErr := gzip.NewReader(idxFile)
+			if gzErr != nil {
+				idxFile.Close()
+				return nil, fmt.Errorf("invalid StarDict index: %w", gzErr)
+			}
+			r.idx = bufio.NewReader(gz)
+		}
+	} else if err == nil {
+		r.idx = bufio.NewReader(idxFile)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open StarDict index: %w", err)
+	}
+	r.idxFile = idxFile
+
+	if err := r.openDict(base); err != nil {
+		idxFile.Close()
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// readStarDictInfo parses an .ifo file
+func readStarDictInfo(path string) (*stardictInfo, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read StarDict info: %w", err)
+	}
+
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	if len(lines) == 0 || strings.TrimPrefix(lines[0], "\ufeff") != stardictMagic {
+		return nil, fmt.Errorf("%s is not a StarDict info file", path)
+	}
+
+	info := &stardictInfo{idxOffsetBits: 32}
+	for _, line := range lines[1:] {
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "idxoffsetbits":
+			info.idxOffsetBits, _ = strconv.Atoi(strings.TrimSpace(value))
+		case "sametypesequence":
+			info.sameTypeSequence = strings.TrimSpace(value)
+		}
+	}
+
+	if info.idxOffsetBits != 32 && info.idxOffsetBits != 64 {
+		return nil, fmt.Errorf("unsupported idxoffsetbits %d", info.idxOffsetBits)
+	}
+	return info, nil
+}
+
+// openDict opens the article file. A compressed one is inflated into a
+// temporary file first so that articles can be read at any offset
+func (r *StarDictReader) openDict(base string) error {
+	dict, err := os.Open(base + ".dict")
+	if err == nil {
+		r.dict, r.dictFile = dict, dict
+		return nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to open StarDict articles: %w", err)
+	}
+
+	compressed, err := os.Open(base + ".dict.dz")
+	if err != nil {
+		return fmt.Errorf("failed to open StarDict articles: %w", err)
+	}
+	defer compressed.Close()
+
+	gz, err := gzip.NewReader(compressed)
+	if err != nil {
+		return fmt.Errorf("invalid StarDict articles: %w", err)
+	}
+
+	inflated, err := os.CreateTemp("", "trytrago-stardict-*")
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(inflated, gz); err != nil {
+		inflated.Close()
+		os.Remove(inflated.Name())
+		return fmt.Errorf("invalid StarDict articles: %w", err)
+	}
+
+	r.dict, r.dictFile, r.tempDict = inflated, inflated, true
+	return nil
+}
+
+// Close releases the dictionary files
+func (r *StarDictReader) Close() error {
+	r.idxFile.Close()
+	err := r.dictFile.Close()
+	if r.tempDict {
+		os.Remove(r.dictFile.Name())
+	}
+	return err
+}
+
+// Read implements RecordReader
+func (r *StarDictReader) Read() (*Record, error) {
+	word, err := r.idx.ReadString(0)
+	if err != nil {
+		if errors.Is(err, io.EOF) && word == "" {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("invalid StarDict index: %w", err)
+	}
+	word = strings.TrimSuffix(word, "\x00")
+
+	var offset uint64
+	if r.info.idxOffsetBits == 64 {
+		err = binary.Read(r.idx, binary.BigEndian, &offset)
+	} else {
+		var offset32 uint32
+		err = binary.Read(r.idx, binary.BigEndian, &offset32)
+		offset = uint64(offset32)
+	}
+	var size uint32
+	if err == nil {
+		err = binary.Read(r.idx, binary.BigEndian, &size)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid StarDict index: %w", err)
+	}
+
+	r.position++
+
+	article := make([]byte, size)
+	if _, err := r.dict.ReadAt(article, int64(offset)); err != nil {
+		return nil, &RowError{Position: r.position, Message: "article lies outside the dictionary file"}
+	}
+
+	record := &Record{
+		Position:         r.position,
+		Word:             strings.TrimSpace(word),
+		Type:             "WORD",
+		SourceLanguageID: r.options.SourceLanguageID,
+	}
+	if strings.ContainsAny(record.Word, " \t") {
+		record.Type = "PHRASE"
+	}
+
+	var texts []string
+	for _, field := range splitStarDictFields(article, r.info.sameTypeSequence) {
+		switch field.kind {
+		case 't':
+			record.Pronunciation = strings.TrimSpace(string(field.data))
+		case 'm', 'l', 'y':
+			texts = append(texts, string(field.data))
+		case 'g', 'h', 'x':
+			texts = append(texts, stripMarkup(string(field.data)))
+		}
+		// Binary fields (sounds, pictures, resources) are not imported
+	}
+
+	r.addSenses(record, strings.Join(texts, "\n"))
+	return record, nil
+}
+
+// stardictField is one typed piece of an article
+type stardictField struct {
+	kind byte
+	data []byte
+}
+
+// splitStarDictFields splits an article into its typed fields. With a
+// sametypesequence the type markers are implied and the final field runs to
+// the end of the article. Lower-case types are NUL-terminated text, upper-case
+// types are prefixed with a 32-bit size
+func splitStarDictFields(article []byte, sequence string) []stardictField {
+	var fields []stardictField
+
+	next := func(kind byte, last bool) bool {
+		if last {
+			fields = append(fields, stardictField{kind: kind, data: article})
+			article = nil
+			return true
+		}
+		if kind >= 'a' && kind <= 'z' {
+			end := bytes.IndexByte(article, 0)
+			if end < 0 {
+				end = len(article)
+			}
+			fields = append(fields, stardictField{kind: kind, data: article[:end]})
+			article = article[min(end+1, len(article)):]
+			return true
+		}
+		if len(article) < 4 {
+			return false
+		}
+		size := int(binary.BigEndian.Uint32(article))
+		if 4+size > len(article) {
+			return false
+		}
+		fields = append(fields, stardictField{kind: kind, data: article[4 : 4+size]})
+		article = article[4+size:]
+		return true
+	}
+
+	if sequence != "" {
+		for i := 0; i < len(sequence); i++ {
+			if !next(sequence[i], i == len(sequence)-1) {
+				break
+			}
+		}
+		return fields
+	}
+
+	for len(article) > 0 {
+		kind := article[0]
+		article = article[1:]
+		if !next(kind, false) {
+			break
+		}
+	}
+	return fields
+}
+
+var (
+	markupBreak = regexp.MustCompile(`(?i)<\s*(br|/p|/div|/li|/k)\s*/?>`)
+	markupTag   = regexp.MustCompile(`<[^>]*>`)
+)
+
+// stripMarkup turns HTML, Pango or XDXF markup into plain text lines
+func stripMarkup(s string) string {
+	s = markupBreak.ReplaceAllString(s, "\n")
+	s = markupTag.ReplaceAllString(s, "")
+	return html.UnescapeString(s)
+}
+
+var (
+	// senseMarker matches "1.", "2)" or "IV." at the start of a line
+	senseMarker = regexp.MustCompile(`^(?:\d+|[IVX]+)[.)]\s*`)
+
+	// posAbbreviation matches an abbreviated part of speech such as "adj."
+	// or one in parentheses such as "(noun)"
+	posAbbreviation = regexp.MustCompile(`^(?:([a-zA-Z]+)\.|\(([a-zA-Z]+)\))\s*`)
+
+	// translationLine matches "fr: banque", a translation into one language
+	translationLine = regexp.MustCompile(`^([a-z]{2}(?:-[a-z0-9]+)?):\s+(.+)$`)
+
+	// pronunciationLine matches a pronunciation in brackets on its own line
+	pronunciationLine = regexp.MustCompile(`^\[([^\]]+)\]$`)
+)
+
+// partsOfSpeech maps the abbreviations common in StarDict articles to the
+// names used for parts of speech
+var partsOfSpeech = map[string]string{
+	"n": "noun", "noun": "noun",
+	"v": "verb", "vt": "verb", "vi": "verb", "verb": "verb",
+	"a": "adjective", "adj": "adjective", "adjective": "adjective",
+	"adv": "adverb", "adverb": "adverb",
+	"prep": "preposition", "preposition": "preposition",
+	"conj": "conjunction", "conjunction": "conjunction",
+	"pron": "pronoun", "pronoun": "pronoun",
+	"int": "interjection", "interj": "interjection", "interjection": "interjection",
+	"num": "numeral", "numeral": "numeral",
+	"art": "article", "article": "article",
+}
+
+// leadingPartOfSpeech strips a part of speech from the start of a line
+func leadingPartOfSpeech(line string) (pos, rest string) {
+	if name, ok := partsOfSpeech[strings.ToLower(line)]; ok {
+		return name, ""
+	}
+	if m := posAbbreviation.FindStringSubmatch(line); m != nil {
+		if name, ok := partsOfSpeech[strings.ToLower(m[1]+m[2])]; ok {
+			return name, line[len(m[0]):]
+		}
+	}
+	return "", line
+}
+
+// addSenses splits the text of an article into meanings. Numbered lines
+// start a new meaning and the lines that follow add to it; without
+// numbering every line is a meaning of its own. The text of a meaning is a
+// translation when a target language is set and a description otherwise.
+// This also reads back the layout the StarDict and dictd writers produce
+func (r *StarDictReader) addSenses(record *Record, text string) {
+	pos := r.options.DefaultPartOfSpeech
+	numbered := false
+	for _, line := range strings.Split(text, "\n") {
+		if senseMarker.MatchString(strings.TrimSpace(line)) {
+			numbered = true
+			break
+		}
+	}
+
+	var current *RecordMeaning
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.Join(strings.Fields(line), " ")
+		if line == "" {
+			continue
+		}
+
+		if current == nil && record.Pronunciation == "" {
+			if m := pronunciationLine.FindStringSubmatch(line); m != nil {
+				record.Pronunciation = m[1]
+				continue
+			}
+		}
+
+		marked := senseMarker.MatchString(line)
+		line = senseMarker.ReplaceAllString(line, "")
+
+		linePOS, rest := leadingPartOfSpeech(line)
+		if linePOS != "" {
+			pos = linePOS
+		}
+
+		if numbered && !marked {
+			// A part of speech on its own heads the meanings below it
+			if rest != "" && current != nil {
+				addStarDictDetail(current, rest)
+			}
+			continue
+		}
+		if rest == "" && !marked {
+			continue
+		}
+
+		record.Meanings = append(record.Meanings, RecordMeaning{PartOfSpeech: pos})
+		current = &record.Meanings[len(record.Meanings)-1]
+
+		if r.options.TargetLanguageID == "" {
+			current.Description = rest
+			continue
+		}
+		addTranslations(current, r.options.TargetLanguageID, rest)
+	}
+}
+
+// addStarDictDetail adds a line that follows a numbered meaning: either
+// translations prefixed with their language, or an example
+func addStarDictDetail(meaning *RecordMeaning, line string) {
+	if m := translationLine.FindStringSubmatch(line); m != nil {
+		addTranslations(meaning, m[1], m[2])
+		return
+	}
+
+	example := strings.TrimLeft(line, "*• ")
+	if example != "" {
+		meaning.Examples = append(meaning.Examples, example)
+	}
+}
+
+// addTranslations adds the semicolon-separated translations in text
+func addTranslations(meaning *RecordMeaning, languageID, text string) {
+	for _, t := range strings.Split(text, ";") {
+		if t = strings.TrimSpace(t); t != "" {
+			meaning.Translations = append(meaning.Translations, RecordTranslation{
+				LanguageID: languageID,
+				Text:       t,
+			})
+		}
+	}
+}
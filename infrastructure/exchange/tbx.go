@@ -0,0 +1,459 @@
+package exchange
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// TBXNamespace is the namespace of TBX (ISO 30042:2019) documents
+const TBXNamespace = "urn:iso:std:iso:30042:ed-2"
+
+// tbxValue is an element carrying a data category in its type attribute,
+// such as descrip or termNote
+type tbxValue struct {
+	Type string `xml:"type,attr"`
+	Text string `xml:",chardata"`
+}
+
+// UnmarshalXML implements xml.Unmarshaler. Values may contain inline markup,
+// whose text is kept
+func (v *tbxValue) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "type" {
+			v.Type = attr.Value
+		}
+	}
+	var text teiText
+	if err := text.UnmarshalXML(d, start); err != nil {
+		return err
+	}
+	v.Text = string(text)
+	return nil
+}
+
+// The tbx* types mirror the subset of TBX-Basic that maps onto entries.
+// They are used for both decoding and encoding. TBX 2008 documents (martif,
+// termEntry, langSet, tig) are read as well, since many CAT tools still
+// produce them
+type tbxConceptEntry struct {
+	ID          string          `xml:"id,attr,omitempty"`
+	Descrips    []tbxValue      `xml:"descrip"`
+	DescripGrps []tbxDescripGrp `xml:"descripGrp"`
+	LangSecs    []tbxLangSec    `xml:"langSec"`
+	LangSets    []tbxLangSec    `xml:"langSet"`
+}
+
+// tbxDescripGrp wraps a descrip with its source
+type tbxDescripGrp struct {
+	Descrip tbxValue `xml:"descrip"`
+}
+
+type tbxLangSec struct {
+	Lang        string          `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+	Descrips    []tbxValue      `xml:"descrip"`
+	DescripGrps []tbxDescripGrp `xml:"descripGrp"`
+	TermSecs    []tbxTermSec    `xml:"termSec"`
+	Tigs        []tbxTermSec    `xml:"tig"`
+	NTigs       []tbxNTig       `xml:"ntig"`
+}
+
+type tbxTermSec struct {
+	Term        teiText         `xml:"term"`
+	TermNotes   []tbxValue      `xml:"termNote"`
+	Descrips    []tbxValue      `xml:"descrip"`
+	DescripGrps []tbxDescripGrp `xml:"descripGrp"`
+}
+
+// tbxNTig is the nested form of a TBX 2008 term group
+type tbxNTig struct {
+	TermGrp struct {
+		Term      teiText    `xml:"term"`
+		TermNotes []tbxValue `xml:"termNote"`
+	} `xml:"termGrp"`
+	Descrips    []tbxValue      `xml:"descrip"`
+	DescripGrps []tbxDescripGrp `xml:"descripGrp"`
+}
+
+// sections returns the language sections whichever TBX version named them
+func (c *tbxConceptEntry) sections() []tbxLangSec {
+	return append(append([]tbxLangSec{}, c.LangSecs...), c.LangSets...)
+}
+
+// terms returns the terms of a language section in document order
+func (l *tbxLangSec) terms() []tbxTermSec {
+	terms := append([]tbxTermSec{}, l.TermSecs...)
+	terms = append(terms, l.Tigs...)
+	for _, ntig := range l.NTigs {
+		terms = append(terms, tbxTermSec{
+			Term:        ntig.TermGrp.Term,
+			TermNotes:   ntig.TermGrp.TermNotes,
+			Descrips:    ntig.Descrips,
+			DescripGrps: ntig.DescripGrps,
+		})
+	}
+	return terms
+}
+
+// tbxValues returns the values of one data category
+func tbxValues(category string, values []tbxValue, groups []tbxDescripGrp) []string {
+	all := append([]tbxValue{}, values...)
+	for _, group := range groups {
+		all = append(all, group.Descrip)
+	}
+
+	var found []string
+	for _, value := range all {
+		if value.Type != category {
+			continue
+		}
+		if text := strings.TrimSpace(value.Text); text != "" {
+			found = append(found, text)
+		}
+	}
+	return found
+}
+
+// tbxLanguage turns a TBX language tag into a language code. Tags too long
+// to store, such as zh-Hans, are cut back to the language itself
+func tbxLanguage(tag string) string {
+	tag = strings.ToLower(strings.ReplaceAll(tag, "_", "-"))
+	if len(tag) > 5 {
+		tag, _, _ = strings.Cut(tag, "-")
+	}
+	return tag
+}
+
+// TBXImportOptions describe what a TBX document does not say
+type TBXImportOptions struct {
+	// SourceLanguageID selects the language whose terms become entries. The
+	// document language is used if empty
+	SourceLanguageID string
+
+	// DefaultPartOfSpeech is used for terms without a part of speech
+	DefaultPartOfSpeech string
+}
+
+// TBXReader streams records out of a TBX termbase. Each term in the source
+// language becomes an entry, the concept its meaning, and the terms of the
+// other languages its translations. Consecutive concepts with the same
+// source term, as written by TBXWriter for entries with several meanings,
+// are read back as one entry
+type TBXReader struct {
+	decoder *xml.Decoder
+	options TBXImportOptions
+	pending []*Record // Records of the last concept read
+	current *Record   // Record being completed by further concepts
+	done    bool
+}
+
+// NewTBXReader creates a reader for a TBX source
+func NewTBXReader(r io.Reader, options TBXImportOptions) *TBXReader {
+	options.SourceLanguageID = strings.ToLower(options.SourceLanguageID)
+	return &TBXReader{decoder: xml.NewDecoder(r), options: options}
+}
+
+// Read implements RecordReader
+func (r *TBXReader) Read() (*Record, error) {
+	for {
+		if len(r.pending) > 0 {
+			next := r.pending[0]
+			r.pending = r.pending[1:]
+
+			if r.current != nil && r.current.Word == next.Word && r.current.SourceLanguageID == next.SourceLanguageID {
+				r.current.Meanings = append(r.current.Meanings, next.Meanings...)
+				continue
+			}
+			record := r.current
+			r.current = next
+			if record != nil {
+				return record, nil
+			}
+			continue
+		}
+
+		if r.done {
+			if record := r.current; record != nil {
+				r.current = nil
+				return record, nil
+			}
+			return nil, io.EOF
+		}
+
+		if err := r.readConcept(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// readConcept decodes the next concept into pending records, or marks the
+// end of the document
+func (r *TBXReader) readConcept() error {
+	for {
+		tok, err := r.decoder.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				r.done = true
+				return nil
+			}
+			return fmt.Errorf("invalid TBX document: %w", err)
+		}
+
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch start.Name.Local {
+		case "tbx", "martif":
+			if r.options.SourceLanguageID != "" {
+				continue
+			}
+			for _, attr := range start.Attr {
+				if attr.Name.Space == xmlNamespace && attr.Name.Local == "lang" {
+					r.options.SourceLanguageID = tbxLanguage(attr.Value)
+				}
+			}
+		case "tbxHeader", "martifHeader":
+			if err := r.decoder.Skip(); err != nil {
+				return fmt.Errorf("invalid TBX document: %w", err)
+			}
+		case "conceptEntry", "termEntry":
+			line, _ := r.decoder.InputPos()
+			var concept tbxConceptEntry
+			if err := r.decoder.DecodeElement(&concept, &start); err != nil {
+				// Malformed XML cannot be resynchronised, so this is fatal
+				return fmt.Errorf("invalid TBX concept at line %d: %w", line, err)
+			}
+			r.pending = r.records(&concept, line)
+			return nil
+		}
+	}
+}
+
+// records converts a concept into one record per source language term
+func (r *TBXReader) records(concept *tbxConceptEntry, line int) []*Record {
+	sections := concept.sections()
+
+	// Without a document language the first language of each concept is
+	// the source
+	source := r.options.SourceLanguageID
+	if source == "" && len(sections) > 0 {
+		source = tbxLanguage(sections[0].Lang)
+	}
+
+	labels := tbxValues("subjectField", concept.Descrips, concept.DescripGrps)
+	definitions := tbxValues("definition", concept.Descrips, concept.DescripGrps)
+
+	var sourceSections []tbxLangSec
+	var translations []RecordTranslation
+	for _, section := range sections {
+		lang := tbxLanguage(section.Lang)
+		if lang == source || strings.HasPrefix(lang, source+"-") {
+			sourceSections = append(sourceSections, section)
+			continue
+		}
+		for _, term := range section.terms() {
+			if term.Term != "" {
+				translations = append(translations, RecordTranslation{LanguageID: lang, Text: string(term.Term)})
+			}
+		}
+	}
+
+	var records []*Record
+	for _, section := range sourceSections {
+		sectionLabels := append(append([]string{}, labels...), tbxValues("subjectField", section.Descrips, section.DescripGrps)...)
+		description := strings.Join(tbxValues("definition", section.Descrips, section.DescripGrps), "; ")
+		if description == "" {
+			description = strings.Join(definitions, "; ")
+		}
+
+		for _, term := range section.terms() {
+			if term.Term == "" {
+				continue
+			}
+
+			record := &Record{
+				Position:         line,
+				Word:             string(term.Term),
+				Type:             "WORD",
+				SourceLanguageID: source,
+			}
+			if termType := tbxValues("termType", term.TermNotes, nil); len(termType) > 0 && termType[0] == "phrase" {
+				record.Type = "PHRASE"
+			}
+			if pron := tbxValues("pronunciation", term.TermNotes, nil); len(pron) > 0 {
+				record.Pronunciation = pron[0]
+			}
+
+			meaning := RecordMeaning{
+				PartOfSpeech: r.options.DefaultPartOfSpeech,
+				Description:  description,
+				Translations: translations,
+			}
+			if pos := tbxValues("partOfSpeech", term.TermNotes, nil); len(pos) > 0 {
+				meaning.PartOfSpeech = strings.ToLower(pos[0])
+			}
+			for _, label := range append(sectionLabels, tbxValues("usageNote", term.TermNotes, nil)...) {
+				meaning.Labels = append(meaning.Labels, strings.ToLower(label))
+			}
+			meaning.Examples = tbxValues("context", term.Descrips, term.DescripGrps)
+
+			record.Meanings = []RecordMeaning{meaning}
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+// TBXWriter streams records into a TBX-Basic termbase. TBX is concept
+// oriented: every term in a concept entry is taken to mean the same thing,
+// so each meaning of an entry becomes a concept entry of its own, with the
+// entry's word in the source language section and the translations of the
+// meaning in one section per target language
+type TBXWriter struct {
+	encoder *xml.Encoder
+	title   string
+	started bool
+}
+
+// NewTBXWriter creates a writer producing a TBX-Basic document with the given title
+func NewTBXWriter(w io.Writer, title string) *TBXWriter {
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	return &TBXWriter{encoder: encoder, title: title}
+}
+
+// start writes everything that precedes the first concept. The document
+// language is that of the first record, as it is known by then
+func (w *TBXWriter) start(lang string) error {
+	if w.started {
+		return nil
+	}
+	w.started = true
+	if lang == "" {
+		lang = "und"
+	}
+
+	tokens := []xml.Token{
+		xml.ProcInst{Target: "xml", Inst: []byte(`version="1.0" encoding="UTF-8"`)},
+		xml.CharData("\n"),
+		xml.StartElement{
+			Name: xml.Name{Local: "tbx"},
+			Attr: []xml.Attr{
+				{Name: xml.Name{Local: "type"}, Value: "TBX-Basic"},
+				{Name: xml.Name{Local: "style"}, Value: "dca"},
+				{Name: xml.Name{Space: xmlNamespace, Local: "lang"}, Value: lang},
+				{Name: xml.Name{Local: "xmlns"}, Value: TBXNamespace},
+			},
+		},
+	}
+	for _, tok := range tokens {
+		if err := w.encoder.EncodeToken(tok); err != nil {
+			return err
+		}
+	}
+
+	header := struct {
+		XMLName  xml.Name `xml:"tbxHeader"`
+		FileDesc struct {
+			Title  string `xml:"titleStmt>title"`
+			Source string `xml:"sourceDesc>p"`
+		} `xml:"fileDesc"`
+	}{}
+	header.FileDesc.Title = w.title
+	header.FileDesc.Source = "Exported from TryTraGo"
+	if err := w.encoder.Encode(header); err != nil {
+		return err
+	}
+
+	for _, name := range []string{"text", "body"} {
+		if err := w.encoder.EncodeToken(xml.StartElement{Name: xml.Name{Local: name}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Write implements RecordWriter
+func (w *TBXWriter) Write(record *Record) error {
+	if err := w.start(record.SourceLanguageID); err != nil {
+		return err
+	}
+
+	meanings := record.Meanings
+	if len(meanings) == 0 {
+		// The term is still worth having without a definition
+		meanings = []RecordMeaning{{}}
+	}
+
+	for i, meaning := range meanings {
+		term := tbxTermSec{Term: teiText(record.Word)}
+		if meaning.PartOfSpeech != "" {
+			term.TermNotes = append(term.TermNotes, tbxValue{Type: "partOfSpeech", Text: meaning.PartOfSpeech})
+		}
+		if record.Type == "PHRASE" {
+			term.TermNotes = append(term.TermNotes, tbxValue{Type: "termType", Text: "phrase"})
+		}
+		if record.Pronunciation != "" {
+			term.TermNotes = append(term.TermNotes, tbxValue{Type: "pronunciation", Text: record.Pronunciation})
+		}
+		for _, label := range meaning.Labels {
+			term.TermNotes = append(term.TermNotes, tbxValue{Type: "usageNote", Text: label})
+		}
+		for _, example := range meaning.Examples {
+			term.Descrips = append(term.Descrips, tbxValue{Type: "context", Text: example})
+		}
+
+		source := tbxLangSec{Lang: record.SourceLanguageID, TermSecs: []tbxTermSec{term}}
+		if meaning.Description != "" {
+			source.Descrips = []tbxValue{{Type: "definition", Text: meaning.Description}}
+		}
+
+		concept := tbxConceptEntry{LangSecs: []tbxLangSec{source}}
+		if record.ID != "" {
+			// IDs must not start with a digit, which UUIDs may
+			concept.ID = fmt.Sprintf("c-%s-%d", record.ID, i+1)
+		}
+
+		// Translations are grouped by language, in order of appearance
+		sections := make(map[string]int)
+		for _, translation := range meaning.Translations {
+			index, ok := sections[translation.LanguageID]
+			if !ok {
+				index = len(concept.LangSecs)
+				sections[translation.LanguageID] = index
+				concept.LangSecs = append(concept.LangSecs, tbxLangSec{Lang: translation.LanguageID})
+			}
+
+			target := tbxTermSec{Term: teiText(translation.Text)}
+			if meaning.PartOfSpeech != "" {
+				target.TermNotes = []tbxValue{{Type: "partOfSpeech", Text: meaning.PartOfSpeech}}
+			}
+			concept.LangSecs[index].TermSecs = append(concept.LangSecs[index].TermSecs, target)
+		}
+
+		if err := w.encoder.EncodeElement(concept, xml.StartElement{Name: xml.Name{Local: "conceptEntry"}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close implements RecordWriter
+func (w *TBXWriter) Close() error {
+	if err := w.start(""); err != nil {
+		return err
+	}
+	for _, name := range []string{"body", "text", "tbx"} {
+		if err := w.encoder.EncodeToken(xml.EndElement{Name: xml.Name{Local: name}}); err != nil {
+			return err
+		}
+	}
+	if err := w.encoder.EncodeToken(xml.CharData("\n")); err != nil {
+		return err
+	}
+	return w.encoder.Flush()
+}
@@ -0,0 +1,405 @@
+package exchange
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	// TEINamespace is the namespace of TEI documents
+	TEINamespace = "http://www.tei-c.org/ns/1.0"
+
+	// xmlNamespace is what encoding/xml reports for the reserved "xml" prefix
+	xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+)
+
+// TEI Lex-0 has no notion of our entry types, so they travel in entry/@type.
+// Unknown types are imported as words
+var teiEntryTypes = map[string]string{
+	"WORD":          "mainEntry",
+	"COMPOUND_WORD": "compound",
+	"PHRASE":        "phrase",
+}
+
+// teiText collects the text of an element, including the text of any
+// markup nested inside it, with whitespace collapsed
+type teiText string
+
+// UnmarshalXML implements xml.Unmarshaler
+func (t *teiText) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var sb strings.Builder
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch tok := tok.(type) {
+		case xml.CharData:
+			sb.Write(tok)
+		case xml.EndElement:
+			if tok.Name == start.Name {
+				*t = teiText(strings.Join(strings.Fields(sb.String()), " "))
+				return nil
+			}
+		}
+	}
+}
+
+// The tei* types mirror the subset of TEI Lex-0 that maps onto entries.
+// They are used for both decoding and encoding
+type teiEntry struct {
+	ID       string       `xml:"http://www.w3.org/XML/1998/namespace id,attr,omitempty"`
+	Lang     string       `xml:"http://www.w3.org/XML/1998/namespace lang,attr,omitempty"`
+	Type     string       `xml:"type,attr,omitempty"`
+	Forms    []teiForm    `xml:"form"`
+	GramGrps []teiGramGrp `xml:"gramGrp"`
+	Etyms    []teiText    `xml:"etym"`
+	Senses   []teiSense   `xml:"sense"`
+}
+
+type teiForm struct {
+	Type  string    `xml:"type,attr,omitempty"`
+	Orths []teiText `xml:"orth"`
+	Prons []teiText `xml:"pron"`
+}
+
+type teiGramGrp struct {
+	Grams []teiGram `xml:"gram"`
+}
+
+type teiGram struct {
+	Type  string `xml:"type,attr,omitempty"`
+	Norm  string `xml:"norm,attr,omitempty"`
+	Value string `xml:",chardata"`
+}
+
+type teiSense struct {
+	N        int          `xml:"n,attr,omitempty"`
+	GramGrps []teiGramGrp `xml:"gramGrp"`
+	Usgs     []teiText    `xml:"usg"` // Usage labels
+	Defs     []teiText    `xml:"def"`
+	Cits     []teiCit     `xml:"cit"`
+	Senses   []teiSense   `xml:"sense"` // Subsenses
+}
+
+type teiCit struct {
+	Type   string    `xml:"type,attr,omitempty"`
+	Lang   string    `xml:"http://www.w3.org/XML/1998/namespace lang,attr,omitempty"`
+	Quotes []teiText `xml:"quote"`
+	Forms  []teiForm `xml:"form"`
+}
+
+// teiPartOfSpeech returns the first part of speech in the groups, if any
+func teiPartOfSpeech(groups []teiGramGrp) string {
+	for _, group := range groups {
+		for _, gram := range group.Grams {
+			if gram.Type != "pos" {
+				continue
+			}
+			if v := strings.TrimSpace(gram.Value); v != "" {
+				return v
+			}
+			if v := strings.TrimSpace(gram.Norm); v != "" {
+				return v
+			}
+		}
+	}
+	return ""
+}
+
+// TEIReader streams records out of a TEI Lex-0 document. Only one entry is
+// held in memory at a time, so documents may be larger than memory
+type TEIReader struct {
+	decoder *xml.Decoder
+	lang    string // Language inherited from TEI, text or body
+}
+
+// NewTEIReader creates a reader for a TEI Lex-0 source
+func NewTEIReader(r io.Reader) *TEIReader {
+	return &TEIReader{decoder: xml.NewDecoder(r)}
+}
+
+// Read implements RecordReader
+func (r *TEIReader) Read() (*Record, error) {
+	for {
+		tok, err := r.decoder.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, io.EOF
+			}
+			return nil, fmt.Errorf("invalid TEI document: %w", err)
+		}
+
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch start.Name.Local {
+		case "TEI", "text", "body":
+			for _, attr := range start.Attr {
+				if attr.Name.Space == xmlNamespace && attr.Name.Local == "lang" {
+					r.lang = attr.Value
+				}
+			}
+		case "teiHeader":
+			if err := r.decoder.Skip(); err != nil {
+				return nil, fmt.Errorf("invalid TEI document: %w", err)
+			}
+		case "entry":
+			line, _ := r.decoder.InputPos()
+			var entry teiEntry
+			if err := r.decoder.DecodeElement(&entry, &start); err != nil {
+				// Malformed XML cannot be resynchronised, so this is fatal
+				return nil, fmt.Errorf("invalid TEI entry at line %d: %w", line, err)
+			}
+			return r.record(&entry, line), nil
+		}
+	}
+}
+
+// record converts a decoded entry into a record
+func (r *TEIReader) record(entry *teiEntry, line int) *Record {
+	record := &Record{
+		Position:         line,
+		Type:             "WORD",
+		SourceLanguageID: strings.ToLower(entry.Lang),
+	}
+	if record.SourceLanguageID == "" {
+		record.SourceLanguageID = strings.ToLower(r.lang)
+	}
+	for entryType, teiType := range teiEntryTypes {
+		if entry.Type == teiType {
+			record.Type = entryType
+		}
+	}
+
+	// The lemma form carries the headword; fall back to the first spelled form
+	if form := teiHeadwordForm(entry.Forms); form != nil {
+		record.Word = string(form.Orths[0])
+		if len(form.Prons) > 0 {
+			record.Pronunciation = string(form.Prons[0])
+		}
+	}
+
+	if len(entry.Etyms) > 0 {
+		record.Etymology = string(entry.Etyms[0])
+	}
+
+	r.addSenses(record, entry.Senses, teiPartOfSpeech(entry.GramGrps))
+	return record
+}
+
+// teiHeadwordForm picks the form holding the headword
+func teiHeadwordForm(forms []teiForm) *teiForm {
+	var fallback *teiForm
+	for i := range forms {
+		if len(forms[i].Orths) == 0 {
+			continue
+		}
+		if forms[i].Type == "lemma" {
+			return &forms[i]
+		}
+		if fallback == nil {
+			fallback = &forms[i]
+		}
+	}
+	return fallback
+}
+
+// addSenses flattens senses and their subsenses into meanings. A sense
+// without its own part of speech inherits the one of its parent
+func (r *TEIReader) addSenses(record *Record, senses []teiSense, partOfSpeech string) {
+	for _, sense := range senses {
+		pos := teiPartOfSpeech(sense.GramGrps)
+		if pos == "" {
+			pos = partOfSpeech
+		}
+
+		meaning := RecordMeaning{PartOfSpeech: strings.ToLower(pos)}
+
+		var defs []string
+		for _, def := range sense.Defs {
+			if def != "" {
+				defs = append(defs, string(def))
+			}
+		}
+		meaning.Description = strings.Join(defs, "; ")
+
+		for _, usg := range sense.Usgs {
+			if usg != "" {
+				meaning.Labels = append(meaning.Labels, strings.ToLower(string(usg)))
+			}
+		}
+
+		for _, cit := range sense.Cits {
+			switch cit.Type {
+			case "example":
+				for _, quote := range cit.Quotes {
+					if quote != "" {
+						meaning.Examples = append(meaning.Examples, string(quote))
+					}
+				}
+			case "translationEquivalent", "translation":
+				if text := teiCitText(cit); text != "" {
+					meaning.Translations = append(meaning.Translations, RecordTranslation{
+						LanguageID: strings.ToLower(cit.Lang),
+						Text:       text,
+					})
+				}
+			}
+		}
+
+		// Senses that only group subsenses carry no meaning of their own
+		if meaning.Description != "" || len(meaning.Examples) > 0 || len(meaning.Translations) > 0 {
+			record.Meanings = append(record.Meanings, meaning)
+		}
+
+		r.addSenses(record, sense.Senses, pos)
+	}
+}
+
+// teiCitText returns the text of a translation equivalent, which TEI Lex-0
+// puts in form/orth; older documents use quote
+func teiCitText(cit teiCit) string {
+	for _, form := range cit.Forms {
+		if len(form.Orths) > 0 && form.Orths[0] != "" {
+			return string(form.Orths[0])
+		}
+	}
+	if len(cit.Quotes) > 0 {
+		return string(cit.Quotes[0])
+	}
+	return ""
+}
+
+// TEIWriter streams records into a TEI Lex-0 document
+type TEIWriter struct {
+	encoder *xml.Encoder
+	title   string
+	started bool
+}
+
+// NewTEIWriter creates a writer producing a TEI Lex-0 document with the given title
+func NewTEIWriter(w io.Writer, title string) *TEIWriter {
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	return &TEIWriter{encoder: encoder, title: title}
+}
+
+// start writes everything that precedes the first entry. It is deferred
+// until there is something to write so that failures before the first
+// entry leave the destination untouched
+func (w *TEIWriter) start() error {
+	if w.started {
+		return nil
+	}
+	w.started = true
+
+	tokens := []xml.Token{
+		xml.ProcInst{Target: "xml", Inst: []byte(`version="1.0" encoding="UTF-8"`)},
+		xml.CharData("\n"),
+		xml.StartElement{
+			Name: xml.Name{Local: "TEI"},
+			Attr: []xml.Attr{{Name: xml.Name{Local: "xmlns"}, Value: TEINamespace}},
+		},
+	}
+	for _, tok := range tokens {
+		if err := w.encoder.EncodeToken(tok); err != nil {
+			return err
+		}
+	}
+
+	header := struct {
+		XMLName  xml.Name `xml:"teiHeader"`
+		FileDesc struct {
+			Title       string `xml:"titleStmt>title"`
+			Publication string `xml:"publicationStmt>p"`
+			Source      string `xml:"sourceDesc>p"`
+		} `xml:"fileDesc"`
+	}{}
+	header.FileDesc.Title = w.title
+	header.FileDesc.Publication = "Exported from TryTraGo"
+	header.FileDesc.Source = "Born digital"
+	if err := w.encoder.Encode(header); err != nil {
+		return err
+	}
+
+	for _, name := range []string{"text", "body"} {
+		if err := w.encoder.EncodeToken(xml.StartElement{Name: xml.Name{Local: name}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Write implements RecordWriter
+func (w *TEIWriter) Write(record *Record) error {
+	if err := w.start(); err != nil {
+		return err
+	}
+
+	entry := teiEntry{
+		Lang: record.SourceLanguageID,
+		Type: teiEntryTypes[record.Type],
+		Forms: []teiForm{{
+			Type:  "lemma",
+			Orths: []teiText{teiText(record.Word)},
+		}},
+	}
+	if record.ID != "" {
+		// xml:id must not start with a digit, which UUIDs may
+		entry.ID = "e-" + record.ID
+	}
+	if record.Pronunciation != "" {
+		entry.Forms[0].Prons = []teiText{teiText(record.Pronunciation)}
+	}
+	if record.Etymology != "" {
+		entry.Etyms = []teiText{teiText(record.Etymology)}
+	}
+
+	for i, meaning := range record.Meanings {
+		sense := teiSense{N: i + 1}
+		if meaning.PartOfSpeech != "" {
+			sense.GramGrps = []teiGramGrp{{Grams: []teiGram{{Type: "pos", Value: meaning.PartOfSpeech}}}}
+		}
+		for _, label := range meaning.Labels {
+			sense.Usgs = append(sense.Usgs, teiText(label))
+		}
+		if meaning.Description != "" {
+			sense.Defs = []teiText{teiText(meaning.Description)}
+		}
+		for _, example := range meaning.Examples {
+			sense.Cits = append(sense.Cits, teiCit{Type: "example", Quotes: []teiText{teiText(example)}})
+		}
+		for _, translation := range meaning.Translations {
+			sense.Cits = append(sense.Cits, teiCit{
+				Type:  "translationEquivalent",
+				Lang:  translation.LanguageID,
+				Forms: []teiForm{{Orths: []teiText{teiText(translation.Text)}}},
+			})
+		}
+		entry.Senses = append(entry.Senses, sense)
+	}
+
+	return w.encoder.EncodeElement(entry, xml.StartElement{Name: xml.Name{Local: "entry"}})
+}
+
+// Close implements RecordWriter
+func (w *TEIWriter) Close() error {
+	if err := w.start(); err != nil {
+		return err
+	}
+	for _, name := range []string{"body", "text", "TEI"} {
+		if err := w.encoder.EncodeToken(xml.EndElement{Name: xml.Name{Local: name}}); err != nil {
+			return err
+		}
+	}
+	if err := w.encoder.EncodeToken(xml.CharData("\n")); err != nil {
+		return err
+	}
+	return w.encoder.Flush()
+}
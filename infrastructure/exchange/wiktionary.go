@@ -0,0 +1,198 @@
+package exchange
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// Attribution recorded on entries imported from Wiktionary. Wiktionary text
+// is available under CC BY-SA, which requires crediting the source
+const (
+	WiktionarySource  = "Wiktionary (kaikki.org)"
+	WiktionaryLicense = "CC BY-SA 4.0"
+)
+
+// wiktionaryPartsOfSpeech maps the part of speech codes of the kaikki.org
+// extraction to the names used for parts of speech. Codes not listed are
+// kept as they are
+var wiktionaryPartsOfSpeech = map[string]string{
+	"adj":         "adjective",
+	"adv":         "adverb",
+	"intj":        "interjection",
+	"conj":        "conjunction",
+	"prep":        "preposition",
+	"postp":       "postposition",
+	"pron":        "pronoun",
+	"num":         "numeral",
+	"det":         "determiner",
+	"name":        "proper noun",
+	"abbrev":      "abbreviation",
+	"prep_phrase": "prepositional phrase",
+}
+
+// wiktionaryPhrasePOS lists the parts of speech of multi-word entries
+var wiktionaryPhrasePOS = map[string]bool{
+	"phrase":      true,
+	"proverb":     true,
+	"prep_phrase": true,
+}
+
+// twoLetterLanguage matches the language codes translations can be stored
+// under. Wiktionary also uses three-letter codes, which have nowhere to go
+var twoLetterLanguage = regexp.MustCompile(`^[a-z]{2}(-[a-z0-9]{1,2})?$`)
+
+// wiktionaryEntry is one line of a kaikki.org JSONL file, reduced to the
+// fields that map onto entries
+type wiktionaryEntry struct {
+	Word          string `json:"word"`
+	POS           string `json:"pos"`
+	LangCode      string `json:"lang_code"`
+	EtymologyText string `json:"etymology_text"`
+	Senses        []struct {
+		Glosses  []string `json:"glosses"`
+		Examples []struct {
+			Text string `json:"text"`
+		} `json:"examples"`
+	} `json:"senses"`
+	Sounds []struct {
+		IPA string `json:"ipa"`
+	} `json:"sounds"`
+	Translations []struct {
+		Code  string `json:"code"`
+		Word  string `json:"word"`
+		Sense string `json:"sense"`
+	} `json:"translations"`
+}
+
+// WiktionaryReader streams records out of a kaikki.org JSONL extraction of
+// Wiktionary. Lines in other languages and lines without glosses, such as
+// redirects, are passed over
+type WiktionaryReader struct {
+	reader     *bufio.Reader
+	languageID string
+	line       int
+}
+
+// NewWiktionaryReader creates a reader importing the entries of one language
+func NewWiktionaryReader(r io.Reader, languageID string) *WiktionaryReader {
+	return &WiktionaryReader{
+		reader:     bufio.NewReaderSize(r, 1<<20),
+		languageID: strings.ToLower(languageID),
+	}
+}
+
+// Read implements RecordReader
+func (r *WiktionaryReader) Read() (*Record, error) {
+	for {
+		// Lines for well-developed words run to hundreds of kilobytes, so
+		// they are read whole rather than through a size-limited scanner
+		line, err := r.reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("failed to read Wiktionary data: %w", err)
+		}
+		if len(line) == 0 && errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		r.line++
+
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		var entry wiktionaryEntry
+		if jsonErr := json.Unmarshal(line, &entry); jsonErr != nil {
+			return nil, &RowError{Position: r.line, Message: "invalid JSON"}
+		}
+		if entry.LangCode != r.languageID || entry.Word == "" {
+			continue
+		}
+
+		if record := r.record(&entry); record != nil {
+			return record, nil
+		}
+	}
+}
+
+// record converts a Wiktionary entry, or returns nil if it has no glosses
+func (r *WiktionaryReader) record(entry *wiktionaryEntry) *Record {
+	record := &Record{
+		Position:         r.line,
+		Word:             entry.Word,
+		Type:             "WORD",
+		SourceLanguageID: r.languageID,
+		Etymology:        strings.TrimSpace(entry.EtymologyText),
+		Source:           WiktionarySource,
+		License:          WiktionaryLicense,
+	}
+	if wiktionaryPhrasePOS[entry.POS] || strings.Contains(strings.TrimSpace(entry.Word), " ") {
+		record.Type = "PHRASE"
+	}
+
+	for _, sound := range entry.Sounds {
+		if sound.IPA != "" {
+			record.Pronunciation = sound.IPA
+			break
+		}
+	}
+
+	pos := entry.POS
+	if name, ok := wiktionaryPartsOfSpeech[pos]; ok {
+		pos = name
+	}
+
+	var glosses []string // Gloss of each meaning, for placing translations
+	for _, sense := range entry.Senses {
+		if len(sense.Glosses) == 0 {
+			continue
+		}
+
+		// Subsenses repeat the glosses of their parents first
+		meaning := RecordMeaning{
+			PartOfSpeech: pos,
+			Description:  sense.Glosses[len(sense.Glosses)-1],
+		}
+		for _, example := range sense.Examples {
+			if text := strings.TrimSpace(example.Text); text != "" {
+				meaning.Examples = append(meaning.Examples, text)
+			}
+		}
+
+		record.Meanings = append(record.Meanings, meaning)
+		glosses = append(glosses, strings.ToLower(strings.Join(sense.Glosses, " ")))
+	}
+	if len(record.Meanings) == 0 {
+		return nil
+	}
+
+	for _, t := range entry.Translations {
+		if t.Word == "" || !twoLetterLanguage.MatchString(t.Code) {
+			continue
+		}
+
+		// Translation tables are headed by a short sense label; put each
+		// translation with the meaning whose gloss mentions it
+		target := 0
+		if label := strings.ToLower(strings.TrimSpace(t.Sense)); label != "" {
+			for i, gloss := range glosses {
+				if strings.Contains(gloss, label) {
+					target = i
+					break
+				}
+			}
+		}
+
+		record.Meanings[target].Translations = append(record.Meanings[target].Translations, RecordTranslation{
+			LanguageID: t.Code,
+			Text:       t.Word,
+		})
+	}
+
+	return record
+}
@@ -0,0 +1,117 @@
+package migration
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Dialects lists the databases with a migration set of their own
+var Dialects = []string{"postgres", "mysql", "sqlite"}
+
+var (
+	// migrationFile matches migration and rollback filenames, capturing the version
+	migrationFile = regexp.MustCompile(`^[VR](\d+)__.*\.sql$`)
+
+	// unsafeName matches the runs of characters not kept in migration names
+	unsafeName = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// CreateMigration writes an empty migration and its rollback file numbered
+// after the highest version in dir. When dir has a subdirectory per dialect
+// the pair is created in each of them, with the same version. It returns the
+// paths of the files written
+func CreateMigration(dir, name string) ([]string, error) {
+	name = strings.Trim(unsafeName.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return nil, fmt.Errorf("invalid migration name")
+	}
+
+	dirs := []string{dir}
+	var dialectDirs []string
+	for _, dialect := range Dialects {
+		if sub := DialectDir(dir, dialect); sub != dir {
+			dialectDirs = append(dialectDirs, sub)
+		}
+	}
+	if len(dialectDirs) > 0 {
+		dirs = dialectDirs
+	}
+
+	// Every set shares the version numbers, so the next one is above them all
+	var latest int64
+	for _, d := range dirs {
+		version, err := latestVersion(d)
+		if err != nil {
+			return nil, err
+		}
+		if version > latest {
+			latest = version
+		}
+	}
+	version := latest + 1
+
+	description := strings.ReplaceAll(name, "_", " ")
+	var paths []string
+	for _, d := range dirs {
+		files := []struct {
+			name    string
+			content string
+		}{
+			{
+				name:    fmt.Sprintf("V%d__%s.sql", version, name),
+				content: fmt.Sprintf("-- %s\n\n", strings.ToUpper(description[:1])+description[1:]),
+			},
+			{
+				name:    fmt.Sprintf("R%d__rollback_%s.sql", version, name),
+				content: fmt.Sprintf("-- R%d__rollback_%s.sql\n-- Rollback script for %s\n\n", version, name, description),
+			},
+		}
+
+		for _, file := range files {
+			path := filepath.Join(d, file.name)
+			// O_EXCL keeps an existing file from being overwritten
+			f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+			if err != nil {
+				return paths, fmt.Errorf("failed to create migration file: %w", err)
+			}
+			_, err = f.WriteString(file.content)
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return paths, fmt.Errorf("failed to write migration file %s: %w", path, err)
+			}
+			paths = append(paths, path)
+		}
+	}
+
+	return paths, nil
+}
+
+// latestVersion returns the highest migration version in dir, 0 if it has none
+func latestVersion(dir string) (int64, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read migrations directory: %w", err)
+	}
+
+	var latest int64
+	for _, entry := range entries {
+		match := migrationFile.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		if version > latest {
+			latest = version
+		}
+	}
+	return latest, nil
+}
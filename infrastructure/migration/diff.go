@@ -0,0 +1,243 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+// ColumnDiff is a column present on one side of a schema comparison only
+type ColumnDiff struct {
+	Table  string
+	Column string
+	Type   string // Type declared by the model, or found in the database
+}
+
+// IndexDiff is an index declared by a model that the database lacks
+type IndexDiff struct {
+	Table   string
+	Name    string
+	Columns []string
+	Unique  bool
+}
+
+// SchemaDiff lists the differences between the live database schema and the
+// GORM models
+type SchemaDiff struct {
+	MissingTables    []string     // Model tables absent from the database
+	UnmodeledTables  []string     // Database tables no model maps
+	MissingColumns   []ColumnDiff // Model fields without a database column
+	UnmodeledColumns []ColumnDiff // Database columns no model field maps
+	MissingIndexes   []IndexDiff  // Model indexes the database has no equivalent of
+}
+
+// Empty reports whether the database matches the models
+func (d *SchemaDiff) Empty() bool {
+	return len(d.MissingTables) == 0 && len(d.UnmodeledTables) == 0 &&
+		len(d.MissingColumns) == 0 && len(d.UnmodeledColumns) == 0 &&
+		len(d.MissingIndexes) == 0
+}
+
+// diffSchemas caches the models parsed by Diff
+var diffSchemas sync.Map
+
+// Diff compares the database schema with the given GORM models. An index
+// counts as present when the database has one on the same columns, whatever
+// its name; a non-unique one may also be the leading columns of a wider index
+func (m *Migrator) Diff(ctx context.Context, models []interface{}) (*SchemaDiff, error) {
+	// The SQLite driver forces debug logging when reading indexes
+	db := m.db.Session(&gorm.Session{Context: ctx, Logger: logger.Discard})
+	dbMigrator := db.Migrator()
+
+	tables, err := dbMigrator.GetTables()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+	live := make(map[string]bool, len(tables))
+	for _, table := range tables {
+		live[table] = true
+	}
+
+	diff := &SchemaDiff{}
+	modeled := make(map[string]bool, len(models))
+
+	for _, model := range models {
+		s, err := schema.Parse(model, &diffSchemas, db.NamingStrategy)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse model %T: %w", model, err)
+		}
+		modeled[s.Table] = true
+
+		if !live[s.Table] {
+			diff.MissingTables = append(diff.MissingTables, s.Table)
+			continue
+		}
+
+		columnTypes, err := dbMigrator.ColumnTypes(s.Table)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read columns of %s: %w", s.Table, err)
+		}
+		columns := make(map[string]gorm.ColumnType, len(columnTypes))
+		for _, column := range columnTypes {
+			columns[column.Name()] = column
+		}
+
+		fields := make(map[string]bool, len(s.DBNames))
+		for _, name := range s.DBNames {
+			fields[name] = true
+			if _, ok := columns[name]; !ok {
+				diff.MissingColumns = append(diff.MissingColumns, ColumnDiff{
+					Table:  s.Table,
+					Column: name,
+					Type:   string(s.FieldsByDBName[name].DataType),
+				})
+			}
+		}
+		for _, column := range columnTypes {
+			if !fields[column.Name()] {
+				diff.UnmodeledColumns = append(diff.UnmodeledColumns, ColumnDiff{
+					Table:  s.Table,
+					Column: column.Name(),
+					Type:   column.DatabaseTypeName(),
+				})
+			}
+		}
+
+		indexes, err := m.liveIndexes(db, s.Table)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read indexes of %s: %w", s.Table, err)
+		}
+		diff.MissingIndexes = append(diff.MissingIndexes, missingIndexes(s, indexes, columns)...)
+	}
+
+	for _, table := range tables {
+		if !modeled[table] && !internalTable(table) {
+			diff.UnmodeledTables = append(diff.UnmodeledTables, table)
+		}
+	}
+	sort.Strings(diff.UnmodeledTables)
+
+	return diff, nil
+}
+
+// liveIndex is an index found in the database
+type liveIndex struct {
+	columns []string
+	unique  bool
+}
+
+// liveIndexes reads the indexes of a table. On SQLite they are read directly:
+// the driver fails on indexes over expressions, whose columns have no name
+func (m *Migrator) liveIndexes(db *gorm.DB, table string) ([]liveIndex, error) {
+	var indexes []liveIndex
+
+	if m.Dialect() != "sqlite" {
+		found, err := db.Migrator().GetIndexes(table)
+		if err != nil {
+			return nil, err
+		}
+		for _, index := range found {
+			unique, _ := index.Unique()
+			indexes = append(indexes, liveIndex{columns: index.Columns(), unique: unique})
+		}
+		return indexes, nil
+	}
+
+	var list []struct {
+		Name   string
+		Unique bool
+	}
+	if err := db.Raw("SELECT name, \"unique\" FROM pragma_index_list(?)", table).Scan(&list).Error; err != nil {
+		return nil, err
+	}
+	for _, index := range list {
+		var names []sql.NullString
+		if err := db.Raw("SELECT name FROM pragma_index_info(?) ORDER BY seqno", index.Name).Scan(&names).Error; err != nil {
+			return nil, err
+		}
+		columns := make([]string, len(names))
+		for i, name := range names {
+			columns[i] = name.String // Empty for an expression
+		}
+		indexes = append(indexes, liveIndex{columns: columns, unique: index.Unique})
+	}
+	return indexes, nil
+}
+
+// missingIndexes returns the model's indexes the database has no equivalent of
+func missingIndexes(s *schema.Schema, indexes []liveIndex, columns map[string]gorm.ColumnType) []IndexDiff {
+	var missing []IndexDiff
+
+	names := make([]string, 0)
+	declared := s.ParseIndexes()
+	for name := range declared {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		index := declared[name]
+		unique := index.Class == "UNIQUE"
+
+		var want []string
+		for _, option := range index.Fields {
+			if option.Field != nil {
+				want = append(want, option.DBName)
+			}
+		}
+		if len(want) == 0 {
+			continue
+		}
+
+		if !hasIndex(indexes, want, unique) && !uniqueColumn(columns, want, unique) {
+			missing = append(missing, IndexDiff{Table: s.Table, Name: name, Columns: want, Unique: unique})
+		}
+	}
+
+	return missing
+}
+
+// hasIndex reports whether one of the indexes covers the columns
+func hasIndex(indexes []liveIndex, want []string, unique bool) bool {
+	for _, index := range indexes {
+		have := index.columns
+		if len(have) < len(want) || (unique && (len(have) != len(want) || !index.unique)) {
+			continue
+		}
+		if strings.Join(have[:len(want)], ",") == strings.Join(want, ",") {
+			return true
+		}
+	}
+	return false
+}
+
+// uniqueColumn reports whether a single column index is implied by a UNIQUE
+// or PRIMARY KEY constraint, which some drivers do not list as an index
+func uniqueColumn(columns map[string]gorm.ColumnType, want []string, unique bool) bool {
+	if len(want) != 1 {
+		return false
+	}
+	column, ok := columns[want[0]]
+	if !ok {
+		return false
+	}
+	if primary, ok := column.PrimaryKey(); ok && primary {
+		return true
+	}
+	isUnique, ok := column.Unique()
+	return ok && isUnique && unique
+}
+
+// internalTable reports whether a table belongs to the database or to the
+// migrator rather than to the application
+func internalTable(table string) bool {
+	return strings.HasPrefix(table, "sqlite_") ||
+		table == "migration_records" || table == "migration_locks"
+}
@@ -3,7 +3,9 @@ package migration
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"io/fs"
 	"time"
 
 	"github.com/valpere/trytrago/domain/database/repository"
@@ -13,9 +15,11 @@ import (
 
 // Helper provides utility functions for database migrations
 type Helper struct {
-	repo   repository.Repository
-	db     *gorm.DB
-	logger logging.Logger
+	repo        repository.Repository
+	db          *gorm.DB
+	logger      logging.Logger
+	lockTimeout time.Duration
+	fsys        fs.FS
 }
 
 // NewHelper creates a new migration helper
@@ -26,18 +30,41 @@ func NewHelper(repo repository.Repository, logger logging.Logger) (*Helper, erro
 	}
 
 	return &Helper{
-		repo:   repo,
-		db:     db,
-		logger: logger.With(logging.String("component", "migration_helper")),
+		repo:        repo,
+		db:          db,
+		logger:      logger.With(logging.String("component", "migration_helper")),
+		lockTimeout: DefaultLockTimeout,
 	}, nil
 }
 
-// EnsureMigrationsRun checks if migrations have been applied and runs them if necessary
+// SetLockTimeout sets how long EnsureMigrationsRun waits for another instance
+// applying migrations
+func (h *Helper) SetLockTimeout(timeout time.Duration) {
+	h.lockTimeout = timeout
+}
+
+// SetFS sets the migration files EnsureMigrationsRun reads, normally the ones
+// embedded in the binary
+func (h *Helper) SetFS(fsys fs.FS) {
+	h.fsys = fsys
+}
+
+// EnsureMigrationsRun checks if migrations have been applied and runs them if
+// necessary. The migrations come from the files given to SetFS; a non-empty
+// migrationsDir overrides them with a directory on disk
 func (h *Helper) EnsureMigrationsRun(ctx context.Context, migrationsDir string, autoApply bool) error {
 	h.logger.Info("checking migration status")
 
 	// Create migrator
 	migrator := NewMigrator(h.repo, h.logger)
+	migrator.SetLockTimeout(h.lockTimeout)
+	if migrationsDir == "" {
+		if h.fsys == nil {
+			return errors.New("no migration files: set them with SetFS or give a migrations directory")
+		}
+		migrator.SetFS(h.fsys)
+		migrationsDir = "."
+	}
 
 	// Ensure the migration table exists
 	if err := migrator.EnsureMigrationTable(); err != nil {
@@ -68,6 +95,8 @@ func (h *Helper) EnsureMigrationsRun(ctx context.Context, migrationsDir string,
 	// Run migrations if needed and auto-apply is enabled
 	if pendingCount > 0 {
 		if autoApply {
+			// Migrate takes the migration lock and skips whatever another
+			// instance applied while this one waited
 			h.logger.Info("applying pending migrations", logging.Int("count", pendingCount))
 			if err := migrator.Migrate(ctx, migrationsDir); err != nil {
 				return fmt.Errorf("failed to apply migrations: %w", err)
@@ -0,0 +1,379 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mattn/go-sqlite3"
+	"github.com/valpere/trytrago/domain/logging"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// DefaultLockTimeout is how long a migrator waits for another one to finish
+	DefaultLockTimeout = 2 * time.Minute
+
+	// lockPollInterval is how often a waiting migrator retries
+	lockPollInterval = time.Second
+
+	// lockLease is how long an SQLite lock row stays valid without renewal,
+	// so a crashed process does not block migrations for good
+	lockLease = time.Minute
+
+	// advisoryLockKey identifies the migration lock among PostgreSQL
+	// advisory locks; it is arbitrary but shared by all instances
+	advisoryLockKey = 7468253
+)
+
+// ErrLockTimeout is returned when the migration lock is not acquired in time
+var ErrLockTimeout = errors.New("timed out waiting for the migration lock")
+
+// MigrationLock is the lock row used on SQLite, which has no named locks
+type MigrationLock struct {
+	Name       string `gorm:"primaryKey;size:50"`
+	Holder     string `gorm:"size:255;not null"`
+	AcquiredAt time.Time
+	ExpiresAt  time.Time
+}
+
+// migrationLock is a lock held by one migrator across processes
+type migrationLock interface {
+	// tryAcquire takes the lock if it is free. Otherwise it describes who
+	// holds it
+	tryAcquire(ctx context.Context) (holder string, err error)
+	release(ctx context.Context) error
+}
+
+// SetLockTimeout sets how long Migrate and MigrateTo wait for the migration
+// lock held by another process
+func (m *Migrator) SetLockTimeout(timeout time.Duration) {
+	m.lockTimeout = timeout
+}
+
+// Lock acquires the migration lock, waiting for the current holder up to the
+// lock timeout. The returned function releases it
+func (m *Migrator) Lock(ctx context.Context) (func(), error) {
+	lock, err := m.newLock()
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := m.lockTimeout
+	if timeout <= 0 {
+		timeout = DefaultLockTimeout
+	}
+
+	if err := m.waitForLock(ctx, lock, timeout); err != nil {
+		// Give back the connection a session lock was tried on
+		lock.release(context.Background())
+		return nil, err
+	}
+
+	m.logger.Info("migration lock acquired", logging.String("holder", m.lockHolder))
+	return func() {
+		// The migration context may have expired by now
+		if err := lock.release(context.Background()); err != nil {
+			m.logger.Error("failed to release migration lock", logging.Error(err))
+			return
+		}
+		m.logger.Info("migration lock released", logging.String("holder", m.lockHolder))
+	}, nil
+}
+
+// waitForLock retries the lock until it is acquired or the timeout passes,
+// logging each new holder it waits for
+func (m *Migrator) waitForLock(ctx context.Context, lock migrationLock, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	lastHolder := ""
+	for {
+		holder, err := lock.tryAcquire(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+		if holder == "" {
+			return nil
+		}
+
+		if holder != lastHolder {
+			m.logger.Warn("waiting for migration lock held by another process",
+				logging.String("holder", holder),
+				logging.Duration("timeout", timeout),
+			)
+			lastHolder = holder
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%w after %s, held by %s", ErrLockTimeout, timeout, holder)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(lockPollInterval):
+		}
+	}
+}
+
+// newLock picks the lock implementation for the dialect
+func (m *Migrator) newLock() (migrationLock, error) {
+	switch m.Dialect() {
+	case "postgres":
+		return &advisoryLock{db: m.db, holder: m.lockHolder}, nil
+	case "mysql":
+		return &namedLock{db: m.db}, nil
+	case "sqlite":
+		return &leaseLock{db: m.db, holder: m.lockHolder, logger: m.logger}, nil
+	default:
+		return nil, fmt.Errorf("no migration lock for dialect %s", m.Dialect())
+	}
+}
+
+// lockHolderName identifies this process to migrators waiting for the lock
+func lockHolderName() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s:%d:%s", host, os.Getpid(), uuid.NewString()[:8])
+}
+
+// pinConn takes a connection out of the pool; session-level locks belong to
+// the connection that took them
+func pinConn(ctx context.Context, db *gorm.DB) (*sql.Conn, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	return sqlDB.Conn(ctx)
+}
+
+// advisoryLock is a PostgreSQL session advisory lock. The holder's name is
+// published as the connection's application_name for waiters to report
+type advisoryLock struct {
+	db     *gorm.DB
+	holder string
+	conn   *sql.Conn
+}
+
+func (l *advisoryLock) tryAcquire(ctx context.Context) (string, error) {
+	if l.conn == nil {
+		conn, err := pinConn(ctx, l.db)
+		if err != nil {
+			return "", err
+		}
+		if _, err := conn.ExecContext(ctx, "SELECT set_config('application_name', $1, false)", "trytrago migrate "+l.holder); err != nil {
+			conn.Close()
+			return "", err
+		}
+		l.conn = conn
+	}
+
+	var acquired bool
+	if err := l.conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", advisoryLockKey).Scan(&acquired); err != nil {
+		return "", err
+	}
+	if acquired {
+		return "", nil
+	}
+
+	var (
+		pid     int64
+		name    string
+		address sql.NullString
+		since   sql.NullTime
+	)
+	err := l.conn.QueryRowContext(ctx, `
+		SELECT a.pid, a.application_name, host(a.client_addr), a.backend_start
+		FROM pg_locks l
+		JOIN pg_stat_activity a ON a.pid = l.pid
+		WHERE l.locktype = 'advisory' AND l.granted
+		AND l.classid = 0 AND l.objid = $1 AND l.objsubid = 1`, advisoryLockKey).
+		Scan(&pid, &name, &address, &since)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		// Released in the meantime
+		return "unknown", nil
+	case err != nil:
+		return "", err
+	}
+	return fmt.Sprintf("%s (backend pid %d, client %s, connected %s)",
+		name, pid, address.String, since.Time.Format(time.RFC3339)), nil
+}
+
+func (l *advisoryLock) release(ctx context.Context) error {
+	if l.conn == nil {
+		return nil
+	}
+	defer func() {
+		l.conn.Close()
+		l.conn = nil
+	}()
+
+	if _, err := l.conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", advisoryLockKey); err != nil {
+		return err
+	}
+	_, err := l.conn.ExecContext(ctx, "RESET application_name")
+	return err
+}
+
+// namedLock is a MySQL user-level lock, named after the database since such
+// locks are server-wide
+type namedLock struct {
+	db   *gorm.DB
+	conn *sql.Conn
+}
+
+func (l *namedLock) tryAcquire(ctx context.Context) (string, error) {
+	if l.conn == nil {
+		conn, err := pinConn(ctx, l.db)
+		if err != nil {
+			return "", err
+		}
+		l.conn = conn
+	}
+
+	var acquired sql.NullInt64
+	if err := l.conn.QueryRowContext(ctx, "SELECT GET_LOCK(CONCAT(DATABASE(), '.migrations'), 0)").Scan(&acquired); err != nil {
+		return "", err
+	}
+	if acquired.Int64 == 1 {
+		return "", nil
+	}
+
+	var id sql.NullInt64
+	if err := l.conn.QueryRowContext(ctx, "SELECT IS_USED_LOCK(CONCAT(DATABASE(), '.migrations'))").Scan(&id); err != nil {
+		return "", err
+	}
+	if !id.Valid {
+		return "unknown", nil
+	}
+
+	// Other sessions are only listed with the PROCESS privilege
+	var user, host sql.NullString
+	err := l.conn.QueryRowContext(ctx, "SELECT USER, HOST FROM information_schema.PROCESSLIST WHERE ID = ?", id.Int64).Scan(&user, &host)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return "", err
+	}
+	if !user.Valid {
+		return fmt.Sprintf("connection %d", id.Int64), nil
+	}
+	return fmt.Sprintf("connection %d (%s@%s)", id.Int64, user.String, host.String), nil
+}
+
+func (l *namedLock) release(ctx context.Context) error {
+	if l.conn == nil {
+		return nil
+	}
+	defer func() {
+		l.conn.Close()
+		l.conn = nil
+	}()
+
+	_, err := l.conn.ExecContext(ctx, "SELECT RELEASE_LOCK(CONCAT(DATABASE(), '.migrations'))")
+	return err
+}
+
+// leaseLock is a row in migration_locks that expires unless renewed. It is
+// renewed in the background while held
+type leaseLock struct {
+	db     *gorm.DB
+	holder string
+	logger logging.Logger
+
+	stop chan struct{}
+	done sync.WaitGroup
+}
+
+// leaseLockName is the key of the migration lock row
+const leaseLockName = "migrations"
+
+func (l *leaseLock) tryAcquire(ctx context.Context) (string, error) {
+	db := l.db.WithContext(ctx)
+	if !db.Migrator().HasTable(&MigrationLock{}) {
+		if err := db.Migrator().CreateTable(&MigrationLock{}); err != nil {
+			return "", fmt.Errorf("failed to create migration lock table: %w", err)
+		}
+	}
+
+	// Take the row unless someone else holds an unexpired lease
+	now := time.Now().UTC()
+	result := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"holder", "acquired_at", "expires_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: "migration_locks.expires_at < ? OR migration_locks.holder = ?", Vars: []interface{}{now, l.holder}},
+		}},
+	}).Create(&MigrationLock{
+		Name:       leaseLockName,
+		Holder:     l.holder,
+		AcquiredAt: now,
+		ExpiresAt:  now.Add(lockLease),
+	})
+	if result.Error != nil {
+		// Another process is writing, most likely the holder migrating
+		var sqliteErr sqlite3.Error
+		if errors.As(result.Error, &sqliteErr) && sqliteErr.Code == sqlite3.ErrBusy {
+			return "another process writing to the database", nil
+		}
+		return "", result.Error
+	}
+	if result.RowsAffected == 1 {
+		l.renew()
+		return "", nil
+	}
+
+	var current MigrationLock
+	if err := db.First(&current, "name = ?", leaseLockName).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "unknown", nil
+		}
+		return "", err
+	}
+	return fmt.Sprintf("%s (since %s, lease until %s)", current.Holder,
+		current.AcquiredAt.Format(time.RFC3339), current.ExpiresAt.Format(time.RFC3339)), nil
+}
+
+// renew extends the lease periodically until the lock is released
+func (l *leaseLock) renew() {
+	l.stop = make(chan struct{})
+	l.done.Add(1)
+	go func() {
+		defer l.done.Done()
+		ticker := time.NewTicker(lockLease / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-l.stop:
+				return
+			case <-ticker.C:
+				result := l.db.Model(&MigrationLock{}).
+					Where("name = ? AND holder = ?", leaseLockName, l.holder).
+					Update("expires_at", time.Now().UTC().Add(lockLease))
+				if result.Error != nil {
+					l.logger.Warn("failed to renew migration lock", logging.Error(result.Error))
+				} else if result.RowsAffected == 0 {
+					l.logger.Error("migration lock lost to another process", logging.String("holder", l.holder))
+				}
+			}
+		}
+	}()
+}
+
+func (l *leaseLock) release(ctx context.Context) error {
+	if l.stop != nil {
+		close(l.stop)
+		l.done.Wait()
+		l.stop = nil
+	}
+
+	return l.db.WithContext(ctx).
+		Where("name = ? AND holder = ?", leaseLockName, l.holder).
+		Delete(&MigrationLock{}).Error
+}
@@ -2,8 +2,11 @@ package migration
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"sort"
@@ -12,6 +15,7 @@ import (
 	"time"
 
 	"github.com/valpere/trytrago/domain/database"
+	"github.com/valpere/trytrago/domain/model"
 	"github.com/valpere/trytrago/domain/database/repository"
 	"github.com/valpere/trytrago/domain/logging"
 	"gorm.io/gorm"
@@ -22,6 +26,8 @@ type Migration struct {
 	Version     int64
 	Description string
 	SQL         string
+	RollbackSQL string // Content of the matching R file, empty when there is none
+	Checksum    string // Hex SHA-256 of the migration file
 	Timestamp   time.Time
 }
 
@@ -29,26 +35,40 @@ type Migration struct {
 type MigrationRecord struct {
 	Version     int64 `gorm:"primaryKey"`
 	Description string
+	Checksum    string `gorm:"size:64"` // Empty for migrations applied before checksums were kept
 	AppliedAt   time.Time
 }
 
 // Migrator handles database migrations
 type Migrator struct {
-	db     *gorm.DB
-	repo   repository.Repository
-	logger logging.Logger
+	db           *gorm.DB
+	repo         repository.Repository
+	logger       logging.Logger
+	checksumMode ChecksumMode
+	lockTimeout  time.Duration
+	lockHolder   string
+	fsys         fs.FS
 }
 
 // NewMigrator creates a new Migrator instance
 func NewMigrator(repo repository.Repository, logger logging.Logger) *Migrator {
 	db, _ := repo.GetDB()
 	return &Migrator{
-		db:     db,
-		repo:   repo,
-		logger: logger.With(logging.String("component", "migrator")),
+		db:           db,
+		repo:         repo,
+		logger:       logger.With(logging.String("component", "migrator")),
+		checksumMode: ChecksumRefuse,
+		lockTimeout:  DefaultLockTimeout,
+		lockHolder:   lockHolderName(),
 	}
 }
 
+// SetChecksumMode sets how Migrate reacts to applied migrations whose file
+// has changed since
+func (m *Migrator) SetChecksumMode(mode ChecksumMode) {
+	m.checksumMode = mode
+}
+
 // EnsureMigrationTable creates the migrations table if it doesn't exist
 func (m *Migrator) EnsureMigrationTable() error {
 	if !m.db.Migrator().HasTable(&MigrationRecord{}) {
@@ -56,33 +76,105 @@ func (m *Migrator) EnsureMigrationTable() error {
 		if err != nil {
 			return fmt.Errorf("failed to create migrations table: %w", err)
 		}
+		return nil
+	}
+
+	// Tables created before checksums were kept lack the column
+	if !m.db.Migrator().HasColumn(&MigrationRecord{}, "Checksum") {
+		if err := m.db.Migrator().AddColumn(&MigrationRecord{}, "Checksum"); err != nil {
+			return fmt.Errorf("failed to add checksum column to migrations table: %w", err)
+		}
 	}
 	return nil
 }
 
-// LoadMigrationsFromDir loads migration files from a directory
+// Dialect returns the SQL dialect of the database, as named by its GORM
+// driver: postgres, mysql or sqlite
+func (m *Migrator) Dialect() string {
+	return m.db.Dialector.Name()
+}
+
+// DialectDir returns the directory holding the migrations for a dialect: the
+// subdirectory of dir named after it, such as migrations/mysql, or dir itself
+// when it has no such subdirectory
+func DialectDir(dir, dialect string) string {
+	sub := filepath.Join(dir, dialect)
+	if info, err := os.Stat(sub); err == nil && info.IsDir() {
+		return sub
+	}
+	return dir
+}
+
+// DialectFS is DialectDir for a file system: the subdirectory of fsys named
+// after the dialect, or fsys itself
+func DialectFS(fsys fs.FS, dialect string) fs.FS {
+	if info, err := fs.Stat(fsys, dialect); err == nil && info.IsDir() {
+		if sub, err := fs.Sub(fsys, dialect); err == nil {
+			return sub
+		}
+	}
+	return fsys
+}
+
+// SetFS makes the migrator read migration files from fsys, such as the ones
+// embedded in the binary, instead of the local file system. The directories
+// given to its methods are then paths within fsys, "." being its root
+func (m *Migrator) SetFS(fsys fs.FS) {
+	m.fsys = fsys
+}
+
+// openDir returns the migration files in dir for the database's dialect
+func (m *Migrator) openDir(dir string) (fs.FS, error) {
+	if m.fsys == nil {
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			return nil, fmt.Errorf("migrations directory does not exist: %s", dir)
+		}
+		return DialectFS(os.DirFS(dir), m.Dialect()), nil
+	}
+
+	sub, err := fs.Sub(m.fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("invalid migrations directory %s: %w", dir, err)
+	}
+	return DialectFS(sub, m.Dialect()), nil
+}
+
+// LoadMigrationsFromDir loads migration files from a directory, or from its
+// subdirectory for the database's dialect
 func (m *Migrator) LoadMigrationsFromDir(dir string) ([]Migration, error) {
-	// Ensure directory exists
-	if _, err := os.Stat(dir); os.IsNotExist(err) {
-		return nil, fmt.Errorf("migrations directory does not exist: %s", dir)
+	source := "filesystem"
+	if m.fsys != nil {
+		source = "embedded"
+	}
+	m.logger.Debug("loading migrations",
+		logging.String("dialect", m.Dialect()),
+		logging.String("source", source),
+		logging.String("path", dir),
+	)
+
+	migrationFS, err := m.openDir(dir)
+	if err != nil {
+		return nil, err
 	}
 
 	// Read migration files
-	files, err := os.ReadDir(dir)
+	files, err := fs.ReadDir(migrationFS, ".")
 	if err != nil {
 		return nil, fmt.Errorf("failed to read migrations directory: %w", err)
 	}
 
 	var migrations []Migration
+	rollbacks := make(map[int64]string)
 
 	for _, file := range files {
 		if file.IsDir() || !strings.HasSuffix(strings.ToLower(file.Name()), ".sql") {
 			continue
 		}
 
-		// Parse migration filename: V{version}_{description}.sql
+		// Parse migration filename: V{version}_{description}.sql, or
+		// R{version}_{description}.sql for its rollback
 		filename := file.Name()
-		if !strings.HasPrefix(filename, "V") {
+		if !strings.HasPrefix(filename, "V") && !strings.HasPrefix(filename, "R") {
 			continue
 		}
 
@@ -101,20 +193,26 @@ func (m *Migrator) LoadMigrationsFromDir(dir string) ([]Migration, error) {
 			continue
 		}
 
-		description := strings.ReplaceAll(parts[1], "_", " ")
+		description := strings.ReplaceAll(strings.TrimLeft(parts[1], "_"), "_", " ")
 
 		// Read SQL content
-		path := filepath.Join(dir, filename)
-		content, err := os.ReadFile(path)
+		content, err := fs.ReadFile(migrationFS, filename)
 		if err != nil {
 			return nil, fmt.Errorf("failed to read migration file %s: %w", filename, err)
 		}
 
+		if filename[0] == 'R' {
+			rollbacks[version] = string(content)
+			continue
+		}
+
 		// Create migration
+		sum := sha256.Sum256(content)
 		migrations = append(migrations, Migration{
 			Version:     version,
 			Description: description,
 			SQL:         string(content),
+			Checksum:    hex.EncodeToString(sum[:]),
 			Timestamp:   time.Now(),
 		})
 	}
@@ -124,6 +222,10 @@ func (m *Migrator) LoadMigrationsFromDir(dir string) ([]Migration, error) {
 		return migrations[i].Version < migrations[j].Version
 	})
 
+	for i := range migrations {
+		migrations[i].RollbackSQL = rollbacks[migrations[i].Version]
+	}
+
 	return migrations, nil
 }
 
@@ -144,39 +246,30 @@ func (m *Migrator) ApplyMigration(ctx context.Context, migration Migration) erro
 		logging.String("description", migration.Description),
 	)
 
-	// Start a transaction
-	tx := m.db.Begin()
-	if tx.Error != nil {
-		return fmt.Errorf("failed to begin transaction: %w", tx.Error)
-	}
-
-	// Execute migration SQL
-	if err := tx.Exec(migration.SQL).Error; err != nil {
-		tx.Rollback()
-		return fmt.Errorf("failed to execute migration: %w", err)
-	}
-
-	// Record migration as applied
+	// Execute migration SQL and record migration as applied
 	record := MigrationRecord{
 		Version:     migration.Version,
 		Description: migration.Description,
+		Checksum:    migration.Checksum,
 		AppliedAt:   time.Now().UTC(),
 	}
-	if err := tx.Create(&record).Error; err != nil {
-		tx.Rollback()
-		return fmt.Errorf("failed to record migration: %w", err)
-	}
-
-	// Commit transaction
-	if err := tx.Commit().Error; err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
-	}
-
-	return nil
+	return m.execute(ctx, migration.SQL, func(tx *gorm.DB) error {
+		if err := tx.Create(&record).Error; err != nil {
+			return fmt.Errorf("failed to record migration: %w", err)
+		}
+		return nil
+	})
 }
 
 // Migrate runs all pending migrations
 func (m *Migrator) Migrate(ctx context.Context, dir string) error {
+	// Keep other instances from applying the same migrations
+	unlock, err := m.Lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	// Ensure migrations table exists
 	if err := m.EnsureMigrationTable(); err != nil {
 		return err
@@ -194,6 +287,11 @@ func (m *Migrator) Migrate(ctx context.Context, dir string) error {
 		return err
 	}
 
+	// Refuse to build on applied migrations that were edited afterwards
+	if err := m.checkDrift(m.compare(migrations, appliedMigrations)); err != nil {
+		return err
+	}
+
 	// Create a map of applied migrations for quick lookup
 	appliedMap := make(map[int64]bool)
 	for _, record := range appliedMigrations {
@@ -229,11 +327,14 @@ func (m *Migrator) CreateInitialSchema(ctx context.Context) error {
 
 	// Define tables to auto-migrate
 	tables := []interface{}{
+		&database.PartOfSpeech{},
 		&database.Entry{},
 		&database.Meaning{},
 		&database.Example{},
 		&database.Translation{},
 		&database.ChangeHistory{},
+		&database.EntryRedirect{},
+		&model.Notification{},
 		&MigrationRecord{},
 	}
 
@@ -247,31 +348,50 @@ func (m *Migrator) CreateInitialSchema(ctx context.Context) error {
 	return nil
 }
 
-// Rollback rolls back the last migration
-func (m *Migrator) Rollback(ctx context.Context) error {
-	// Get the last applied migration
-	var lastMigration MigrationRecord
-	if err := m.db.Order("version DESC").First(&lastMigration).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("no migrations to roll back")
-		}
-		return fmt.Errorf("failed to get last migration: %w", err)
+// Rollback rolls back the last applied migration by running its rollback
+// file, under the migration lock. It is MigrateTo the migration applied before
+// it, except that pending migrations below that version are left pending
+func (m *Migrator) Rollback(ctx context.Context, dir string) error {
+	unlock, err := m.Lock(ctx)
+	if err != nil {
+		return err
 	}
+	defer unlock()
 
-	m.logger.Info("rolling back migration",
-		logging.Int64("version", lastMigration.Version),
-		logging.String("description", lastMigration.Description),
-	)
+	migrations, applied, err := m.load(dir)
+	if err != nil {
+		return err
+	}
+	if len(applied) == 0 {
+		return errors.New("no migrations to roll back")
+	}
 
-	// In a real implementation, we would need to store rollback SQL in migrations
-	// For now, we'll just delete the migration record
-	if err := m.db.Delete(&lastMigration).Error; err != nil {
-		return fmt.Errorf("failed to delete migration record: %w", err)
+	if err := m.checkDrift(m.compare(migrations, applied)); err != nil {
+		return err
 	}
 
-	m.logger.Info("migration rolled back successfully",
-		logging.Int64("version", lastMigration.Version),
-	)
+	var target int64
+	if len(applied) > 1 {
+		target = applied[len(applied)-2].Version
+	}
+	steps, err := plan(migrations, applied, target)
+	if err != nil {
+		return err
+	}
+
+	for _, step := range steps {
+		if step.Direction != Down {
+			continue
+		}
+		if err := m.RollbackMigration(ctx, step.Migration); err != nil {
+			return fmt.Errorf("failed to roll back migration %d: %w", step.Migration.Version, err)
+		}
+
+		m.logger.Info("migration rolled back successfully",
+			logging.Int64("version", step.Migration.Version),
+			logging.String("description", step.Migration.Description),
+		)
+	}
 
 	return nil
 }
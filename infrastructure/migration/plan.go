@@ -0,0 +1,193 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/valpere/trytrago/domain/logging"
+	"gorm.io/gorm"
+)
+
+// Direction tells whether a step applies or rolls back a migration
+type Direction string
+
+const (
+	Up   Direction = "up"
+	Down Direction = "down"
+)
+
+// Step is one migration applied or rolled back on the way to a target version
+type Step struct {
+	Direction Direction
+	Migration Migration
+}
+
+// TransactionalDDL reports whether schema changes can be rolled back as part
+// of a transaction. MySQL commits implicitly before and after DDL statements
+func (m *Migrator) TransactionalDDL() bool {
+	return m.Dialect() != "mysql"
+}
+
+// Plan lists the steps that bring the database to the target version: the
+// applied migrations above it are rolled back newest first, then the pending
+// ones up to it are applied oldest first. Target 0 rolls back everything
+func (m *Migrator) Plan(ctx context.Context, dir string, target int64) ([]Step, error) {
+	migrations, applied, err := m.load(dir)
+	if err != nil {
+		return nil, err
+	}
+	return plan(migrations, applied, target)
+}
+
+// MigrateTo applies and rolls back migrations until the database is at the
+// target version. Each step runs in its own transaction where the dialect
+// allows; a failing step stops the run with the earlier steps kept
+func (m *Migrator) MigrateTo(ctx context.Context, dir string, target int64) error {
+	unlock, err := m.Lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	migrations, applied, err := m.load(dir)
+	if err != nil {
+		return err
+	}
+
+	if err := m.checkDrift(m.compare(migrations, applied)); err != nil {
+		return err
+	}
+
+	steps, err := plan(migrations, applied, target)
+	if err != nil {
+		return err
+	}
+
+	for _, step := range steps {
+		if step.Direction == Down {
+			err = m.RollbackMigration(ctx, step.Migration)
+		} else {
+			err = m.ApplyMigration(ctx, step.Migration)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to %s migration %d: %w", step.Direction, step.Migration.Version, err)
+		}
+
+		m.logger.Info("migration step completed",
+			logging.String("direction", string(step.Direction)),
+			logging.Int64("version", step.Migration.Version),
+			logging.String("description", step.Migration.Description),
+		)
+	}
+
+	return nil
+}
+
+// RollbackMigration runs a migration's rollback file and removes its record
+func (m *Migrator) RollbackMigration(ctx context.Context, migration Migration) error {
+	m.logger.Info("rolling back migration",
+		logging.Int64("version", migration.Version),
+		logging.String("description", migration.Description),
+	)
+
+	if strings.TrimSpace(migration.RollbackSQL) == "" {
+		return fmt.Errorf("migration %d has no rollback file", migration.Version)
+	}
+
+	return m.execute(ctx, migration.RollbackSQL, func(tx *gorm.DB) error {
+		if err := tx.Delete(&MigrationRecord{Version: migration.Version}).Error; err != nil {
+			return fmt.Errorf("failed to delete migration record: %w", err)
+		}
+		return nil
+	})
+}
+
+// load reads the migration files and the applied migrations
+func (m *Migrator) load(dir string) ([]Migration, []MigrationRecord, error) {
+	if err := m.EnsureMigrationTable(); err != nil {
+		return nil, nil, err
+	}
+
+	migrations, err := m.LoadMigrationsFromDir(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	applied, err := m.GetAppliedMigrations()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return migrations, applied, nil
+}
+
+// execute runs a migration script together with its bookkeeping in one
+// transaction. Where DDL is not transactional the script runs on its own and
+// only the bookkeeping is wrapped
+func (m *Migrator) execute(ctx context.Context, sql string, record func(tx *gorm.DB) error) error {
+	db := m.db.WithContext(ctx)
+
+	if !m.TransactionalDDL() {
+		if err := db.Exec(sql).Error; err != nil {
+			return fmt.Errorf("failed to execute migration: %w", err)
+		}
+		return db.Transaction(record)
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(sql).Error; err != nil {
+			return fmt.Errorf("failed to execute migration: %w", err)
+		}
+		return record(tx)
+	})
+}
+
+// plan computes the steps from the applied migrations to the target version
+func plan(migrations []Migration, applied []MigrationRecord, target int64) ([]Step, error) {
+	if target < 0 {
+		return nil, fmt.Errorf("invalid target version %d", target)
+	}
+
+	files := make(map[int64]Migration, len(migrations))
+	for _, migration := range migrations {
+		files[migration.Version] = migration
+	}
+
+	appliedMap := make(map[int64]bool, len(applied))
+	for _, record := range applied {
+		appliedMap[record.Version] = true
+	}
+
+	if _, ok := files[target]; target != 0 && !ok && !appliedMap[target] {
+		return nil, fmt.Errorf("unknown target version %d", target)
+	}
+
+	var steps []Step
+
+	// Roll back newest first; applied is sorted by version
+	for i := len(applied) - 1; i >= 0; i-- {
+		record := applied[i]
+		if record.Version <= target {
+			continue
+		}
+
+		migration, ok := files[record.Version]
+		if !ok {
+			return nil, fmt.Errorf("cannot roll back migration %d: its file is missing", record.Version)
+		}
+		if strings.TrimSpace(migration.RollbackSQL) == "" {
+			return nil, fmt.Errorf("cannot roll back migration %d: there is no R%d file", record.Version, record.Version)
+		}
+		steps = append(steps, Step{Direction: Down, Migration: migration})
+	}
+
+	for _, migration := range migrations {
+		if appliedMap[migration.Version] || migration.Version > target {
+			continue
+		}
+		steps = append(steps, Step{Direction: Up, Migration: migration})
+	}
+
+	return steps, nil
+}
@@ -0,0 +1,172 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/valpere/trytrago/domain/logging"
+	"gorm.io/gorm"
+)
+
+// ChecksumMode selects how Migrate reacts to modified migrations
+type ChecksumMode string
+
+const (
+	// ChecksumRefuse stops Migrate before it applies anything
+	ChecksumRefuse ChecksumMode = "refuse"
+	// ChecksumWarn logs the modified migrations and carries on
+	ChecksumWarn ChecksumMode = "warn"
+)
+
+// ErrChecksumMismatch is returned by Migrate when an applied migration's file
+// no longer matches what was applied
+var ErrChecksumMismatch = errors.New("applied migration has been modified")
+
+// ParseChecksumMode validates a checksum mode name
+func ParseChecksumMode(name string) (ChecksumMode, error) {
+	switch mode := ChecksumMode(name); mode {
+	case ChecksumRefuse, ChecksumWarn:
+		return mode, nil
+	case "":
+		return ChecksumRefuse, nil
+	default:
+		return "", fmt.Errorf("unknown checksum mode %q, expected %q or %q", name, ChecksumRefuse, ChecksumWarn)
+	}
+}
+
+// ModifiedMigration is an applied migration whose file has changed since
+type ModifiedMigration struct {
+	Version         int64
+	Description     string
+	AppliedChecksum string
+	FileChecksum    string
+}
+
+// VerifyReport compares the migration files with the applied migrations
+type VerifyReport struct {
+	Missing    []MigrationRecord   // Applied, but the file is gone
+	Modified   []ModifiedMigration // Applied, but the file has changed
+	OutOfOrder []Migration         // Pending, numbered below the latest applied version
+	Unrecorded []Migration         // Applied before checksums were kept, cannot be checked
+	Applied    int
+	Pending    int
+}
+
+// OK reports whether the applied migrations match the files
+func (r *VerifyReport) OK() bool {
+	return len(r.Missing) == 0 && len(r.Modified) == 0 && len(r.OutOfOrder) == 0
+}
+
+// Verify checks the applied migrations against the files in dir without
+// changing anything
+func (m *Migrator) Verify(ctx context.Context, dir string) (*VerifyReport, error) {
+	migrations, applied, err := m.load(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.compare(migrations, applied), nil
+}
+
+// compare matches migration files with applied migrations by version
+func (m *Migrator) compare(migrations []Migration, applied []MigrationRecord) *VerifyReport {
+	report := &VerifyReport{Applied: len(applied)}
+
+	files := make(map[int64]Migration, len(migrations))
+	for _, migration := range migrations {
+		files[migration.Version] = migration
+	}
+
+	var latest int64
+	appliedMap := make(map[int64]bool, len(applied))
+	for _, record := range applied {
+		appliedMap[record.Version] = true
+		if record.Version > latest {
+			latest = record.Version
+		}
+
+		file, ok := files[record.Version]
+		switch {
+		case !ok:
+			report.Missing = append(report.Missing, record)
+		case record.Checksum == "":
+			report.Unrecorded = append(report.Unrecorded, file)
+		case record.Checksum != file.Checksum:
+			report.Modified = append(report.Modified, ModifiedMigration{
+				Version:         record.Version,
+				Description:     record.Description,
+				AppliedChecksum: record.Checksum,
+				FileChecksum:    file.Checksum,
+			})
+		}
+	}
+
+	for _, migration := range migrations {
+		if appliedMap[migration.Version] {
+			continue
+		}
+		report.Pending++
+		if migration.Version < latest {
+			report.OutOfOrder = append(report.OutOfOrder, migration)
+		}
+	}
+
+	return report
+}
+
+// checkDrift applies the checksum mode to a report before migrating. Missing
+// and out-of-order migrations are only logged; migrations applied before
+// checksums were kept take the checksum of their current file
+func (m *Migrator) checkDrift(report *VerifyReport) error {
+	for _, record := range report.Missing {
+		m.logger.Warn("applied migration file is missing",
+			logging.Int64("version", record.Version),
+			logging.String("description", record.Description),
+		)
+	}
+	for _, migration := range report.OutOfOrder {
+		m.logger.Warn("pending migration is older than the latest applied one",
+			logging.Int64("version", migration.Version),
+			logging.String("description", migration.Description),
+		)
+	}
+
+	for _, modified := range report.Modified {
+		m.logger.Warn("applied migration has been modified",
+			logging.Int64("version", modified.Version),
+			logging.String("description", modified.Description),
+			logging.String("applied_checksum", modified.AppliedChecksum),
+			logging.String("file_checksum", modified.FileChecksum),
+		)
+	}
+	if len(report.Modified) > 0 && m.checksumMode != ChecksumWarn {
+		versions := make([]int64, 0, len(report.Modified))
+		for _, modified := range report.Modified {
+			versions = append(versions, modified.Version)
+		}
+		return fmt.Errorf("%w: versions %v", ErrChecksumMismatch, versions)
+	}
+
+	return m.recordChecksums(report.Unrecorded)
+}
+
+// recordChecksums stores the current file checksums of migrations applied
+// before checksums were kept
+func (m *Migrator) recordChecksums(migrations []Migration) error {
+	if len(migrations) == 0 {
+		return nil
+	}
+
+	return m.db.Transaction(func(tx *gorm.DB) error {
+		for _, migration := range migrations {
+			if err := tx.Model(&MigrationRecord{}).
+				Where("version = ?", migration.Version).
+				Update("checksum", migration.Checksum).Error; err != nil {
+				return fmt.Errorf("failed to record checksum of migration %d: %w", migration.Version, err)
+			}
+		}
+		m.logger.Info("recorded checksums of earlier migrations", logging.Int("count", len(migrations)))
+		return nil
+	})
+}
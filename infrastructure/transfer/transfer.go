@@ -0,0 +1,397 @@
+package transfer
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/valpere/trytrago/domain/database"
+	"github.com/valpere/trytrago/domain/database/repository"
+	"github.com/valpere/trytrago/domain/logging"
+	"github.com/valpere/trytrago/domain/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// Table is a table copied between databases
+type Table struct {
+	Model interface{}
+	// SelfRef is a column referencing the same table. It is filled in once all
+	// rows are copied, as the rows it points at may come later in key order
+	SelfRef string
+}
+
+// schemas caches the parsed models
+var schemas sync.Map
+
+// Name returns the name of the table, as GORM derives it from the model
+func (t Table) Name() string {
+	s, err := schema.Parse(t.Model, &schemas, schema.NamingStrategy{})
+	if err != nil {
+		return fmt.Sprintf("%T", t.Model)
+	}
+	return s.Table
+}
+
+// Tables lists every table in dependency order: referenced tables come before
+// the tables that reference them
+var Tables = []Table{
+	{Model: &database.Language{}},
+	{Model: &model.User{}},
+	{Model: &database.PartOfSpeech{}},
+	{Model: &database.Entry{}},
+	{Model: &database.EntryRedirect{}},
+	{Model: &database.Meaning{}},
+	{Model: &database.Example{}},
+	{Model: &database.Translation{}, SelfRef: "supersedes_id"},
+	{Model: &database.ChangeHistory{}},
+	{Model: &model.AuthToken{}},
+	{Model: &model.UserPreference{}},
+	{Model: &model.UserStats{}},
+	{Model: &model.Comment{}},
+	{Model: &model.Like{}},
+	{Model: &model.Notification{}},
+}
+
+// DefaultBatchSize is the number of rows copied per transaction
+const DefaultBatchSize = 500
+
+// Checkpoint records how far a copy got, so that it can be resumed
+type Checkpoint struct {
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	Completed []string  `json:"completed"`
+	Table     string    `json:"table,omitempty"`    // Table being copied
+	LastKey   string    `json:"last_key,omitempty"` // Primary key of the last row copied
+	Copied    int64     `json:"copied,omitempty"`   // Rows of Table copied so far
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Progress reports the rows copied from a table
+type Progress struct {
+	Table  string
+	Copied int64
+	Total  int64
+}
+
+// Options controls a copy
+type Options struct {
+	BatchSize int
+	// Resume continues from a checkpoint written by an earlier run
+	Resume *Checkpoint
+	// OnCheckpoint is called after every committed batch
+	OnCheckpoint func(Checkpoint) error
+	OnProgress   func(Progress)
+}
+
+// Copier copies the content of one database into another, whatever drivers
+// they use
+type Copier struct {
+	src    *gorm.DB
+	dst    *gorm.DB
+	logger logging.Logger
+}
+
+// NewCopier creates a new Copier instance
+func NewCopier(src, dst repository.Repository, logger logging.Logger) (*Copier, error) {
+	srcDB, err := src.GetDB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source database: %w", err)
+	}
+	dstDB, err := dst.GetDB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get destination database: %w", err)
+	}
+
+	return &Copier{
+		src:    srcDB,
+		dst:    dstDB,
+		logger: logger.With(logging.String("component", "copier")),
+	}, nil
+}
+
+// Copy copies every table in dependency order. Rows are upserted by primary
+// key in batches, each in its own transaction, so an interrupted copy can be
+// resumed from its checkpoint or simply run again
+func (c *Copier) Copy(ctx context.Context, opts Options) error {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultBatchSize
+	}
+
+	var checkpoint Checkpoint
+	if opts.Resume != nil {
+		checkpoint = *opts.Resume
+	}
+	completed := make(map[string]bool)
+	for _, name := range checkpoint.Completed {
+		completed[name] = true
+	}
+
+	for _, table := range Tables {
+		if completed[table.Name()] {
+			c.logger.Info("table already copied", logging.String("table", table.Name()))
+			continue
+		}
+		if checkpoint.Table != table.Name() {
+			checkpoint.Table, checkpoint.LastKey, checkpoint.Copied = table.Name(), "", 0
+		}
+
+		if err := c.copyTable(ctx, table, &checkpoint, opts); err != nil {
+			return fmt.Errorf("failed to copy %s: %w", table.Name(), err)
+		}
+
+		checkpoint.Completed = append(checkpoint.Completed, table.Name())
+		checkpoint.Table, checkpoint.LastKey, checkpoint.Copied = "", "", 0
+		if err := saveCheckpoint(&checkpoint, opts); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// copyTable copies the rows of one table after the checkpoint's last key
+func (c *Copier) copyTable(ctx context.Context, table Table, checkpoint *Checkpoint, opts Options) error {
+	if !c.src.Migrator().HasTable(table.Name()) {
+		c.logger.Warn("table missing in source, skipped", logging.String("table", table.Name()))
+		return nil
+	}
+	if !c.dst.Migrator().HasTable(table.Name()) {
+		c.logger.Info("creating table in destination", logging.String("table", table.Name()))
+		if err := c.dst.WithContext(ctx).Migrator().CreateTable(table.Model); err != nil {
+			return err
+		}
+	}
+
+	plan, err := c.plan(table)
+	if err != nil {
+		return err
+	}
+
+	var total int64
+	if err := c.src.WithContext(ctx).Table(table.Name()).Count(&total).Error; err != nil {
+		return err
+	}
+
+	// Self references are left out of the first pass and filled in afterwards
+	insertColumns := plan.columns
+	if table.SelfRef != "" {
+		insertColumns = without(insertColumns, table.SelfRef)
+	}
+
+	for {
+		rows, keys, err := c.readBatch(ctx, c.src, table, plan, checkpoint.LastKey, opts.BatchSize)
+		if err != nil {
+			return err
+		}
+		if len(keys) == 0 {
+			break
+		}
+
+		err = c.dst.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if _, ok := table.Model.(*database.PartOfSpeech); ok {
+				if err := adoptPartsOfSpeech(tx, rows); err != nil {
+					return err
+				}
+			}
+			return tx.Model(table.Model).
+				Select(insertColumns).
+				Omit(clause.Associations).
+				Clauses(clause.OnConflict{
+					Columns:   []clause.Column{{Name: plan.key}},
+					DoUpdates: clause.AssignmentColumns(without(insertColumns, plan.key)),
+				}).
+				Create(rows).Error
+		})
+		if err != nil {
+			return err
+		}
+
+		checkpoint.LastKey = keys[len(keys)-1]
+		checkpoint.Copied += int64(len(keys))
+		if err := saveCheckpoint(checkpoint, opts); err != nil {
+			return err
+		}
+		if opts.OnProgress != nil {
+			opts.OnProgress(Progress{Table: table.Name(), Copied: checkpoint.Copied, Total: total})
+		}
+	}
+
+	if table.SelfRef != "" && contains(plan.columns, table.SelfRef) {
+		return c.copySelfRefs(ctx, table, plan)
+	}
+	return nil
+}
+
+// copySelfRefs fills in a self referencing column once all rows exist
+func (c *Copier) copySelfRefs(ctx context.Context, table Table, plan *tablePlan) error {
+	rows, err := c.src.WithContext(ctx).Table(table.Name()).
+		Select(plan.key, table.SelfRef).
+		Where(table.SelfRef + " IS NOT NULL").
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	type ref struct{ key, value string }
+	var refs []ref
+	for rows.Next() {
+		var r ref
+		if err := rows.Scan(&r.key, &r.value); err != nil {
+			return err
+		}
+		refs = append(refs, r)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	return c.dst.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, r := range refs {
+			if err := tx.Table(table.Name()).Where(plan.key+" = ?", r.key).Update(table.SelfRef, r.value).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// adoptPartsOfSpeech hands the source IDs to parts of speech the destination
+// already has under the same name, such as those seeded by the migrations
+func adoptPartsOfSpeech(tx *gorm.DB, rows interface{}) error {
+	for _, pos := range *rows.(*[]database.PartOfSpeech) {
+		if err := tx.Model(&database.PartOfSpeech{}).
+			Where("name = ? AND id <> ?", pos.Name, pos.ID).
+			Update("id", pos.ID).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tablePlan holds what is needed to move the rows of a table
+type tablePlan struct {
+	schema  *schema.Schema
+	key     string   // Primary key column
+	columns []string // Columns known to the model and present on both sides
+}
+
+// plan works out the columns to copy. Columns the destination lacks cannot
+// be copied and are reported
+func (c *Copier) plan(table Table) (*tablePlan, error) {
+	stmt := &gorm.Statement{DB: c.src}
+	if err := stmt.Parse(table.Model); err != nil {
+		return nil, err
+	}
+	if stmt.Schema.PrioritizedPrimaryField == nil {
+		return nil, fmt.Errorf("table %s has no single primary key", table.Name())
+	}
+
+	srcColumns, err := columnSet(c.src, table.Name())
+	if err != nil {
+		return nil, err
+	}
+	dstColumns, err := columnSet(c.dst, table.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &tablePlan{schema: stmt.Schema, key: stmt.Schema.PrioritizedPrimaryField.DBName}
+	var dropped []string
+	for _, name := range stmt.Schema.DBNames {
+		switch {
+		case !srcColumns[name]:
+		case !dstColumns[name]:
+			dropped = append(dropped, name)
+		default:
+			plan.columns = append(plan.columns, name)
+		}
+	}
+	if len(dropped) > 0 {
+		c.logger.Warn("columns missing in destination are not copied",
+			logging.String("table", table.Name()),
+			logging.String("columns", strings.Join(dropped, ", ")),
+		)
+	}
+	if !contains(plan.columns, plan.key) {
+		return nil, fmt.Errorf("table %s lacks its primary key %s", table.Name(), plan.key)
+	}
+
+	return plan, nil
+}
+
+// readBatch reads the next rows after lastKey in primary key order. It
+// returns a pointer to a slice of the table's model and the rows' keys
+func (c *Copier) readBatch(ctx context.Context, db *gorm.DB, table Table, plan *tablePlan, lastKey string, limit int) (interface{}, []string, error) {
+	rows := reflect.New(reflect.SliceOf(plan.schema.ModelType))
+
+	query := db.WithContext(ctx).Model(table.Model).Select(plan.columns).Order(plan.key).Limit(limit)
+	if lastKey != "" {
+		query = query.Where(plan.key+" > ?", lastKey)
+	}
+	if err := query.Find(rows.Interface()).Error; err != nil {
+		return nil, nil, err
+	}
+
+	keys := rowKeys(ctx, plan, rows.Elem())
+	return rows.Interface(), keys, nil
+}
+
+// rowKeys returns the primary keys of rows as strings
+func rowKeys(ctx context.Context, plan *tablePlan, rows reflect.Value) []string {
+	field := plan.schema.PrioritizedPrimaryField
+	keys := make([]string, rows.Len())
+	for i := range keys {
+		value, _ := field.ValueOf(ctx, rows.Index(i))
+		keys[i] = fmt.Sprint(value)
+	}
+	return keys
+}
+
+// columnSet lists the columns a table has in the database
+func columnSet(db *gorm.DB, table string) (map[string]bool, error) {
+	types, err := db.Migrator().ColumnTypes(table)
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]bool, len(types))
+	for _, t := range types {
+		columns[strings.ToLower(t.Name())] = true
+	}
+	return columns, nil
+}
+
+// saveCheckpoint hands the checkpoint to the caller
+func saveCheckpoint(checkpoint *Checkpoint, opts Options) error {
+	if opts.OnCheckpoint == nil {
+		return nil
+	}
+	checkpoint.UpdatedAt = time.Now().UTC()
+	cp := *checkpoint
+	cp.Completed = append([]string(nil), checkpoint.Completed...)
+	return opts.OnCheckpoint(cp)
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func without(list []string, s string) []string {
+	kept := make([]string, 0, len(list))
+	for _, item := range list {
+		if item != s {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,230 @@
+package transfer
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/valpere/trytrago/domain/logging"
+	"gorm.io/gorm/schema"
+)
+
+// timeTolerance absorbs the differing timestamp precision of the drivers;
+// MySQL keeps milliseconds, rounded
+const timeTolerance = time.Millisecond
+
+// maxReportedMismatches bounds the mismatching keys kept per table
+const maxReportedMismatches = 10
+
+// TableReport is the outcome of verifying one table
+type TableReport struct {
+	Table          string
+	SourceRows     int64
+	MatchedRows    int64
+	Missing        int64    // Source rows absent from the destination
+	Different      int64    // Rows present on both sides with different content
+	Mismatches     []string // Keys of some missing or different rows
+	SourceChecksum string
+	TargetChecksum string
+	Skipped        bool // Table missing in the source
+}
+
+// OK reports whether the destination holds every source row unchanged
+func (r *TableReport) OK() bool {
+	return r.Missing == 0 && r.Different == 0 && r.SourceChecksum == r.TargetChecksum
+}
+
+// Verify compares every source row with its copy in the destination. Row
+// counts and a SHA-256 checksum of the copied columns are computed for both
+// sides; timestamps are compared separately, to the millisecond. Rows only
+// found in the destination, such as seeded reference data, are ignored
+func (c *Copier) Verify(ctx context.Context, batchSize int) ([]TableReport, error) {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	reports := make([]TableReport, 0, len(Tables))
+	for _, table := range Tables {
+		report := TableReport{Table: table.Name()}
+		if !c.src.Migrator().HasTable(table.Name()) {
+			report.Skipped = true
+			reports = append(reports, report)
+			continue
+		}
+		if !c.dst.Migrator().HasTable(table.Name()) {
+			return reports, fmt.Errorf("table %s missing in destination", table.Name())
+		}
+
+		plan, err := c.plan(table)
+		if err != nil {
+			return reports, err
+		}
+		if err := c.verifyTable(ctx, table, plan, batchSize, &report); err != nil {
+			return reports, fmt.Errorf("failed to verify %s: %w", table.Name(), err)
+		}
+
+		if !report.OK() {
+			c.logger.Warn("table differs after copy",
+				logging.String("table", table.Name()),
+				logging.Int64("missing", report.Missing),
+				logging.Int64("different", report.Different),
+			)
+		}
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+// verifyTable walks the source in key order and looks up each batch in the
+// destination
+func (c *Copier) verifyTable(ctx context.Context, table Table, plan *tablePlan, batchSize int, report *TableReport) error {
+	fields := make([]*schema.Field, 0, len(plan.columns))
+	for _, column := range plan.columns {
+		fields = append(fields, plan.schema.LookUpField(column))
+	}
+
+	srcHash, dstHash := sha256.New(), sha256.New()
+	lastKey := ""
+	for {
+		srcRows, keys, err := c.readBatch(ctx, c.src, table, plan, lastKey, batchSize)
+		if err != nil {
+			return err
+		}
+		if len(keys) == 0 {
+			break
+		}
+		lastKey = keys[len(keys)-1]
+
+		dstRows := reflect.New(reflect.SliceOf(plan.schema.ModelType))
+		if err := c.dst.WithContext(ctx).Model(table.Model).
+			Select(plan.columns).
+			Where(plan.key+" IN ?", keys).
+			Find(dstRows.Interface()).Error; err != nil {
+			return err
+		}
+		copies := make(map[string]reflect.Value, len(keys))
+		for i, key := range rowKeys(ctx, plan, dstRows.Elem()) {
+			copies[key] = dstRows.Elem().Index(i)
+		}
+
+		source := reflect.ValueOf(srcRows).Elem()
+		for i, key := range keys {
+			report.SourceRows++
+			row := source.Index(i)
+			writeRow(ctx, srcHash, fields, row)
+
+			copied, ok := copies[key]
+			switch {
+			case !ok:
+				report.Missing++
+			case !sameRow(ctx, fields, row, copied):
+				report.Different++
+				writeRow(ctx, dstHash, fields, copied)
+			default:
+				report.MatchedRows++
+				writeRow(ctx, dstHash, fields, copied)
+				continue
+			}
+			if len(report.Mismatches) < maxReportedMismatches {
+				report.Mismatches = append(report.Mismatches, key)
+			}
+		}
+	}
+
+	report.SourceChecksum = hex.EncodeToString(srcHash.Sum(nil))
+	report.TargetChecksum = hex.EncodeToString(dstHash.Sum(nil))
+	return nil
+}
+
+// writeRow feeds the canonical form of a row's columns into h, timestamps
+// excepted
+func writeRow(ctx context.Context, h hash.Hash, fields []*schema.Field, row reflect.Value) {
+	for _, field := range fields {
+		value, _ := field.ValueOf(ctx, row)
+		if _, ok := timestamp(value); ok {
+			continue
+		}
+		fmt.Fprintf(h, "%s=%s\x1f", field.DBName, canonical(value))
+	}
+	h.Write([]byte{'\x1e'})
+}
+
+// sameRow compares two rows column by column
+func sameRow(ctx context.Context, fields []*schema.Field, a, b reflect.Value) bool {
+	for _, field := range fields {
+		va, _ := field.ValueOf(ctx, a)
+		vb, _ := field.ValueOf(ctx, b)
+
+		ta, aIsTime := timestamp(va)
+		tb, bIsTime := timestamp(vb)
+		if aIsTime || bIsTime {
+			if ta == nil || tb == nil {
+				if ta != tb {
+					return false
+				}
+				continue
+			}
+			if d := ta.Sub(*tb); d >= timeTolerance || d <= -timeTolerance {
+				return false
+			}
+			continue
+		}
+
+		if canonical(va) != canonical(vb) {
+			return false
+		}
+	}
+	return true
+}
+
+// timestamp unwraps time values; nil stands for a NULL timestamp
+func timestamp(value interface{}) (*time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return &v, true
+	case *time.Time:
+		return v, true
+	}
+	return nil, false
+}
+
+// canonical renders a column value independently of the driver it was read
+// from. JSON documents are re-encoded, as PostgreSQL reorders jsonb keys
+func canonical(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case uuid.UUID:
+		return v.String()
+	case *uuid.UUID:
+		if v == nil {
+			return "NULL"
+		}
+		return v.String()
+	case []byte:
+		var doc interface{}
+		if json.Unmarshal(v, &doc) == nil {
+			if encoded, err := json.Marshal(doc); err == nil {
+				return string(encoded)
+			}
+		}
+		return hex.EncodeToString(bytes.TrimSpace(v))
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return "NULL"
+		}
+		rv = rv.Elem()
+	}
+	return fmt.Sprint(rv.Interface())
+}
@@ -0,0 +1,63 @@
+package graph
+
+import (
+	"errors"
+
+	"github.com/valpere/trytrago/domain/database"
+	domainErrors "github.com/valpere/trytrago/domain/errors"
+	"github.com/valpere/trytrago/domain/logging"
+)
+
+// Error codes sent in the extensions of an error, so clients can tell
+// failures apart without parsing messages
+const (
+	codeBadUserInput    = "BAD_USER_INPUT"
+	codeUnauthenticated = "UNAUTHENTICATED"
+	codeNotFound        = "NOT_FOUND"
+	codeConflict        = "CONFLICT"
+	codeVersionConflict = "VERSION_CONFLICT"
+	codeQueryLimit      = "QUERY_LIMIT_EXCEEDED"
+	codeInternal        = "INTERNAL_SERVER_ERROR"
+)
+
+// graphError is an error that a client is meant to see
+type graphError struct {
+	message string
+	code    string
+}
+
+// Error implements error
+func (e *graphError) Error() string {
+	return e.message
+}
+
+// Extensions adds the error code to the error sent to the client
+func (e *graphError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+// errUnauthenticated is returned by mutations called without a token
+var errUnauthenticated = &graphError{message: "authentication required", code: codeUnauthenticated}
+
+// badInput reports a request the client has to correct
+func badInput(err error) error {
+	return &graphError{message: err.Error(), code: codeBadUserInput}
+}
+
+// serviceError turns an error from a service into one a client can see.
+// Unexpected errors are logged and replaced by a message that leaks nothing
+func serviceError(logger logging.Logger, err error, kind, action string) error {
+	switch {
+	case database.IsNotFoundError(err), domainErrors.IsNotFoundError(err):
+		return &graphError{message: kind + " not found", code: codeNotFound}
+	case database.IsVersionConflictError(err):
+		return &graphError{message: kind + " has been changed since it was read", code: codeVersionConflict}
+	case database.IsDuplicateError(err), domainErrors.IsDuplicateError(err):
+		return &graphError{message: kind + " conflicts with an existing one", code: codeConflict}
+	case errors.Is(err, domainErrors.ErrValidation), errors.Is(err, domainErrors.ErrInvalidInput), errors.Is(err, database.ErrInvalidInput):
+		return badInput(err)
+	default:
+		logger.Error("failed to "+action, logging.Error(err))
+		return &graphError{message: "failed to " + action, code: codeInternal}
+	}
+}
@@ -0,0 +1,182 @@
+package graph
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// defaultListSize is the number of items a list field without a limit
+// argument is expected to hold when the complexity of a query is estimated
+const defaultListSize = 5
+
+// Limits bounds the queries a schema runs
+type Limits struct {
+	MaxDepth      int // Deepest nesting of fields, 0 for no limit
+	MaxComplexity int // Highest estimated cost, 0 for no limit
+}
+
+// cost walks an operation and estimates what running it takes. A field costs
+// 1 plus the cost of its selections, multiplied by its list size for lists:
+// the limit argument when the field has one, defaultListSize otherwise.
+// Introspection fields are free, so tools can always read the schema
+type cost struct {
+	limits    Limits
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+	err       error
+}
+
+// checkLimits reports an error when the operation to run is nested deeper or
+// estimated to cost more than the limits allow
+func checkLimits(schema *graphql.Schema, doc *ast.Document, operationName string, variables map[string]interface{}, limits Limits) error {
+	c := &cost{
+		limits:    limits,
+		fragments: make(map[string]*ast.FragmentDefinition),
+		variables: variables,
+	}
+
+	var operation *ast.OperationDefinition
+	for _, definition := range doc.Definitions {
+		switch definition := definition.(type) {
+		case *ast.FragmentDefinition:
+			c.fragments[definition.Name.Value] = definition
+		case *ast.OperationDefinition:
+			if operationName == "" || (definition.Name != nil && definition.Name.Value == operationName) {
+				operation = definition
+			}
+		}
+	}
+	if operation == nil {
+		// Left to the executor, which reports the missing operation
+		return nil
+	}
+
+	root := schema.QueryType()
+	if operation.Operation == ast.OperationTypeMutation {
+		root = schema.MutationType()
+	}
+
+	complexity := c.selections(root, operation.SelectionSet, 0)
+	if c.err != nil {
+		return c.err
+	}
+	if limits.MaxComplexity > 0 && complexity > limits.MaxComplexity {
+		return limitError(fmt.Sprintf("query is too complex: its estimated cost is more than the limit of %d", limits.MaxComplexity))
+	}
+	return nil
+}
+
+// selections returns the cost of a selection set on an object type. Costs
+// stop growing once they pass the limit, so they cannot overflow
+func (c *cost) selections(parent *graphql.Object, set *ast.SelectionSet, depth int) int {
+	if set == nil || parent == nil || c.err != nil {
+		return 0
+	}
+
+	total := 0
+	for _, selection := range set.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			total += c.field(parent, selection, depth+1)
+		case *ast.InlineFragment:
+			total += c.selections(parent, selection.SelectionSet, depth)
+		case *ast.FragmentSpread:
+			if fragment, ok := c.fragments[selection.Name.Value]; ok {
+				total += c.selections(parent, fragment.SelectionSet, depth)
+			}
+		}
+		total = c.cap(total)
+	}
+	return total
+}
+
+// field returns the cost of a field and what it selects
+func (c *cost) field(parent *graphql.Object, field *ast.Field, depth int) int {
+	name := field.Name.Value
+	if strings.HasPrefix(name, "__") {
+		return 0
+	}
+	if c.limits.MaxDepth > 0 && depth > c.limits.MaxDepth {
+		c.err = limitError(fmt.Sprintf("query is too deep: %s is nested %d levels deep, more than the limit of %d", name, depth, c.limits.MaxDepth))
+		return 0
+	}
+
+	definition, ok := parent.Fields()[name]
+	if !ok {
+		return 1
+	}
+
+	fieldType, list := definition.Type, false
+	for {
+		if nonNull, ok := fieldType.(*graphql.NonNull); ok {
+			fieldType = nonNull.OfType
+			continue
+		}
+		if of, ok := fieldType.(*graphql.List); ok {
+			fieldType, list = of.OfType, true
+			continue
+		}
+		break
+	}
+
+	object, _ := fieldType.(*graphql.Object)
+	selected := c.selections(object, field.SelectionSet, depth)
+	if list {
+		selected = c.cap(selected * c.listSize(definition, field))
+	}
+	return c.cap(1 + selected)
+}
+
+// listSize returns the number of items a list field is expected to hold
+func (c *cost) listSize(definition *graphql.FieldDefinition, field *ast.Field) int {
+	for _, arg := range definition.Args {
+		if arg.Name() != "limit" {
+			continue
+		}
+		for _, given := range field.Arguments {
+			if given.Name.Value == "limit" {
+				if size, ok := c.intValue(given.Value); ok {
+					return size
+				}
+			}
+		}
+		if size, ok := arg.DefaultValue.(int); ok {
+			return size
+		}
+	}
+	return defaultListSize
+}
+
+// intValue reads an integer argument, given inline or as a variable
+func (c *cost) intValue(value ast.Value) (int, bool) {
+	switch value := value.(type) {
+	case *ast.IntValue:
+		size, err := strconv.Atoi(value.Value)
+		return size, err == nil && size > 0
+	case *ast.Variable:
+		switch size := c.variables[value.Name.Value].(type) {
+		case float64:
+			return int(size), size > 0
+		case int:
+			return size, size > 0
+		}
+	}
+	return 0, false
+}
+
+// limitError reports a query the limits do not allow
+func limitError(message string) error {
+	return &graphError{message: message, code: codeQueryLimit}
+}
+
+// cap keeps a cost from growing far past the complexity limit
+func (c *cost) cap(value int) int {
+	if c.limits.MaxComplexity > 0 && value > c.limits.MaxComplexity {
+		return c.limits.MaxComplexity + 1
+	}
+	return value
+}
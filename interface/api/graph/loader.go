@@ -0,0 +1,183 @@
+package graph
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/valpere/trytrago/application/dto/response"
+	"github.com/valpere/trytrago/application/service"
+)
+
+// loader batches the loads of one kind of value. The executor resolves a
+// query one level at a time: every field of a level queues its key and
+// returns a thunk, and the first thunk called loads all queued keys with a
+// single fetch. Values are kept for the rest of the request
+type loader[K comparable, V any] struct {
+	fetch   func(ctx context.Context, keys []K) (map[K]V, error)
+	mu      sync.Mutex
+	pending []K
+	values  map[K]V
+	errs    map[K]error
+}
+
+// newLoader creates a loader that fetches values with fetch. Keys fetch
+// leaves out of its result load as the zero value
+func newLoader[K comparable, V any](fetch func(ctx context.Context, keys []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{
+		fetch:  fetch,
+		values: make(map[K]V),
+		errs:   make(map[K]error),
+	}
+}
+
+// load queues a key and returns a thunk for its value
+func (l *loader[K, V]) load(ctx context.Context, key K) func() (interface{}, error) {
+	l.mu.Lock()
+	if _, loaded := l.values[key]; !loaded && l.errs[key] == nil {
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (interface{}, error) {
+		return l.get(ctx, key)
+	}
+}
+
+// get returns the value of a key, fetching it with every queued key
+func (l *loader[K, V]) get(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// A key is no longer queued when a reset came between load and get
+	if _, loaded := l.values[key]; !loaded && l.errs[key] == nil {
+		l.pending = append(l.pending, key)
+	}
+	if len(l.pending) > 0 {
+		keys := make([]K, 0, len(l.pending))
+		seen := make(map[K]bool, len(l.pending))
+		for _, k := range l.pending {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+		l.pending = nil
+
+		values, err := l.fetch(ctx, keys)
+		for _, k := range keys {
+			if err != nil {
+				l.errs[k] = err
+				continue
+			}
+			l.values[k] = values[k]
+		}
+		// A fetch may return more than it was asked for, like all languages
+		for k, v := range values {
+			l.values[k] = v
+		}
+	}
+
+	if err := l.errs[key]; err != nil {
+		var zero V
+		return zero, err
+	}
+	return l.values[key], nil
+}
+
+// reset forgets every loaded value
+func (l *loader[K, V]) reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.pending = nil
+	l.values = make(map[K]V)
+	l.errs = make(map[K]error)
+}
+
+// loaders holds the loaders of one request
+type loaders struct {
+	entries             *loader[uuid.UUID, *response.EntryResponse]
+	meanings            *loader[uuid.UUID, []*response.MeaningResponse]
+	translations        *loader[uuid.UUID, []*response.TranslationResponse]
+	meaningComments     *loader[uuid.UUID, []*response.CommentResponse]
+	translationComments *loader[uuid.UUID, []*response.CommentResponse]
+	meaningLikes        *loader[uuid.UUID, int]
+	translationLikes    *loader[uuid.UUID, int]
+	users               *loader[uuid.UUID, *response.UserSummary]
+	languages           *loader[string, *response.LanguageInfo]
+}
+
+// newLoaders creates the loaders for one request
+func newLoaders(graphService service.GraphService) *loaders {
+	comments := func(targetType string) func(context.Context, []uuid.UUID) (map[uuid.UUID][]*response.CommentResponse, error) {
+		return func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]*response.CommentResponse, error) {
+			return graphService.CommentsByTarget(ctx, targetType, ids)
+		}
+	}
+	likes := func(targetType string) func(context.Context, []uuid.UUID) (map[uuid.UUID]int, error) {
+		return func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]int, error) {
+			return graphService.LikeCounts(ctx, targetType, ids)
+		}
+	}
+
+	return &loaders{
+		entries:             newLoader(graphService.EntriesByID),
+		meanings:            newLoader(graphService.MeaningsByEntry),
+		translations:        newLoader(graphService.TranslationsByMeaning),
+		meaningComments:     newLoader(comments("meaning")),
+		translationComments: newLoader(comments("translation")),
+		meaningLikes:        newLoader(likes("meaning")),
+		translationLikes:    newLoader(likes("translation")),
+		users:               newLoader(graphService.UsersByID),
+		// The languages table is small, so all of it is loaded at once
+		languages: newLoader(func(ctx context.Context, _ []string) (map[string]*response.LanguageInfo, error) {
+			languages, err := graphService.ListLanguages(ctx)
+			if err != nil {
+				return nil, err
+			}
+			byCode := make(map[string]*response.LanguageInfo, len(languages))
+			for _, language := range languages {
+				byCode[language.Code] = language
+			}
+			return byCode, nil
+		}),
+	}
+}
+
+// reset forgets everything loaded, so fields resolved after a mutation see its result
+func (l *loaders) reset() {
+	l.entries.reset()
+	l.meanings.reset()
+	l.translations.reset()
+	l.meaningComments.reset()
+	l.translationComments.reset()
+	l.meaningLikes.reset()
+	l.translationLikes.reset()
+	l.users.reset()
+	l.languages.reset()
+}
+
+type contextKey int
+
+const (
+	loadersKey contextKey = iota
+	userIDKey
+)
+
+// loadersFrom returns the loaders of the request
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey).(*loaders)
+}
+
+// WithUserID returns a context that runs requests for an authenticated user
+func WithUserID(ctx context.Context, userID uuid.UUID) context.Context {
+	return context.WithValue(ctx, userIDKey, userID)
+}
+
+// userIDFrom returns the authenticated user of the request, if any
+func userIDFrom(ctx context.Context) (uuid.UUID, bool) {
+	userID, ok := ctx.Value(userIDKey).(uuid.UUID)
+	return userID, ok && userID != uuid.Nil
+}
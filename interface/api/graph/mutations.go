@@ -0,0 +1,453 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+
+	"github.com/valpere/trytrago/application/dto/request"
+	"github.com/valpere/trytrago/application/dto/response"
+)
+
+// mutate runs a mutation for the authenticated user. Values loaded before it
+// are dropped, so fields resolved on its result see what it changed
+func (s *Schema) mutate(resolve func(p graphql.ResolveParams, userID uuid.UUID) (interface{}, error)) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		userID, ok := userIDFrom(p.Context)
+		if !ok {
+			return nil, errUnauthenticated
+		}
+		loadersFrom(p.Context).reset()
+		return resolve(p, userID)
+	}
+}
+
+// validate checks a request against its binding tags, like the REST handlers do
+func validate(req interface{}) error {
+	if err := binding.Validator.ValidateStruct(req); err != nil {
+		return badInput(err)
+	}
+	return nil
+}
+
+// versionArg reads the version a mutation expects to change
+func (s *Schema) versionArg(args map[string]interface{}) (int, error) {
+	version := intArg(args, "version")
+	if version < 0 {
+		return 0, &graphError{message: "version must be positive", code: codeBadUserInput}
+	}
+	if version == 0 && s.requireVersion {
+		return 0, &graphError{message: "version is required to change a resource", code: codeBadUserInput}
+	}
+	return version, nil
+}
+
+// stringsArg reads an optional list of strings, keeping nil when it is left out
+func stringsArg(args map[string]interface{}, name string) []string {
+	values, ok := args[name].([]interface{})
+	if !ok {
+		return nil
+	}
+	strings := make([]string, 0, len(values))
+	for _, value := range values {
+		if s, ok := value.(string); ok {
+			strings = append(strings, s)
+		}
+	}
+	return strings
+}
+
+// optionalIDArg reads an optional ID argument, uuid.Nil when it is left out
+func optionalIDArg(args map[string]interface{}, name string) (uuid.UUID, error) {
+	if _, ok := args[name]; !ok {
+		return uuid.Nil, nil
+	}
+	return idArg(args, name)
+}
+
+// input returns the input object argument of a mutation
+func input(args map[string]interface{}) map[string]interface{} {
+	value, _ := args["input"].(map[string]interface{})
+	return value
+}
+
+// mutationType creates the root mutation type. Every mutation needs a token
+func (s *Schema) mutationType(entryType, meaningType, translationType, commentType *graphql.Object) *graphql.Object {
+	id := &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}
+	version := &graphql.ArgumentConfig{Type: graphql.Int, Description: "Version last read; a changed resource fails with VERSION_CONFLICT"}
+
+	createEntryInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "CreateEntryInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"word":             &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"type":             &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String), Description: "WORD, COMPOUND_WORD or PHRASE"},
+			"sourceLanguageId": &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"homographIndex":   &graphql.InputObjectFieldConfig{Type: graphql.Int, Description: "Assigned automatically when left out"},
+			"pronunciation":    &graphql.InputObjectFieldConfig{Type: graphql.String},
+		},
+	})
+	updateEntryInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        "UpdateEntryInput",
+		Description: "Fields left out keep their value",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"word":           &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"type":           &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"homographIndex": &graphql.InputObjectFieldConfig{Type: graphql.Int},
+			"pronunciation":  &graphql.InputObjectFieldConfig{Type: graphql.String},
+		},
+	})
+	createMeaningInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "CreateMeaningInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"partOfSpeechId": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.ID)},
+			"description":    &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"examples":       &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+			"labels":         &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+		},
+	})
+	updateMeaningInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        "UpdateMeaningInput",
+		Description: "Fields left out keep their value; examples and labels given replace the old ones",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"partOfSpeechId": &graphql.InputObjectFieldConfig{Type: graphql.ID},
+			"description":    &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"examples":       &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+			"labels":         &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+		},
+	})
+	createTranslationInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "CreateTranslationInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"languageId": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"text":       &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"replacesId": &graphql.InputObjectFieldConfig{Type: graphql.ID, Description: "Approved translation this one should supersede"},
+		},
+	})
+
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createEntry": &graphql.Field{
+				Type: graphql.NewNonNull(entryType),
+				Args: graphql.FieldConfigArgument{
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(createEntryInput)},
+				},
+				Resolve: s.mutate(func(p graphql.ResolveParams, _ uuid.UUID) (interface{}, error) {
+					in := input(p.Args)
+					req := &request.CreateEntryRequest{
+						Word:             stringArg(in, "word"),
+						Type:             stringArg(in, "type"),
+						SourceLanguageID: stringArg(in, "sourceLanguageId"),
+						HomographIndex:   intArg(in, "homographIndex"),
+						Pronunciation:    stringArg(in, "pronunciation"),
+					}
+					if err := validate(req); err != nil {
+						return nil, err
+					}
+
+					entry, err := s.entryService.CreateEntry(p.Context, req)
+					if err != nil {
+						return nil, serviceError(s.logger, err, "Entry", "create entry")
+					}
+					return entry, nil
+				}),
+			},
+			"updateEntry": &graphql.Field{
+				Type: graphql.NewNonNull(entryType),
+				Args: graphql.FieldConfigArgument{
+					"id":      id,
+					"version": version,
+					"input":   &graphql.ArgumentConfig{Type: graphql.NewNonNull(updateEntryInput)},
+				},
+				Resolve: s.mutate(func(p graphql.ResolveParams, _ uuid.UUID) (interface{}, error) {
+					entryID, err := idArg(p.Args, "id")
+					if err != nil {
+						return nil, err
+					}
+					in := input(p.Args)
+					req := &request.UpdateEntryRequest{
+						Word:           stringArg(in, "word"),
+						Type:           stringArg(in, "type"),
+						HomographIndex: intArg(in, "homographIndex"),
+						Pronunciation:  stringArg(in, "pronunciation"),
+					}
+					if req.Version, err = s.versionArg(p.Args); err != nil {
+						return nil, err
+					}
+					if err := validate(req); err != nil {
+						return nil, err
+					}
+
+					entry, err := s.entryService.UpdateEntry(p.Context, entryID, req)
+					if err != nil {
+						return nil, serviceError(s.logger, err, "Entry", "update entry")
+					}
+					return entry, nil
+				}),
+			},
+			"deleteEntry": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Boolean),
+				Description: "Deletes an entry with its meanings and translations",
+				Args:        graphql.FieldConfigArgument{"id": id, "version": version},
+				Resolve: s.mutate(func(p graphql.ResolveParams, _ uuid.UUID) (interface{}, error) {
+					return s.delete(p, "Entry", "delete entry", s.entryService.DeleteEntry)
+				}),
+			},
+			"addMeaning": &graphql.Field{
+				Type: graphql.NewNonNull(meaningType),
+				Args: graphql.FieldConfigArgument{
+					"entryId": id,
+					"input":   &graphql.ArgumentConfig{Type: graphql.NewNonNull(createMeaningInput)},
+				},
+				Resolve: s.mutate(func(p graphql.ResolveParams, _ uuid.UUID) (interface{}, error) {
+					entryID, err := idArg(p.Args, "entryId")
+					if err != nil {
+						return nil, err
+					}
+					in := input(p.Args)
+					partOfSpeechID, err := idArg(in, "partOfSpeechId")
+					if err != nil {
+						return nil, err
+					}
+					req := &request.CreateMeaningRequest{
+						PartOfSpeechID: partOfSpeechID,
+						Description:    stringArg(in, "description"),
+						Examples:       stringsArg(in, "examples"),
+						Labels:         stringsArg(in, "labels"),
+					}
+					if err := validate(req); err != nil {
+						return nil, err
+					}
+
+					meaning, err := s.entryService.AddMeaning(p.Context, entryID, req)
+					if err != nil {
+						return nil, serviceError(s.logger, err, "Entry", "add meaning")
+					}
+					return s.reread(p, meaning.ID)
+				}),
+			},
+			"updateMeaning": &graphql.Field{
+				Type: graphql.NewNonNull(meaningType),
+				Args: graphql.FieldConfigArgument{
+					"id":      id,
+					"version": version,
+					"input":   &graphql.ArgumentConfig{Type: graphql.NewNonNull(updateMeaningInput)},
+				},
+				Resolve: s.mutate(func(p graphql.ResolveParams, _ uuid.UUID) (interface{}, error) {
+					meaningID, err := idArg(p.Args, "id")
+					if err != nil {
+						return nil, err
+					}
+					in := input(p.Args)
+					partOfSpeechID, err := optionalIDArg(in, "partOfSpeechId")
+					if err != nil {
+						return nil, err
+					}
+					req := &request.UpdateMeaningRequest{
+						PartOfSpeechID: partOfSpeechID,
+						Description:    stringArg(in, "description"),
+						Examples:       stringsArg(in, "examples"),
+						Labels:         stringsArg(in, "labels"),
+					}
+					if req.Version, err = s.versionArg(p.Args); err != nil {
+						return nil, err
+					}
+					if err := validate(req); err != nil {
+						return nil, err
+					}
+
+					meaning, err := s.entryService.UpdateMeaning(p.Context, meaningID, req)
+					if err != nil {
+						return nil, serviceError(s.logger, err, "Meaning", "update meaning")
+					}
+					return s.reread(p, meaning.ID)
+				}),
+			},
+			"deleteMeaning": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Args: graphql.FieldConfigArgument{"id": id, "version": version},
+				Resolve: s.mutate(func(p graphql.ResolveParams, _ uuid.UUID) (interface{}, error) {
+					return s.delete(p, "Meaning", "delete meaning", s.entryService.DeleteMeaning)
+				}),
+			},
+			"createTranslation": &graphql.Field{
+				Type:        graphql.NewNonNull(translationType),
+				Description: "Proposes a translation, which is public once a reviewer approves it",
+				Args: graphql.FieldConfigArgument{
+					"meaningId": id,
+					"input":     &graphql.ArgumentConfig{Type: graphql.NewNonNull(createTranslationInput)},
+				},
+				Resolve: s.mutate(func(p graphql.ResolveParams, userID uuid.UUID) (interface{}, error) {
+					meaningID, err := idArg(p.Args, "meaningId")
+					if err != nil {
+						return nil, err
+					}
+					in := input(p.Args)
+					req := &request.CreateTranslationRequest{
+						LanguageID: stringArg(in, "languageId"),
+						Text:       stringArg(in, "text"),
+						UserID:     userID,
+					}
+					replacesID, err := optionalIDArg(in, "replacesId")
+					if err != nil {
+						return nil, err
+					}
+					if replacesID != uuid.Nil {
+						req.ReplacesID = &replacesID
+					}
+					if err := validate(req); err != nil {
+						return nil, err
+					}
+
+					translation, err := s.translationService.CreateTranslation(p.Context, meaningID, req)
+					if err != nil {
+						return nil, serviceError(s.logger, err, "Translation", "create translation")
+					}
+					return translation, nil
+				}),
+			},
+			"updateTranslation": &graphql.Field{
+				Type: graphql.NewNonNull(translationType),
+				Args: graphql.FieldConfigArgument{
+					"id":      id,
+					"version": version,
+					"text":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: s.mutate(func(p graphql.ResolveParams, _ uuid.UUID) (interface{}, error) {
+					translationID, err := idArg(p.Args, "id")
+					if err != nil {
+						return nil, err
+					}
+					req := &request.UpdateTranslationRequest{Text: stringArg(p.Args, "text")}
+					if req.Version, err = s.versionArg(p.Args); err != nil {
+						return nil, err
+					}
+					if err := validate(req); err != nil {
+						return nil, err
+					}
+
+					translation, err := s.translationService.UpdateTranslation(p.Context, translationID, req)
+					if err != nil {
+						return nil, serviceError(s.logger, err, "Translation", "update translation")
+					}
+					return translation, nil
+				}),
+			},
+			"deleteTranslation": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Args: graphql.FieldConfigArgument{"id": id, "version": version},
+				Resolve: s.mutate(func(p graphql.ResolveParams, _ uuid.UUID) (interface{}, error) {
+					return s.delete(p, "Translation", "delete translation", s.translationService.DeleteTranslation)
+				}),
+			},
+			"addMeaningComment": &graphql.Field{
+				Type: graphql.NewNonNull(commentType),
+				Args: graphql.FieldConfigArgument{
+					"meaningId": id,
+					"content":   &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: s.mutate(func(p graphql.ResolveParams, userID uuid.UUID) (interface{}, error) {
+					return s.comment(p, userID, "meaningId", "Meaning", s.entryService.AddMeaningComment)
+				}),
+			},
+			"addTranslationComment": &graphql.Field{
+				Type: graphql.NewNonNull(commentType),
+				Args: graphql.FieldConfigArgument{
+					"translationId": id,
+					"content":       &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: s.mutate(func(p graphql.ResolveParams, userID uuid.UUID) (interface{}, error) {
+					return s.comment(p, userID, "translationId", "Translation", s.translationService.AddTranslationComment)
+				}),
+			},
+			"toggleMeaningLike": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Boolean),
+				Description: "Likes a meaning, or takes a like back",
+				Args:        graphql.FieldConfigArgument{"meaningId": id},
+				Resolve: s.mutate(func(p graphql.ResolveParams, userID uuid.UUID) (interface{}, error) {
+					return s.toggleLike(p, userID, "meaningId", "Meaning", s.entryService.ToggleMeaningLike)
+				}),
+			},
+			"toggleTranslationLike": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Boolean),
+				Description: "Likes a translation, or takes a like back",
+				Args:        graphql.FieldConfigArgument{"translationId": id},
+				Resolve: s.mutate(func(p graphql.ResolveParams, userID uuid.UUID) (interface{}, error) {
+					return s.toggleLike(p, userID, "translationId", "Translation", s.translationService.ToggleTranslationLike)
+				}),
+			},
+		},
+	})
+}
+
+// reread returns a meaning as queries see it, since the entry service leaves
+// out the name of its part of speech
+func (s *Schema) reread(p graphql.ResolveParams, meaningID uuid.UUID) (interface{}, error) {
+	meaning, err := s.graphService.GetMeaning(p.Context, meaningID)
+	if err != nil {
+		return nil, serviceError(s.logger, err, "Meaning", "get meaning")
+	}
+	return meaning, nil
+}
+
+// delete runs a delete mutation
+func (s *Schema) delete(p graphql.ResolveParams, kind, action string, remove func(ctx context.Context, id uuid.UUID, version int) error) (interface{}, error) {
+	id, err := idArg(p.Args, "id")
+	if err != nil {
+		return nil, err
+	}
+	version, err := s.versionArg(p.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := remove(p.Context, id, version); err != nil {
+		return nil, serviceError(s.logger, err, kind, action)
+	}
+	return true, nil
+}
+
+// comment runs a mutation that comments on the target named by idName
+func (s *Schema) comment(
+	p graphql.ResolveParams,
+	userID uuid.UUID,
+	idName, kind string,
+	add func(ctx context.Context, targetID uuid.UUID, req *request.CreateCommentRequest) (*response.CommentResponse, error),
+) (interface{}, error) {
+	targetID, err := idArg(p.Args, idName)
+	if err != nil {
+		return nil, err
+	}
+	req := &request.CreateCommentRequest{Content: stringArg(p.Args, "content"), UserID: userID}
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+
+	comment, err := add(p.Context, targetID, req)
+	if err != nil {
+		return nil, serviceError(s.logger, err, kind, "add comment")
+	}
+	// The author is loaded by ID like on any other comment
+	comment.User.ID = userID
+	return comment, nil
+}
+
+// toggleLike runs a mutation that toggles a like on the target named by idName
+func (s *Schema) toggleLike(
+	p graphql.ResolveParams,
+	userID uuid.UUID,
+	idName, kind string,
+	toggle func(ctx context.Context, targetID, userID uuid.UUID) error,
+) (interface{}, error) {
+	targetID, err := idArg(p.Args, idName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := toggle(p.Context, targetID, userID); err != nil {
+		return nil, serviceError(s.logger, err, kind, "toggle like")
+	}
+	return true, nil
+}
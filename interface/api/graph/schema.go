@@ -0,0 +1,240 @@
+// Package graph serves the dictionary as a GraphQL graph, so a client can
+// fetch an entry with its meanings, translations and comments in one request
+package graph
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+
+	"github.com/valpere/trytrago/application/dto/request"
+	"github.com/valpere/trytrago/application/service"
+	"github.com/valpere/trytrago/domain/database"
+	"github.com/valpere/trytrago/domain/logging"
+)
+
+// Request is a GraphQL request as clients post it
+type Request struct {
+	Query         string                 `json:"query" binding:"required"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Schema runs GraphQL requests against the dictionary services
+type Schema struct {
+	schema             graphql.Schema
+	entryService       service.EntryService
+	translationService service.TranslationService
+	graphService       service.GraphService
+	limits             Limits
+	requireVersion     bool
+	logger             logging.Logger
+}
+
+// NewSchema creates the GraphQL schema. Reads go through graphService, which
+// loads each level of a query at once; mutations go through the same entry
+// and translation services as the REST API. When requireVersion is set,
+// updates and deletes must name the version they change, like If-Match
+func NewSchema(
+	entryService service.EntryService,
+	translationService service.TranslationService,
+	graphService service.GraphService,
+	limits Limits,
+	requireVersion bool,
+	logger logging.Logger,
+) (*Schema, error) {
+	s := &Schema{
+		entryService:       entryService,
+		translationService: translationService,
+		graphService:       graphService,
+		limits:             limits,
+		requireVersion:     requireVersion,
+		logger:             logger.With(logging.String("component", "graphql")),
+	}
+
+	entryType, meaningType, translationType, commentType, userType, languageType := s.buildTypes()
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query:    s.queryType(entryType, meaningType, userType, languageType),
+		Mutation: s.mutationType(entryType, meaningType, translationType, commentType),
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.schema = schema
+
+	return s, nil
+}
+
+// Execute runs a request. It returns false when the request could not be
+// run at all, because it does not parse, is not valid against the schema
+// or goes past the query limits; errors of single fields leave it true
+func (s *Schema) Execute(ctx context.Context, req *Request) (*graphql.Result, bool) {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"}),
+	})
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}, false
+	}
+
+	validation := graphql.ValidateDocument(&s.schema, doc, nil)
+	if !validation.IsValid {
+		return &graphql.Result{Errors: validation.Errors}, false
+	}
+
+	if err := checkLimits(&s.schema, doc, req.OperationName, req.Variables, s.limits); err != nil {
+		errs := gqlerrors.FormatErrors(err)
+		withExtensions(errs)
+		return &graphql.Result{Errors: errs}, false
+	}
+
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        s.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       context.WithValue(ctx, loadersKey, newLoaders(s.graphService)),
+	})
+	withExtensions(result.Errors)
+
+	return result, true
+}
+
+// withExtensions sets the codes of errors the executor did not format itself,
+// like those returned by loader thunks, whose extensions it drops
+func withExtensions(errs []gqlerrors.FormattedError) {
+	for i := range errs {
+		if errs[i].Extensions != nil {
+			continue
+		}
+		err := errs[i].OriginalError()
+		for err != nil {
+			if graphErr, ok := err.(*graphError); ok {
+				errs[i].Extensions = graphErr.Extensions()
+				break
+			}
+			switch wrapped := err.(type) {
+			case *gqlerrors.Error:
+				err = wrapped.OriginalError
+			case gqlerrors.FormattedError:
+				err = wrapped.OriginalError()
+			default:
+				err = errors.Unwrap(err)
+			}
+		}
+	}
+}
+
+// queryType creates the root query type
+func (s *Schema) queryType(entryType, meaningType, userType, languageType *graphql.Object) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"entry": &graphql.Field{
+				Type:        entryType,
+				Description: "The entry with the given ID, null when there is none",
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := idArg(p.Args, "id")
+					if err != nil {
+						return nil, err
+					}
+					return s.resolved(loadersFrom(p.Context).entries.load(p.Context, id), "Entry", "load entries"), nil
+				},
+			},
+			"entries": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(entryType))),
+				Description: "Entries whose word contains the given text",
+				Args: graphql.FieldConfigArgument{
+					"word":   &graphql.ArgumentConfig{Type: graphql.String},
+					"type":   &graphql.ArgumentConfig{Type: graphql.String, Description: "WORD, COMPOUND_WORD or PHRASE"},
+					"limit":  &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 20, Description: "At most 100"},
+					"offset": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 0},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					req := &request.ListEntriesRequest{
+						WordFilter: stringArg(p.Args, "word"),
+						Type:       stringArg(p.Args, "type"),
+						Limit:      intArg(p.Args, "limit"),
+						Offset:     intArg(p.Args, "offset"),
+					}
+					if err := binding.Validator.ValidateStruct(req); err != nil {
+						return nil, badInput(err)
+					}
+
+					entries, err := s.graphService.ListEntries(p.Context, req)
+					if err != nil {
+						return nil, serviceError(s.logger, err, "Entry", "list entries")
+					}
+					return entries, nil
+				},
+			},
+			"meaning": &graphql.Field{
+				Type:        meaningType,
+				Description: "The meaning with the given ID, null when there is none",
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := idArg(p.Args, "id")
+					if err != nil {
+						return nil, err
+					}
+
+					meaning, err := s.graphService.GetMeaning(p.Context, id)
+					if err != nil {
+						if database.IsNotFoundError(err) {
+							return nil, nil
+						}
+						return nil, serviceError(s.logger, err, "Meaning", "get meaning")
+					}
+					return meaning, nil
+				},
+			},
+			"user": &graphql.Field{
+				Type:        userType,
+				Description: "The user with the given ID, null when there is none",
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := idArg(p.Args, "id")
+					if err != nil {
+						return nil, err
+					}
+					return s.resolved(loadersFrom(p.Context).users.load(p.Context, id), "User", "load users"), nil
+				},
+			},
+			"languages": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(languageType))),
+				Description: "Languages entries and translations can be written in",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					languages, err := s.graphService.ListLanguages(p.Context)
+					if err != nil {
+						return nil, serviceError(s.logger, err, "Language", "list languages")
+					}
+					return languages, nil
+				},
+			},
+		},
+	})
+}
+
+// stringArg reads an optional string argument
+func stringArg(args map[string]interface{}, name string) string {
+	value, _ := args[name].(string)
+	return value
+}
+
+// intArg reads an optional integer argument
+func intArg(args map[string]interface{}, name string) int {
+	value, _ := args[name].(int)
+	return value
+}
@@ -0,0 +1,249 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+
+	"github.com/valpere/trytrago/application/dto/response"
+)
+
+// Fields without a resolver are read from the response DTOs, whose field
+// names match the camelCase names of the schema case-insensitively
+
+// resolved wraps a loader thunk so a failed load reaches the client as a service error
+func (s *Schema) resolved(thunk func() (interface{}, error), kind, action string) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		value, err := thunk()
+		if err != nil {
+			return nil, serviceError(s.logger, err, kind, action)
+		}
+		return value, nil
+	}
+}
+
+// languageField resolves a language from the code code returns
+func (s *Schema) languageField(languageType *graphql.Object, code func(source interface{}) string) *graphql.Field {
+	return &graphql.Field{
+		Type: languageType,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			languageCode := code(p.Source)
+			if languageCode == "" {
+				return nil, nil
+			}
+			return s.resolved(loadersFrom(p.Context).languages.load(p.Context, languageCode), "Language", "load languages"), nil
+		},
+	}
+}
+
+// commentsField resolves the comments on a target, newest first
+func (s *Schema) commentsField(commentType *graphql.Object, comments func(ctx context.Context, source interface{}) func() (interface{}, error)) *graphql.Field {
+	return &graphql.Field{
+		Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(commentType))),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return s.resolved(comments(p.Context, p.Source), "Comment", "load comments"), nil
+		},
+	}
+}
+
+// buildTypes creates the object types of the dictionary graph
+func (s *Schema) buildTypes() (entryType, meaningType, translationType, commentType, userType, languageType *graphql.Object) {
+	languageType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Language",
+		Description: "A language entries and translations are written in",
+		Fields: graphql.Fields{
+			"code":       &graphql.Field{Type: graphql.NewNonNull(graphql.String), Description: "ISO 639-1 code"},
+			"name":       &graphql.Field{Type: graphql.NewNonNull(graphql.String), Description: "English name"},
+			"nativeName": &graphql.Field{Type: graphql.String, Description: "Name in the language itself"},
+			"rtl":        &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean), Description: "Whether it is written right to left"},
+		},
+	})
+
+	userType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "User",
+		Description: "A contributor",
+		Fields: graphql.Fields{
+			"id":       &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"username": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"avatar":   &graphql.Field{Type: graphql.String},
+		},
+	})
+
+	commentType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Comment",
+		Description: "A comment on a meaning or a translation",
+		Fields: graphql.Fields{
+			"id":      &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"content": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"user": &graphql.Field{
+				Type:        userType,
+				Description: "Author of the comment, null once their account is deleted",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					comment := p.Source.(*response.CommentResponse)
+					return s.resolved(loadersFrom(p.Context).users.load(p.Context, comment.User.ID), "User", "load users"), nil
+				},
+			},
+			"createdAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"updatedAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+		},
+	})
+
+	exampleType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Example",
+		Description: "An example of a meaning in use",
+		Fields: graphql.Fields{
+			"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"text":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"context":   &graphql.Field{Type: graphql.String},
+			"createdAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"updatedAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+		},
+	})
+
+	translationType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Translation",
+		Description: "A translation of a meaning into another language",
+		Fields: graphql.Fields{
+			"id":         &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"meaningId":  &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"languageId": &graphql.Field{Type: graphql.NewNonNull(graphql.String), Description: "ISO 639-1 code"},
+			"language": s.languageField(languageType, func(source interface{}) string {
+				return source.(*response.TranslationResponse).LanguageID
+			}),
+			"text":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"status": &graphql.Field{Type: graphql.String, Description: "Review status: PROPOSED, APPROVED, REJECTED or SUPERSEDED"},
+			"likesCount": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					translation := p.Source.(*response.TranslationResponse)
+					return s.resolved(loadersFrom(p.Context).translationLikes.load(p.Context, translation.ID), "Translation", "count likes"), nil
+				},
+			},
+			"comments": s.commentsField(commentType, func(ctx context.Context, source interface{}) func() (interface{}, error) {
+				return loadersFrom(ctx).translationComments.load(ctx, source.(*response.TranslationResponse).ID)
+			}),
+			"version":   &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"createdAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"updatedAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+		},
+	})
+
+	meaningType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Meaning",
+		Description: "One sense of an entry",
+		Fields: graphql.Fields{
+			"id":           &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"entryId":      &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"partOfSpeech": &graphql.Field{Type: graphql.String},
+			"description":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"labels": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if labels := p.Source.(*response.MeaningResponse).Labels; labels != nil {
+						return labels, nil
+					}
+					return []string{}, nil
+				},
+			},
+			"examples": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(exampleType))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if examples := p.Source.(*response.MeaningResponse).Examples; examples != nil {
+						return examples, nil
+					}
+					return []response.ExampleResponse{}, nil
+				},
+			},
+			"translations": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(translationType))),
+				Description: "Approved translations, in the order they were added",
+				Args: graphql.FieldConfigArgument{
+					"language": &graphql.ArgumentConfig{Type: graphql.String, Description: "Only translations into this language"},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					meaning := p.Source.(*response.MeaningResponse)
+					language, _ := p.Args["language"].(string)
+					thunk := s.resolved(loadersFrom(p.Context).translations.load(p.Context, meaning.ID), "Translation", "load translations")
+					return func() (interface{}, error) {
+						value, err := thunk()
+						if err != nil {
+							return nil, err
+						}
+						translations := []*response.TranslationResponse{}
+						for _, translation := range value.([]*response.TranslationResponse) {
+							if language == "" || translation.LanguageID == language {
+								translations = append(translations, translation)
+							}
+						}
+						return translations, nil
+					}, nil
+				},
+			},
+			"comments": s.commentsField(commentType, func(ctx context.Context, source interface{}) func() (interface{}, error) {
+				return loadersFrom(ctx).meaningComments.load(ctx, source.(*response.MeaningResponse).ID)
+			}),
+			"likesCount": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					meaning := p.Source.(*response.MeaningResponse)
+					return s.resolved(loadersFrom(p.Context).meaningLikes.load(p.Context, meaning.ID), "Meaning", "count likes"), nil
+				},
+			},
+			"version":   &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"createdAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"updatedAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+		},
+	})
+
+	entryType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Entry",
+		Description: "A dictionary entry",
+		Fields: graphql.Fields{
+			"id":               &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"word":             &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"type":             &graphql.Field{Type: graphql.NewNonNull(graphql.String), Description: "WORD, COMPOUND_WORD or PHRASE"},
+			"sourceLanguageId": &graphql.Field{Type: graphql.String, Description: "ISO 639-1 code"},
+			"sourceLanguage": s.languageField(languageType, func(source interface{}) string {
+				return source.(*response.EntryResponse).SourceLanguageID
+			}),
+			"homographIndex": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"displayWord":    &graphql.Field{Type: graphql.NewNonNull(graphql.String), Description: "Word with its homograph index when it has homographs, e.g. \"bank²\""},
+			"pronunciation":  &graphql.Field{Type: graphql.String},
+			"etymology":      &graphql.Field{Type: graphql.String},
+			"version":        &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"meanings": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(meaningType))),
+				Description: "Meanings in the order they were added",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					entry := p.Source.(*response.EntryResponse)
+					return s.resolved(loadersFrom(p.Context).meanings.load(p.Context, entry.ID), "Meaning", "load meanings"), nil
+				},
+			},
+			"createdAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"updatedAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+		},
+	})
+
+	// Added last, as entries and meanings refer to each other
+	meaningType.AddFieldConfig("entry", &graphql.Field{
+		Type:        graphql.NewNonNull(entryType),
+		Description: "The entry the meaning belongs to",
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			meaning := p.Source.(*response.MeaningResponse)
+			return s.resolved(loadersFrom(p.Context).entries.load(p.Context, meaning.EntryID), "Entry", "load entries"), nil
+		},
+	})
+
+	return entryType, meaningType, translationType, commentType, userType, languageType
+}
+
+// idArg reads an ID argument
+func idArg(args map[string]interface{}, name string) (uuid.UUID, error) {
+	value, _ := args[name].(string)
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, &graphError{message: "invalid " + name + ": " + value, code: codeBadUserInput}
+	}
+	return id, nil
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/valpere/trytrago/application/dto/request"
+	"github.com/valpere/trytrago/application/service"
+	"github.com/valpere/trytrago/domain/database"
+	domainErrors "github.com/valpere/trytrago/domain/errors"
+	"github.com/valpere/trytrago/domain/logging"
+	"github.com/valpere/trytrago/domain/utils"
+)
+
+// BatchHandler implements the BatchHandlerInterface
+type BatchHandler struct {
+	service service.BatchService
+	logger  logging.Logger
+}
+
+// NewBatchHandler creates a new instance of BatchHandler
+func NewBatchHandler(service service.BatchService, logger logging.Logger) *BatchHandler {
+	return &BatchHandler{
+		service: service,
+		logger:  logger.With(logging.String("component", "batch_handler")),
+	}
+}
+
+// ExecuteBatch handles POST /api/v1/batch
+func (h *BatchHandler) ExecuteBatch(c *gin.Context) {
+	var req request.BatchRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("invalid batch request", logging.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	// Sanitize the free text fields, as the single-operation endpoints do
+	for _, op := range req.Operations {
+		if op.Entry != nil {
+			op.Entry.Word = utils.SanitizeString(op.Entry.Word)
+			op.Entry.Pronunciation = utils.SanitizeString(op.Entry.Pronunciation)
+		}
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("userID")
+	if !exists {
+		h.logger.Error("user ID not found in context")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Authentication error"})
+		return
+	}
+	req.UserID = userID.(uuid.UUID)
+
+	resp, err := h.service.ExecuteBatch(c.Request.Context(), &req)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// writeError responds to a batch that was not applied. When one operation
+// failed, the response names it so the client can correct it and resubmit
+func (h *BatchHandler) writeError(c *gin.Context, err error) {
+	if errors.Is(err, domainErrors.ErrBatchTooLarge) {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+		return
+	}
+
+	body := gin.H{"error": err.Error()}
+	var opErr *service.BatchOperationError
+	if errors.As(err, &opErr) {
+		body["index"] = opErr.Index
+		body["op"] = opErr.Op
+	}
+
+	switch {
+	case errors.Is(err, domainErrors.ErrValidation), errors.Is(err, database.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, body)
+	case database.IsNotFoundError(err):
+		c.JSON(http.StatusNotFound, body)
+	case database.IsDuplicateError(err):
+		c.JSON(http.StatusConflict, body)
+	default:
+		h.logger.Error("failed to execute batch", logging.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to execute batch"})
+	}
+}
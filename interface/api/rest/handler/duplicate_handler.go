@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/valpere/trytrago/application/dto/request"
+	"github.com/valpere/trytrago/application/service"
+	"github.com/valpere/trytrago/domain/database"
+	"github.com/valpere/trytrago/domain/logging"
+)
+
+// DuplicateHandler implements the DuplicateHandlerInterface
+type DuplicateHandler struct {
+	service service.DuplicateService
+	logger  logging.Logger
+}
+
+// NewDuplicateHandler creates a new instance of DuplicateHandler
+func NewDuplicateHandler(service service.DuplicateService, logger logging.Logger) *DuplicateHandler {
+	return &DuplicateHandler{
+		service: service,
+		logger:  logger.With(logging.String("component", "duplicate_handler")),
+	}
+}
+
+// ListDuplicates handles GET /api/v1/admin/duplicates
+func (h *DuplicateHandler) ListDuplicates(c *gin.Context) {
+	var req request.DuplicateReportRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Warn("invalid duplicate report request", logging.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+
+	resp, err := h.service.FindDuplicates(c.Request.Context(), &req)
+	if err != nil {
+		h.logger.Error("failed to build duplicate report", logging.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build duplicate report"})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// MergeEntries handles POST /api/v1/admin/entries/:id/merge
+func (h *DuplicateHandler) MergeEntries(c *gin.Context) {
+	idParam := c.Param("id")
+
+	// Parse source entry UUID
+	sourceID, err := uuid.Parse(idParam)
+	if err != nil {
+		h.logger.Warn("invalid entry ID format", logging.String("id", idParam))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid entry ID format"})
+		return
+	}
+
+	var req request.MergeEntriesRequest
+
+	// Bind JSON body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("invalid merge entries request", logging.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("userID")
+	if !exists {
+		h.logger.Error("user ID not found in context")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Authentication error"})
+		return
+	}
+	req.UserID = userID.(uuid.UUID)
+
+	resp, err := h.service.MergeEntries(c.Request.Context(), sourceID, &req)
+	if err != nil {
+		switch {
+		case database.IsNotFoundError(err):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Entry not found"})
+		case errors.Is(err, database.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "An entry cannot be merged into itself"})
+		default:
+			h.logger.Error("failed to merge entries",
+				logging.Error(err),
+				logging.String("sourceId", idParam),
+			)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to merge entries"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
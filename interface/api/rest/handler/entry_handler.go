@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -14,6 +16,9 @@ import (
 	"github.com/valpere/trytrago/interface/api/rest/middleware"
 )
 
+// streamPageSize is how many entries a streamed CSV list loads at a time
+const streamPageSize = 100
+
 // EntryHandler implements the EntryHandlerInterface
 type EntryHandler struct {
 	service service.EntryService
@@ -44,7 +49,80 @@ func (h *EntryHandler) ListEntries(c *gin.Context) {
 		req.WordFilter = utils.SanitizeString(req.WordFilter)
 	}
 
-	// Rest of the implementation...
+	// Without a limit a CSV download holds every matching entry
+	if middleware.ResponseFormat(c) == middleware.FormatCSV && req.Limit == 0 {
+		h.streamEntries(c, &req)
+		return
+	}
+
+	// Set default values if not provided
+	if req.Limit == 0 {
+		req.Limit = 20
+	}
+
+	// Call service
+	resp, err := h.service.ListEntries(c.Request.Context(), &req)
+	if err != nil {
+		h.logger.Error("failed to list entries", logging.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve entries"})
+		return
+	}
+
+	render(c, http.StatusOK, resp, entryListView)
+}
+
+// streamEntries sends the entries matching req as CSV, loading them a page at
+// a time and writing each page out before the next, so that a large
+// dictionary is never held in memory. Pages follow entry IDs rather than
+// offsets, so each costs the same and writes meanwhile do not shift them
+func (h *EntryHandler) streamEntries(c *gin.Context, req *request.ListEntriesRequest) {
+	req.Limit = streamPageSize
+	after := uuid.Nil
+	req.AfterID = &after
+
+	// The first page is loaded before anything is sent, so failures still get a proper error response
+	resp, err := h.service.ListEntries(c.Request.Context(), req)
+	if err != nil {
+		h.logger.Error("failed to list entries", logging.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve entries"})
+		return
+	}
+
+	// A large dictionary takes longer to stream than the server write timeout allows
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		h.logger.Debug("cannot lift write deadline for entry list", logging.Error(err))
+	}
+
+	c.Header("Content-Type", contentTypes[middleware.FormatCSV])
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", entryListView.filename()))
+	c.Status(http.StatusOK)
+
+	list, err := newListWriter(c.Writer, middleware.FormatCSV, entryListView, csvColumns(c, entryListView), nil)
+	for err == nil {
+		for _, entry := range resp.Entries {
+			if err = list.item(entry); err != nil {
+				break
+			}
+		}
+		if err == nil {
+			err = list.flush()
+		}
+		if err != nil || len(resp.Entries) < req.Limit {
+			break
+		}
+		c.Writer.Flush()
+
+		after = resp.Entries[len(resp.Entries)-1].ID
+		resp, err = h.service.ListEntries(c.Request.Context(), req)
+	}
+	if err == nil {
+		err = list.close()
+	}
+
+	if err != nil {
+		// The status line is already out; the client gets a truncated list
+		h.logger.Error("entry list interrupted", logging.Error(err), logging.String("after", after.String()))
+	}
 }
 
 // GetEntry handles GET /api/v1/entries/:id
@@ -80,7 +158,15 @@ func (h *EntryHandler) GetEntry(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, resp)
+	// A merged entry resolves to its replacement; point clients at the canonical URL
+	if resp.ID != id {
+		c.Header("Content-Location", "/api/v1/entries/"+resp.ID.String())
+	}
+
+	if notModified(c, versionETag(c, resp.Version), resp.UpdatedAt) {
+		return
+	}
+	render(c, http.StatusOK, resp, entryView)
 }
 
 // UpdateEntry handles PUT /api/v1/entries/:id
@@ -104,6 +190,12 @@ func (h *EntryHandler) UpdateEntry(c *gin.Context) {
 		return
 	}
 
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+	req.Version = version
+
 	// Call service
 	resp, err := h.service.UpdateEntry(c.Request.Context(), id, &req)
 	if err != nil {
@@ -111,12 +203,21 @@ func (h *EntryHandler) UpdateEntry(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Entry not found"})
 			return
 		}
+		if database.IsDuplicateError(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Homograph index is already taken for this word"})
+			return
+		}
+		if database.IsVersionConflictError(err) {
+			writeVersionConflict(c, "Entry has been changed since it was read")
+			return
+		}
 
 		h.logger.Error("failed to update entry", logging.Error(err), logging.String("id", idParam))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update entry"})
 		return
 	}
 
+	setVersionETag(c, resp.Version)
 	c.JSON(http.StatusOK, resp)
 }
 
@@ -132,13 +233,22 @@ func (h *EntryHandler) DeleteEntry(c *gin.Context) {
 		return
 	}
 
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
 	// Call service
-	err = h.service.DeleteEntry(c.Request.Context(), id)
+	err = h.service.DeleteEntry(c.Request.Context(), id, version)
 	if err != nil {
 		if database.IsNotFoundError(err) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Entry not found"})
 			return
 		}
+		if database.IsVersionConflictError(err) {
+			writeVersionConflict(c, "Entry has been changed since it was read")
+			return
+		}
 
 		h.logger.Error("failed to delete entry", logging.Error(err), logging.String("id", idParam))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete entry"})
@@ -173,7 +283,16 @@ func (h *EntryHandler) ListMeanings(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, resp)
+	// Meanings of a merged entry are listed from its replacement
+	if len(resp.Meanings) > 0 && resp.Meanings[0].EntryID != id {
+		c.Header("Content-Location", "/api/v1/entries/"+resp.Meanings[0].EntryID.String()+"/meanings")
+	}
+
+	// The list is a view of the entry, so it is current as long as the entry is
+	if notModified(c, versionETag(c, resp.EntryVersion), resp.EntryUpdatedAt) {
+		return
+	}
+	render(c, http.StatusOK, resp, meaningListView)
 }
 
 // GetMeaning retrieves a specific meaning
@@ -230,7 +349,15 @@ func (h *EntryHandler) GetMeaning(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, meaningResp)
+	// A meaning of a merged entry now lives under the entry it was merged into
+	if meaningResp.EntryID != entryID {
+		c.Header("Content-Location", "/api/v1/meaning-details/"+meaningResp.EntryID.String()+"/"+meaningID.String())
+	}
+
+	if notModified(c, versionETag(c, meaningResp.Version), meaningResp.UpdatedAt) {
+		return
+	}
+	render(c, http.StatusOK, meaningResp, meaningView)
 }
 
 // AddMeaning adds a new meaning to an entry
@@ -294,6 +421,12 @@ func (h *EntryHandler) UpdateMeaning(c *gin.Context) {
 		return
 	}
 
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+	req.Version = version
+
 	// Call service
 	resp, err := h.service.UpdateMeaning(c.Request.Context(), meaningID, &req)
 	if err != nil {
@@ -301,6 +434,10 @@ func (h *EntryHandler) UpdateMeaning(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Meaning not found"})
 			return
 		}
+		if database.IsVersionConflictError(err) {
+			writeVersionConflict(c, "Meaning has been changed since it was read")
+			return
+		}
 
 		h.logger.Error("failed to update meaning",
 			logging.Error(err),
@@ -310,6 +447,7 @@ func (h *EntryHandler) UpdateMeaning(c *gin.Context) {
 		return
 	}
 
+	setVersionETag(c, resp.Version)
 	c.JSON(http.StatusOK, resp)
 }
 
@@ -325,13 +463,22 @@ func (h *EntryHandler) DeleteMeaning(c *gin.Context) {
 		return
 	}
 
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
 	// Call service
-	err = h.service.DeleteMeaning(c.Request.Context(), meaningID)
+	err = h.service.DeleteMeaning(c.Request.Context(), meaningID, version)
 	if err != nil {
 		if database.IsNotFoundError(err) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Meaning not found"})
 			return
 		}
+		if database.IsVersionConflictError(err) {
+			writeVersionConflict(c, "Meaning has been changed since it was read")
+			return
+		}
 
 		h.logger.Error("failed to delete meaning",
 			logging.Error(err),
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/valpere/trytrago/application/dto/request"
+	"github.com/valpere/trytrago/application/service"
+	"github.com/valpere/trytrago/domain/database"
+	domainErrors "github.com/valpere/trytrago/domain/errors"
+	"github.com/valpere/trytrago/domain/logging"
+	"github.com/valpere/trytrago/domain/utils"
+)
+
+// EntryTreeHandler implements the EntryTreeHandlerInterface
+type EntryTreeHandler struct {
+	service service.EntryTreeService
+	logger  logging.Logger
+}
+
+// NewEntryTreeHandler creates a new instance of EntryTreeHandler
+func NewEntryTreeHandler(service service.EntryTreeService, logger logging.Logger) *EntryTreeHandler {
+	return &EntryTreeHandler{
+		service: service,
+		logger:  logger.With(logging.String("component", "entry_tree_handler")),
+	}
+}
+
+// CreateEntry handles POST /api/v2/entries
+func (h *EntryTreeHandler) CreateEntry(c *gin.Context) {
+	req, ok := h.bind(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.service.CreateEntryTree(c.Request.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainErrors.ErrValidation):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case database.IsDuplicateError(err):
+			c.JSON(http.StatusConflict, gin.H{"error": "Entry already exists"})
+		default:
+			h.logger.Error("failed to create entry tree", logging.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create entry"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// ReplaceEntry handles PUT /api/v2/entries/:id
+func (h *EntryTreeHandler) ReplaceEntry(c *gin.Context) {
+	idParam := c.Param("id")
+
+	// Parse UUID
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		h.logger.Warn("invalid entry ID format", logging.String("id", idParam))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid entry ID format"})
+		return
+	}
+
+	req, ok := h.bind(c)
+	if !ok {
+		return
+	}
+	if req.Version, ok = ifMatchVersion(c); !ok {
+		return
+	}
+
+	resp, err := h.service.ReplaceEntryTree(c.Request.Context(), id, req)
+	if err != nil {
+		switch {
+		case database.IsNotFoundError(err):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Entry not found"})
+		case errors.Is(err, domainErrors.ErrValidation), errors.Is(err, database.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case database.IsDuplicateError(err):
+			c.JSON(http.StatusConflict, gin.H{"error": "Homograph index is already taken for this word"})
+		case database.IsVersionConflictError(err):
+			writeVersionConflict(c, "Entry has been changed since it was read")
+		default:
+			h.logger.Error("failed to replace entry tree", logging.Error(err), logging.String("id", idParam))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update entry"})
+		}
+		return
+	}
+
+	setVersionETag(c, resp.Version)
+	c.JSON(http.StatusOK, resp)
+}
+
+// bind reads an entry tree from the body and attributes it to the caller,
+// writing the error response itself when it fails
+func (h *EntryTreeHandler) bind(c *gin.Context) (*request.EntryTreeRequest, bool) {
+	var req request.EntryTreeRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("invalid entry tree request", logging.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return nil, false
+	}
+
+	// Sanitize the free text fields, as the v1 endpoints do
+	req.Word = utils.SanitizeString(req.Word)
+	req.Pronunciation = utils.SanitizeString(req.Pronunciation)
+
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("userID")
+	if !exists {
+		h.logger.Error("user ID not found in context")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Authentication error"})
+		return nil, false
+	}
+	req.UserID = userID.(uuid.UUID)
+
+	return &req, true
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/valpere/trytrago/interface/api/rest/middleware"
+)
+
+// setVersionETag sends the version of a resource as its strong entity tag
+func setVersionETag(c *gin.Context, version int) {
+	c.Header("ETag", versionETag(c, version))
+}
+
+// versionETag returns the strong entity tag of a version in the format
+// negotiated for the request. JSON is tagged with the bare version; the other
+// formats are representations of their own and add their name, as in "3-csv"
+func versionETag(c *gin.Context, version int) string {
+	tag := strconv.Itoa(version)
+	if format := middleware.ResponseFormat(c); format != middleware.FormatJSON {
+		tag += "-" + format
+	}
+	return `"` + tag + `"`
+}
+
+// notModified sends the validators of a read and answers 304 Not Modified
+// when the client's copy is still current. If-None-Match is compared first;
+// If-Modified-Since is only looked at when it is absent. It reports whether
+// the response has been written
+func notModified(c *gin.Context, etag string, lastModified time.Time) bool {
+	c.Header("ETag", etag)
+	if !lastModified.IsZero() {
+		c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if header := c.GetHeader("If-None-Match"); header != "" {
+		if !etagListContains(header, etag) {
+			return false
+		}
+	} else if header := c.GetHeader("If-Modified-Since"); header != "" && !lastModified.IsZero() {
+		since, err := http.ParseTime(header)
+		if err != nil || lastModified.Truncate(time.Second).After(since) {
+			return false
+		}
+	} else {
+		return false
+	}
+
+	c.Status(http.StatusNotModified)
+	return true
+}
+
+// etagListContains reports whether an If-None-Match header names the tag.
+// The comparison is weak, so W/"3" matches "3"
+func etagListContains(header, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// ifMatchVersion reads the version a write expects from its If-Match header.
+// Without the header, or with "*", it returns 0 and the version is not
+// checked. Only the version is compared, so a tag taken from any format
+// matches. A header that cannot match a version this API issued, such as a
+// weak tag or a list of tags, is answered with 412 and ok is false
+func ifMatchVersion(c *gin.Context) (version int, ok bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return 0, true
+	}
+
+	if len(header) > 2 && strings.HasPrefix(header, `"`) && strings.HasSuffix(header, `"`) {
+		tag, _, _ := strings.Cut(header[1:len(header)-1], "-")
+		version, err := strconv.Atoi(tag)
+		if err == nil && version > 0 {
+			return version, true
+		}
+	}
+
+	c.JSON(http.StatusPreconditionFailed, gin.H{"error": "If-Match must be a single ETag as returned by the API"})
+	return 0, false
+}
+
+// writeVersionConflict responds to a write that another one got in before.
+// When the client sent If-Match its precondition failed; otherwise the version
+// read by the server itself went stale, and the request may simply be retried
+func writeVersionConflict(c *gin.Context, message string) {
+	status := http.StatusConflict
+	if header := strings.TrimSpace(c.GetHeader("If-Match")); header != "" && header != "*" {
+		status = http.StatusPreconditionFailed
+	}
+	c.JSON(status, gin.H{"error": message})
+}
@@ -0,0 +1,247 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/valpere/trytrago/application/dto/request"
+	"github.com/valpere/trytrago/application/service"
+	"github.com/valpere/trytrago/domain/logging"
+	"github.com/valpere/trytrago/infrastructure/exchange"
+)
+
+// ExchangeHandler implements the ExchangeHandlerInterface
+type ExchangeHandler struct {
+	service  service.ExchangeService
+	audioDir string
+	logger   logging.Logger
+}
+
+// NewExchangeHandler creates a new instance of ExchangeHandler. audioDir
+// holds the pronunciation recordings attached to Anki decks
+func NewExchangeHandler(service service.ExchangeService, audioDir string, logger logging.Logger) *ExchangeHandler {
+	return &ExchangeHandler{
+		service:  service,
+		audioDir: audioDir,
+		logger:   logger.With(logging.String("component", "exchange_handler")),
+	}
+}
+
+// ImportCSV handles POST /api/v1/admin/import/csv
+//
+// The multipart form carries the file in "file" and, optionally, a JSON
+// column mapping in "mapping". Files ending in .tsv default to tabs
+func (h *ExchangeHandler) ImportCSV(c *gin.Context) {
+	var req request.ImportRequest
+	if err := c.ShouldBind(&req); err != nil {
+		h.logger.Warn("invalid import request", logging.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid import options"})
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A file is required"})
+		return
+	}
+
+	mapping := &exchange.CSVMapping{}
+	if raw := c.PostForm("mapping"); raw != "" {
+		mapping, err = exchange.LoadCSVMapping(strings.NewReader(raw))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	if mapping.Delimiter == "" && strings.EqualFold(filepath.Ext(fileHeader.Filename), ".tsv") {
+		mapping.Delimiter = "\t"
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		h.logger.Error("failed to open uploaded file", logging.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read uploaded file"})
+		return
+	}
+	defer file.Close()
+
+	reader, err := exchange.NewCSVReader(file, mapping)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.runImport(c, reader, &req, fileHeader.Filename)
+}
+
+// ImportTEI handles POST /api/v1/admin/import/tei
+//
+// The multipart form carries a TEI Lex-0 document in "file"
+func (h *ExchangeHandler) ImportTEI(c *gin.Context) {
+	var req request.ImportRequest
+	if err := c.ShouldBind(&req); err != nil {
+		h.logger.Warn("invalid import request", logging.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid import options"})
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A file is required"})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		h.logger.Error("failed to open uploaded file", logging.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read uploaded file"})
+		return
+	}
+	defer file.Close()
+
+	h.runImport(c, exchange.NewTEIReader(file), &req, fileHeader.Filename)
+}
+
+// ImportTBX handles POST /api/v1/admin/import/tbx
+//
+// The multipart form carries a TBX termbase in "file". "language_id" picks
+// the language whose terms become entries and "default_pos" the part of
+// speech of terms without one
+func (h *ExchangeHandler) ImportTBX(c *gin.Context) {
+	var req request.ImportRequest
+	if err := c.ShouldBind(&req); err != nil {
+		h.logger.Warn("invalid import request", logging.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid import options"})
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A file is required"})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		h.logger.Error("failed to open uploaded file", logging.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read uploaded file"})
+		return
+	}
+	defer file.Close()
+
+	reader := exchange.NewTBXReader(file, exchange.TBXImportOptions{
+		SourceLanguageID:    c.PostForm("language_id"),
+		DefaultPartOfSpeech: c.DefaultPostForm("default_pos", "unspecified"),
+	})
+	h.runImport(c, reader, &req, fileHeader.Filename)
+}
+
+// runImport imports the records of an uploaded file and reports the outcome
+func (h *ExchangeHandler) runImport(c *gin.Context, reader exchange.RecordReader, req *request.ImportRequest, filename string) {
+	report, err := h.service.Import(c.Request.Context(), reader, req)
+	if err != nil {
+		h.logger.Error("import stopped early",
+			logging.Error(err),
+			logging.String("file", filename),
+		)
+		// The partial report carries the checkpoint to resume from
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":  "Import stopped before the end of the file",
+			"report": report,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// ExportTEI handles GET /api/v1/admin/export/tei
+func (h *ExchangeHandler) ExportTEI(c *gin.Context) {
+	var req request.ExportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Warn("invalid export request", logging.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+
+	writer := exchange.NewTEIWriter(c.Writer, exchange.DefaultTitle)
+	h.export(c, &req, "dictionary.tei.xml", "application/tei+xml; charset=utf-8", writer)
+}
+
+// ExportTBX handles GET /api/v1/admin/export/tbx
+func (h *ExchangeHandler) ExportTBX(c *gin.Context) {
+	var req request.ExportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Warn("invalid export request", logging.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+
+	writer := exchange.NewTBXWriter(c.Writer, exchange.DefaultTitle)
+	h.export(c, &req, "termbase.tbx", "application/x-tbx+xml; charset=utf-8", writer)
+}
+
+// ExportAnki handles GET /api/v1/export/anki
+//
+// The deck holds the entries selected by the query, or the words listed in
+// repeated "word" parameters
+func (h *ExchangeHandler) ExportAnki(c *gin.Context) {
+	var req request.AnkiExportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Warn("invalid export request", logging.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+
+	// The package is assembled before any of it is sent, so failures still
+	// get a proper error response
+	writer, err := exchange.NewAnkiWriter(c.Writer, exchange.AnkiOptions{
+		DeckName:  req.Deck,
+		Templates: req.Cards,
+		AudioDir:  h.audioDir,
+	})
+	if err != nil {
+		h.logger.Error("failed to create Anki deck", logging.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export entries"})
+		return
+	}
+
+	h.export(c, &req.ExportRequest, "dictionary.apkg", "application/apkg", writer)
+}
+
+// export streams the entries selected by req into the response body
+func (h *ExchangeHandler) export(c *gin.Context, req *request.ExportRequest, filename, contentType string, writer exchange.RecordWriter) {
+	// A large dictionary takes longer to stream than the server write timeout allows
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		h.logger.Debug("cannot lift write deadline for export", logging.Error(err))
+	}
+
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	report, err := h.service.Export(c.Request.Context(), writer, req)
+	if err != nil {
+		// Writers that stage files clean up after themselves
+		if aborter, ok := writer.(exchange.Aborter); ok {
+			if abortErr := aborter.Abort(); abortErr != nil {
+				h.logger.Warn("failed to discard partial export", logging.Error(abortErr))
+			}
+		}
+
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Type")
+			c.Writer.Header().Del("Content-Disposition")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export entries"})
+			return
+		}
+		// The status line is already out; the client gets a truncated document
+		h.logger.Error("export interrupted",
+			logging.Error(err),
+			logging.Int("exported", report.Exported),
+		)
+	}
+}
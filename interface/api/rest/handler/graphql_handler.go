@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/valpere/trytrago/domain/logging"
+	"github.com/valpere/trytrago/interface/api/graph"
+)
+
+// GraphQLHandler implements the GraphQLHandlerInterface
+type GraphQLHandler struct {
+	schema *graph.Schema
+	logger logging.Logger
+}
+
+// NewGraphQLHandler creates a new instance of GraphQLHandler
+func NewGraphQLHandler(schema *graph.Schema, logger logging.Logger) *GraphQLHandler {
+	return &GraphQLHandler{
+		schema: schema,
+		logger: logger.With(logging.String("component", "graphql_handler")),
+	}
+}
+
+// Query handles POST /graphql. A request that cannot be run at all is
+// answered with 400; errors of single fields come back with 200 next to the
+// data that could be resolved, as GraphQL clients expect
+func (h *GraphQLHandler) Query(c *gin.Context) {
+	var req graph.Request
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("invalid GraphQL request", logging.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"errors": []gin.H{{"message": "Invalid request format"}}})
+		return
+	}
+
+	// Mutations run as the user the optional token names
+	ctx := c.Request.Context()
+	if userID, exists := c.Get("userID"); exists {
+		ctx = graph.WithUserID(ctx, userID.(uuid.UUID))
+	}
+
+	result, ok := h.schema.Execute(ctx, &req)
+	if !ok {
+		c.JSON(http.StatusBadRequest, result)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -20,6 +20,26 @@ type EntryHandlerInterface interface {
     ToggleMeaningLike(c *gin.Context)
 }
 
+// EntryTreeHandlerInterface defines the interface for the v2 entry endpoints,
+// which take an entry with everything beneath it
+type EntryTreeHandlerInterface interface {
+    CreateEntry(c *gin.Context)
+    ReplaceEntry(c *gin.Context)
+}
+
+// PatchHandlerInterface defines the interface for partial updates by merge
+// patch or JSON Patch
+type PatchHandlerInterface interface {
+    PatchEntry(c *gin.Context)
+    PatchMeaning(c *gin.Context)
+    PatchTranslation(c *gin.Context)
+}
+
+// GraphQLHandlerInterface defines the interface for the GraphQL endpoint
+type GraphQLHandlerInterface interface {
+    Query(c *gin.Context)
+}
+
 // TranslationHandlerInterface defines the interface for translation-related endpoints
 type TranslationHandlerInterface interface {
     ListTranslations(c *gin.Context)
@@ -43,3 +63,32 @@ type UserHandlerInterface interface {
     ListUserComments(c *gin.Context)
     ListUserLikes(c *gin.Context)
 }
+
+// ReviewHandlerInterface defines the interface for translation review endpoints
+type ReviewHandlerInterface interface {
+    ListReviewQueue(c *gin.Context)
+    ApproveTranslation(c *gin.Context)
+    RejectTranslation(c *gin.Context)
+    ListNotifications(c *gin.Context)
+}
+
+// DuplicateHandlerInterface defines the interface for duplicate entry endpoints
+type DuplicateHandlerInterface interface {
+    ListDuplicates(c *gin.Context)
+    MergeEntries(c *gin.Context)
+}
+
+// ExchangeHandlerInterface defines the interface for import and export endpoints
+type ExchangeHandlerInterface interface {
+    ImportCSV(c *gin.Context)
+    ImportTEI(c *gin.Context)
+    ExportTEI(c *gin.Context)
+    ImportTBX(c *gin.Context)
+    ExportTBX(c *gin.Context)
+    ExportAnki(c *gin.Context)
+}
+
+// BatchHandlerInterface defines the interface for the batch endpoint
+type BatchHandlerInterface interface {
+    ExecuteBatch(c *gin.Context)
+}
@@ -0,0 +1,294 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/valpere/trytrago/interface/api/rest/middleware"
+)
+
+// listWriter writes a list response in a format other than JSON one item at a
+// time, so that only the item being written is ever converted. The envelope
+// holds the other members of the response, such as its total, with the items
+// member marking where the items go
+type listWriter interface {
+	// item writes the next item, such as an *EntryResponse
+	item(value interface{}) error
+	// flush sends what has been written so far on to the client
+	flush() error
+	// close writes the members that follow the items and flushes
+	close() error
+}
+
+// newListWriter starts a list in the given format. CSV leaves the envelope out
+func newListWriter(w io.Writer, format string, v view, columns []string, envelope object) (listWriter, error) {
+	switch format {
+	case middleware.FormatCSV:
+		rows, err := newCSVRows(w, columns)
+		if err != nil {
+			return nil, err
+		}
+		return csvList{rows}, nil
+	case middleware.FormatXML:
+		return newXMLList(w, v, envelope)
+	case middleware.FormatYAML:
+		return newYAMLList(w, v, envelope)
+	}
+	return nil, fmt.Errorf("no list writer for format %q", format)
+}
+
+// listRender renders a list response with a listWriter
+type listRender struct {
+	format  string
+	body    interface{}
+	view    view
+	columns []string
+}
+
+// Render implements render.Render
+func (r listRender) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+
+	envelope, items, err := splitList(r.body, r.view.items)
+	if err != nil {
+		return err
+	}
+	list, err := newListWriter(w, r.format, r.view, r.columns, envelope)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < items.Len(); i++ {
+		if err := list.item(items.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return list.close()
+}
+
+// WriteContentType implements render.Render
+func (r listRender) WriteContentType(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", contentTypes[r.format])
+}
+
+// splitList separates the items of a list response, a struct or a pointer to
+// one, from its other members. The envelope is the response with the items
+// left empty, so only the items themselves stay unconverted
+func splitList(body interface{}, items string) (object, reflect.Value, error) {
+	value := reflect.ValueOf(body)
+	for value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil, reflect.Value{}, fmt.Errorf("list response of type %T is not a struct", body)
+	}
+
+	envelope := reflect.New(value.Type()).Elem()
+	envelope.Set(value)
+	var list reflect.Value
+	for i := 0; i < value.NumField(); i++ {
+		name, _, _ := strings.Cut(value.Type().Field(i).Tag.Get("json"), ",")
+		if name == items && value.Field(i).Kind() == reflect.Slice {
+			list = value.Field(i)
+			envelope.Field(i).Set(reflect.MakeSlice(list.Type(), 0, 0))
+			break
+		}
+	}
+	if !list.IsValid() {
+		return nil, reflect.Value{}, fmt.Errorf("list response of type %T has no %q member", body, items)
+	}
+
+	tree, err := toTree(envelope.Interface())
+	if err != nil {
+		return nil, reflect.Value{}, err
+	}
+	obj, _ := tree.(object)
+	return obj, list, nil
+}
+
+// splitEnvelope returns the members before and after the items member
+func splitEnvelope(envelope object, items string) (before, after object) {
+	for i, f := range envelope {
+		if f.name == items {
+			return envelope[:i], envelope[i+1:]
+		}
+	}
+	return envelope, nil
+}
+
+// csvList writes one CSV row per item
+type csvList struct {
+	rows *csvRows
+}
+
+func (l csvList) item(value interface{}) error { return l.rows.write(value) }
+func (l csvList) flush() error                 { return l.rows.flush() }
+func (l csvList) close() error                 { return l.rows.flush() }
+
+// xmlList writes the items as elements of the list element, inside the root
+// element of the response
+type xmlList struct {
+	encoder  *xml.Encoder
+	root     xml.StartElement
+	list     xml.StartElement
+	itemName string
+	after    object
+}
+
+// newXMLList writes the document up to the first item
+func newXMLList(w io.Writer, v view, envelope object) (*xmlList, error) {
+	l := &xmlList{
+		encoder:  xml.NewEncoder(w),
+		root:     xml.StartElement{Name: xml.Name{Local: v.root}},
+		list:     xml.StartElement{Name: xml.Name{Local: v.items}},
+		itemName: xmlItemName(v.items),
+	}
+	before, after := splitEnvelope(envelope, v.items)
+	l.after = after
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return nil, err
+	}
+	if err := l.encoder.EncodeToken(l.root); err != nil {
+		return nil, err
+	}
+	for _, f := range before {
+		if err := encodeXML(l.encoder, f.name, f.value); err != nil {
+			return nil, err
+		}
+	}
+	if err := l.encoder.EncodeToken(l.list); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *xmlList) item(value interface{}) error {
+	tree, err := toTree(value)
+	if err != nil {
+		return err
+	}
+	return encodeXML(l.encoder, l.itemName, tree)
+}
+
+func (l *xmlList) flush() error { return l.encoder.Flush() }
+
+func (l *xmlList) close() error {
+	if err := l.encoder.EncodeToken(l.list.End()); err != nil {
+		return err
+	}
+	for _, f := range l.after {
+		if err := encodeXML(l.encoder, f.name, f.value); err != nil {
+			return err
+		}
+	}
+	if err := l.encoder.EncodeToken(l.root.End()); err != nil {
+		return err
+	}
+	return l.encoder.Flush()
+}
+
+// yamlList writes the items as a block sequence under the items key, each
+// item rendered on its own and indented into place
+type yamlList struct {
+	w     io.Writer
+	items string
+	after object
+	empty bool // No item has been written yet
+}
+
+// newYAMLList writes the members before the items
+func newYAMLList(w io.Writer, v view, envelope object) (*yamlList, error) {
+	before, after := splitEnvelope(envelope, v.items)
+	for _, f := range before {
+		if err := writeYAMLMember(w, f); err != nil {
+			return nil, err
+		}
+	}
+	return &yamlList{w: w, items: v.items, after: after, empty: true}, nil
+}
+
+func (l *yamlList) item(value interface{}) error {
+	tree, err := toTree(value)
+	if err != nil {
+		return err
+	}
+	if l.empty {
+		if _, err := io.WriteString(l.w, yamlKey(l.items)+":\n"); err != nil {
+			return err
+		}
+		l.empty = false
+	}
+	text, err := yamlText(&yaml.Node{Kind: yaml.SequenceNode, Content: []*yaml.Node{yamlNode(tree)}}, "  ")
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(l.w, text)
+	return err
+}
+
+func (l *yamlList) flush() error { return nil }
+
+func (l *yamlList) close() error {
+	if l.empty {
+		if err := writeYAMLMember(l.w, field{name: l.items, value: []interface{}{}}); err != nil {
+			return err
+		}
+	}
+	for _, f := range l.after {
+		if err := writeYAMLMember(l.w, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeYAMLMember writes a member of the top level mapping
+func writeYAMLMember(w io.Writer, f field) error {
+	text, err := yamlText(yamlNode(object{f}), "")
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, text)
+	return err
+}
+
+// yamlKey renders a mapping key the way the encoder would
+func yamlKey(name string) string {
+	text, err := yamlText(&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name}, "")
+	if err != nil {
+		return name
+	}
+	return strings.TrimSuffix(text, "\n")
+}
+
+// yamlText renders a node as YAML with every line indented by prefix
+func yamlText(node *yaml.Node, prefix string) (string, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(node); err != nil {
+		return "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+	if prefix == "" {
+		return buf.String(), nil
+	}
+
+	var text strings.Builder
+	for _, line := range strings.SplitAfter(buf.String(), "\n") {
+		if line != "" && line != "\n" {
+			text.WriteString(prefix)
+		}
+		text.WriteString(line)
+	}
+	return text.String(), nil
+}
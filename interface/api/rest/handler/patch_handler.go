@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/valpere/trytrago/application/dto/request"
+	"github.com/valpere/trytrago/application/service"
+	"github.com/valpere/trytrago/domain/database"
+	domainErrors "github.com/valpere/trytrago/domain/errors"
+	"github.com/valpere/trytrago/domain/logging"
+)
+
+// acceptedPatchTypes lists the patch media types, as sent in Accept-Patch
+var acceptedPatchTypes = strings.Join([]string{request.MergePatchMediaType, request.JSONPatchMediaType}, ", ")
+
+// PatchHandler implements the PatchHandlerInterface
+type PatchHandler struct {
+	service service.PatchService
+	logger  logging.Logger
+}
+
+// NewPatchHandler creates a new instance of PatchHandler
+func NewPatchHandler(service service.PatchService, logger logging.Logger) *PatchHandler {
+	return &PatchHandler{
+		service: service,
+		logger:  logger.With(logging.String("component", "patch_handler")),
+	}
+}
+
+// PatchEntry handles PATCH /api/v1/entries/:id
+func (h *PatchHandler) PatchEntry(c *gin.Context) {
+	id, req, ok := h.bind(c, "id", "entry")
+	if !ok {
+		return
+	}
+
+	resp, err := h.service.PatchEntry(c.Request.Context(), id, req)
+	if err != nil {
+		if database.IsDuplicateError(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Homograph index is already taken for this word"})
+			return
+		}
+		h.writeError(c, err, "Entry")
+		return
+	}
+
+	setVersionETag(c, resp.Version)
+	c.JSON(http.StatusOK, resp)
+}
+
+// PatchMeaning handles PATCH /api/v1/meaning-details/:entryId/:meaningId
+func (h *PatchHandler) PatchMeaning(c *gin.Context) {
+	id, req, ok := h.bind(c, "meaningId", "meaning")
+	if !ok {
+		return
+	}
+
+	resp, err := h.service.PatchMeaning(c.Request.Context(), id, req)
+	if err != nil {
+		h.writeError(c, err, "Meaning")
+		return
+	}
+
+	setVersionETag(c, resp.Version)
+	c.JSON(http.StatusOK, resp)
+}
+
+// PatchTranslation handles PATCH /api/v1/meaning-details/:entryId/:meaningId/translations/:translationId
+func (h *PatchHandler) PatchTranslation(c *gin.Context) {
+	id, req, ok := h.bind(c, "translationId", "translation")
+	if !ok {
+		return
+	}
+
+	resp, err := h.service.PatchTranslation(c.Request.Context(), id, req)
+	if err != nil {
+		h.writeError(c, err, "Translation")
+		return
+	}
+
+	setVersionETag(c, resp.Version)
+	c.JSON(http.StatusOK, resp)
+}
+
+// bind reads the resource ID, the patch document and its media type, writing
+// the error response itself when one of them is unusable
+func (h *PatchHandler) bind(c *gin.Context, param, kind string) (uuid.UUID, *request.PatchRequest, bool) {
+	idParam := c.Param(param)
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		h.logger.Warn("invalid "+kind+" ID format", logging.String("id", idParam))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + kind + " ID format"})
+		return uuid.Nil, nil, false
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type"))
+	if mediaType != request.MergePatchMediaType && mediaType != request.JSONPatchMediaType {
+		c.Header("Accept-Patch", acceptedPatchTypes)
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Content-Type must be one of " + acceptedPatchTypes})
+		return uuid.Nil, nil, false
+	}
+
+	patch, err := c.GetRawData()
+	if err != nil || len(patch) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Patch document is required"})
+		return uuid.Nil, nil, false
+	}
+
+	req := &request.PatchRequest{MediaType: mediaType, Patch: patch}
+	var ok bool
+	if req.Version, ok = ifMatchVersion(c); !ok {
+		return uuid.Nil, nil, false
+	}
+
+	if userID, exists := c.Get("userID"); exists {
+		req.UserID = userID.(uuid.UUID)
+	}
+
+	return id, req, true
+}
+
+// writeError responds to a failed patch. A patch that cannot be applied to
+// the resource, or whose result is not valid, is answered with 422
+func (h *PatchHandler) writeError(c *gin.Context, err error, kind string) {
+	switch {
+	case database.IsNotFoundError(err):
+		c.JSON(http.StatusNotFound, gin.H{"error": kind + " not found"})
+	case database.IsVersionConflictError(err):
+		writeVersionConflict(c, kind+" has been changed since it was read")
+	case errors.Is(err, domainErrors.ErrUnsupportedPatch):
+		c.Header("Accept-Patch", acceptedPatchTypes)
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+	case errors.Is(err, domainErrors.ErrInvalidPatch):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, domainErrors.ErrValidation), errors.Is(err, database.ErrInvalidInput):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		h.logger.Error("failed to patch "+strings.ToLower(kind), logging.Error(err), logging.String("path", c.Request.URL.Path))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update " + strings.ToLower(kind)})
+	}
+}
@@ -0,0 +1,465 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gopkg.in/yaml.v3"
+
+	"github.com/valpere/trytrago/interface/api/rest/middleware"
+)
+
+// csvListSeparator joins the values of a list of scalars, such as the labels
+// of a meaning, in one CSV cell
+const csvListSeparator = "|"
+
+// view describes how a response body renders in the formats other than JSON
+type view struct {
+	root    string   // Root element of XML documents
+	items   string   // Member holding the rows of a list; a single resource is one CSV row
+	columns []string // CSV columns sent when the request names none
+}
+
+// filename returns the name CSV downloads are saved under
+func (v view) filename() string {
+	if v.items != "" {
+		return v.items + ".csv"
+	}
+	return v.root + ".csv"
+}
+
+var (
+	entryView = view{
+		root: "entry",
+		columns: []string{"id", "word", "type", "source_language_id", "homograph_index", "display_word",
+			"pronunciation", "version", "created_at", "updated_at"},
+	}
+	entryListView = view{root: "entry_list", items: "entries", columns: entryView.columns}
+
+	meaningView = view{
+		root: "meaning",
+		columns: []string{"id", "entry_id", "part_of_speech", "description", "labels", "likes_count",
+			"version", "created_at", "updated_at"},
+	}
+	meaningListView = view{root: "meaning_list", items: "meanings", columns: meaningView.columns}
+
+	translationListView = view{
+		root:  "translation_list",
+		items: "translations",
+		columns: []string{"id", "meaning_id", "language_id", "text", "status", "likes_count",
+			"created_by.username", "version", "created_at", "updated_at"},
+	}
+	commentListView = view{
+		root:    "comment_list",
+		items:   "comments",
+		columns: []string{"id", "content", "user.username", "created_at", "updated_at"},
+	}
+	likeListView = view{
+		root:    "like_list",
+		items:   "likes",
+		columns: []string{"id", "user_id", "target_type", "target_id", "created_at"},
+	}
+)
+
+// render sends body in the format negotiated for the request. The other
+// formats use the member names and order of the JSON body, so a column or
+// element is named like the JSON field it comes from. The rows of a list are
+// converted and written one at a time
+func render(c *gin.Context, status int, body interface{}, v view) {
+	format := middleware.ResponseFormat(c)
+	if format == middleware.FormatCSV {
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", v.filename()))
+	}
+
+	switch {
+	case format == middleware.FormatJSON:
+		c.JSON(status, body)
+	case v.items != "":
+		c.Render(status, listRender{format: format, body: body, view: v, columns: csvColumns(c, v)})
+	case format == middleware.FormatCSV:
+		c.Render(status, csvRender{body: body, columns: csvColumns(c, v)})
+	case format == middleware.FormatXML:
+		c.Render(status, xmlRender{body: body, root: v.root})
+	default:
+		c.Render(status, yamlRender{body: body})
+	}
+}
+
+// contentTypes are the Content-Type headers of the formats other than JSON
+var contentTypes = map[string]string{
+	middleware.FormatCSV:  "text/csv; charset=utf-8",
+	middleware.FormatXML:  "application/xml; charset=utf-8",
+	middleware.FormatYAML: "application/yaml; charset=utf-8",
+}
+
+// csvColumns returns the columns named by the "columns" query parameter, such
+// as "word,meanings.0.description", or the default columns of the view
+func csvColumns(c *gin.Context, v view) []string {
+	var columns []string
+	for _, column := range strings.Split(c.Query("columns"), ",") {
+		if column = strings.TrimSpace(column); column != "" {
+			columns = append(columns, column)
+		}
+	}
+	if len(columns) == 0 {
+		return v.columns
+	}
+	return columns
+}
+
+// field is a member of a JSON object
+type field struct {
+	name  string
+	value interface{}
+}
+
+// object is a JSON object with its members in the order they were encoded
+type object []field
+
+// get returns the value of the named member, or nil
+func (o object) get(name string) interface{} {
+	for _, f := range o {
+		if f.name == name {
+			return f.value
+		}
+	}
+	return nil
+}
+
+// toTree encodes a value as JSON and decodes it again into objects, slices
+// and scalars, keeping the order of object members
+func toTree(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decodeTree(decoder)
+}
+
+// decodeTree reads the next JSON value from the decoder
+func decodeTree(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		obj := object{}
+		for decoder.More() {
+			name, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeTree(decoder)
+			if err != nil {
+				return nil, err
+			}
+			obj = append(obj, field{name: name.(string), value: value})
+		}
+		_, err := decoder.Token()
+		return obj, err
+	case json.Delim('['):
+		list := []interface{}{}
+		for decoder.More() {
+			value, err := decodeTree(decoder)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		_, err := decoder.Token()
+		return list, err
+	}
+
+	// A string, json.Number, bool or nil
+	return token, nil
+}
+
+// csvRows writes the rows of a CSV document as they come, so a long list
+// never has to be held in memory
+type csvRows struct {
+	writer  *csv.Writer
+	columns []string
+	record  []string
+}
+
+// newCSVRows writes the header row with the column names
+func newCSVRows(w io.Writer, columns []string) (*csvRows, error) {
+	rows := &csvRows{
+		writer:  csv.NewWriter(w),
+		columns: columns,
+		record:  make([]string, len(columns)),
+	}
+	if err := rows.writer.Write(columns); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// write adds a row for a response value, such as an *EntryResponse
+func (r *csvRows) write(item interface{}) error {
+	tree, err := toTree(item)
+	if err != nil {
+		return err
+	}
+	return r.writeTree(tree)
+}
+
+// writeTree adds a row for a value decoded by toTree
+func (r *csvRows) writeTree(tree interface{}) error {
+	for i, column := range r.columns {
+		r.record[i] = csvCell(tree, column)
+	}
+	return r.writer.Write(r.record)
+}
+
+// flush sends the buffered rows on to the writer
+func (r *csvRows) flush() error {
+	r.writer.Flush()
+	return r.writer.Error()
+}
+
+// csvCell returns the text of the member a dotted column name points to,
+// such as "user.username" or "meanings.0.description". Numbers step into
+// lists; a list of scalars fills one cell
+func csvCell(tree interface{}, column string) string {
+	value := tree
+	for _, name := range strings.Split(column, ".") {
+		switch v := value.(type) {
+		case object:
+			value = v.get(name)
+		case []interface{}:
+			i, err := strconv.Atoi(name)
+			if err != nil || i < 0 || i >= len(v) {
+				return ""
+			}
+			value = v[i]
+		default:
+			return ""
+		}
+	}
+
+	if list, ok := value.([]interface{}); ok {
+		var parts []string
+		for _, element := range list {
+			switch element.(type) {
+			case object, []interface{}, nil:
+			default:
+				parts = append(parts, csvScalar(element))
+			}
+		}
+		return strings.Join(parts, csvListSeparator)
+	}
+	return csvScalar(value)
+}
+
+// csvScalar returns the text of a scalar value. Text that a spreadsheet would
+// take for a formula is quoted with a leading apostrophe, since words and
+// translations come from users. A leading "-" followed by a letter is left
+// alone, as suffixes like "-ing" are ordinary headwords
+func csvScalar(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return v
+		}
+		switch v[0] {
+		case '=', '+', '@', '\t', '\r':
+			return "'" + v
+		case '-':
+			if len(v) > 1 && !isLetter(v[1]) {
+				return "'" + v
+			}
+		}
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return ""
+}
+
+// isLetter reports whether a byte starts a letter. Bytes of multi-byte
+// characters count as letters
+func isLetter(b byte) bool {
+	return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || b >= 0x80
+}
+
+// csvRender renders a single resource as a CSV row
+type csvRender struct {
+	body    interface{}
+	columns []string
+}
+
+// Render implements render.Render
+func (r csvRender) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+
+	rows, err := newCSVRows(w, r.columns)
+	if err != nil {
+		return err
+	}
+	if err := rows.write(r.body); err != nil {
+		return err
+	}
+	return rows.flush()
+}
+
+// WriteContentType implements render.Render
+func (r csvRender) WriteContentType(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", contentTypes[middleware.FormatCSV])
+}
+
+// xmlRender renders a response as an XML document. Object members become
+// elements, and each item of a list an element named after the list in the
+// singular, so "entries" holds "entry" elements. Null members are left out
+type xmlRender struct {
+	body interface{}
+	root string
+}
+
+// Render implements render.Render
+func (r xmlRender) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+
+	tree, err := toTree(r.body)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	if err := encodeXML(encoder, r.root, tree); err != nil {
+		return err
+	}
+	return encoder.Flush()
+}
+
+// WriteContentType implements render.Render
+func (r xmlRender) WriteContentType(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", contentTypes[middleware.FormatXML])
+}
+
+// encodeXML writes a value decoded by toTree as the element name
+func encodeXML(encoder *xml.Encoder, name string, value interface{}) error {
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case object:
+		if err := encoder.EncodeToken(start); err != nil {
+			return err
+		}
+		for _, f := range v {
+			if err := encodeXML(encoder, f.name, f.value); err != nil {
+				return err
+			}
+		}
+		return encoder.EncodeToken(start.End())
+	case []interface{}:
+		if err := encoder.EncodeToken(start); err != nil {
+			return err
+		}
+		itemName := xmlItemName(name)
+		for _, item := range v {
+			if err := encodeXML(encoder, itemName, item); err != nil {
+				return err
+			}
+		}
+		return encoder.EncodeToken(start.End())
+	case string:
+		return encoder.EncodeElement(v, start)
+	case json.Number:
+		return encoder.EncodeElement(v.String(), start)
+	case bool:
+		return encoder.EncodeElement(v, start)
+	}
+	return fmt.Errorf("unexpected value of type %T", value)
+}
+
+// xmlItemName returns the element name of the items of a list
+func xmlItemName(list string) string {
+	switch {
+	case strings.HasSuffix(list, "ies"):
+		return strings.TrimSuffix(list, "ies") + "y"
+	case len(list) > 1 && strings.HasSuffix(list, "s"):
+		return strings.TrimSuffix(list, "s")
+	}
+	return "item"
+}
+
+// yamlRender renders a response as a YAML document
+type yamlRender struct {
+	body interface{}
+}
+
+// Render implements render.Render
+func (r yamlRender) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+
+	tree, err := toTree(r.body)
+	if err != nil {
+		return err
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(yamlNode(tree)); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// WriteContentType implements render.Render
+func (r yamlRender) WriteContentType(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", contentTypes[middleware.FormatYAML])
+}
+
+// yamlNode converts a value decoded by toTree into a YAML node. Tagging the
+// scalars keeps strings such as "true" or "2024" quoted
+func yamlNode(value interface{}) *yaml.Node {
+	switch v := value.(type) {
+	case object:
+		node := &yaml.Node{Kind: yaml.MappingNode}
+		for _, f := range v {
+			node.Content = append(node.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: f.name},
+				yamlNode(f.value),
+			)
+		}
+		return node
+	case []interface{}:
+		node := &yaml.Node{Kind: yaml.SequenceNode}
+		for _, item := range v {
+			node.Content = append(node.Content, yamlNode(item))
+		}
+		return node
+	case string:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v}
+	case json.Number:
+		tag := "!!int"
+		if strings.ContainsAny(v.String(), ".eE") {
+			tag = "!!float"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: v.String()}
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(v)}
+	}
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+}
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/valpere/trytrago/application/dto/request"
+	"github.com/valpere/trytrago/application/dto/response"
+	"github.com/valpere/trytrago/application/service"
+	"github.com/valpere/trytrago/domain/database"
+	domainErrors "github.com/valpere/trytrago/domain/errors"
+	"github.com/valpere/trytrago/domain/logging"
+)
+
+// ReviewHandler implements the ReviewHandlerInterface
+type ReviewHandler struct {
+	service service.ReviewService
+	logger  logging.Logger
+}
+
+// NewReviewHandler creates a new instance of ReviewHandler
+func NewReviewHandler(service service.ReviewService, logger logging.Logger) *ReviewHandler {
+	return &ReviewHandler{
+		service: service,
+		logger:  logger.With(logging.String("component", "review_handler")),
+	}
+}
+
+// ListReviewQueue handles GET /api/v1/reviews/translations
+func (h *ReviewHandler) ListReviewQueue(c *gin.Context) {
+	var req request.ReviewQueueRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Warn("invalid review queue request", logging.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+
+	resp, err := h.service.ListReviewQueue(c.Request.Context(), &req)
+	if err != nil {
+		h.logger.Error("failed to list review queue", logging.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve review queue"})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ApproveTranslation handles POST /api/v1/reviews/translations/:translationId/approve
+func (h *ReviewHandler) ApproveTranslation(c *gin.Context) {
+	h.decide(c, h.service.ApproveTranslation)
+}
+
+// RejectTranslation handles POST /api/v1/reviews/translations/:translationId/reject
+func (h *ReviewHandler) RejectTranslation(c *gin.Context) {
+	h.decide(c, h.service.RejectTranslation)
+}
+
+// decide binds a review decision and applies it with the given service call
+func (h *ReviewHandler) decide(
+	c *gin.Context,
+	apply func(context.Context, uuid.UUID, *request.ReviewDecisionRequest) (*response.TranslationResponse, error),
+) {
+	translationIDParam := c.Param("translationId")
+
+	// Parse translation UUID
+	translationID, err := uuid.Parse(translationIDParam)
+	if err != nil {
+		h.logger.Warn("invalid translation ID format", logging.String("id", translationIDParam))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid translation ID format"})
+		return
+	}
+
+	// The body is optional for approvals, so an empty request is accepted
+	var req request.ReviewDecisionRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.logger.Warn("invalid review decision request", logging.Error(err))
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+			return
+		}
+	}
+
+	// Get reviewer ID from context (set by auth middleware)
+	userID, exists := c.Get("userID")
+	if !exists {
+		h.logger.Error("user ID not found in context")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Authentication error"})
+		return
+	}
+	req.ReviewerID = userID.(uuid.UUID)
+
+	resp, err := apply(c.Request.Context(), translationID, &req)
+	if err != nil {
+		switch {
+		case database.IsNotFoundError(err):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Translation not found"})
+		case errors.Is(err, domainErrors.ErrValidation):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, domainErrors.ErrDuplicate):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			h.logger.Error("failed to review translation",
+				logging.Error(err),
+				logging.String("translationId", translationIDParam),
+			)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to review translation"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ListNotifications handles GET /api/v1/users/me/notifications
+func (h *ReviewHandler) ListNotifications(c *gin.Context) {
+	var req request.ListNotificationsRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Warn("invalid list notifications request", logging.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		h.logger.Error("user ID not found in context")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Authentication error"})
+		return
+	}
+
+	resp, err := h.service.ListNotifications(c.Request.Context(), userID.(uuid.UUID), &req)
+	if err != nil {
+		h.logger.Error("failed to list notifications", logging.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve notifications"})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+    "errors"
     "net/http"
 
     "github.com/gin-gonic/gin"
@@ -8,6 +9,7 @@ import (
     "github.com/valpere/trytrago/application/dto/request"
     "github.com/valpere/trytrago/application/service"
     "github.com/valpere/trytrago/domain/database"
+    domainErrors "github.com/valpere/trytrago/domain/errors"
     "github.com/valpere/trytrago/domain/logging"
 )
 
@@ -54,7 +56,11 @@ func (h *TranslationHandler) ListTranslations(c *gin.Context) {
         return
     }
 
-    c.JSON(http.StatusOK, resp)
+    // The list is a view of the meaning, whose version moves with its translations
+    if resp.MeaningUpdatedAt != nil && notModified(c, versionETag(c, resp.MeaningVersion), *resp.MeaningUpdatedAt) {
+        return
+    }
+    render(c, http.StatusOK, resp, translationListView)
 }
 
 // CreateTranslation handles POST /api/v1/entries/:entryId/meanings/:meaningId/translations
@@ -78,9 +84,18 @@ func (h *TranslationHandler) CreateTranslation(c *gin.Context) {
         return
     }
 
+    // Record the contributor so they can be notified about the review outcome
+    if userID, exists := c.Get("userID"); exists {
+        req.UserID = userID.(uuid.UUID)
+    }
+
     // Call service
     resp, err := h.service.CreateTranslation(c.Request.Context(), meaningID, &req)
     if err != nil {
+        if errors.Is(err, domainErrors.ErrValidation) {
+            c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+            return
+        }
         if database.IsNotFoundError(err) {
             c.JSON(http.StatusNotFound, gin.H{"error": "Meaning not found"})
             return
@@ -118,6 +133,12 @@ func (h *TranslationHandler) UpdateTranslation(c *gin.Context) {
         return
     }
 
+    version, ok := ifMatchVersion(c)
+    if !ok {
+        return
+    }
+    req.Version = version
+
     // Call service
     resp, err := h.service.UpdateTranslation(c.Request.Context(), translationID, &req)
     if err != nil {
@@ -125,6 +146,10 @@ func (h *TranslationHandler) UpdateTranslation(c *gin.Context) {
             c.JSON(http.StatusNotFound, gin.H{"error": "Translation not found"})
             return
         }
+        if database.IsVersionConflictError(err) {
+            writeVersionConflict(c, "Translation has been changed since it was read")
+            return
+        }
 
         h.logger.Error("failed to update translation",
             logging.Error(err),
@@ -134,6 +159,7 @@ func (h *TranslationHandler) UpdateTranslation(c *gin.Context) {
         return
     }
 
+    setVersionETag(c, resp.Version)
     c.JSON(http.StatusOK, resp)
 }
 
@@ -149,13 +175,22 @@ func (h *TranslationHandler) DeleteTranslation(c *gin.Context) {
         return
     }
 
+    version, ok := ifMatchVersion(c)
+    if !ok {
+        return
+    }
+
     // Call service
-    err = h.service.DeleteTranslation(c.Request.Context(), translationID)
+    err = h.service.DeleteTranslation(c.Request.Context(), translationID, version)
     if err != nil {
         if database.IsNotFoundError(err) {
             c.JSON(http.StatusNotFound, gin.H{"error": "Translation not found"})
             return
         }
+        if database.IsVersionConflictError(err) {
+            writeVersionConflict(c, "Translation has been changed since it was read")
+            return
+        }
 
         h.logger.Error("failed to delete translation",
             logging.Error(err),
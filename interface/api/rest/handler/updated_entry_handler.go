@@ -185,8 +185,13 @@ func (h *EntryHandlerImpl) DeleteEntry(c *gin.Context) {
 		return
 	}
 
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
 	// Call service
-	err = h.service.DeleteEntry(c.Request.Context(), id)
+	err = h.service.DeleteEntry(c.Request.Context(), id, version)
 	if err != nil {
 		h.logger.Error("failed to delete entry",
 			logging.Error(err),
@@ -403,8 +408,13 @@ func (h *EntryHandlerImpl) DeleteMeaning(c *gin.Context) {
 		return
 	}
 
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
 	// Call service
-	err = h.service.DeleteMeaning(c.Request.Context(), meaningID)
+	err = h.service.DeleteMeaning(c.Request.Context(), meaningID, version)
 	if err != nil {
 		h.logger.Error("failed to delete meaning",
 			logging.Error(err),
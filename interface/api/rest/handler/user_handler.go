@@ -235,7 +235,7 @@ func (h *UserHandler) ListUserEntries(c *gin.Context) {
         return
     }
 
-    c.JSON(http.StatusOK, resp)
+    render(c, http.StatusOK, resp, entryListView)
 }
 
 // ListUserTranslations handles GET /api/v1/users/me/translations
@@ -273,7 +273,7 @@ func (h *UserHandler) ListUserTranslations(c *gin.Context) {
         return
     }
 
-    c.JSON(http.StatusOK, resp)
+    render(c, http.StatusOK, resp, translationListView)
 }
 
 // ListUserComments handles GET /api/v1/users/me/comments
@@ -311,7 +311,7 @@ func (h *UserHandler) ListUserComments(c *gin.Context) {
         return
     }
 
-    c.JSON(http.StatusOK, resp)
+    render(c, http.StatusOK, resp, commentListView)
 }
 
 // ListUserLikes handles GET /api/v1/users/me/likes
@@ -349,5 +349,5 @@ func (h *UserHandler) ListUserLikes(c *gin.Context) {
         return
     }
 
-    c.JSON(http.StatusOK, resp)
+    render(c, http.StatusOK, resp, likeListView)
 }
@@ -94,6 +94,42 @@ func (m *jwtAuthMiddleware) RequireAdmin() gin.HandlerFunc {
 	}
 }
 
+// RequireReviewer implements middleware that requires review privileges.
+// Administrators are always allowed to review.
+func (m *jwtAuthMiddleware) RequireReviewer() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, err := m.extractToken(c)
+		if err != nil {
+			m.logger.Debug("Authentication failed", logging.Error(err))
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+			return
+		}
+
+		userID, err := uuid.Parse(token.UserID)
+		if err != nil {
+			m.logger.Error("Invalid user ID in token", logging.Error(err))
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid authentication token"})
+			return
+		}
+
+		c.Set("userID", userID)
+		c.Set("username", token.Username)
+		c.Set("userRole", token.Role)
+		c.Set("authenticated", true)
+
+		if token.Role != "REVIEWER" && token.Role != "ADMIN" {
+			m.logger.Debug("Reviewer access denied",
+				logging.String("role", token.Role),
+				logging.String("path", c.Request.URL.Path),
+			)
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Forbidden"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
 // OptionalAuth implements middleware that makes authentication optional
 func (m *jwtAuthMiddleware) OptionalAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -13,6 +13,9 @@ type AuthMiddleware interface {
     // RequireAdmin returns middleware that requires admin privileges
     RequireAdmin() gin.HandlerFunc
 
+    // RequireReviewer returns middleware that requires reviewer or admin privileges
+    RequireReviewer() gin.HandlerFunc
+
     // OptionalAuth returns middleware that makes authentication optional
     OptionalAuth() gin.HandlerFunc
 }
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// cacheControlWriter adds the Cache-Control header once the status is known,
+// so that errors are never stored by browsers or CDNs
+type cacheControlWriter struct {
+	gin.ResponseWriter
+	policy string
+}
+
+// WriteHeader sets Cache-Control on successful and not modified responses
+func (w *cacheControlWriter) WriteHeader(code int) {
+	if code == http.StatusOK || code == http.StatusNotModified {
+		w.Header().Set("Cache-Control", w.policy)
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// CacheControl returns a middleware that sends the given Cache-Control policy,
+// e.g. "public, max-age=60", with the successful reads of a route group. An
+// empty policy leaves responses as they are
+func CacheControl(policy string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if policy == "" || (c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead) {
+			c.Next()
+			return
+		}
+
+		c.Writer = &cacheControlWriter{ResponseWriter: c.Writer, policy: policy}
+		c.Next()
+	}
+}
@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Response formats that content negotiation can choose
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+	FormatXML  = "xml"
+	FormatYAML = "yaml"
+)
+
+// formatKey holds the negotiated response format in the request context
+const formatKey = "responseFormat"
+
+// mediaTypes maps the media types a client may ask for onto the formats other
+// than JSON. Of those the Accept header rates equally, the first one wins
+var mediaTypes = []struct {
+	mediaType string
+	format    string
+}{
+	{"text/csv", FormatCSV},
+	{"application/xml", FormatXML},
+	{"text/xml", FormatXML},
+	{"application/yaml", FormatYAML},
+	{"application/x-yaml", FormatYAML},
+	{"text/yaml", FormatYAML},
+}
+
+// Negotiate returns a middleware that picks the format of a read from the
+// "format" query parameter or, without one, from the Accept header. Anything
+// the Accept header does not clearly ask for is answered with JSON. Handlers
+// read the choice with ResponseFormat
+func Negotiate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// The same URL answers with different bodies depending on Accept
+		c.Writer.Header().Add("Vary", "Accept")
+
+		if param, ok := c.GetQuery("format"); ok {
+			format := strings.ToLower(strings.TrimSpace(param))
+			switch format {
+			case FormatJSON, FormatCSV, FormatXML, FormatYAML:
+			case "yml":
+				format = FormatYAML
+			default:
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+					"error": "Invalid format. Must be one of: json, csv, xml, yaml",
+				})
+				return
+			}
+			c.Set(formatKey, format)
+			c.Next()
+			return
+		}
+
+		c.Set(formatKey, acceptedFormat(c.GetHeader("Accept")))
+
+		c.Next()
+	}
+}
+
+// acceptedFormat returns the format the Accept header asks for. A format other
+// than JSON is chosen only when the header names its media type, rates it
+// above JSON and rates nothing else higher. Wildcards only ever stand for
+// JSON, so browsers, which prefer text/html and merely tolerate XML, get JSON
+func acceptedFormat(header string) string {
+	ranges := parseAccept(header)
+	top := 0.0
+	for _, r := range ranges {
+		top = math.Max(top, r.quality)
+	}
+
+	best, bestQuality := FormatJSON, acceptQuality(ranges, "application/json")
+	for _, mt := range mediaTypes {
+		for _, r := range ranges {
+			if r.mediaType == mt.mediaType && r.quality == top && r.quality > bestQuality {
+				best, bestQuality = mt.format, r.quality
+			}
+		}
+	}
+	return best
+}
+
+// mediaRange is one element of an Accept header
+type mediaRange struct {
+	mediaType string // e.g. "text/csv", "text/*" or "*/*"
+	quality   float64
+}
+
+// parseAccept splits an Accept header into media ranges. A range with an
+// unreadable quality counts as not acceptable
+func parseAccept(header string) []mediaRange {
+	var ranges []mediaRange
+	for _, part := range strings.Split(header, ",") {
+		params := strings.Split(part, ";")
+		r := mediaRange{mediaType: strings.ToLower(strings.TrimSpace(params[0])), quality: 1}
+		if r.mediaType == "" {
+			continue
+		}
+		for _, param := range params[1:] {
+			name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.EqualFold(strings.TrimSpace(name), "q") {
+				quality, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+				if err != nil {
+					quality = 0
+				}
+				r.quality = quality
+			}
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges
+}
+
+// acceptQuality returns the quality the most specific matching range gives a
+// media type, so "text/csv;q=0" rules CSV out even next to "*/*"
+func acceptQuality(ranges []mediaRange, mediaType string) float64 {
+	mainType, _, _ := strings.Cut(mediaType, "/")
+	quality, specificity := 0.0, 0
+	for _, r := range ranges {
+		var s int
+		switch r.mediaType {
+		case mediaType:
+			s = 3
+		case mainType + "/*":
+			s = 2
+		case "*/*":
+			s = 1
+		default:
+			continue
+		}
+		if s > specificity {
+			quality, specificity = r.quality, s
+		}
+	}
+	return quality
+}
+
+// ResponseFormat returns the format negotiated for the request, or JSON on
+// routes without the Negotiate middleware
+func ResponseFormat(c *gin.Context) string {
+	if format := c.GetString(formatKey); format != "" {
+		return format
+	}
+	return FormatJSON
+}
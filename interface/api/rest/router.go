@@ -30,8 +30,15 @@ func NewRouter(
 	config domain.Config,
 	logger logging.Logger,
 	entryHandler *handler.EntryHandler,
+	entryTreeHandler *handler.EntryTreeHandler,
 	translationHandler *handler.TranslationHandler,
 	userHandler *handler.UserHandler,
+	reviewHandler *handler.ReviewHandler,
+	duplicateHandler *handler.DuplicateHandler,
+	exchangeHandler *handler.ExchangeHandler,
+	batchHandler *handler.BatchHandler,
+	patchHandler *handler.PatchHandler,
+	graphQLHandler *handler.GraphQLHandler,
 	authMiddleware middleware.AuthMiddleware,
 ) Router {
 	// Set Gin mode based on environment
@@ -74,6 +81,8 @@ func NewRouter(
 
 	// Public dictionary routes - basic entry endpoints
 	entries := v1.Group("/entries")
+	entries.Use(middleware.CacheControl(config.Server.CacheControl.Entries))
+	entries.Use(middleware.Negotiate())
 	{
 		entries.GET("", entryHandler.ListEntries)
 		entries.GET("/:id", entryHandler.GetEntry)
@@ -82,6 +91,8 @@ func NewRouter(
 
 	// Separate routes for meanings with different param name pattern
 	meanings := v1.Group("/meaning-details")
+	meanings.Use(middleware.CacheControl(config.Server.CacheControl.Meanings))
+	meanings.Use(middleware.Negotiate())
 	{
 		meanings.GET("/:entryId/:meaningId", entryHandler.GetMeaning)
 		meanings.GET("/:entryId/:meaningId/translations", translationHandler.ListTranslations)
@@ -98,26 +109,35 @@ func NewRouter(
 		users.PUT("/me", userHandler.UpdateCurrentUser)
 		users.DELETE("/me", userHandler.DeleteCurrentUser)
 
-		// User content
-		users.GET("/me/entries", userHandler.ListUserEntries)
-		users.GET("/me/translations", userHandler.ListUserTranslations)
-		users.GET("/me/comments", userHandler.ListUserComments)
-		users.GET("/me/likes", userHandler.ListUserLikes)
+		// User content, also available as CSV, XML or YAML
+		users.GET("/me/entries", middleware.Negotiate(), userHandler.ListUserEntries)
+		users.GET("/me/translations", middleware.Negotiate(), userHandler.ListUserTranslations)
+		users.GET("/me/comments", middleware.Negotiate(), userHandler.ListUserComments)
+		users.GET("/me/likes", middleware.Negotiate(), userHandler.ListUserLikes)
+		users.GET("/me/notifications", reviewHandler.ListNotifications)
 	}
 
 	// Protected entry management
 	protectedEntries := protected.Group("/entries")
+	if config.Server.RequireIfMatch {
+		protectedEntries.Use(middleware.RequireIfMatch())
+	}
 	{
 		protectedEntries.POST("", entryHandler.CreateEntry)
 		protectedEntries.PUT("/:id", entryHandler.UpdateEntry)
+		protectedEntries.PATCH("/:id", patchHandler.PatchEntry)
 		protectedEntries.DELETE("/:id", entryHandler.DeleteEntry)
 	}
 
 	// Protected meaning management with different route pattern
 	protectedMeanings := protected.Group("/meaning-details")
+	if config.Server.RequireIfMatch {
+		protectedMeanings.Use(middleware.RequireIfMatch())
+	}
 	{
 		protectedMeanings.POST("/:entryId", entryHandler.AddMeaning)
 		protectedMeanings.PUT("/:entryId/:meaningId", entryHandler.UpdateMeaning)
+		protectedMeanings.PATCH("/:entryId/:meaningId", patchHandler.PatchMeaning)
 		protectedMeanings.DELETE("/:entryId/:meaningId", entryHandler.DeleteMeaning)
 		protectedMeanings.POST("/:entryId/:meaningId/comments", entryHandler.AddMeaningComment)
 		protectedMeanings.POST("/:entryId/:meaningId/likes", entryHandler.ToggleMeaningLike)
@@ -125,11 +145,46 @@ func NewRouter(
 		// Translation routes
 		protectedMeanings.POST("/:entryId/:meaningId/translations", translationHandler.CreateTranslation)
 		protectedMeanings.PUT("/:entryId/:meaningId/translations/:translationId", translationHandler.UpdateTranslation)
+		protectedMeanings.PATCH("/:entryId/:meaningId/translations/:translationId", patchHandler.PatchTranslation)
 		protectedMeanings.DELETE("/:entryId/:meaningId/translations/:translationId", translationHandler.DeleteTranslation)
 		protectedMeanings.POST("/:entryId/:meaningId/translations/:translationId/comments", translationHandler.AddTranslationComment)
 		protectedMeanings.POST("/:entryId/:meaningId/translations/:translationId/likes", translationHandler.ToggleTranslationLike)
 	}
 
+	// Flashcard decks for learners
+	protected.GET("/export/anki", exchangeHandler.ExportAnki)
+
+	// Many mutations applied together, or not at all
+	protected.POST("/batch", batchHandler.ExecuteBatch)
+
+	// Review routes - require reviewer or admin privileges
+	reviews := v1.Group("/reviews")
+	reviews.Use(authMiddleware.RequireReviewer())
+	{
+		reviews.GET("/translations", reviewHandler.ListReviewQueue)
+		reviews.POST("/translations/:translationId/approve", reviewHandler.ApproveTranslation)
+		reviews.POST("/translations/:translationId/reject", reviewHandler.RejectTranslation)
+	}
+
+	// GraphQL endpoint - reads are public, mutations need a token
+	graphQL := router.Group("/graphql")
+	graphQL.Use(authMiddleware.OptionalAuth())
+	{
+		graphQL.POST("", graphQLHandler.Query)
+	}
+
+	// API v2 routes - entries are written as a whole tree
+	v2 := router.Group("/api/v2")
+	protectedV2 := v2.Group("")
+	protectedV2.Use(authMiddleware.RequireAuth())
+	if config.Server.RequireIfMatch {
+		protectedV2.Use(middleware.RequireIfMatch())
+	}
+	{
+		protectedV2.POST("/entries", entryTreeHandler.CreateEntry)
+		protectedV2.PUT("/entries/:id", entryTreeHandler.ReplaceEntry)
+	}
+
 	// Admin routes
 	admin := v1.Group("/admin")
 	admin.Use(authMiddleware.RequireAdmin())
@@ -141,6 +196,17 @@ func NewRouter(
 				"message": "Admin stats endpoint",
 			})
 		})
+
+		// Duplicate detection and merging
+		admin.GET("/duplicates", duplicateHandler.ListDuplicates)
+		admin.POST("/entries/:id/merge", duplicateHandler.MergeEntries)
+
+		// Bulk import and export
+		admin.POST("/import/csv", exchangeHandler.ImportCSV)
+		admin.POST("/import/tei", exchangeHandler.ImportTEI)
+		admin.GET("/export/tei", exchangeHandler.ExportTEI)
+		admin.POST("/import/tbx", exchangeHandler.ImportTBX)
+		admin.GET("/export/tbx", exchangeHandler.ExportTBX)
 	}
 
 	return &ginRouter{
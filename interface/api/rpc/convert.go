@@ -0,0 +1,180 @@
+package rpc
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/valpere/trytrago/application/dto/response"
+	"github.com/valpere/trytrago/interface/api/rpc/pb"
+)
+
+// timestamp converts a time, leaving zero times unset
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// timeOf converts a timestamp, leaving unset ones zero
+func timeOf(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+// entryToProto converts an entry with its meanings
+func entryToProto(entry *response.EntryResponse) *pb.Entry {
+	meanings := make([]*pb.Meaning, len(entry.Meanings))
+	for i := range entry.Meanings {
+		meanings[i] = meaningToProto(&entry.Meanings[i])
+	}
+
+	return &pb.Entry{
+		Id:               entry.ID.String(),
+		Word:             entry.Word,
+		Type:             entry.Type,
+		SourceLanguageId: entry.SourceLanguageID,
+		HomographIndex:   int32(entry.HomographIndex),
+		DisplayWord:      entry.DisplayWord,
+		Pronunciation:    entry.Pronunciation,
+		Etymology:        entry.Etymology,
+		Source:           entry.Source,
+		License:          entry.License,
+		Meanings:         meanings,
+		Version:          int32(entry.Version),
+		CreatedAt:        timestamp(entry.CreatedAt),
+		UpdatedAt:        timestamp(entry.UpdatedAt),
+	}
+}
+
+// meaningToProto converts a meaning with its examples, translations and comments
+func meaningToProto(meaning *response.MeaningResponse) *pb.Meaning {
+	examples := make([]*pb.Example, len(meaning.Examples))
+	for i, example := range meaning.Examples {
+		examples[i] = &pb.Example{
+			Id:        example.ID.String(),
+			Text:      example.Text,
+			Context:   example.Context,
+			CreatedAt: timestamp(example.CreatedAt),
+			UpdatedAt: timestamp(example.UpdatedAt),
+		}
+	}
+
+	translations := make([]*pb.Translation, len(meaning.Translations))
+	for i := range meaning.Translations {
+		translations[i] = translationToProto(&meaning.Translations[i])
+	}
+
+	return &pb.Meaning{
+		Id:           meaning.ID.String(),
+		EntryId:      meaning.EntryID.String(),
+		PartOfSpeech: meaning.PartOfSpeech,
+		Description:  meaning.Description,
+		Labels:       meaning.Labels,
+		Examples:     examples,
+		Translations: translations,
+		Comments:     commentsToProto(meaning.Comments),
+		LikesCount:   int32(meaning.LikesCount),
+		Version:      int32(meaning.Version),
+		CreatedAt:    timestamp(meaning.CreatedAt),
+		UpdatedAt:    timestamp(meaning.UpdatedAt),
+	}
+}
+
+// translationToProto converts a translation with its comments
+func translationToProto(translation *response.TranslationResponse) *pb.Translation {
+	result := &pb.Translation{
+		Id:           translation.ID.String(),
+		MeaningId:    translation.MeaningID.String(),
+		LanguageId:   translation.LanguageID,
+		Text:         translation.Text,
+		Comments:     commentsToProto(translation.Comments),
+		LikesCount:   int32(translation.LikesCount),
+		Version:      int32(translation.Version),
+		CreatedAt:    timestamp(translation.CreatedAt),
+		UpdatedAt:    timestamp(translation.UpdatedAt),
+		Status:       translation.Status,
+		ReviewReason: translation.ReviewReason,
+	}
+	if translation.CreatedBy != nil {
+		result.CreatedBy = userSummaryToProto(translation.CreatedBy)
+	}
+	if translation.ReviewedAt != nil {
+		result.ReviewedAt = timestamp(*translation.ReviewedAt)
+	}
+	return result
+}
+
+// translationsToProto converts a list of translations
+func translationsToProto(translations []*response.TranslationResponse) []*pb.Translation {
+	result := make([]*pb.Translation, len(translations))
+	for i, translation := range translations {
+		result[i] = translationToProto(translation)
+	}
+	return result
+}
+
+// commentToProto converts a comment with its author
+func commentToProto(comment *response.CommentResponse) *pb.Comment {
+	return &pb.Comment{
+		Id:        comment.ID.String(),
+		Content:   comment.Content,
+		User:      userSummaryToProto(&comment.User),
+		CreatedAt: timestamp(comment.CreatedAt),
+		UpdatedAt: timestamp(comment.UpdatedAt),
+	}
+}
+
+// commentsToProto converts a list of comments
+func commentsToProto(comments []response.CommentResponse) []*pb.Comment {
+	result := make([]*pb.Comment, len(comments))
+	for i := range comments {
+		result[i] = commentToProto(&comments[i])
+	}
+	return result
+}
+
+// userSummaryToProto converts what a resource carries of a user
+func userSummaryToProto(user *response.UserSummary) *pb.UserSummary {
+	return &pb.UserSummary{
+		Id:       user.ID.String(),
+		Username: user.Username,
+		Avatar:   user.Avatar,
+	}
+}
+
+// userToProto converts a user account
+func userToProto(user *response.UserResponse) *pb.User {
+	return &pb.User{
+		Id:        user.ID.String(),
+		Username:  user.Username,
+		Email:     user.Email,
+		Avatar:    user.Avatar,
+		CreatedAt: timestamp(user.CreatedAt),
+		UpdatedAt: timestamp(user.UpdatedAt),
+	}
+}
+
+// authToProto converts the tokens and user of a sign-in
+func authToProto(auth *response.AuthResponse) *pb.AuthResponse {
+	return &pb.AuthResponse{
+		AccessToken:  auth.AccessToken,
+		RefreshToken: auth.RefreshToken,
+		ExpiresIn:    int32(auth.ExpiresIn),
+		User:         userToProto(&auth.User),
+	}
+}
+
+// likeToProto converts a like
+func likeToProto(like *response.LikeResponse) *pb.Like {
+	return &pb.Like{
+		Id:         like.ID.String(),
+		UserId:     like.UserID.String(),
+		TargetType: like.TargetType,
+		TargetId:   like.TargetID.String(),
+		CreatedAt:  timestamp(like.CreatedAt),
+	}
+}
@@ -0,0 +1,316 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/valpere/trytrago/application/dto/request"
+	"github.com/valpere/trytrago/application/dto/response"
+	"github.com/valpere/trytrago/application/service"
+	"github.com/valpere/trytrago/domain/logging"
+	"github.com/valpere/trytrago/interface/api/rpc/pb"
+)
+
+// exportPageSize is how many entries ExportEntries loads at a time
+const exportPageSize = 100
+
+// entryServer implements pb.EntryServiceServer over the entry service
+type entryServer struct {
+	pb.UnimplementedEntryServiceServer
+	service        service.EntryService
+	requireVersion bool
+	logger         logging.Logger
+}
+
+// CreateEntry implements pb.EntryServiceServer
+func (s *entryServer) CreateEntry(ctx context.Context, req *pb.CreateEntryRequest) (*pb.Entry, error) {
+	createReq := &request.CreateEntryRequest{
+		Word:             req.GetWord(),
+		Type:             req.GetType(),
+		SourceLanguageID: req.GetSourceLanguageId(),
+		HomographIndex:   int(req.GetHomographIndex()),
+		Pronunciation:    req.GetPronunciation(),
+	}
+	if err := validate(createReq); err != nil {
+		return nil, err
+	}
+
+	entry, err := s.service.CreateEntry(ctx, createReq)
+	if err != nil {
+		return nil, statusError(s.logger, err, "Entry", "create entry")
+	}
+	return entryToProto(entry), nil
+}
+
+// GetEntry implements pb.EntryServiceServer
+func (s *entryServer) GetEntry(ctx context.Context, req *pb.GetEntryRequest) (*pb.Entry, error) {
+	id, err := parseID(req.GetId(), "id")
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := s.service.GetEntryByID(ctx, id)
+	if err != nil {
+		return nil, statusError(s.logger, err, "Entry", "retrieve entry")
+	}
+	return entryToProto(entry), nil
+}
+
+// UpdateEntry implements pb.EntryServiceServer
+func (s *entryServer) UpdateEntry(ctx context.Context, req *pb.UpdateEntryRequest) (*pb.Entry, error) {
+	id, err := parseID(req.GetId(), "id")
+	if err != nil {
+		return nil, err
+	}
+	if err := checkVersion(s.requireVersion, req.GetVersion()); err != nil {
+		return nil, err
+	}
+
+	updateReq := &request.UpdateEntryRequest{
+		Word:           req.GetWord(),
+		Type:           req.GetType(),
+		HomographIndex: int(req.GetHomographIndex()),
+		Pronunciation:  req.GetPronunciation(),
+		Version:        int(req.GetVersion()),
+	}
+	if err := validate(updateReq); err != nil {
+		return nil, err
+	}
+
+	entry, err := s.service.UpdateEntry(ctx, id, updateReq)
+	if err != nil {
+		return nil, statusError(s.logger, err, "Entry", "update entry")
+	}
+	return entryToProto(entry), nil
+}
+
+// DeleteEntry implements pb.EntryServiceServer
+func (s *entryServer) DeleteEntry(ctx context.Context, req *pb.DeleteEntryRequest) (*emptypb.Empty, error) {
+	id, err := parseID(req.GetId(), "id")
+	if err != nil {
+		return nil, err
+	}
+	if err := checkVersion(s.requireVersion, req.GetVersion()); err != nil {
+		return nil, err
+	}
+
+	if err := s.service.DeleteEntry(ctx, id, int(req.GetVersion())); err != nil {
+		return nil, statusError(s.logger, err, "Entry", "delete entry")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ListEntries implements pb.EntryServiceServer
+func (s *entryServer) ListEntries(ctx context.Context, req *pb.ListEntriesRequest) (*pb.ListEntriesResponse, error) {
+	listReq := listEntriesRequest(req)
+	if err := validate(listReq); err != nil {
+		return nil, err
+	}
+
+	list, err := s.service.ListEntries(ctx, listReq)
+	if err != nil {
+		return nil, statusError(s.logger, err, "Entry", "list entries")
+	}
+	return entryListToProto(list.Entries, list.Total, list.Limit, list.Offset), nil
+}
+
+// ExportEntries implements pb.EntryServiceServer. It loads the entries a
+// page at a time, so the whole dictionary is never held in memory. Pages
+// follow entry IDs rather than offsets, so each costs the same and writes
+// meanwhile do not shift them
+func (s *entryServer) ExportEntries(req *pb.ExportEntriesRequest, stream pb.EntryService_ExportEntriesServer) error {
+	after := uuid.Nil
+	listReq := &request.ListEntriesRequest{
+		Limit:      exportPageSize,
+		WordFilter: req.GetWordFilter(),
+		Type:       req.GetType(),
+		AfterID:    &after,
+	}
+	if err := validate(listReq); err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	for {
+		list, err := s.service.ListEntries(ctx, listReq)
+		if err != nil {
+			return statusError(s.logger, err, "Entry", "export entries")
+		}
+
+		for _, entry := range list.Entries {
+			if err := stream.Send(entryToProto(entry)); err != nil {
+				return err
+			}
+		}
+
+		if len(list.Entries) < exportPageSize {
+			return nil
+		}
+		after = list.Entries[len(list.Entries)-1].ID
+	}
+}
+
+// AddMeaning implements pb.EntryServiceServer
+func (s *entryServer) AddMeaning(ctx context.Context, req *pb.AddMeaningRequest) (*pb.Meaning, error) {
+	entryID, err := parseID(req.GetEntryId(), "entry_id")
+	if err != nil {
+		return nil, err
+	}
+	partOfSpeechID, err := parseID(req.GetPartOfSpeechId(), "part_of_speech_id")
+	if err != nil {
+		return nil, err
+	}
+
+	createReq := &request.CreateMeaningRequest{
+		PartOfSpeechID: partOfSpeechID,
+		Description:    req.GetDescription(),
+		Examples:       req.GetExamples(),
+		Labels:         req.GetLabels(),
+	}
+	if err := validate(createReq); err != nil {
+		return nil, err
+	}
+
+	meaning, err := s.service.AddMeaning(ctx, entryID, createReq)
+	if err != nil {
+		return nil, statusError(s.logger, err, "Entry", "add meaning")
+	}
+	return meaningToProto(meaning), nil
+}
+
+// UpdateMeaning implements pb.EntryServiceServer
+func (s *entryServer) UpdateMeaning(ctx context.Context, req *pb.UpdateMeaningRequest) (*pb.Meaning, error) {
+	id, err := parseID(req.GetId(), "id")
+	if err != nil {
+		return nil, err
+	}
+	if err := checkVersion(s.requireVersion, req.GetVersion()); err != nil {
+		return nil, err
+	}
+
+	var partOfSpeechID uuid.UUID
+	if req.GetPartOfSpeechId() != "" {
+		if partOfSpeechID, err = parseID(req.GetPartOfSpeechId(), "part_of_speech_id"); err != nil {
+			return nil, err
+		}
+	}
+
+	updateReq := &request.UpdateMeaningRequest{
+		PartOfSpeechID: partOfSpeechID,
+		Description:    req.GetDescription(),
+		Examples:       req.GetExamples(),
+		Labels:         req.GetLabels(),
+		Version:        int(req.GetVersion()),
+	}
+	if err := validate(updateReq); err != nil {
+		return nil, err
+	}
+
+	meaning, err := s.service.UpdateMeaning(ctx, id, updateReq)
+	if err != nil {
+		return nil, statusError(s.logger, err, "Meaning", "update meaning")
+	}
+	return meaningToProto(meaning), nil
+}
+
+// DeleteMeaning implements pb.EntryServiceServer
+func (s *entryServer) DeleteMeaning(ctx context.Context, req *pb.DeleteMeaningRequest) (*emptypb.Empty, error) {
+	id, err := parseID(req.GetId(), "id")
+	if err != nil {
+		return nil, err
+	}
+	if err := checkVersion(s.requireVersion, req.GetVersion()); err != nil {
+		return nil, err
+	}
+
+	if err := s.service.DeleteMeaning(ctx, id, int(req.GetVersion())); err != nil {
+		return nil, statusError(s.logger, err, "Meaning", "delete meaning")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ListMeanings implements pb.EntryServiceServer
+func (s *entryServer) ListMeanings(ctx context.Context, req *pb.ListMeaningsRequest) (*pb.ListMeaningsResponse, error) {
+	entryID, err := parseID(req.GetEntryId(), "entry_id")
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := s.service.ListMeanings(ctx, entryID)
+	if err != nil {
+		return nil, statusError(s.logger, err, "Entry", "retrieve meanings")
+	}
+
+	meanings := make([]*pb.Meaning, len(list.Meanings))
+	for i, meaning := range list.Meanings {
+		meanings[i] = meaningToProto(meaning)
+	}
+	return &pb.ListMeaningsResponse{
+		Meanings:     meanings,
+		Total:        int32(list.Total),
+		EntryVersion: int32(list.EntryVersion),
+	}, nil
+}
+
+// AddMeaningComment implements pb.EntryServiceServer
+func (s *entryServer) AddMeaningComment(ctx context.Context, req *pb.AddMeaningCommentRequest) (*pb.Comment, error) {
+	meaningID, err := parseID(req.GetMeaningId(), "meaning_id")
+	if err != nil {
+		return nil, err
+	}
+
+	commentReq := &request.CreateCommentRequest{
+		Content: req.GetContent(),
+		UserID:  userIDFrom(ctx),
+	}
+	if err := validate(commentReq); err != nil {
+		return nil, err
+	}
+
+	comment, err := s.service.AddMeaningComment(ctx, meaningID, commentReq)
+	if err != nil {
+		return nil, statusError(s.logger, err, "Meaning", "add comment")
+	}
+	return commentToProto(comment), nil
+}
+
+// ToggleMeaningLike implements pb.EntryServiceServer
+func (s *entryServer) ToggleMeaningLike(ctx context.Context, req *pb.ToggleMeaningLikeRequest) (*emptypb.Empty, error) {
+	meaningID, err := parseID(req.GetMeaningId(), "meaning_id")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.service.ToggleMeaningLike(ctx, meaningID, userIDFrom(ctx)); err != nil {
+		return nil, statusError(s.logger, err, "Meaning", "toggle like")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// listEntriesRequest converts the filters and paging of an entry listing
+func listEntriesRequest(req *pb.ListEntriesRequest) *request.ListEntriesRequest {
+	return &request.ListEntriesRequest{
+		Limit:      int(req.GetLimit()),
+		Offset:     int(req.GetOffset()),
+		SortBy:     req.GetSortBy(),
+		SortDesc:   req.GetSortDesc(),
+		WordFilter: req.GetWordFilter(),
+		Type:       req.GetType(),
+	}
+}
+
+// entryListToProto converts a page of entries
+func entryListToProto(entries []*response.EntryResponse, total, limit, offset int) *pb.ListEntriesResponse {
+	result := make([]*pb.Entry, len(entries))
+	for i, entry := range entries {
+		result[i] = entryToProto(entry)
+	}
+	return &pb.ListEntriesResponse{
+		Entries: result,
+		Total:   int32(total),
+		Limit:   int32(limit),
+		Offset:  int32(offset),
+	}
+}
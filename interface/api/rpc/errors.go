@@ -0,0 +1,52 @@
+package rpc
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/valpere/trytrago/domain/database"
+	domainErrors "github.com/valpere/trytrago/domain/errors"
+	"github.com/valpere/trytrago/domain/logging"
+)
+
+// errUnauthenticated is returned for protected calls made without a valid token
+var errUnauthenticated = status.Error(codes.Unauthenticated, "authentication required")
+
+// errVersionRequired is returned for updates and deletes without a version
+// when versions are required, as REST answers them without If-Match
+var errVersionRequired = status.Error(codes.FailedPrecondition, "version of the resource is required")
+
+// invalidArgument reports a request the client has to correct
+func invalidArgument(err error) error {
+	return status.Error(codes.InvalidArgument, err.Error())
+}
+
+// statusError turns an error from a service into a status a client can see.
+// Unexpected errors are logged and replaced by a message that leaks nothing
+func statusError(logger logging.Logger, err error, kind, action string) error {
+	switch {
+	case database.IsNotFoundError(err), domainErrors.IsNotFoundError(err):
+		return status.Error(codes.NotFound, kind+" not found")
+	case database.IsVersionConflictError(err):
+		return status.Error(codes.Aborted, kind+" has been changed since it was read")
+	case database.IsDuplicateError(err), domainErrors.IsDuplicateError(err):
+		return status.Error(codes.AlreadyExists, kind+" conflicts with an existing one")
+	case errors.Is(err, domainErrors.ErrValidation), errors.Is(err, domainErrors.ErrInvalidInput), errors.Is(err, database.ErrInvalidInput):
+		return invalidArgument(err)
+	default:
+		logger.Error("failed to "+action, logging.Error(err))
+		return status.Error(codes.Internal, "failed to "+action)
+	}
+}
+
+// parseID reads a UUID field of a request
+func parseID(value, name string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, status.Errorf(codes.InvalidArgument, "invalid %s: %q", name, value)
+	}
+	return id, nil
+}
@@ -0,0 +1,285 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap/zapcore"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/valpere/trytrago/domain/logging"
+	"github.com/valpere/trytrago/infrastructure/auth"
+)
+
+// The interceptors do for gRPC calls what the Gin middleware does for REST
+// requests. Each is written once as an interceptor and adapted to unary and
+// streaming calls, which differ only in how they pass the context on
+
+// interceptor runs call, which continues the call with the context it is given
+type interceptor func(ctx context.Context, method string, call func(ctx context.Context) error) error
+
+// unary adapts an interceptor to unary calls
+func unary(ic interceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		var resp interface{}
+		err := ic(ctx, info.FullMethod, func(ctx context.Context) error {
+			var err error
+			resp, err = handler(ctx, req)
+			return err
+		})
+		return resp, err
+	}
+}
+
+// stream adapts an interceptor to streaming calls
+func stream(ic interceptor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return ic(ss.Context(), info.FullMethod, func(ctx context.Context) error {
+			return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		})
+	}
+}
+
+// serverStream is a stream whose context an interceptor has extended
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the extended context
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+type contextKey int
+
+const (
+	requestIDKey contextKey = iota
+	userIDKey
+)
+
+// requestIDHeader is the metadata key of request IDs, as X-Request-ID is for REST
+const requestIDHeader = "x-request-id"
+
+// requestID takes the request ID from the metadata or generates one, and
+// sends it back in the response header
+func requestID(ctx context.Context, _ string, call func(ctx context.Context) error) error {
+	id := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestIDHeader); len(values) > 0 {
+			id = values[0]
+		}
+	}
+	if id == "" {
+		id = uuid.New().String()
+	}
+
+	// The header is only missing for calls that are not served over a transport
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDHeader, id))
+
+	return call(context.WithValue(ctx, requestIDKey, id))
+}
+
+// requestIDFrom returns the request ID of a call
+func requestIDFrom(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// peerIP returns the address a call came from, without its port
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+		return host
+	}
+	return p.Addr.String()
+}
+
+// serverError tells whether a status code means the server failed the call
+func serverError(code codes.Code) bool {
+	switch code {
+	case codes.Unknown, codes.Internal, codes.Unavailable, codes.DataLoss, codes.Unimplemented:
+		return true
+	}
+	return false
+}
+
+// logCalls logs every call with its outcome
+func logCalls(logger logging.Logger) interceptor {
+	return func(ctx context.Context, method string, call func(ctx context.Context) error) error {
+		start := time.Now()
+		err := call(ctx)
+		code := status.Code(err)
+
+		fields := []zapcore.Field{
+			logging.String("method", method),
+			logging.String("ip", peerIP(ctx)),
+			logging.String("request_id", requestIDFrom(ctx)),
+			logging.String("code", code.String()),
+			logging.Duration("duration", time.Since(start)),
+		}
+
+		switch {
+		case serverError(code):
+			logger.Error("server error", append(fields, logging.Error(err))...)
+		case code != codes.OK:
+			logger.Warn("client error", append(fields, logging.Error(err))...)
+		default:
+			logger.Info("request completed", fields...)
+		}
+
+		return err
+	}
+}
+
+// recovery turns a panic in a handler into an Internal status
+func recovery(logger logging.Logger) interceptor {
+	return func(ctx context.Context, method string, call func(ctx context.Context) error) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error("request handler panic",
+					logging.String("method", method),
+					logging.String("error", fmt.Sprint(r)),
+				)
+				err = status.Error(codes.Internal, "internal server error")
+			}
+		}()
+
+		return call(ctx)
+	}
+}
+
+// Rate limits of each client, the same as those of the REST API
+const (
+	requestsPerSecond = 10
+	burst             = 20
+	cleanupInterval   = 5 * time.Minute
+	clientTimeout     = 10 * time.Minute
+)
+
+// clientLimiter is the rate limiter of a single client
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// rateLimiter limits the rate of calls per client IP
+type rateLimiter struct {
+	mu          sync.Mutex
+	limiters    map[string]*clientLimiter
+	lastCleanup time.Time
+}
+
+// newRateLimiter creates a rate limiter without clients
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{limiters: make(map[string]*clientLimiter), lastCleanup: time.Now()}
+}
+
+// allow tells whether a client may make another call. Clients not seen for
+// a while are dropped along the way, so no goroutine outlives the server
+func (l *rateLimiter) allow(ip string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(l.lastCleanup) > cleanupInterval {
+		for key, client := range l.limiters {
+			if now.Sub(client.lastSeen) > clientTimeout {
+				delete(l.limiters, key)
+			}
+		}
+		l.lastCleanup = now
+	}
+
+	client, exists := l.limiters[ip]
+	if !exists {
+		client = &clientLimiter{limiter: rate.NewLimiter(rate.Limit(requestsPerSecond), burst)}
+		l.limiters[ip] = client
+	}
+	client.lastSeen = now
+
+	return client.limiter.Allow()
+}
+
+// rateLimit rejects calls of clients past their rate limit
+func rateLimit(logger logging.Logger, limiter *rateLimiter) interceptor {
+	return func(ctx context.Context, method string, call func(ctx context.Context) error) error {
+		ip := peerIP(ctx)
+		if !limiter.allow(ip) {
+			logger.Warn("rate limit exceeded",
+				logging.String("ip", ip),
+				logging.String("method", method),
+			)
+			return status.Error(codes.ResourceExhausted, "rate limit exceeded, please try again later")
+		}
+
+		return call(ctx)
+	}
+}
+
+// authenticate requires a valid bearer token in the authorization metadata
+// of every method but the public ones, and passes on the ID of its user
+func authenticate(logger logging.Logger, public map[string]bool) interceptor {
+	return func(ctx context.Context, method string, call func(ctx context.Context) error) error {
+		if public[method] {
+			return call(ctx)
+		}
+
+		claims, err := extractToken(ctx)
+		if err != nil {
+			logger.Debug("Authentication failed", logging.Error(err))
+			return errUnauthenticated
+		}
+
+		userID, err := uuid.Parse(claims.UserID)
+		if err != nil {
+			logger.Error("Invalid user ID in token", logging.Error(err))
+			return status.Error(codes.Unauthenticated, "invalid authentication token")
+		}
+
+		return call(context.WithValue(ctx, userIDKey, userID))
+	}
+}
+
+// extractToken extracts and validates the JWT token from the metadata of a call
+func extractToken(ctx context.Context) (*auth.TokenClaims, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("missing metadata")
+	}
+
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, fmt.Errorf("missing authorization metadata")
+	}
+
+	if !strings.HasPrefix(values[0], "Bearer ") {
+		return nil, fmt.Errorf("invalid authorization format")
+	}
+
+	token := strings.TrimPrefix(values[0], "Bearer ")
+	if token == "" {
+		return nil, fmt.Errorf("empty token")
+	}
+
+	return auth.ValidateToken(token)
+}
+
+// userIDFrom returns the ID of the authenticated user of a call
+func userIDFrom(ctx context.Context) uuid.UUID {
+	id, _ := ctx.Value(userIDKey).(uuid.UUID)
+	return id
+}
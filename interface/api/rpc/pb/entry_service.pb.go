@@ -0,0 +1,1087 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: trytrago/v1/entry_service.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CreateEntryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Word  string                 `protobuf:"bytes,1,opt,name=word,proto3" json:"word,omitempty"`
+	// WORD, COMPOUND_WORD or PHRASE
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// ISO 639-1 code
+	SourceLanguageId string `protobuf:"bytes,3,opt,name=source_language_id,json=sourceLanguageId,proto3" json:"source_language_id,omitempty"`
+	// Assigned automatically when 0
+	HomographIndex int32  `protobuf:"varint,4,opt,name=homograph_index,json=homographIndex,proto3" json:"homograph_index,omitempty"`
+	Pronunciation  string `protobuf:"bytes,5,opt,name=pronunciation,proto3" json:"pronunciation,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateEntryRequest) Reset() {
+	*x = CreateEntryRequest{}
+	mi := &file_trytrago_v1_entry_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateEntryRequest) ProtoMessage() {}
+
+func (x *CreateEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trytrago_v1_entry_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateEntryRequest.ProtoReflect.Descriptor instead.
+func (*CreateEntryRequest) Descriptor() ([]byte, []int) {
+	return file_trytrago_v1_entry_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateEntryRequest) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+func (x *CreateEntryRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CreateEntryRequest) GetSourceLanguageId() string {
+	if x != nil {
+		return x.SourceLanguageId
+	}
+	return ""
+}
+
+func (x *CreateEntryRequest) GetHomographIndex() int32 {
+	if x != nil {
+		return x.HomographIndex
+	}
+	return 0
+}
+
+func (x *CreateEntryRequest) GetPronunciation() string {
+	if x != nil {
+		return x.Pronunciation
+	}
+	return ""
+}
+
+type GetEntryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEntryRequest) Reset() {
+	*x = GetEntryRequest{}
+	mi := &file_trytrago_v1_entry_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEntryRequest) ProtoMessage() {}
+
+func (x *GetEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trytrago_v1_entry_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEntryRequest.ProtoReflect.Descriptor instead.
+func (*GetEntryRequest) Descriptor() ([]byte, []int) {
+	return file_trytrago_v1_entry_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetEntryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// UpdateEntryRequest changes the fields that are set
+type UpdateEntryRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Word           string                 `protobuf:"bytes,2,opt,name=word,proto3" json:"word,omitempty"`
+	Type           string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	HomographIndex int32                  `protobuf:"varint,4,opt,name=homograph_index,json=homographIndex,proto3" json:"homograph_index,omitempty"`
+	Pronunciation  string                 `protobuf:"bytes,5,opt,name=pronunciation,proto3" json:"pronunciation,omitempty"`
+	// Version the change is based on, like If-Match; 0 skips the check
+	Version       int32 `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateEntryRequest) Reset() {
+	*x = UpdateEntryRequest{}
+	mi := &file_trytrago_v1_entry_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateEntryRequest) ProtoMessage() {}
+
+func (x *UpdateEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trytrago_v1_entry_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateEntryRequest.ProtoReflect.Descriptor instead.
+func (*UpdateEntryRequest) Descriptor() ([]byte, []int) {
+	return file_trytrago_v1_entry_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UpdateEntryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateEntryRequest) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+func (x *UpdateEntryRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *UpdateEntryRequest) GetHomographIndex() int32 {
+	if x != nil {
+		return x.HomographIndex
+	}
+	return 0
+}
+
+func (x *UpdateEntryRequest) GetPronunciation() string {
+	if x != nil {
+		return x.Pronunciation
+	}
+	return ""
+}
+
+func (x *UpdateEntryRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type DeleteEntryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Version the deletion is based on, like If-Match; 0 skips the check
+	Version       int32 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteEntryRequest) Reset() {
+	*x = DeleteEntryRequest{}
+	mi := &file_trytrago_v1_entry_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteEntryRequest) ProtoMessage() {}
+
+func (x *DeleteEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trytrago_v1_entry_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteEntryRequest.ProtoReflect.Descriptor instead.
+func (*DeleteEntryRequest) Descriptor() ([]byte, []int) {
+	return file_trytrago_v1_entry_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DeleteEntryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteEntryRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type ListEntriesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 1 to 100
+	Limit  int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset int32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// word, created_at or updated_at
+	SortBy   string `protobuf:"bytes,3,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	SortDesc bool   `protobuf:"varint,4,opt,name=sort_desc,json=sortDesc,proto3" json:"sort_desc,omitempty"`
+	// Only entries whose word contains this text
+	WordFilter    string `protobuf:"bytes,5,opt,name=word_filter,json=wordFilter,proto3" json:"word_filter,omitempty"`
+	Type          string `protobuf:"bytes,6,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEntriesRequest) Reset() {
+	*x = ListEntriesRequest{}
+	mi := &file_trytrago_v1_entry_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEntriesRequest) ProtoMessage() {}
+
+func (x *ListEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trytrago_v1_entry_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEntriesRequest.ProtoReflect.Descriptor instead.
+func (*ListEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_trytrago_v1_entry_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListEntriesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListEntriesRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListEntriesRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *ListEntriesRequest) GetSortDesc() bool {
+	if x != nil {
+		return x.SortDesc
+	}
+	return false
+}
+
+func (x *ListEntriesRequest) GetWordFilter() string {
+	if x != nil {
+		return x.WordFilter
+	}
+	return ""
+}
+
+func (x *ListEntriesRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type ListEntriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*Entry               `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEntriesResponse) Reset() {
+	*x = ListEntriesResponse{}
+	mi := &file_trytrago_v1_entry_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEntriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEntriesResponse) ProtoMessage() {}
+
+func (x *ListEntriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_trytrago_v1_entry_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEntriesResponse.ProtoReflect.Descriptor instead.
+func (*ListEntriesResponse) Descriptor() ([]byte, []int) {
+	return file_trytrago_v1_entry_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListEntriesResponse) GetEntries() []*Entry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *ListEntriesResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListEntriesResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListEntriesResponse) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ExportEntriesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only entries whose word contains this text
+	WordFilter    string `protobuf:"bytes,1,opt,name=word_filter,json=wordFilter,proto3" json:"word_filter,omitempty"`
+	Type          string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportEntriesRequest) Reset() {
+	*x = ExportEntriesRequest{}
+	mi := &file_trytrago_v1_entry_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportEntriesRequest) ProtoMessage() {}
+
+func (x *ExportEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trytrago_v1_entry_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportEntriesRequest.ProtoReflect.Descriptor instead.
+func (*ExportEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_trytrago_v1_entry_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ExportEntriesRequest) GetWordFilter() string {
+	if x != nil {
+		return x.WordFilter
+	}
+	return ""
+}
+
+func (x *ExportEntriesRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type AddMeaningRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	EntryId        string                 `protobuf:"bytes,1,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	PartOfSpeechId string                 `protobuf:"bytes,2,opt,name=part_of_speech_id,json=partOfSpeechId,proto3" json:"part_of_speech_id,omitempty"`
+	Description    string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Examples       []string               `protobuf:"bytes,4,rep,name=examples,proto3" json:"examples,omitempty"`
+	// Usage labels, e.g. "formal"
+	Labels        []string `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddMeaningRequest) Reset() {
+	*x = AddMeaningRequest{}
+	mi := &file_trytrago_v1_entry_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddMeaningRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddMeaningRequest) ProtoMessage() {}
+
+func (x *AddMeaningRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trytrago_v1_entry_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddMeaningRequest.ProtoReflect.Descriptor instead.
+func (*AddMeaningRequest) Descriptor() ([]byte, []int) {
+	return file_trytrago_v1_entry_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AddMeaningRequest) GetEntryId() string {
+	if x != nil {
+		return x.EntryId
+	}
+	return ""
+}
+
+func (x *AddMeaningRequest) GetPartOfSpeechId() string {
+	if x != nil {
+		return x.PartOfSpeechId
+	}
+	return ""
+}
+
+func (x *AddMeaningRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *AddMeaningRequest) GetExamples() []string {
+	if x != nil {
+		return x.Examples
+	}
+	return nil
+}
+
+func (x *AddMeaningRequest) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+// UpdateMeaningRequest changes the fields that are set
+type UpdateMeaningRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PartOfSpeechId string                 `protobuf:"bytes,2,opt,name=part_of_speech_id,json=partOfSpeechId,proto3" json:"part_of_speech_id,omitempty"`
+	Description    string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Examples       []string               `protobuf:"bytes,4,rep,name=examples,proto3" json:"examples,omitempty"`
+	// Replaces the labels when given
+	Labels []string `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty"`
+	// Version the change is based on, like If-Match; 0 skips the check
+	Version       int32 `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMeaningRequest) Reset() {
+	*x = UpdateMeaningRequest{}
+	mi := &file_trytrago_v1_entry_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMeaningRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMeaningRequest) ProtoMessage() {}
+
+func (x *UpdateMeaningRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trytrago_v1_entry_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMeaningRequest.ProtoReflect.Descriptor instead.
+func (*UpdateMeaningRequest) Descriptor() ([]byte, []int) {
+	return file_trytrago_v1_entry_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateMeaningRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateMeaningRequest) GetPartOfSpeechId() string {
+	if x != nil {
+		return x.PartOfSpeechId
+	}
+	return ""
+}
+
+func (x *UpdateMeaningRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateMeaningRequest) GetExamples() []string {
+	if x != nil {
+		return x.Examples
+	}
+	return nil
+}
+
+func (x *UpdateMeaningRequest) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *UpdateMeaningRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type DeleteMeaningRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Version the deletion is based on, like If-Match; 0 skips the check
+	Version       int32 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMeaningRequest) Reset() {
+	*x = DeleteMeaningRequest{}
+	mi := &file_trytrago_v1_entry_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMeaningRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMeaningRequest) ProtoMessage() {}
+
+func (x *DeleteMeaningRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trytrago_v1_entry_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMeaningRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMeaningRequest) Descriptor() ([]byte, []int) {
+	return file_trytrago_v1_entry_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteMeaningRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteMeaningRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type ListMeaningsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntryId       string                 `protobuf:"bytes,1,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMeaningsRequest) Reset() {
+	*x = ListMeaningsRequest{}
+	mi := &file_trytrago_v1_entry_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMeaningsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMeaningsRequest) ProtoMessage() {}
+
+func (x *ListMeaningsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trytrago_v1_entry_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMeaningsRequest.ProtoReflect.Descriptor instead.
+func (*ListMeaningsRequest) Descriptor() ([]byte, []int) {
+	return file_trytrago_v1_entry_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListMeaningsRequest) GetEntryId() string {
+	if x != nil {
+		return x.EntryId
+	}
+	return ""
+}
+
+type ListMeaningsResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Meanings []*Meaning             `protobuf:"bytes,1,rep,name=meanings,proto3" json:"meanings,omitempty"`
+	Total    int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	// The list changes only with its entry
+	EntryVersion  int32 `protobuf:"varint,3,opt,name=entry_version,json=entryVersion,proto3" json:"entry_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMeaningsResponse) Reset() {
+	*x = ListMeaningsResponse{}
+	mi := &file_trytrago_v1_entry_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMeaningsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMeaningsResponse) ProtoMessage() {}
+
+func (x *ListMeaningsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_trytrago_v1_entry_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMeaningsResponse.ProtoReflect.Descriptor instead.
+func (*ListMeaningsResponse) Descriptor() ([]byte, []int) {
+	return file_trytrago_v1_entry_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListMeaningsResponse) GetMeanings() []*Meaning {
+	if x != nil {
+		return x.Meanings
+	}
+	return nil
+}
+
+func (x *ListMeaningsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListMeaningsResponse) GetEntryVersion() int32 {
+	if x != nil {
+		return x.EntryVersion
+	}
+	return 0
+}
+
+type AddMeaningCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MeaningId     string                 `protobuf:"bytes,1,opt,name=meaning_id,json=meaningId,proto3" json:"meaning_id,omitempty"`
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddMeaningCommentRequest) Reset() {
+	*x = AddMeaningCommentRequest{}
+	mi := &file_trytrago_v1_entry_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddMeaningCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddMeaningCommentRequest) ProtoMessage() {}
+
+func (x *AddMeaningCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trytrago_v1_entry_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddMeaningCommentRequest.ProtoReflect.Descriptor instead.
+func (*AddMeaningCommentRequest) Descriptor() ([]byte, []int) {
+	return file_trytrago_v1_entry_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AddMeaningCommentRequest) GetMeaningId() string {
+	if x != nil {
+		return x.MeaningId
+	}
+	return ""
+}
+
+func (x *AddMeaningCommentRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+type ToggleMeaningLikeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MeaningId     string                 `protobuf:"bytes,1,opt,name=meaning_id,json=meaningId,proto3" json:"meaning_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ToggleMeaningLikeRequest) Reset() {
+	*x = ToggleMeaningLikeRequest{}
+	mi := &file_trytrago_v1_entry_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToggleMeaningLikeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToggleMeaningLikeRequest) ProtoMessage() {}
+
+func (x *ToggleMeaningLikeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trytrago_v1_entry_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToggleMeaningLikeRequest.ProtoReflect.Descriptor instead.
+func (*ToggleMeaningLikeRequest) Descriptor() ([]byte, []int) {
+	return file_trytrago_v1_entry_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ToggleMeaningLikeRequest) GetMeaningId() string {
+	if x != nil {
+		return x.MeaningId
+	}
+	return ""
+}
+
+var File_trytrago_v1_entry_service_proto protoreflect.FileDescriptor
+
+const file_trytrago_v1_entry_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1ftrytrago/v1/entry_service.proto\x12\vtrytrago.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1ctrytrago/v1/dictionary.proto\"\xb9\x01\n" +
+	"\x12CreateEntryRequest\x12\x12\n" +
+	"\x04word\x18\x01 \x01(\tR\x04word\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12,\n" +
+	"\x12source_language_id\x18\x03 \x01(\tR\x10sourceLanguageId\x12'\n" +
+	"\x0fhomograph_index\x18\x04 \x01(\x05R\x0ehomographIndex\x12$\n" +
+	"\rpronunciation\x18\x05 \x01(\tR\rpronunciation\"!\n" +
+	"\x0fGetEntryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xb5\x01\n" +
+	"\x12UpdateEntryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04word\x18\x02 \x01(\tR\x04word\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12'\n" +
+	"\x0fhomograph_index\x18\x04 \x01(\x05R\x0ehomographIndex\x12$\n" +
+	"\rpronunciation\x18\x05 \x01(\tR\rpronunciation\x12\x18\n" +
+	"\aversion\x18\x06 \x01(\x05R\aversion\">\n" +
+	"\x12DeleteEntryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\"\xad\x01\n" +
+	"\x12ListEntriesRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\x17\n" +
+	"\asort_by\x18\x03 \x01(\tR\x06sortBy\x12\x1b\n" +
+	"\tsort_desc\x18\x04 \x01(\bR\bsortDesc\x12\x1f\n" +
+	"\vword_filter\x18\x05 \x01(\tR\n" +
+	"wordFilter\x12\x12\n" +
+	"\x04type\x18\x06 \x01(\tR\x04type\"\x87\x01\n" +
+	"\x13ListEntriesResponse\x12,\n" +
+	"\aentries\x18\x01 \x03(\v2\x12.trytrago.v1.EntryR\aentries\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\"K\n" +
+	"\x14ExportEntriesRequest\x12\x1f\n" +
+	"\vword_filter\x18\x01 \x01(\tR\n" +
+	"wordFilter\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\"\xaf\x01\n" +
+	"\x11AddMeaningRequest\x12\x19\n" +
+	"\bentry_id\x18\x01 \x01(\tR\aentryId\x12)\n" +
+	"\x11part_of_speech_id\x18\x02 \x01(\tR\x0epartOfSpeechId\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bexamples\x18\x04 \x03(\tR\bexamples\x12\x16\n" +
+	"\x06labels\x18\x05 \x03(\tR\x06labels\"\xc1\x01\n" +
+	"\x14UpdateMeaningRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12)\n" +
+	"\x11part_of_speech_id\x18\x02 \x01(\tR\x0epartOfSpeechId\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bexamples\x18\x04 \x03(\tR\bexamples\x12\x16\n" +
+	"\x06labels\x18\x05 \x03(\tR\x06labels\x12\x18\n" +
+	"\aversion\x18\x06 \x01(\x05R\aversion\"@\n" +
+	"\x14DeleteMeaningRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\"0\n" +
+	"\x13ListMeaningsRequest\x12\x19\n" +
+	"\bentry_id\x18\x01 \x01(\tR\aentryId\"\x83\x01\n" +
+	"\x14ListMeaningsResponse\x120\n" +
+	"\bmeanings\x18\x01 \x03(\v2\x14.trytrago.v1.MeaningR\bmeanings\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12#\n" +
+	"\rentry_version\x18\x03 \x01(\x05R\fentryVersion\"S\n" +
+	"\x18AddMeaningCommentRequest\x12\x1d\n" +
+	"\n" +
+	"meaning_id\x18\x01 \x01(\tR\tmeaningId\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\"9\n" +
+	"\x18ToggleMeaningLikeRequest\x12\x1d\n" +
+	"\n" +
+	"meaning_id\x18\x01 \x01(\tR\tmeaningId2\x8d\a\n" +
+	"\fEntryService\x12B\n" +
+	"\vCreateEntry\x12\x1f.trytrago.v1.CreateEntryRequest\x1a\x12.trytrago.v1.Entry\x12<\n" +
+	"\bGetEntry\x12\x1c.trytrago.v1.GetEntryRequest\x1a\x12.trytrago.v1.Entry\x12B\n" +
+	"\vUpdateEntry\x12\x1f.trytrago.v1.UpdateEntryRequest\x1a\x12.trytrago.v1.Entry\x12F\n" +
+	"\vDeleteEntry\x12\x1f.trytrago.v1.DeleteEntryRequest\x1a\x16.google.protobuf.Empty\x12P\n" +
+	"\vListEntries\x12\x1f.trytrago.v1.ListEntriesRequest\x1a .trytrago.v1.ListEntriesResponse\x12H\n" +
+	"\rExportEntries\x12!.trytrago.v1.ExportEntriesRequest\x1a\x12.trytrago.v1.Entry0\x01\x12B\n" +
+	"\n" +
+	"AddMeaning\x12\x1e.trytrago.v1.AddMeaningRequest\x1a\x14.trytrago.v1.Meaning\x12H\n" +
+	"\rUpdateMeaning\x12!.trytrago.v1.UpdateMeaningRequest\x1a\x14.trytrago.v1.Meaning\x12J\n" +
+	"\rDeleteMeaning\x12!.trytrago.v1.DeleteMeaningRequest\x1a\x16.google.protobuf.Empty\x12S\n" +
+	"\fListMeanings\x12 .trytrago.v1.ListMeaningsRequest\x1a!.trytrago.v1.ListMeaningsResponse\x12P\n" +
+	"\x11AddMeaningComment\x12%.trytrago.v1.AddMeaningCommentRequest\x1a\x14.trytrago.v1.Comment\x12R\n" +
+	"\x11ToggleMeaningLike\x12%.trytrago.v1.ToggleMeaningLikeRequest\x1a\x16.google.protobuf.EmptyB2Z0github.com/valpere/trytrago/interface/api/rpc/pbb\x06proto3"
+
+var (
+	file_trytrago_v1_entry_service_proto_rawDescOnce sync.Once
+	file_trytrago_v1_entry_service_proto_rawDescData []byte
+)
+
+func file_trytrago_v1_entry_service_proto_rawDescGZIP() []byte {
+	file_trytrago_v1_entry_service_proto_rawDescOnce.Do(func() {
+		file_trytrago_v1_entry_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_trytrago_v1_entry_service_proto_rawDesc), len(file_trytrago_v1_entry_service_proto_rawDesc)))
+	})
+	return file_trytrago_v1_entry_service_proto_rawDescData
+}
+
+var file_trytrago_v1_entry_service_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_trytrago_v1_entry_service_proto_goTypes = []any{
+	(*CreateEntryRequest)(nil),       // 0: trytrago.v1.CreateEntryRequest
+	(*GetEntryRequest)(nil),          // 1: trytrago.v1.GetEntryRequest
+	(*UpdateEntryRequest)(nil),       // 2: trytrago.v1.UpdateEntryRequest
+	(*DeleteEntryRequest)(nil),       // 3: trytrago.v1.DeleteEntryRequest
+	(*ListEntriesRequest)(nil),       // 4: trytrago.v1.ListEntriesRequest
+	(*ListEntriesResponse)(nil),      // 5: trytrago.v1.ListEntriesResponse
+	(*ExportEntriesRequest)(nil),     // 6: trytrago.v1.ExportEntriesRequest
+	(*AddMeaningRequest)(nil),        // 7: trytrago.v1.AddMeaningRequest
+	(*UpdateMeaningRequest)(nil),     // 8: trytrago.v1.UpdateMeaningRequest
+	(*DeleteMeaningRequest)(nil),     // 9: trytrago.v1.DeleteMeaningRequest
+	(*ListMeaningsRequest)(nil),      // 10: trytrago.v1.ListMeaningsRequest
+	(*ListMeaningsResponse)(nil),     // 11: trytrago.v1.ListMeaningsResponse
+	(*AddMeaningCommentRequest)(nil), // 12: trytrago.v1.AddMeaningCommentRequest
+	(*ToggleMeaningLikeRequest)(nil), // 13: trytrago.v1.ToggleMeaningLikeRequest
+	(*Entry)(nil),                    // 14: trytrago.v1.Entry
+	(*Meaning)(nil),                  // 15: trytrago.v1.Meaning
+	(*emptypb.Empty)(nil),            // 16: google.protobuf.Empty
+	(*Comment)(nil),                  // 17: trytrago.v1.Comment
+}
+var file_trytrago_v1_entry_service_proto_depIdxs = []int32{
+	14, // 0: trytrago.v1.ListEntriesResponse.entries:type_name -> trytrago.v1.Entry
+	15, // 1: trytrago.v1.ListMeaningsResponse.meanings:type_name -> trytrago.v1.Meaning
+	0,  // 2: trytrago.v1.EntryService.CreateEntry:input_type -> trytrago.v1.CreateEntryRequest
+	1,  // 3: trytrago.v1.EntryService.GetEntry:input_type -> trytrago.v1.GetEntryRequest
+	2,  // 4: trytrago.v1.EntryService.UpdateEntry:input_type -> trytrago.v1.UpdateEntryRequest
+	3,  // 5: trytrago.v1.EntryService.DeleteEntry:input_type -> trytrago.v1.DeleteEntryRequest
+	4,  // 6: trytrago.v1.EntryService.ListEntries:input_type -> trytrago.v1.ListEntriesRequest
+	6,  // 7: trytrago.v1.EntryService.ExportEntries:input_type -> trytrago.v1.ExportEntriesRequest
+	7,  // 8: trytrago.v1.EntryService.AddMeaning:input_type -> trytrago.v1.AddMeaningRequest
+	8,  // 9: trytrago.v1.EntryService.UpdateMeaning:input_type -> trytrago.v1.UpdateMeaningRequest
+	9,  // 10: trytrago.v1.EntryService.DeleteMeaning:input_type -> trytrago.v1.DeleteMeaningRequest
+	10, // 11: trytrago.v1.EntryService.ListMeanings:input_type -> trytrago.v1.ListMeaningsRequest
+	12, // 12: trytrago.v1.EntryService.AddMeaningComment:input_type -> trytrago.v1.AddMeaningCommentRequest
+	13, // 13: trytrago.v1.EntryService.ToggleMeaningLike:input_type -> trytrago.v1.ToggleMeaningLikeRequest
+	14, // 14: trytrago.v1.EntryService.CreateEntry:output_type -> trytrago.v1.Entry
+	14, // 15: trytrago.v1.EntryService.GetEntry:output_type -> trytrago.v1.Entry
+	14, // 16: trytrago.v1.EntryService.UpdateEntry:output_type -> trytrago.v1.Entry
+	16, // 17: trytrago.v1.EntryService.DeleteEntry:output_type -> google.protobuf.Empty
+	5,  // 18: trytrago.v1.EntryService.ListEntries:output_type -> trytrago.v1.ListEntriesResponse
+	14, // 19: trytrago.v1.EntryService.ExportEntries:output_type -> trytrago.v1.Entry
+	15, // 20: trytrago.v1.EntryService.AddMeaning:output_type -> trytrago.v1.Meaning
+	15, // 21: trytrago.v1.EntryService.UpdateMeaning:output_type -> trytrago.v1.Meaning
+	16, // 22: trytrago.v1.EntryService.DeleteMeaning:output_type -> google.protobuf.Empty
+	11, // 23: trytrago.v1.EntryService.ListMeanings:output_type -> trytrago.v1.ListMeaningsResponse
+	17, // 24: trytrago.v1.EntryService.AddMeaningComment:output_type -> trytrago.v1.Comment
+	16, // 25: trytrago.v1.EntryService.ToggleMeaningLike:output_type -> google.protobuf.Empty
+	14, // [14:26] is the sub-list for method output_type
+	2,  // [2:14] is the sub-list for method input_type
+	2,  // [2:2] is the sub-list for extension type_name
+	2,  // [2:2] is the sub-list for extension extendee
+	0,  // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_trytrago_v1_entry_service_proto_init() }
+func file_trytrago_v1_entry_service_proto_init() {
+	if File_trytrago_v1_entry_service_proto != nil {
+		return
+	}
+	file_trytrago_v1_dictionary_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_trytrago_v1_entry_service_proto_rawDesc), len(file_trytrago_v1_entry_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_trytrago_v1_entry_service_proto_goTypes,
+		DependencyIndexes: file_trytrago_v1_entry_service_proto_depIdxs,
+		MessageInfos:      file_trytrago_v1_entry_service_proto_msgTypes,
+	}.Build()
+	File_trytrago_v1_entry_service_proto = out.File
+	file_trytrago_v1_entry_service_proto_goTypes = nil
+	file_trytrago_v1_entry_service_proto_depIdxs = nil
+}
@@ -0,0 +1,554 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: trytrago/v1/entry_service.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	EntryService_CreateEntry_FullMethodName       = "/trytrago.v1.EntryService/CreateEntry"
+	EntryService_GetEntry_FullMethodName          = "/trytrago.v1.EntryService/GetEntry"
+	EntryService_UpdateEntry_FullMethodName       = "/trytrago.v1.EntryService/UpdateEntry"
+	EntryService_DeleteEntry_FullMethodName       = "/trytrago.v1.EntryService/DeleteEntry"
+	EntryService_ListEntries_FullMethodName       = "/trytrago.v1.EntryService/ListEntries"
+	EntryService_ExportEntries_FullMethodName     = "/trytrago.v1.EntryService/ExportEntries"
+	EntryService_AddMeaning_FullMethodName        = "/trytrago.v1.EntryService/AddMeaning"
+	EntryService_UpdateMeaning_FullMethodName     = "/trytrago.v1.EntryService/UpdateMeaning"
+	EntryService_DeleteMeaning_FullMethodName     = "/trytrago.v1.EntryService/DeleteMeaning"
+	EntryService_ListMeanings_FullMethodName      = "/trytrago.v1.EntryService/ListMeanings"
+	EntryService_AddMeaningComment_FullMethodName = "/trytrago.v1.EntryService/AddMeaningComment"
+	EntryService_ToggleMeaningLike_FullMethodName = "/trytrago.v1.EntryService/ToggleMeaningLike"
+)
+
+// EntryServiceClient is the client API for EntryService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// EntryService manages dictionary entries and their meanings. Reads are
+// public; every other call needs a bearer token in the authorization metadata
+type EntryServiceClient interface {
+	CreateEntry(ctx context.Context, in *CreateEntryRequest, opts ...grpc.CallOption) (*Entry, error)
+	GetEntry(ctx context.Context, in *GetEntryRequest, opts ...grpc.CallOption) (*Entry, error)
+	UpdateEntry(ctx context.Context, in *UpdateEntryRequest, opts ...grpc.CallOption) (*Entry, error)
+	DeleteEntry(ctx context.Context, in *DeleteEntryRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListEntries(ctx context.Context, in *ListEntriesRequest, opts ...grpc.CallOption) (*ListEntriesResponse, error)
+	// ExportEntries streams every entry matching the filters, with its
+	// meanings and translations, one message per entry
+	ExportEntries(ctx context.Context, in *ExportEntriesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Entry], error)
+	AddMeaning(ctx context.Context, in *AddMeaningRequest, opts ...grpc.CallOption) (*Meaning, error)
+	UpdateMeaning(ctx context.Context, in *UpdateMeaningRequest, opts ...grpc.CallOption) (*Meaning, error)
+	DeleteMeaning(ctx context.Context, in *DeleteMeaningRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListMeanings(ctx context.Context, in *ListMeaningsRequest, opts ...grpc.CallOption) (*ListMeaningsResponse, error)
+	AddMeaningComment(ctx context.Context, in *AddMeaningCommentRequest, opts ...grpc.CallOption) (*Comment, error)
+	ToggleMeaningLike(ctx context.Context, in *ToggleMeaningLikeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type entryServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEntryServiceClient(cc grpc.ClientConnInterface) EntryServiceClient {
+	return &entryServiceClient{cc}
+}
+
+func (c *entryServiceClient) CreateEntry(ctx context.Context, in *CreateEntryRequest, opts ...grpc.CallOption) (*Entry, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Entry)
+	err := c.cc.Invoke(ctx, EntryService_CreateEntry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *entryServiceClient) GetEntry(ctx context.Context, in *GetEntryRequest, opts ...grpc.CallOption) (*Entry, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Entry)
+	err := c.cc.Invoke(ctx, EntryService_GetEntry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *entryServiceClient) UpdateEntry(ctx context.Context, in *UpdateEntryRequest, opts ...grpc.CallOption) (*Entry, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Entry)
+	err := c.cc.Invoke(ctx, EntryService_UpdateEntry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *entryServiceClient) DeleteEntry(ctx context.Context, in *DeleteEntryRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, EntryService_DeleteEntry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *entryServiceClient) ListEntries(ctx context.Context, in *ListEntriesRequest, opts ...grpc.CallOption) (*ListEntriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListEntriesResponse)
+	err := c.cc.Invoke(ctx, EntryService_ListEntries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *entryServiceClient) ExportEntries(ctx context.Context, in *ExportEntriesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Entry], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &EntryService_ServiceDesc.Streams[0], EntryService_ExportEntries_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportEntriesRequest, Entry]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EntryService_ExportEntriesClient = grpc.ServerStreamingClient[Entry]
+
+func (c *entryServiceClient) AddMeaning(ctx context.Context, in *AddMeaningRequest, opts ...grpc.CallOption) (*Meaning, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Meaning)
+	err := c.cc.Invoke(ctx, EntryService_AddMeaning_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *entryServiceClient) UpdateMeaning(ctx context.Context, in *UpdateMeaningRequest, opts ...grpc.CallOption) (*Meaning, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Meaning)
+	err := c.cc.Invoke(ctx, EntryService_UpdateMeaning_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *entryServiceClient) DeleteMeaning(ctx context.Context, in *DeleteMeaningRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, EntryService_DeleteMeaning_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *entryServiceClient) ListMeanings(ctx context.Context, in *ListMeaningsRequest, opts ...grpc.CallOption) (*ListMeaningsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMeaningsResponse)
+	err := c.cc.Invoke(ctx, EntryService_ListMeanings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *entryServiceClient) AddMeaningComment(ctx context.Context, in *AddMeaningCommentRequest, opts ...grpc.CallOption) (*Comment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Comment)
+	err := c.cc.Invoke(ctx, EntryService_AddMeaningComment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *entryServiceClient) ToggleMeaningLike(ctx context.Context, in *ToggleMeaningLikeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, EntryService_ToggleMeaningLike_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EntryServiceServer is the server API for EntryService service.
+// All implementations must embed UnimplementedEntryServiceServer
+// for forward compatibility.
+//
+// EntryService manages dictionary entries and their meanings. Reads are
+// public; every other call needs a bearer token in the authorization metadata
+type EntryServiceServer interface {
+	CreateEntry(context.Context, *CreateEntryRequest) (*Entry, error)
+	GetEntry(context.Context, *GetEntryRequest) (*Entry, error)
+	UpdateEntry(context.Context, *UpdateEntryRequest) (*Entry, error)
+	DeleteEntry(context.Context, *DeleteEntryRequest) (*emptypb.Empty, error)
+	ListEntries(context.Context, *ListEntriesRequest) (*ListEntriesResponse, error)
+	// ExportEntries streams every entry matching the filters, with its
+	// meanings and translations, one message per entry
+	ExportEntries(*ExportEntriesRequest, grpc.ServerStreamingServer[Entry]) error
+	AddMeaning(context.Context, *AddMeaningRequest) (*Meaning, error)
+	UpdateMeaning(context.Context, *UpdateMeaningRequest) (*Meaning, error)
+	DeleteMeaning(context.Context, *DeleteMeaningRequest) (*emptypb.Empty, error)
+	ListMeanings(context.Context, *ListMeaningsRequest) (*ListMeaningsResponse, error)
+	AddMeaningComment(context.Context, *AddMeaningCommentRequest) (*Comment, error)
+	ToggleMeaningLike(context.Context, *ToggleMeaningLikeRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedEntryServiceServer()
+}
+
+// UnimplementedEntryServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedEntryServiceServer struct{}
+
+func (UnimplementedEntryServiceServer) CreateEntry(context.Context, *CreateEntryRequest) (*Entry, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateEntry not implemented")
+}
+func (UnimplementedEntryServiceServer) GetEntry(context.Context, *GetEntryRequest) (*Entry, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEntry not implemented")
+}
+func (UnimplementedEntryServiceServer) UpdateEntry(context.Context, *UpdateEntryRequest) (*Entry, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateEntry not implemented")
+}
+func (UnimplementedEntryServiceServer) DeleteEntry(context.Context, *DeleteEntryRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteEntry not implemented")
+}
+func (UnimplementedEntryServiceServer) ListEntries(context.Context, *ListEntriesRequest) (*ListEntriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEntries not implemented")
+}
+func (UnimplementedEntryServiceServer) ExportEntries(*ExportEntriesRequest, grpc.ServerStreamingServer[Entry]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportEntries not implemented")
+}
+func (UnimplementedEntryServiceServer) AddMeaning(context.Context, *AddMeaningRequest) (*Meaning, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddMeaning not implemented")
+}
+func (UnimplementedEntryServiceServer) UpdateMeaning(context.Context, *UpdateMeaningRequest) (*Meaning, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateMeaning not implemented")
+}
+func (UnimplementedEntryServiceServer) DeleteMeaning(context.Context, *DeleteMeaningRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMeaning not implemented")
+}
+func (UnimplementedEntryServiceServer) ListMeanings(context.Context, *ListMeaningsRequest) (*ListMeaningsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMeanings not implemented")
+}
+func (UnimplementedEntryServiceServer) AddMeaningComment(context.Context, *AddMeaningCommentRequest) (*Comment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddMeaningComment not implemented")
+}
+func (UnimplementedEntryServiceServer) ToggleMeaningLike(context.Context, *ToggleMeaningLikeRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ToggleMeaningLike not implemented")
+}
+func (UnimplementedEntryServiceServer) mustEmbedUnimplementedEntryServiceServer() {}
+func (UnimplementedEntryServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeEntryServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EntryServiceServer will
+// result in compilation errors.
+type UnsafeEntryServiceServer interface {
+	mustEmbedUnimplementedEntryServiceServer()
+}
+
+func RegisterEntryServiceServer(s grpc.ServiceRegistrar, srv EntryServiceServer) {
+	// If the following call pancis, it indicates UnimplementedEntryServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&EntryService_ServiceDesc, srv)
+}
+
+func _EntryService_CreateEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntryServiceServer).CreateEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EntryService_CreateEntry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntryServiceServer).CreateEntry(ctx, req.(*CreateEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EntryService_GetEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntryServiceServer).GetEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EntryService_GetEntry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntryServiceServer).GetEntry(ctx, req.(*GetEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EntryService_UpdateEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntryServiceServer).UpdateEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EntryService_UpdateEntry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntryServiceServer).UpdateEntry(ctx, req.(*UpdateEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EntryService_DeleteEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntryServiceServer).DeleteEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EntryService_DeleteEntry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntryServiceServer).DeleteEntry(ctx, req.(*DeleteEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EntryService_ListEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntryServiceServer).ListEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EntryService_ListEntries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntryServiceServer).ListEntries(ctx, req.(*ListEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EntryService_ExportEntries_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportEntriesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EntryServiceServer).ExportEntries(m, &grpc.GenericServerStream[ExportEntriesRequest, Entry]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EntryService_ExportEntriesServer = grpc.ServerStreamingServer[Entry]
+
+func _EntryService_AddMeaning_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddMeaningRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntryServiceServer).AddMeaning(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EntryService_AddMeaning_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntryServiceServer).AddMeaning(ctx, req.(*AddMeaningRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EntryService_UpdateMeaning_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMeaningRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntryServiceServer).UpdateMeaning(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EntryService_UpdateMeaning_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntryServiceServer).UpdateMeaning(ctx, req.(*UpdateMeaningRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EntryService_DeleteMeaning_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMeaningRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntryServiceServer).DeleteMeaning(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EntryService_DeleteMeaning_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntryServiceServer).DeleteMeaning(ctx, req.(*DeleteMeaningRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EntryService_ListMeanings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMeaningsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntryServiceServer).ListMeanings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EntryService_ListMeanings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntryServiceServer).ListMeanings(ctx, req.(*ListMeaningsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EntryService_AddMeaningComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddMeaningCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntryServiceServer).AddMeaningComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EntryService_AddMeaningComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntryServiceServer).AddMeaningComment(ctx, req.(*AddMeaningCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EntryService_ToggleMeaningLike_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ToggleMeaningLikeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntryServiceServer).ToggleMeaningLike(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EntryService_ToggleMeaningLike_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntryServiceServer).ToggleMeaningLike(ctx, req.(*ToggleMeaningLikeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// EntryService_ServiceDesc is the grpc.ServiceDesc for EntryService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EntryService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "trytrago.v1.EntryService",
+	HandlerType: (*EntryServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateEntry",
+			Handler:    _EntryService_CreateEntry_Handler,
+		},
+		{
+			MethodName: "GetEntry",
+			Handler:    _EntryService_GetEntry_Handler,
+		},
+		{
+			MethodName: "UpdateEntry",
+			Handler:    _EntryService_UpdateEntry_Handler,
+		},
+		{
+			MethodName: "DeleteEntry",
+			Handler:    _EntryService_DeleteEntry_Handler,
+		},
+		{
+			MethodName: "ListEntries",
+			Handler:    _EntryService_ListEntries_Handler,
+		},
+		{
+			MethodName: "AddMeaning",
+			Handler:    _EntryService_AddMeaning_Handler,
+		},
+		{
+			MethodName: "UpdateMeaning",
+			Handler:    _EntryService_UpdateMeaning_Handler,
+		},
+		{
+			MethodName: "DeleteMeaning",
+			Handler:    _EntryService_DeleteMeaning_Handler,
+		},
+		{
+			MethodName: "ListMeanings",
+			Handler:    _EntryService_ListMeanings_Handler,
+		},
+		{
+			MethodName: "AddMeaningComment",
+			Handler:    _EntryService_AddMeaningComment_Handler,
+		},
+		{
+			MethodName: "ToggleMeaningLike",
+			Handler:    _EntryService_ToggleMeaningLike_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportEntries",
+			Handler:       _EntryService_ExportEntries_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "trytrago/v1/entry_service.proto",
+}
@@ -0,0 +1,530 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: trytrago/v1/translation_service.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CreateTranslationRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	MeaningId string                 `protobuf:"bytes,1,opt,name=meaning_id,json=meaningId,proto3" json:"meaning_id,omitempty"`
+	// ISO 639-1 code
+	LanguageId string `protobuf:"bytes,2,opt,name=language_id,json=languageId,proto3" json:"language_id,omitempty"`
+	Text       string `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	// Approved translation this proposal should supersede
+	ReplacesId    string `protobuf:"bytes,4,opt,name=replaces_id,json=replacesId,proto3" json:"replaces_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTranslationRequest) Reset() {
+	*x = CreateTranslationRequest{}
+	mi := &file_trytrago_v1_translation_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTranslationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTranslationRequest) ProtoMessage() {}
+
+func (x *CreateTranslationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trytrago_v1_translation_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTranslationRequest.ProtoReflect.Descriptor instead.
+func (*CreateTranslationRequest) Descriptor() ([]byte, []int) {
+	return file_trytrago_v1_translation_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateTranslationRequest) GetMeaningId() string {
+	if x != nil {
+		return x.MeaningId
+	}
+	return ""
+}
+
+func (x *CreateTranslationRequest) GetLanguageId() string {
+	if x != nil {
+		return x.LanguageId
+	}
+	return ""
+}
+
+func (x *CreateTranslationRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *CreateTranslationRequest) GetReplacesId() string {
+	if x != nil {
+		return x.ReplacesId
+	}
+	return ""
+}
+
+type UpdateTranslationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Text  string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	// Version the change is based on, like If-Match; 0 skips the check
+	Version       int32 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTranslationRequest) Reset() {
+	*x = UpdateTranslationRequest{}
+	mi := &file_trytrago_v1_translation_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTranslationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTranslationRequest) ProtoMessage() {}
+
+func (x *UpdateTranslationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trytrago_v1_translation_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTranslationRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTranslationRequest) Descriptor() ([]byte, []int) {
+	return file_trytrago_v1_translation_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UpdateTranslationRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateTranslationRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *UpdateTranslationRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type DeleteTranslationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Version the deletion is based on, like If-Match; 0 skips the check
+	Version       int32 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTranslationRequest) Reset() {
+	*x = DeleteTranslationRequest{}
+	mi := &file_trytrago_v1_translation_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTranslationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTranslationRequest) ProtoMessage() {}
+
+func (x *DeleteTranslationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trytrago_v1_translation_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTranslationRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTranslationRequest) Descriptor() ([]byte, []int) {
+	return file_trytrago_v1_translation_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DeleteTranslationRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteTranslationRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type ListTranslationsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	MeaningId string                 `protobuf:"bytes,1,opt,name=meaning_id,json=meaningId,proto3" json:"meaning_id,omitempty"`
+	// Only translations into this language
+	LanguageId    string `protobuf:"bytes,2,opt,name=language_id,json=languageId,proto3" json:"language_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTranslationsRequest) Reset() {
+	*x = ListTranslationsRequest{}
+	mi := &file_trytrago_v1_translation_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTranslationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTranslationsRequest) ProtoMessage() {}
+
+func (x *ListTranslationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trytrago_v1_translation_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTranslationsRequest.ProtoReflect.Descriptor instead.
+func (*ListTranslationsRequest) Descriptor() ([]byte, []int) {
+	return file_trytrago_v1_translation_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListTranslationsRequest) GetMeaningId() string {
+	if x != nil {
+		return x.MeaningId
+	}
+	return ""
+}
+
+func (x *ListTranslationsRequest) GetLanguageId() string {
+	if x != nil {
+		return x.LanguageId
+	}
+	return ""
+}
+
+type ListTranslationsResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Translations []*Translation         `protobuf:"bytes,1,rep,name=translations,proto3" json:"translations,omitempty"`
+	Total        int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	// The list changes only with its meaning
+	MeaningVersion int32 `protobuf:"varint,3,opt,name=meaning_version,json=meaningVersion,proto3" json:"meaning_version,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListTranslationsResponse) Reset() {
+	*x = ListTranslationsResponse{}
+	mi := &file_trytrago_v1_translation_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTranslationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTranslationsResponse) ProtoMessage() {}
+
+func (x *ListTranslationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_trytrago_v1_translation_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTranslationsResponse.ProtoReflect.Descriptor instead.
+func (*ListTranslationsResponse) Descriptor() ([]byte, []int) {
+	return file_trytrago_v1_translation_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListTranslationsResponse) GetTranslations() []*Translation {
+	if x != nil {
+		return x.Translations
+	}
+	return nil
+}
+
+func (x *ListTranslationsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListTranslationsResponse) GetMeaningVersion() int32 {
+	if x != nil {
+		return x.MeaningVersion
+	}
+	return 0
+}
+
+type AddTranslationCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TranslationId string                 `protobuf:"bytes,1,opt,name=translation_id,json=translationId,proto3" json:"translation_id,omitempty"`
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTranslationCommentRequest) Reset() {
+	*x = AddTranslationCommentRequest{}
+	mi := &file_trytrago_v1_translation_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTranslationCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTranslationCommentRequest) ProtoMessage() {}
+
+func (x *AddTranslationCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trytrago_v1_translation_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTranslationCommentRequest.ProtoReflect.Descriptor instead.
+func (*AddTranslationCommentRequest) Descriptor() ([]byte, []int) {
+	return file_trytrago_v1_translation_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AddTranslationCommentRequest) GetTranslationId() string {
+	if x != nil {
+		return x.TranslationId
+	}
+	return ""
+}
+
+func (x *AddTranslationCommentRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+type ToggleTranslationLikeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TranslationId string                 `protobuf:"bytes,1,opt,name=translation_id,json=translationId,proto3" json:"translation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ToggleTranslationLikeRequest) Reset() {
+	*x = ToggleTranslationLikeRequest{}
+	mi := &file_trytrago_v1_translation_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToggleTranslationLikeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToggleTranslationLikeRequest) ProtoMessage() {}
+
+func (x *ToggleTranslationLikeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trytrago_v1_translation_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToggleTranslationLikeRequest.ProtoReflect.Descriptor instead.
+func (*ToggleTranslationLikeRequest) Descriptor() ([]byte, []int) {
+	return file_trytrago_v1_translation_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ToggleTranslationLikeRequest) GetTranslationId() string {
+	if x != nil {
+		return x.TranslationId
+	}
+	return ""
+}
+
+var File_trytrago_v1_translation_service_proto protoreflect.FileDescriptor
+
+const file_trytrago_v1_translation_service_proto_rawDesc = "" +
+	"\n" +
+	"%trytrago/v1/translation_service.proto\x12\vtrytrago.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1ctrytrago/v1/dictionary.proto\"\x8f\x01\n" +
+	"\x18CreateTranslationRequest\x12\x1d\n" +
+	"\n" +
+	"meaning_id\x18\x01 \x01(\tR\tmeaningId\x12\x1f\n" +
+	"\vlanguage_id\x18\x02 \x01(\tR\n" +
+	"languageId\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\x12\x1f\n" +
+	"\vreplaces_id\x18\x04 \x01(\tR\n" +
+	"replacesId\"X\n" +
+	"\x18UpdateTranslationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x05R\aversion\"D\n" +
+	"\x18DeleteTranslationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\"Y\n" +
+	"\x17ListTranslationsRequest\x12\x1d\n" +
+	"\n" +
+	"meaning_id\x18\x01 \x01(\tR\tmeaningId\x12\x1f\n" +
+	"\vlanguage_id\x18\x02 \x01(\tR\n" +
+	"languageId\"\x97\x01\n" +
+	"\x18ListTranslationsResponse\x12<\n" +
+	"\ftranslations\x18\x01 \x03(\v2\x18.trytrago.v1.TranslationR\ftranslations\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12'\n" +
+	"\x0fmeaning_version\x18\x03 \x01(\x05R\x0emeaningVersion\"_\n" +
+	"\x1cAddTranslationCommentRequest\x12%\n" +
+	"\x0etranslation_id\x18\x01 \x01(\tR\rtranslationId\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\"E\n" +
+	"\x1cToggleTranslationLikeRequest\x12%\n" +
+	"\x0etranslation_id\x18\x01 \x01(\tR\rtranslationId2\xab\x04\n" +
+	"\x12TranslationService\x12T\n" +
+	"\x11CreateTranslation\x12%.trytrago.v1.CreateTranslationRequest\x1a\x18.trytrago.v1.Translation\x12T\n" +
+	"\x11UpdateTranslation\x12%.trytrago.v1.UpdateTranslationRequest\x1a\x18.trytrago.v1.Translation\x12R\n" +
+	"\x11DeleteTranslation\x12%.trytrago.v1.DeleteTranslationRequest\x1a\x16.google.protobuf.Empty\x12_\n" +
+	"\x10ListTranslations\x12$.trytrago.v1.ListTranslationsRequest\x1a%.trytrago.v1.ListTranslationsResponse\x12X\n" +
+	"\x15AddTranslationComment\x12).trytrago.v1.AddTranslationCommentRequest\x1a\x14.trytrago.v1.Comment\x12Z\n" +
+	"\x15ToggleTranslationLike\x12).trytrago.v1.ToggleTranslationLikeRequest\x1a\x16.google.protobuf.EmptyB2Z0github.com/valpere/trytrago/interface/api/rpc/pbb\x06proto3"
+
+var (
+	file_trytrago_v1_translation_service_proto_rawDescOnce sync.Once
+	file_trytrago_v1_translation_service_proto_rawDescData []byte
+)
+
+func file_trytrago_v1_translation_service_proto_rawDescGZIP() []byte {
+	file_trytrago_v1_translation_service_proto_rawDescOnce.Do(func() {
+		file_trytrago_v1_translation_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_trytrago_v1_translation_service_proto_rawDesc), len(file_trytrago_v1_translation_service_proto_rawDesc)))
+	})
+	return file_trytrago_v1_translation_service_proto_rawDescData
+}
+
+var file_trytrago_v1_translation_service_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_trytrago_v1_translation_service_proto_goTypes = []any{
+	(*CreateTranslationRequest)(nil),     // 0: trytrago.v1.CreateTranslationRequest
+	(*UpdateTranslationRequest)(nil),     // 1: trytrago.v1.UpdateTranslationRequest
+	(*DeleteTranslationRequest)(nil),     // 2: trytrago.v1.DeleteTranslationRequest
+	(*ListTranslationsRequest)(nil),      // 3: trytrago.v1.ListTranslationsRequest
+	(*ListTranslationsResponse)(nil),     // 4: trytrago.v1.ListTranslationsResponse
+	(*AddTranslationCommentRequest)(nil), // 5: trytrago.v1.AddTranslationCommentRequest
+	(*ToggleTranslationLikeRequest)(nil), // 6: trytrago.v1.ToggleTranslationLikeRequest
+	(*Translation)(nil),                  // 7: trytrago.v1.Translation
+	(*emptypb.Empty)(nil),                // 8: google.protobuf.Empty
+	(*Comment)(nil),                      // 9: trytrago.v1.Comment
+}
+var file_trytrago_v1_translation_service_proto_depIdxs = []int32{
+	7, // 0: trytrago.v1.ListTranslationsResponse.translations:type_name -> trytrago.v1.Translation
+	0, // 1: trytrago.v1.TranslationService.CreateTranslation:input_type -> trytrago.v1.CreateTranslationRequest
+	1, // 2: trytrago.v1.TranslationService.UpdateTranslation:input_type -> trytrago.v1.UpdateTranslationRequest
+	2, // 3: trytrago.v1.TranslationService.DeleteTranslation:input_type -> trytrago.v1.DeleteTranslationRequest
+	3, // 4: trytrago.v1.TranslationService.ListTranslations:input_type -> trytrago.v1.ListTranslationsRequest
+	5, // 5: trytrago.v1.TranslationService.AddTranslationComment:input_type -> trytrago.v1.AddTranslationCommentRequest
+	6, // 6: trytrago.v1.TranslationService.ToggleTranslationLike:input_type -> trytrago.v1.ToggleTranslationLikeRequest
+	7, // 7: trytrago.v1.TranslationService.CreateTranslation:output_type -> trytrago.v1.Translation
+	7, // 8: trytrago.v1.TranslationService.UpdateTranslation:output_type -> trytrago.v1.Translation
+	8, // 9: trytrago.v1.TranslationService.DeleteTranslation:output_type -> google.protobuf.Empty
+	4, // 10: trytrago.v1.TranslationService.ListTranslations:output_type -> trytrago.v1.ListTranslationsResponse
+	9, // 11: trytrago.v1.TranslationService.AddTranslationComment:output_type -> trytrago.v1.Comment
+	8, // 12: trytrago.v1.TranslationService.ToggleTranslationLike:output_type -> google.protobuf.Empty
+	7, // [7:13] is the sub-list for method output_type
+	1, // [1:7] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_trytrago_v1_translation_service_proto_init() }
+func file_trytrago_v1_translation_service_proto_init() {
+	if File_trytrago_v1_translation_service_proto != nil {
+		return
+	}
+	file_trytrago_v1_dictionary_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_trytrago_v1_translation_service_proto_rawDesc), len(file_trytrago_v1_translation_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_trytrago_v1_translation_service_proto_goTypes,
+		DependencyIndexes: file_trytrago_v1_translation_service_proto_depIdxs,
+		MessageInfos:      file_trytrago_v1_translation_service_proto_msgTypes,
+	}.Build()
+	File_trytrago_v1_translation_service_proto = out.File
+	file_trytrago_v1_translation_service_proto_goTypes = nil
+	file_trytrago_v1_translation_service_proto_depIdxs = nil
+}
@@ -0,0 +1,1039 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: trytrago/v1/user_service.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CreateUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Password      string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateUserRequest) Reset() {
+	*x = CreateUserRequest{}
+	mi := &file_trytrago_v1_user_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUserRequest) ProtoMessage() {}
+
+func (x *CreateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trytrago_v1_user_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUserRequest.ProtoReflect.Descriptor instead.
+func (*CreateUserRequest) Descriptor() ([]byte, []int) {
+	return file_trytrago_v1_user_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateUserRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *CreateUserRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *CreateUserRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type GetUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserRequest) Reset() {
+	*x = GetUserRequest{}
+	mi := &file_trytrago_v1_user_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserRequest) ProtoMessage() {}
+
+func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trytrago_v1_user_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
+func (*GetUserRequest) Descriptor() ([]byte, []int) {
+	return file_trytrago_v1_user_service_proto_rawDescGZIP(), []int{1}
+}
+
+// UpdateUserRequest changes the fields that are set
+type UpdateUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Password      string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	Avatar        string                 `protobuf:"bytes,4,opt,name=avatar,proto3" json:"avatar,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserRequest) Reset() {
+	*x = UpdateUserRequest{}
+	mi := &file_trytrago_v1_user_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserRequest) ProtoMessage() {}
+
+func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trytrago_v1_user_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserRequest.ProtoReflect.Descriptor instead.
+func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
+	return file_trytrago_v1_user_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UpdateUserRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetAvatar() string {
+	if x != nil {
+		return x.Avatar
+	}
+	return ""
+}
+
+type DeleteUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserRequest) Reset() {
+	*x = DeleteUserRequest{}
+	mi := &file_trytrago_v1_user_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserRequest) ProtoMessage() {}
+
+func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trytrago_v1_user_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
+	return file_trytrago_v1_user_service_proto_rawDescGZIP(), []int{3}
+}
+
+type AuthenticateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthenticateRequest) Reset() {
+	*x = AuthenticateRequest{}
+	mi := &file_trytrago_v1_user_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthenticateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthenticateRequest) ProtoMessage() {}
+
+func (x *AuthenticateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trytrago_v1_user_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthenticateRequest.ProtoReflect.Descriptor instead.
+func (*AuthenticateRequest) Descriptor() ([]byte, []int) {
+	return file_trytrago_v1_user_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AuthenticateRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *AuthenticateRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type RefreshTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshTokenRequest) Reset() {
+	*x = RefreshTokenRequest{}
+	mi := &file_trytrago_v1_user_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshTokenRequest) ProtoMessage() {}
+
+func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trytrago_v1_user_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshTokenRequest.ProtoReflect.Descriptor instead.
+func (*RefreshTokenRequest) Descriptor() ([]byte, []int) {
+	return file_trytrago_v1_user_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RefreshTokenRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type AuthResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken  string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	RefreshToken string                 `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	// Expiration time in seconds
+	ExpiresIn     int32 `protobuf:"varint,3,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"`
+	User          *User `protobuf:"bytes,4,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthResponse) Reset() {
+	*x = AuthResponse{}
+	mi := &file_trytrago_v1_user_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthResponse) ProtoMessage() {}
+
+func (x *AuthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_trytrago_v1_user_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthResponse.ProtoReflect.Descriptor instead.
+func (*AuthResponse) Descriptor() ([]byte, []int) {
+	return file_trytrago_v1_user_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AuthResponse) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *AuthResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *AuthResponse) GetExpiresIn() int32 {
+	if x != nil {
+		return x.ExpiresIn
+	}
+	return 0
+}
+
+func (x *AuthResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type ListUserTranslationsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Limit  int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// created_at, updated_at or language_id
+	SortBy        string `protobuf:"bytes,3,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	SortDesc      bool   `protobuf:"varint,4,opt,name=sort_desc,json=sortDesc,proto3" json:"sort_desc,omitempty"`
+	LanguageId    string `protobuf:"bytes,5,opt,name=language_id,json=languageId,proto3" json:"language_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserTranslationsRequest) Reset() {
+	*x = ListUserTranslationsRequest{}
+	mi := &file_trytrago_v1_user_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserTranslationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserTranslationsRequest) ProtoMessage() {}
+
+func (x *ListUserTranslationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trytrago_v1_user_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserTranslationsRequest.ProtoReflect.Descriptor instead.
+func (*ListUserTranslationsRequest) Descriptor() ([]byte, []int) {
+	return file_trytrago_v1_user_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListUserTranslationsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListUserTranslationsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListUserTranslationsRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *ListUserTranslationsRequest) GetSortDesc() bool {
+	if x != nil {
+		return x.SortDesc
+	}
+	return false
+}
+
+func (x *ListUserTranslationsRequest) GetLanguageId() string {
+	if x != nil {
+		return x.LanguageId
+	}
+	return ""
+}
+
+type ListUserTranslationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Translations  []*Translation         `protobuf:"bytes,1,rep,name=translations,proto3" json:"translations,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserTranslationsResponse) Reset() {
+	*x = ListUserTranslationsResponse{}
+	mi := &file_trytrago_v1_user_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserTranslationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserTranslationsResponse) ProtoMessage() {}
+
+func (x *ListUserTranslationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_trytrago_v1_user_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserTranslationsResponse.ProtoReflect.Descriptor instead.
+func (*ListUserTranslationsResponse) Descriptor() ([]byte, []int) {
+	return file_trytrago_v1_user_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListUserTranslationsResponse) GetTranslations() []*Translation {
+	if x != nil {
+		return x.Translations
+	}
+	return nil
+}
+
+func (x *ListUserTranslationsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListUserTranslationsResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListUserTranslationsResponse) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListUserCommentsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Limit  int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// created_at or target_type
+	SortBy   string `protobuf:"bytes,3,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	SortDesc bool   `protobuf:"varint,4,opt,name=sort_desc,json=sortDesc,proto3" json:"sort_desc,omitempty"`
+	// meaning or translation
+	TargetType    string                 `protobuf:"bytes,5,opt,name=target_type,json=targetType,proto3" json:"target_type,omitempty"`
+	FromDate      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=from_date,json=fromDate,proto3" json:"from_date,omitempty"`
+	ToDate        *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=to_date,json=toDate,proto3" json:"to_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserCommentsRequest) Reset() {
+	*x = ListUserCommentsRequest{}
+	mi := &file_trytrago_v1_user_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserCommentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserCommentsRequest) ProtoMessage() {}
+
+func (x *ListUserCommentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trytrago_v1_user_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserCommentsRequest.ProtoReflect.Descriptor instead.
+func (*ListUserCommentsRequest) Descriptor() ([]byte, []int) {
+	return file_trytrago_v1_user_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListUserCommentsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListUserCommentsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListUserCommentsRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *ListUserCommentsRequest) GetSortDesc() bool {
+	if x != nil {
+		return x.SortDesc
+	}
+	return false
+}
+
+func (x *ListUserCommentsRequest) GetTargetType() string {
+	if x != nil {
+		return x.TargetType
+	}
+	return ""
+}
+
+func (x *ListUserCommentsRequest) GetFromDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FromDate
+	}
+	return nil
+}
+
+func (x *ListUserCommentsRequest) GetToDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ToDate
+	}
+	return nil
+}
+
+type ListUserCommentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Comments      []*Comment             `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserCommentsResponse) Reset() {
+	*x = ListUserCommentsResponse{}
+	mi := &file_trytrago_v1_user_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserCommentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserCommentsResponse) ProtoMessage() {}
+
+func (x *ListUserCommentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_trytrago_v1_user_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserCommentsResponse.ProtoReflect.Descriptor instead.
+func (*ListUserCommentsResponse) Descriptor() ([]byte, []int) {
+	return file_trytrago_v1_user_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListUserCommentsResponse) GetComments() []*Comment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+func (x *ListUserCommentsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListUserCommentsResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListUserCommentsResponse) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListUserLikesRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Limit  int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// created_at or target_type
+	SortBy   string `protobuf:"bytes,3,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	SortDesc bool   `protobuf:"varint,4,opt,name=sort_desc,json=sortDesc,proto3" json:"sort_desc,omitempty"`
+	// meaning or translation
+	TargetType    string                 `protobuf:"bytes,5,opt,name=target_type,json=targetType,proto3" json:"target_type,omitempty"`
+	FromDate      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=from_date,json=fromDate,proto3" json:"from_date,omitempty"`
+	ToDate        *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=to_date,json=toDate,proto3" json:"to_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserLikesRequest) Reset() {
+	*x = ListUserLikesRequest{}
+	mi := &file_trytrago_v1_user_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserLikesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserLikesRequest) ProtoMessage() {}
+
+func (x *ListUserLikesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trytrago_v1_user_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserLikesRequest.ProtoReflect.Descriptor instead.
+func (*ListUserLikesRequest) Descriptor() ([]byte, []int) {
+	return file_trytrago_v1_user_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListUserLikesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListUserLikesRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListUserLikesRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *ListUserLikesRequest) GetSortDesc() bool {
+	if x != nil {
+		return x.SortDesc
+	}
+	return false
+}
+
+func (x *ListUserLikesRequest) GetTargetType() string {
+	if x != nil {
+		return x.TargetType
+	}
+	return ""
+}
+
+func (x *ListUserLikesRequest) GetFromDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FromDate
+	}
+	return nil
+}
+
+func (x *ListUserLikesRequest) GetToDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ToDate
+	}
+	return nil
+}
+
+type ListUserLikesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Likes         []*Like                `protobuf:"bytes,1,rep,name=likes,proto3" json:"likes,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserLikesResponse) Reset() {
+	*x = ListUserLikesResponse{}
+	mi := &file_trytrago_v1_user_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserLikesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserLikesResponse) ProtoMessage() {}
+
+func (x *ListUserLikesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_trytrago_v1_user_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserLikesResponse.ProtoReflect.Descriptor instead.
+func (*ListUserLikesResponse) Descriptor() ([]byte, []int) {
+	return file_trytrago_v1_user_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListUserLikesResponse) GetLikes() []*Like {
+	if x != nil {
+		return x.Likes
+	}
+	return nil
+}
+
+func (x *ListUserLikesResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListUserLikesResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListUserLikesResponse) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+var File_trytrago_v1_user_service_proto protoreflect.FileDescriptor
+
+const file_trytrago_v1_user_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1etrytrago/v1/user_service.proto\x12\vtrytrago.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1ctrytrago/v1/dictionary.proto\x1a\x1ftrytrago/v1/entry_service.proto\"a\n" +
+	"\x11CreateUserRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x1a\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\"\x10\n" +
+	"\x0eGetUserRequest\"y\n" +
+	"\x11UpdateUserRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x1a\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\x12\x16\n" +
+	"\x06avatar\x18\x04 \x01(\tR\x06avatar\"\x13\n" +
+	"\x11DeleteUserRequest\"M\n" +
+	"\x13AuthenticateRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\":\n" +
+	"\x13RefreshTokenRequest\x12#\n" +
+	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\"\x9c\x01\n" +
+	"\fAuthResponse\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12#\n" +
+	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken\x12\x1d\n" +
+	"\n" +
+	"expires_in\x18\x03 \x01(\x05R\texpiresIn\x12%\n" +
+	"\x04user\x18\x04 \x01(\v2\x11.trytrago.v1.UserR\x04user\"\xa2\x01\n" +
+	"\x1bListUserTranslationsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\x17\n" +
+	"\asort_by\x18\x03 \x01(\tR\x06sortBy\x12\x1b\n" +
+	"\tsort_desc\x18\x04 \x01(\bR\bsortDesc\x12\x1f\n" +
+	"\vlanguage_id\x18\x05 \x01(\tR\n" +
+	"languageId\"\xa0\x01\n" +
+	"\x1cListUserTranslationsResponse\x12<\n" +
+	"\ftranslations\x18\x01 \x03(\v2\x18.trytrago.v1.TranslationR\ftranslations\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\"\x8c\x02\n" +
+	"\x17ListUserCommentsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\x17\n" +
+	"\asort_by\x18\x03 \x01(\tR\x06sortBy\x12\x1b\n" +
+	"\tsort_desc\x18\x04 \x01(\bR\bsortDesc\x12\x1f\n" +
+	"\vtarget_type\x18\x05 \x01(\tR\n" +
+	"targetType\x127\n" +
+	"\tfrom_date\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\bfromDate\x123\n" +
+	"\ato_date\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x06toDate\"\x90\x01\n" +
+	"\x18ListUserCommentsResponse\x120\n" +
+	"\bcomments\x18\x01 \x03(\v2\x14.trytrago.v1.CommentR\bcomments\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\"\x89\x02\n" +
+	"\x14ListUserLikesRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\x17\n" +
+	"\asort_by\x18\x03 \x01(\tR\x06sortBy\x12\x1b\n" +
+	"\tsort_desc\x18\x04 \x01(\bR\bsortDesc\x12\x1f\n" +
+	"\vtarget_type\x18\x05 \x01(\tR\n" +
+	"targetType\x127\n" +
+	"\tfrom_date\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\bfromDate\x123\n" +
+	"\ato_date\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x06toDate\"\x84\x01\n" +
+	"\x15ListUserLikesResponse\x12'\n" +
+	"\x05likes\x18\x01 \x03(\v2\x11.trytrago.v1.LikeR\x05likes\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset2\xa6\x06\n" +
+	"\vUserService\x12?\n" +
+	"\n" +
+	"CreateUser\x12\x1e.trytrago.v1.CreateUserRequest\x1a\x11.trytrago.v1.User\x129\n" +
+	"\aGetUser\x12\x1b.trytrago.v1.GetUserRequest\x1a\x11.trytrago.v1.User\x12?\n" +
+	"\n" +
+	"UpdateUser\x12\x1e.trytrago.v1.UpdateUserRequest\x1a\x11.trytrago.v1.User\x12D\n" +
+	"\n" +
+	"DeleteUser\x12\x1e.trytrago.v1.DeleteUserRequest\x1a\x16.google.protobuf.Empty\x12K\n" +
+	"\fAuthenticate\x12 .trytrago.v1.AuthenticateRequest\x1a\x19.trytrago.v1.AuthResponse\x12K\n" +
+	"\fRefreshToken\x12 .trytrago.v1.RefreshTokenRequest\x1a\x19.trytrago.v1.AuthResponse\x12T\n" +
+	"\x0fListUserEntries\x12\x1f.trytrago.v1.ListEntriesRequest\x1a .trytrago.v1.ListEntriesResponse\x12k\n" +
+	"\x14ListUserTranslations\x12(.trytrago.v1.ListUserTranslationsRequest\x1a).trytrago.v1.ListUserTranslationsResponse\x12_\n" +
+	"\x10ListUserComments\x12$.trytrago.v1.ListUserCommentsRequest\x1a%.trytrago.v1.ListUserCommentsResponse\x12V\n" +
+	"\rListUserLikes\x12!.trytrago.v1.ListUserLikesRequest\x1a\".trytrago.v1.ListUserLikesResponseB2Z0github.com/valpere/trytrago/interface/api/rpc/pbb\x06proto3"
+
+var (
+	file_trytrago_v1_user_service_proto_rawDescOnce sync.Once
+	file_trytrago_v1_user_service_proto_rawDescData []byte
+)
+
+func file_trytrago_v1_user_service_proto_rawDescGZIP() []byte {
+	file_trytrago_v1_user_service_proto_rawDescOnce.Do(func() {
+		file_trytrago_v1_user_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_trytrago_v1_user_service_proto_rawDesc), len(file_trytrago_v1_user_service_proto_rawDesc)))
+	})
+	return file_trytrago_v1_user_service_proto_rawDescData
+}
+
+var file_trytrago_v1_user_service_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_trytrago_v1_user_service_proto_goTypes = []any{
+	(*CreateUserRequest)(nil),            // 0: trytrago.v1.CreateUserRequest
+	(*GetUserRequest)(nil),               // 1: trytrago.v1.GetUserRequest
+	(*UpdateUserRequest)(nil),            // 2: trytrago.v1.UpdateUserRequest
+	(*DeleteUserRequest)(nil),            // 3: trytrago.v1.DeleteUserRequest
+	(*AuthenticateRequest)(nil),          // 4: trytrago.v1.AuthenticateRequest
+	(*RefreshTokenRequest)(nil),          // 5: trytrago.v1.RefreshTokenRequest
+	(*AuthResponse)(nil),                 // 6: trytrago.v1.AuthResponse
+	(*ListUserTranslationsRequest)(nil),  // 7: trytrago.v1.ListUserTranslationsRequest
+	(*ListUserTranslationsResponse)(nil), // 8: trytrago.v1.ListUserTranslationsResponse
+	(*ListUserCommentsRequest)(nil),      // 9: trytrago.v1.ListUserCommentsRequest
+	(*ListUserCommentsResponse)(nil),     // 10: trytrago.v1.ListUserCommentsResponse
+	(*ListUserLikesRequest)(nil),         // 11: trytrago.v1.ListUserLikesRequest
+	(*ListUserLikesResponse)(nil),        // 12: trytrago.v1.ListUserLikesResponse
+	(*User)(nil),                         // 13: trytrago.v1.User
+	(*Translation)(nil),                  // 14: trytrago.v1.Translation
+	(*timestamppb.Timestamp)(nil),        // 15: google.protobuf.Timestamp
+	(*Comment)(nil),                      // 16: trytrago.v1.Comment
+	(*Like)(nil),                         // 17: trytrago.v1.Like
+	(*ListEntriesRequest)(nil),           // 18: trytrago.v1.ListEntriesRequest
+	(*emptypb.Empty)(nil),                // 19: google.protobuf.Empty
+	(*ListEntriesResponse)(nil),          // 20: trytrago.v1.ListEntriesResponse
+}
+var file_trytrago_v1_user_service_proto_depIdxs = []int32{
+	13, // 0: trytrago.v1.AuthResponse.user:type_name -> trytrago.v1.User
+	14, // 1: trytrago.v1.ListUserTranslationsResponse.translations:type_name -> trytrago.v1.Translation
+	15, // 2: trytrago.v1.ListUserCommentsRequest.from_date:type_name -> google.protobuf.Timestamp
+	15, // 3: trytrago.v1.ListUserCommentsRequest.to_date:type_name -> google.protobuf.Timestamp
+	16, // 4: trytrago.v1.ListUserCommentsResponse.comments:type_name -> trytrago.v1.Comment
+	15, // 5: trytrago.v1.ListUserLikesRequest.from_date:type_name -> google.protobuf.Timestamp
+	15, // 6: trytrago.v1.ListUserLikesRequest.to_date:type_name -> google.protobuf.Timestamp
+	17, // 7: trytrago.v1.ListUserLikesResponse.likes:type_name -> trytrago.v1.Like
+	0,  // 8: trytrago.v1.UserService.CreateUser:input_type -> trytrago.v1.CreateUserRequest
+	1,  // 9: trytrago.v1.UserService.GetUser:input_type -> trytrago.v1.GetUserRequest
+	2,  // 10: trytrago.v1.UserService.UpdateUser:input_type -> trytrago.v1.UpdateUserRequest
+	3,  // 11: trytrago.v1.UserService.DeleteUser:input_type -> trytrago.v1.DeleteUserRequest
+	4,  // 12: trytrago.v1.UserService.Authenticate:input_type -> trytrago.v1.AuthenticateRequest
+	5,  // 13: trytrago.v1.UserService.RefreshToken:input_type -> trytrago.v1.RefreshTokenRequest
+	18, // 14: trytrago.v1.UserService.ListUserEntries:input_type -> trytrago.v1.ListEntriesRequest
+	7,  // 15: trytrago.v1.UserService.ListUserTranslations:input_type -> trytrago.v1.ListUserTranslationsRequest
+	9,  // 16: trytrago.v1.UserService.ListUserComments:input_type -> trytrago.v1.ListUserCommentsRequest
+	11, // 17: trytrago.v1.UserService.ListUserLikes:input_type -> trytrago.v1.ListUserLikesRequest
+	13, // 18: trytrago.v1.UserService.CreateUser:output_type -> trytrago.v1.User
+	13, // 19: trytrago.v1.UserService.GetUser:output_type -> trytrago.v1.User
+	13, // 20: trytrago.v1.UserService.UpdateUser:output_type -> trytrago.v1.User
+	19, // 21: trytrago.v1.UserService.DeleteUser:output_type -> google.protobuf.Empty
+	6,  // 22: trytrago.v1.UserService.Authenticate:output_type -> trytrago.v1.AuthResponse
+	6,  // 23: trytrago.v1.UserService.RefreshToken:output_type -> trytrago.v1.AuthResponse
+	20, // 24: trytrago.v1.UserService.ListUserEntries:output_type -> trytrago.v1.ListEntriesResponse
+	8,  // 25: trytrago.v1.UserService.ListUserTranslations:output_type -> trytrago.v1.ListUserTranslationsResponse
+	10, // 26: trytrago.v1.UserService.ListUserComments:output_type -> trytrago.v1.ListUserCommentsResponse
+	12, // 27: trytrago.v1.UserService.ListUserLikes:output_type -> trytrago.v1.ListUserLikesResponse
+	18, // [18:28] is the sub-list for method output_type
+	8,  // [8:18] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_trytrago_v1_user_service_proto_init() }
+func file_trytrago_v1_user_service_proto_init() {
+	if File_trytrago_v1_user_service_proto != nil {
+		return
+	}
+	file_trytrago_v1_dictionary_proto_init()
+	file_trytrago_v1_entry_service_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_trytrago_v1_user_service_proto_rawDesc), len(file_trytrago_v1_user_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_trytrago_v1_user_service_proto_goTypes,
+		DependencyIndexes: file_trytrago_v1_user_service_proto_depIdxs,
+		MessageInfos:      file_trytrago_v1_user_service_proto_msgTypes,
+	}.Build()
+	File_trytrago_v1_user_service_proto = out.File
+	file_trytrago_v1_user_service_proto_goTypes = nil
+	file_trytrago_v1_user_service_proto_depIdxs = nil
+}
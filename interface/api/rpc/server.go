@@ -0,0 +1,90 @@
+// Package rpc serves the entry, translation and user services over gRPC, for
+// internal services that want typed calls and streaming instead of JSON
+package rpc
+
+import (
+	"github.com/gin-gonic/gin/binding"
+	"google.golang.org/grpc"
+
+	"github.com/valpere/trytrago/application/service"
+	"github.com/valpere/trytrago/domain/logging"
+	"github.com/valpere/trytrago/interface/api/rpc/pb"
+)
+
+// publicMethods can be called without a token, like the public REST routes
+var publicMethods = map[string]bool{
+	pb.EntryService_GetEntry_FullMethodName:               true,
+	pb.EntryService_ListEntries_FullMethodName:            true,
+	pb.EntryService_ListMeanings_FullMethodName:           true,
+	pb.TranslationService_ListTranslations_FullMethodName: true,
+	pb.UserService_CreateUser_FullMethodName:              true,
+	pb.UserService_Authenticate_FullMethodName:            true,
+	pb.UserService_RefreshToken_FullMethodName:            true,
+}
+
+// NewServer creates a gRPC server for the given services. Every call passes
+// through the same steps as a REST request: it gets a request ID, is logged,
+// recovered from panics, rate limited per client and, unless its method is
+// public, authenticated by a bearer token. When requireVersion is set,
+// updates and deletes must name the version they change, like If-Match
+func NewServer(
+	entryService service.EntryService,
+	translationService service.TranslationService,
+	userService service.UserService,
+	requireVersion bool,
+	logger logging.Logger,
+) *grpc.Server {
+	logger = logger.With(logging.String("component", "grpc"))
+
+	interceptors := []interceptor{
+		requestID,
+		logCalls(logger),
+		recovery(logger),
+		rateLimit(logger, newRateLimiter()),
+		authenticate(logger, publicMethods),
+	}
+	unaryInterceptors := make([]grpc.UnaryServerInterceptor, len(interceptors))
+	streamInterceptors := make([]grpc.StreamServerInterceptor, len(interceptors))
+	for i, ic := range interceptors {
+		unaryInterceptors[i] = unary(ic)
+		streamInterceptors[i] = stream(ic)
+	}
+
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	)
+
+	pb.RegisterEntryServiceServer(server, &entryServer{
+		service:        entryService,
+		requireVersion: requireVersion,
+		logger:         logger,
+	})
+	pb.RegisterTranslationServiceServer(server, &translationServer{
+		service:        translationService,
+		requireVersion: requireVersion,
+		logger:         logger,
+	})
+	pb.RegisterUserServiceServer(server, &userServer{
+		service: userService,
+		logger:  logger,
+	})
+
+	return server
+}
+
+// validate checks a request DTO against the same rules as REST bodies
+func validate(req interface{}) error {
+	if err := binding.Validator.ValidateStruct(req); err != nil {
+		return invalidArgument(err)
+	}
+	return nil
+}
+
+// checkVersion rejects a missing version when versions are required
+func checkVersion(requireVersion bool, version int32) error {
+	if requireVersion && version == 0 {
+		return errVersionRequired
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package rpc
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/valpere/trytrago/application/dto/request"
+	"github.com/valpere/trytrago/application/service"
+	"github.com/valpere/trytrago/domain/logging"
+	"github.com/valpere/trytrago/interface/api/rpc/pb"
+)
+
+// translationServer implements pb.TranslationServiceServer over the translation service
+type translationServer struct {
+	pb.UnimplementedTranslationServiceServer
+	service        service.TranslationService
+	requireVersion bool
+	logger         logging.Logger
+}
+
+// CreateTranslation implements pb.TranslationServiceServer
+func (s *translationServer) CreateTranslation(ctx context.Context, req *pb.CreateTranslationRequest) (*pb.Translation, error) {
+	meaningID, err := parseID(req.GetMeaningId(), "meaning_id")
+	if err != nil {
+		return nil, err
+	}
+
+	createReq := &request.CreateTranslationRequest{
+		LanguageID: req.GetLanguageId(),
+		Text:       req.GetText(),
+		UserID:     userIDFrom(ctx),
+	}
+	if req.GetReplacesId() != "" {
+		replacesID, err := parseID(req.GetReplacesId(), "replaces_id")
+		if err != nil {
+			return nil, err
+		}
+		createReq.ReplacesID = &replacesID
+	}
+	if err := validate(createReq); err != nil {
+		return nil, err
+	}
+
+	translation, err := s.service.CreateTranslation(ctx, meaningID, createReq)
+	if err != nil {
+		return nil, statusError(s.logger, err, "Meaning", "create translation")
+	}
+	return translationToProto(translation), nil
+}
+
+// UpdateTranslation implements pb.TranslationServiceServer
+func (s *translationServer) UpdateTranslation(ctx context.Context, req *pb.UpdateTranslationRequest) (*pb.Translation, error) {
+	id, err := parseID(req.GetId(), "id")
+	if err != nil {
+		return nil, err
+	}
+	if err := checkVersion(s.requireVersion, req.GetVersion()); err != nil {
+		return nil, err
+	}
+
+	updateReq := &request.UpdateTranslationRequest{
+		Text:    req.GetText(),
+		Version: int(req.GetVersion()),
+	}
+	if err := validate(updateReq); err != nil {
+		return nil, err
+	}
+
+	translation, err := s.service.UpdateTranslation(ctx, id, updateReq)
+	if err != nil {
+		return nil, statusError(s.logger, err, "Translation", "update translation")
+	}
+	return translationToProto(translation), nil
+}
+
+// DeleteTranslation implements pb.TranslationServiceServer
+func (s *translationServer) DeleteTranslation(ctx context.Context, req *pb.DeleteTranslationRequest) (*emptypb.Empty, error) {
+	id, err := parseID(req.GetId(), "id")
+	if err != nil {
+		return nil, err
+	}
+	if err := checkVersion(s.requireVersion, req.GetVersion()); err != nil {
+		return nil, err
+	}
+
+	if err := s.service.DeleteTranslation(ctx, id, int(req.GetVersion())); err != nil {
+		return nil, statusError(s.logger, err, "Translation", "delete translation")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ListTranslations implements pb.TranslationServiceServer
+func (s *translationServer) ListTranslations(ctx context.Context, req *pb.ListTranslationsRequest) (*pb.ListTranslationsResponse, error) {
+	meaningID, err := parseID(req.GetMeaningId(), "meaning_id")
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := s.service.ListTranslations(ctx, meaningID, req.GetLanguageId())
+	if err != nil {
+		return nil, statusError(s.logger, err, "Meaning", "retrieve translations")
+	}
+	return &pb.ListTranslationsResponse{
+		Translations:   translationsToProto(list.Translations),
+		Total:          int32(list.Total),
+		MeaningVersion: int32(list.MeaningVersion),
+	}, nil
+}
+
+// AddTranslationComment implements pb.TranslationServiceServer
+func (s *translationServer) AddTranslationComment(ctx context.Context, req *pb.AddTranslationCommentRequest) (*pb.Comment, error) {
+	translationID, err := parseID(req.GetTranslationId(), "translation_id")
+	if err != nil {
+		return nil, err
+	}
+
+	commentReq := &request.CreateCommentRequest{
+		Content: req.GetContent(),
+		UserID:  userIDFrom(ctx),
+	}
+	if err := validate(commentReq); err != nil {
+		return nil, err
+	}
+
+	comment, err := s.service.AddTranslationComment(ctx, translationID, commentReq)
+	if err != nil {
+		return nil, statusError(s.logger, err, "Translation", "add comment")
+	}
+	return commentToProto(comment), nil
+}
+
+// ToggleTranslationLike implements pb.TranslationServiceServer
+func (s *translationServer) ToggleTranslationLike(ctx context.Context, req *pb.ToggleTranslationLikeRequest) (*emptypb.Empty, error) {
+	translationID, err := parseID(req.GetTranslationId(), "translation_id")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.service.ToggleTranslationLike(ctx, translationID, userIDFrom(ctx)); err != nil {
+		return nil, statusError(s.logger, err, "Translation", "toggle like")
+	}
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,214 @@
+package rpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/valpere/trytrago/application/dto/request"
+	"github.com/valpere/trytrago/application/service"
+	"github.com/valpere/trytrago/domain/database"
+	"github.com/valpere/trytrago/domain/logging"
+	"github.com/valpere/trytrago/interface/api/rpc/pb"
+)
+
+// userServer implements pb.UserServiceServer over the user service. Like
+// the /users/me routes, its calls act on the user of the token
+type userServer struct {
+	pb.UnimplementedUserServiceServer
+	service service.UserService
+	logger  logging.Logger
+}
+
+// CreateUser implements pb.UserServiceServer
+func (s *userServer) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.User, error) {
+	createReq := &request.CreateUserRequest{
+		Username: req.GetUsername(),
+		Email:    req.GetEmail(),
+		Password: req.GetPassword(),
+	}
+	if err := validate(createReq); err != nil {
+		return nil, err
+	}
+
+	user, err := s.service.CreateUser(ctx, createReq)
+	if err != nil {
+		if database.IsDuplicateError(err) {
+			return nil, status.Error(codes.AlreadyExists, "username or email already exists")
+		}
+		return nil, statusError(s.logger, err, "User", "create user")
+	}
+	return userToProto(user), nil
+}
+
+// GetUser implements pb.UserServiceServer
+func (s *userServer) GetUser(ctx context.Context, _ *pb.GetUserRequest) (*pb.User, error) {
+	user, err := s.service.GetUser(ctx, userIDFrom(ctx))
+	if err != nil {
+		return nil, statusError(s.logger, err, "User", "retrieve user")
+	}
+	return userToProto(user), nil
+}
+
+// UpdateUser implements pb.UserServiceServer
+func (s *userServer) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.User, error) {
+	updateReq := &request.UpdateUserRequest{
+		Username: req.GetUsername(),
+		Email:    req.GetEmail(),
+		Password: req.GetPassword(),
+		Avatar:   req.GetAvatar(),
+	}
+	if err := validate(updateReq); err != nil {
+		return nil, err
+	}
+
+	user, err := s.service.UpdateUser(ctx, userIDFrom(ctx), updateReq)
+	if err != nil {
+		if database.IsDuplicateError(err) {
+			return nil, status.Error(codes.AlreadyExists, "username or email already exists")
+		}
+		return nil, statusError(s.logger, err, "User", "update user")
+	}
+	return userToProto(user), nil
+}
+
+// DeleteUser implements pb.UserServiceServer
+func (s *userServer) DeleteUser(ctx context.Context, _ *pb.DeleteUserRequest) (*emptypb.Empty, error) {
+	if err := s.service.DeleteUser(ctx, userIDFrom(ctx)); err != nil {
+		return nil, statusError(s.logger, err, "User", "delete user")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// Authenticate implements pb.UserServiceServer
+func (s *userServer) Authenticate(ctx context.Context, req *pb.AuthenticateRequest) (*pb.AuthResponse, error) {
+	authReq := &request.AuthRequest{
+		Username: req.GetUsername(),
+		Password: req.GetPassword(),
+	}
+	if err := validate(authReq); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.service.Authenticate(ctx, authReq)
+	if err != nil {
+		s.logger.Debug("authentication failed", logging.Error(err))
+		return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+	}
+	return authToProto(resp), nil
+}
+
+// RefreshToken implements pb.UserServiceServer
+func (s *userServer) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest) (*pb.AuthResponse, error) {
+	refreshReq := &request.RefreshTokenRequest{RefreshToken: req.GetRefreshToken()}
+	if err := validate(refreshReq); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.service.RefreshToken(ctx, refreshReq.RefreshToken)
+	if err != nil {
+		s.logger.Debug("token refresh failed", logging.Error(err))
+		return nil, status.Error(codes.Unauthenticated, "invalid refresh token")
+	}
+	return authToProto(resp), nil
+}
+
+// ListUserEntries implements pb.UserServiceServer
+func (s *userServer) ListUserEntries(ctx context.Context, req *pb.ListEntriesRequest) (*pb.ListEntriesResponse, error) {
+	listReq := listEntriesRequest(req)
+	if err := validate(listReq); err != nil {
+		return nil, err
+	}
+
+	list, err := s.service.ListUserEntries(ctx, userIDFrom(ctx), listReq)
+	if err != nil {
+		return nil, statusError(s.logger, err, "User", "retrieve entries")
+	}
+	return entryListToProto(list.Entries, list.Total, list.Limit, list.Offset), nil
+}
+
+// ListUserTranslations implements pb.UserServiceServer
+func (s *userServer) ListUserTranslations(ctx context.Context, req *pb.ListUserTranslationsRequest) (*pb.ListUserTranslationsResponse, error) {
+	listReq := &request.ListTranslationsRequest{
+		Limit:      int(req.GetLimit()),
+		Offset:     int(req.GetOffset()),
+		SortBy:     req.GetSortBy(),
+		SortDesc:   req.GetSortDesc(),
+		LanguageID: req.GetLanguageId(),
+	}
+	if err := validate(listReq); err != nil {
+		return nil, err
+	}
+
+	list, err := s.service.ListUserTranslations(ctx, userIDFrom(ctx), listReq)
+	if err != nil {
+		return nil, statusError(s.logger, err, "User", "retrieve translations")
+	}
+	return &pb.ListUserTranslationsResponse{
+		Translations: translationsToProto(list.Translations),
+		Total:        int32(list.Total),
+		Limit:        int32(list.Limit),
+		Offset:       int32(list.Offset),
+	}, nil
+}
+
+// ListUserComments implements pb.UserServiceServer
+func (s *userServer) ListUserComments(ctx context.Context, req *pb.ListUserCommentsRequest) (*pb.ListUserCommentsResponse, error) {
+	listReq := &request.ListCommentsRequest{
+		Limit:      int(req.GetLimit()),
+		Offset:     int(req.GetOffset()),
+		SortBy:     req.GetSortBy(),
+		SortDesc:   req.GetSortDesc(),
+		TargetType: req.GetTargetType(),
+		FromDate:   timeOf(req.GetFromDate()),
+		ToDate:     timeOf(req.GetToDate()),
+	}
+	if err := validate(listReq); err != nil {
+		return nil, err
+	}
+
+	list, err := s.service.ListUserComments(ctx, userIDFrom(ctx), listReq)
+	if err != nil {
+		return nil, statusError(s.logger, err, "User", "retrieve comments")
+	}
+	return &pb.ListUserCommentsResponse{
+		Comments: commentsToProto(list.Comments),
+		Total:    int32(list.Total),
+		Limit:    int32(list.Limit),
+		Offset:   int32(list.Offset),
+	}, nil
+}
+
+// ListUserLikes implements pb.UserServiceServer
+func (s *userServer) ListUserLikes(ctx context.Context, req *pb.ListUserLikesRequest) (*pb.ListUserLikesResponse, error) {
+	listReq := &request.ListLikesRequest{
+		Limit:      int(req.GetLimit()),
+		Offset:     int(req.GetOffset()),
+		SortBy:     req.GetSortBy(),
+		SortDesc:   req.GetSortDesc(),
+		TargetType: req.GetTargetType(),
+		FromDate:   timeOf(req.GetFromDate()),
+		ToDate:     timeOf(req.GetToDate()),
+	}
+	if err := validate(listReq); err != nil {
+		return nil, err
+	}
+
+	list, err := s.service.ListUserLikes(ctx, userIDFrom(ctx), listReq)
+	if err != nil {
+		return nil, statusError(s.logger, err, "User", "retrieve likes")
+	}
+
+	likes := make([]*pb.Like, len(list.Likes))
+	for i := range list.Likes {
+		likes[i] = likeToProto(&list.Likes[i])
+	}
+	return &pb.ListUserLikesResponse{
+		Likes:  likes,
+		Total:  int32(list.Total),
+		Limit:  int32(list.Limit),
+		Offset: int32(list.Offset),
+	}, nil
+}
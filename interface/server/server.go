@@ -5,6 +5,7 @@ package server
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -18,9 +19,12 @@ import (
 	"github.com/valpere/trytrago/domain/cache"
 	"github.com/valpere/trytrago/domain/logging"
 	infraCache "github.com/valpere/trytrago/infrastructure/cache"
+	"github.com/valpere/trytrago/interface/api/graph"
 	"github.com/valpere/trytrago/interface/api/rest"
 	"github.com/valpere/trytrago/interface/api/rest/handler"
 	"github.com/valpere/trytrago/interface/api/rest/middleware"
+	"github.com/valpere/trytrago/interface/api/rpc"
+	"google.golang.org/grpc"
 )
 
 // Server is the interface for all server implementations
@@ -31,14 +35,22 @@ type Server interface {
 
 // AppServer is the main server that handles HTTP traffic
 type AppServer struct {
-	cfg          domain.Config
-	logger       logging.Logger
-	entryService service.EntryService
-	transService service.TranslationService
-	userService  service.UserService
-	cacheService cache.CacheService
+	cfg           domain.Config
+	logger        logging.Logger
+	entryService  service.EntryService
+	treeService   service.EntryTreeService
+	transService  service.TranslationService
+	userService   service.UserService
+	reviewService service.ReviewService
+	dupService    service.DuplicateService
+	xchService    service.ExchangeService
+	batchService  service.BatchService
+	patchService  service.PatchService
+	graphService  service.GraphService
+	cacheService  cache.CacheService
 
 	httpServer *http.Server
+	grpcServer *grpc.Server
 	redisCache infraCache.Cache
 
 	shutdownWg sync.WaitGroup
@@ -50,16 +62,30 @@ func NewServer(
 	cfg domain.Config,
 	logger logging.Logger,
 	entryService service.EntryService,
+	treeService service.EntryTreeService,
 	transService service.TranslationService,
 	userService service.UserService,
+	reviewService service.ReviewService,
+	dupService service.DuplicateService,
+	xchService service.ExchangeService,
+	batchService service.BatchService,
+	patchService service.PatchService,
+	graphService service.GraphService,
 ) *AppServer {
 	return &AppServer{
-		cfg:          cfg,
-		logger:       logger.With(logging.String("component", "server")),
-		entryService: entryService,
-		transService: transService,
-		userService:  userService,
-		shutdownCh:   make(chan os.Signal, 1),
+		cfg:           cfg,
+		logger:        logger.With(logging.String("component", "server")),
+		entryService:  entryService,
+		treeService:   treeService,
+		transService:  transService,
+		userService:   userService,
+		reviewService: reviewService,
+		dupService:    dupService,
+		xchService:    xchService,
+		batchService:  batchService,
+		patchService:  patchService,
+		graphService:  graphService,
+		shutdownCh:    make(chan os.Signal, 1),
 	}
 }
 
@@ -115,6 +141,27 @@ func (s *AppServer) initializeCachedServices() {
 			s.logger,
 		)
 
+		// Wrap entry tree service so its writes invalidate the caches above
+		s.treeService = service.NewCachedEntryTreeService(
+			s.treeService,
+			s.cacheService,
+			s.logger,
+		)
+
+		// Wrap batch service so the entries and translations it writes are not served stale
+		s.batchService = service.NewCachedBatchService(
+			s.batchService,
+			s.cacheService,
+			s.logger,
+		)
+
+		// Wrap patch service so patched resources are not served stale
+		s.patchService = service.NewCachedPatchService(
+			s.patchService,
+			s.cacheService,
+			s.logger,
+		)
+
 		// Wrap translation service with caching
 		s.transService = service.NewCachedTranslationService(
 			s.transService,
@@ -122,6 +169,20 @@ func (s *AppServer) initializeCachedServices() {
 			s.logger,
 		)
 
+		// Wrap review service so review decisions are not hidden behind stale lists and ETags
+		s.reviewService = service.NewCachedReviewService(
+			s.reviewService,
+			s.cacheService,
+			s.logger,
+		)
+
+		// Wrap duplicate service so merged entries resolve to their redirect at once
+		s.dupService = service.NewCachedDuplicateService(
+			s.dupService,
+			s.cacheService,
+			s.logger,
+		)
+
 		s.logger.Info("Services wrapped with Redis caching")
 	}
 }
@@ -145,6 +206,49 @@ func (s *AppServer) Start() error {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
+	// Build the GraphQL schema over the same, possibly cached, services
+	schema, err := graph.NewSchema(
+		s.entryService,
+		s.transService,
+		s.graphService,
+		graph.Limits{
+			MaxDepth:      s.cfg.Server.GraphQL.MaxDepth,
+			MaxComplexity: s.cfg.Server.GraphQL.MaxComplexity,
+		},
+		s.cfg.Server.RequireIfMatch,
+		s.logger,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to build GraphQL schema: %w", err)
+	}
+
+	// Start gRPC server in a goroutine, on its own port
+	if s.cfg.Server.GRPC.Enabled {
+		listener, err := net.Listen("tcp", fmt.Sprintf(":%d", s.cfg.Server.GRPC.Port))
+		if err != nil {
+			return fmt.Errorf("failed to listen on gRPC port: %w", err)
+		}
+
+		s.grpcServer = rpc.NewServer(
+			s.entryService,
+			s.transService,
+			s.userService,
+			s.cfg.Server.RequireIfMatch,
+			s.logger,
+		)
+
+		s.shutdownWg.Add(1)
+		go func() {
+			defer s.shutdownWg.Done()
+
+			s.logger.Info("Starting gRPC server", logging.Int("port", s.cfg.Server.GRPC.Port))
+
+			if err := s.grpcServer.Serve(listener); err != nil {
+				s.logger.Error("Failed to start gRPC server", logging.Error(err))
+			}
+		}()
+	}
+
 	// Start HTTP server in a goroutine
 	s.shutdownWg.Add(1)
 	go func() {
@@ -152,8 +256,15 @@ func (s *AppServer) Start() error {
 
 		// Create router with handlers
 		entryHandler := handler.NewEntryHandler(s.entryService, s.logger)
+		treeHandler := handler.NewEntryTreeHandler(s.treeService, s.logger)
 		transHandler := handler.NewTranslationHandler(s.transService, s.logger)
 		userHandler := handler.NewUserHandler(s.userService, s.logger)
+		reviewHandler := handler.NewReviewHandler(s.reviewService, s.logger)
+		dupHandler := handler.NewDuplicateHandler(s.dupService, s.logger)
+		xchHandler := handler.NewExchangeHandler(s.xchService, s.cfg.Exchange.AudioDir, s.logger)
+		batchHandler := handler.NewBatchHandler(s.batchService, s.logger)
+		patchHandler := handler.NewPatchHandler(s.patchService, s.logger)
+		graphQLHandler := handler.NewGraphQLHandler(schema, s.logger)
 		authMiddleware := middleware.NewAuthMiddleware(s.logger)
 
 		// Create router
@@ -161,8 +272,15 @@ func (s *AppServer) Start() error {
 			s.cfg,
 			s.logger,
 			entryHandler,
+			treeHandler,
 			transHandler,
 			userHandler,
+			reviewHandler,
+			dupHandler,
+			xchHandler,
+			batchHandler,
+			patchHandler,
+			graphQLHandler,
 			authMiddleware,
 		)
 
@@ -201,6 +319,26 @@ func (s *AppServer) Shutdown(ctx context.Context) error {
 		}
 	}
 
+	// Shutdown gRPC server, letting running calls and streams finish
+	if s.grpcServer != nil {
+		shutdownCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+
+		s.logger.Info("Shutting down gRPC server")
+		stopped := make(chan struct{})
+		go func() {
+			s.grpcServer.GracefulStop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+		case <-shutdownCtx.Done():
+			s.logger.Error("gRPC server shutdown error", logging.Error(shutdownCtx.Err()))
+			s.grpcServer.Stop()
+		}
+	}
+
 	// Close Redis connection if initialized
 	if s.redisCache != nil {
 		s.logger.Info("Closing Redis connection")
@@ -0,0 +1,13 @@
+-- R10__rollback_change_history_outlives_entries.sql
+-- Rollback script for change history that outlives entries
+
+DROP INDEX idx_change_history_action ON change_history;
+
+-- History of entries that no longer exist cannot be kept under the constraint
+DELETE FROM change_history WHERE entry_id NOT IN (SELECT id FROM entries);
+
+ALTER TABLE change_history
+  ADD CONSTRAINT change_history_entry_id_fkey
+  FOREIGN KEY (entry_id)
+  REFERENCES entries(id)
+  ON DELETE CASCADE;
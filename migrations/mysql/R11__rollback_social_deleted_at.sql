@@ -0,0 +1,8 @@
+-- R11__rollback_social_deleted_at.sql
+-- Rollback script for comment and like deletion timestamps
+
+DROP INDEX idx_likes_deleted_at ON likes;
+DROP INDEX idx_comments_deleted_at ON comments;
+
+ALTER TABLE likes DROP COLUMN deleted_at;
+ALTER TABLE comments DROP COLUMN deleted_at;
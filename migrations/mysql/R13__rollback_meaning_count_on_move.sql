@@ -0,0 +1,4 @@
+-- R13__rollback_meaning_count_on_move.sql
+-- Rollback script for meaning counts of moved meanings
+
+DROP TRIGGER IF EXISTS update_entry_meaning_count_on_move;
@@ -0,0 +1,12 @@
+-- R1__rollback_initial_schema.sql
+-- Rollback script for the initial schema migration
+
+-- Drop all tables in reverse order of creation to respect foreign key constraints;
+-- their indexes go with them
+DROP TABLE IF EXISTS change_history;
+DROP TABLE IF EXISTS translations;
+DROP TABLE IF EXISTS examples;
+DROP TABLE IF EXISTS meanings;
+DROP TABLE IF EXISTS entries;
+DROP TABLE IF EXISTS languages;
+DROP TABLE IF EXISTS parts_of_speech;
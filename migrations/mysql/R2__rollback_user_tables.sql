@@ -0,0 +1,16 @@
+-- R2__rollback_user_tables.sql
+-- Rollback script for the user tables migration
+
+-- First remove foreign key constraints
+ALTER TABLE entries DROP FOREIGN KEY fk_entries_created_by;
+ALTER TABLE meanings DROP FOREIGN KEY fk_meanings_created_by;
+ALTER TABLE examples DROP FOREIGN KEY fk_examples_created_by;
+ALTER TABLE translations DROP FOREIGN KEY fk_translations_created_by;
+ALTER TABLE change_history DROP FOREIGN KEY fk_change_history_user;
+ALTER TABLE entries DROP FOREIGN KEY fk_entries_source_language;
+
+-- Drop user-related tables; their indexes go with them
+DROP TABLE IF EXISTS user_stats;
+DROP TABLE IF EXISTS user_preferences;
+DROP TABLE IF EXISTS auth_tokens;
+DROP TABLE IF EXISTS users;
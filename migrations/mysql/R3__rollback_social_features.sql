@@ -0,0 +1,14 @@
+-- R3__rollback_social_features.sql
+-- Rollback script for the social features migration
+
+-- Drop triggers first
+DROP TRIGGER IF EXISTS update_likes_count_on_insert;
+DROP TRIGGER IF EXISTS update_likes_count_on_delete;
+
+-- Drop counters
+ALTER TABLE translations DROP COLUMN likes_count;
+ALTER TABLE meanings DROP COLUMN likes_count;
+
+-- Drop tables; their indexes go with them
+DROP TABLE IF EXISTS likes;
+DROP TABLE IF EXISTS comments;
@@ -0,0 +1,25 @@
+-- R4__rollback_performance_optimizations.sql
+-- Rollback script for performance optimizations
+
+-- Drop triggers
+DROP TRIGGER IF EXISTS update_translations_modified;
+DROP TRIGGER IF EXISTS update_meanings_modified;
+DROP TRIGGER IF EXISTS update_entries_modified;
+DROP TRIGGER IF EXISTS update_meaning_translation_count_on_delete;
+DROP TRIGGER IF EXISTS update_meaning_translation_count_on_insert;
+DROP TRIGGER IF EXISTS update_meaning_example_count_on_delete;
+DROP TRIGGER IF EXISTS update_meaning_example_count_on_insert;
+DROP TRIGGER IF EXISTS update_entry_meaning_count_on_delete;
+DROP TRIGGER IF EXISTS update_entry_meaning_count_on_insert;
+
+-- Drop indices
+DROP INDEX idx_entries_active ON entries;
+DROP INDEX idx_entries_word_type ON entries;
+
+-- Drop cached counts
+ALTER TABLE translations DROP COLUMN comments_count;
+ALTER TABLE meanings DROP COLUMN comments_count;
+ALTER TABLE meanings DROP COLUMN translation_count;
+ALTER TABLE meanings DROP COLUMN example_count;
+ALTER TABLE entries DROP COLUMN meaning_count;
+ALTER TABLE entries DROP COLUMN active;
@@ -0,0 +1,19 @@
+-- R5__rollback_translation_review.sql
+-- Rollback script for the translation review workflow
+
+-- Drop notifications
+DROP TABLE IF EXISTS notifications;
+
+-- Drop constraints; the indexes backing the foreign keys go with the columns
+ALTER TABLE translations DROP FOREIGN KEY fk_translations_reviewed_by;
+ALTER TABLE translations DROP FOREIGN KEY fk_translations_supersedes;
+ALTER TABLE translations DROP CHECK chk_translations_status;
+DROP INDEX idx_translations_status ON translations;
+
+-- Drop review columns
+ALTER TABLE translations
+  DROP COLUMN review_reason,
+  DROP COLUMN reviewed_at,
+  DROP COLUMN reviewed_by_id,
+  DROP COLUMN supersedes_id,
+  DROP COLUMN status;
@@ -0,0 +1,4 @@
+-- R6__rollback_entry_merge_redirects.sql
+-- Rollback script for duplicate entry merging
+
+DROP TABLE IF EXISTS entry_redirects;
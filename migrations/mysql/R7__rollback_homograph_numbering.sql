@@ -0,0 +1,8 @@
+-- R7__rollback_homograph_numbering.sql
+-- Rollback script for homograph numbering
+
+DROP INDEX idx_entries_homograph ON entries;
+ALTER TABLE entries DROP CHECK chk_entries_homograph_index;
+ALTER TABLE entries
+  DROP COLUMN homograph_index,
+  DROP COLUMN normalized_word;
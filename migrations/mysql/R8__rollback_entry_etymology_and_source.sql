@@ -0,0 +1,7 @@
+-- R8__rollback_entry_etymology_and_source.sql
+-- Rollback script for entry etymology and attribution
+
+ALTER TABLE entries
+  DROP COLUMN license,
+  DROP COLUMN source,
+  DROP COLUMN etymology;
@@ -0,0 +1,11 @@
+-- Meaning counts for meanings that move between entries
+-- Merging entries moves meanings with an update, which the counter triggers of
+-- V4 do not see. Counts left wrong by earlier merges are recomputed
+
+CREATE TRIGGER update_entry_meaning_count_on_move
+AFTER UPDATE ON meanings
+FOR EACH ROW
+UPDATE entries SET meaning_count = meaning_count + IF(id = NEW.entry_id, 1, -1)
+WHERE NEW.entry_id <> OLD.entry_id AND id IN (OLD.entry_id, NEW.entry_id);
+
+UPDATE entries SET meaning_count = (SELECT COUNT(*) FROM meanings WHERE meanings.entry_id = entries.id);
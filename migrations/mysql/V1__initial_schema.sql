@@ -0,0 +1,126 @@
+-- V1__initial_schema.sql
+-- Initial schema for TryTraGo dictionary database (MySQL 8.0.16 or later)
+
+-- Create parts_of_speech table
+CREATE TABLE IF NOT EXISTS parts_of_speech (
+    id CHAR(36) PRIMARY KEY,
+    name VARCHAR(50) NOT NULL UNIQUE,
+    created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    updated_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Create languages table for reference
+CREATE TABLE IF NOT EXISTS languages (
+    code VARCHAR(5) PRIMARY KEY,  -- ISO 639-1 code
+    name VARCHAR(100) NOT NULL,
+    native_name VARCHAR(100) NOT NULL,
+    rtl BOOLEAN NOT NULL DEFAULT FALSE,
+    active BOOLEAN NOT NULL DEFAULT TRUE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Create entries table
+-- The collation is case-insensitive, so a plain index serves word lookups
+CREATE TABLE IF NOT EXISTS entries (
+    id CHAR(36) PRIMARY KEY,
+    word VARCHAR(255) NOT NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('WORD', 'COMPOUND_WORD', 'PHRASE')),
+    pronunciation VARCHAR(255),
+    created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    updated_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    created_by_id CHAR(36),
+    source_language_id VARCHAR(5),
+    INDEX idx_entries_word (word),
+    INDEX idx_entries_type (type),
+    INDEX idx_entries_created_at (created_at DESC)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Create meanings table
+CREATE TABLE IF NOT EXISTS meanings (
+    id CHAR(36) PRIMARY KEY,
+    entry_id CHAR(36) NOT NULL,
+    part_of_speech_id CHAR(36) NOT NULL,
+    description TEXT NOT NULL,
+    created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    updated_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    created_by_id CHAR(36),
+    INDEX idx_meanings_entry_id (entry_id),
+    INDEX idx_meanings_part_of_speech_id (part_of_speech_id),
+    CONSTRAINT fk_meanings_entry FOREIGN KEY (entry_id) REFERENCES entries(id) ON DELETE CASCADE,
+    CONSTRAINT fk_meanings_part_of_speech FOREIGN KEY (part_of_speech_id) REFERENCES parts_of_speech(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Create examples table
+CREATE TABLE IF NOT EXISTS examples (
+    id CHAR(36) PRIMARY KEY,
+    meaning_id CHAR(36) NOT NULL,
+    text TEXT NOT NULL,
+    context TEXT,
+    created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    updated_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    created_by_id CHAR(36),
+    INDEX idx_examples_meaning_id (meaning_id),
+    CONSTRAINT fk_examples_meaning FOREIGN KEY (meaning_id) REFERENCES meanings(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Create translations table
+CREATE TABLE IF NOT EXISTS translations (
+    id CHAR(36) PRIMARY KEY,
+    meaning_id CHAR(36) NOT NULL,
+    language_id VARCHAR(5) NOT NULL,  -- ISO 639-1 code
+    text TEXT NOT NULL,
+    created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    updated_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    created_by_id CHAR(36),
+    INDEX idx_translations_meaning_id (meaning_id),
+    INDEX idx_translations_language_id (language_id),
+    FULLTEXT INDEX idx_translations_text (text),
+    CONSTRAINT fk_translations_meaning FOREIGN KEY (meaning_id) REFERENCES meanings(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Create change_history table
+CREATE TABLE IF NOT EXISTS change_history (
+    id CHAR(36) PRIMARY KEY,
+    entry_id CHAR(36) NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    data JSON NOT NULL,
+    user_id CHAR(36),
+    created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    INDEX idx_change_history_entry_id (entry_id),
+    INDEX idx_change_history_created_at (created_at DESC),
+    CONSTRAINT change_history_entry_id_fkey FOREIGN KEY (entry_id) REFERENCES entries(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Insert default parts of speech
+INSERT IGNORE INTO parts_of_speech (id, name, created_at, updated_at) VALUES
+    (UUID(), 'noun', CURRENT_TIMESTAMP(3), CURRENT_TIMESTAMP(3)),
+    (UUID(), 'verb', CURRENT_TIMESTAMP(3), CURRENT_TIMESTAMP(3)),
+    (UUID(), 'adjective', CURRENT_TIMESTAMP(3), CURRENT_TIMESTAMP(3)),
+    (UUID(), 'adverb', CURRENT_TIMESTAMP(3), CURRENT_TIMESTAMP(3)),
+    (UUID(), 'pronoun', CURRENT_TIMESTAMP(3), CURRENT_TIMESTAMP(3)),
+    (UUID(), 'preposition', CURRENT_TIMESTAMP(3), CURRENT_TIMESTAMP(3)),
+    (UUID(), 'conjunction', CURRENT_TIMESTAMP(3), CURRENT_TIMESTAMP(3)),
+    (UUID(), 'interjection', CURRENT_TIMESTAMP(3), CURRENT_TIMESTAMP(3)),
+    (UUID(), 'article', CURRENT_TIMESTAMP(3), CURRENT_TIMESTAMP(3)),
+    (UUID(), 'numeral', CURRENT_TIMESTAMP(3), CURRENT_TIMESTAMP(3)),
+    (UUID(), 'determiner', CURRENT_TIMESTAMP(3), CURRENT_TIMESTAMP(3)),
+    (UUID(), 'particle', CURRENT_TIMESTAMP(3), CURRENT_TIMESTAMP(3));
+
+-- Insert default languages
+INSERT IGNORE INTO languages (code, name, native_name, rtl, active) VALUES
+    ('en', 'English', 'English', FALSE, TRUE),
+    ('es', 'Spanish', 'Español', FALSE, TRUE),
+    ('fr', 'French', 'Français', FALSE, TRUE),
+    ('de', 'German', 'Deutsch', FALSE, TRUE),
+    ('it', 'Italian', 'Italiano', FALSE, TRUE),
+    ('pt', 'Portuguese', 'Português', FALSE, TRUE),
+    ('ru', 'Russian', 'Русский', FALSE, TRUE),
+    ('zh', 'Chinese', '中文', FALSE, TRUE),
+    ('ja', 'Japanese', '日本語', FALSE, TRUE),
+    ('ko', 'Korean', '한국어', FALSE, TRUE),
+    ('ar', 'Arabic', 'العربية', TRUE, TRUE),
+    ('hi', 'Hindi', 'हिन्दी', FALSE, TRUE),
+    ('tr', 'Turkish', 'Türkçe', FALSE, TRUE),
+    ('nl', 'Dutch', 'Nederlands', FALSE, TRUE),
+    ('sv', 'Swedish', 'Svenska', FALSE, TRUE),
+    ('pl', 'Polish', 'Polski', FALSE, TRUE),
+    ('uk', 'Ukrainian', 'Українська', FALSE, TRUE);
@@ -0,0 +1,132 @@
+-- V2__add_user_tables.sql
+-- User management tables for TryTraGo dictionary database
+
+-- Create users table
+-- Usernames and emails compare case-insensitively under the default collation
+CREATE TABLE IF NOT EXISTS users (
+    id CHAR(36) PRIMARY KEY,
+    username VARCHAR(50) NOT NULL UNIQUE,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    password VARCHAR(255) NOT NULL, -- Stored as bcrypt hash
+    avatar VARCHAR(255),
+    role VARCHAR(20) NOT NULL DEFAULT 'USER',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    updated_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    last_login DATETIME(3),
+    INDEX idx_users_role (role)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Create auth_tokens table
+CREATE TABLE IF NOT EXISTS auth_tokens (
+    id CHAR(36) PRIMARY KEY,
+    user_id CHAR(36) NOT NULL,
+    access_token VARCHAR(500) NOT NULL,
+    refresh_token VARCHAR(500) NOT NULL,
+    expires_at DATETIME(3) NOT NULL,
+    created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    revoked_at DATETIME(3),
+    user_agent VARCHAR(255),
+    client_ip VARCHAR(45),
+    INDEX idx_auth_tokens_user_id (user_id),
+    INDEX idx_auth_tokens_refresh_token (refresh_token),
+    INDEX idx_auth_tokens_expires_at (expires_at),
+    CONSTRAINT fk_auth_tokens_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Create user_preferences table
+CREATE TABLE IF NOT EXISTS user_preferences (
+    id CHAR(36) PRIMARY KEY,
+    user_id CHAR(36) NOT NULL UNIQUE,
+    default_language VARCHAR(5) NOT NULL DEFAULT 'en',
+    theme_preference VARCHAR(20) NOT NULL DEFAULT 'system',
+    email_notify BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    updated_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    CONSTRAINT fk_user_preferences_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_user_preferences_language FOREIGN KEY (default_language) REFERENCES languages(code)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Create user_stats table
+CREATE TABLE IF NOT EXISTS user_stats (
+    id CHAR(36) PRIMARY KEY,
+    user_id CHAR(36) NOT NULL UNIQUE,
+    entries_created INT NOT NULL DEFAULT 0,
+    entries_updated INT NOT NULL DEFAULT 0,
+    meanings_added INT NOT NULL DEFAULT 0,
+    translations_added INT NOT NULL DEFAULT 0,
+    comments_posted INT NOT NULL DEFAULT 0,
+    likes_given INT NOT NULL DEFAULT 0,
+    reputation_points INT NOT NULL DEFAULT 0,
+    last_activity_at DATETIME(3),
+    created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    updated_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    CONSTRAINT fk_user_stats_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Update foreign keys in existing tables
+-- Add foreign key constraints for created_by_id fields
+ALTER TABLE entries
+    ADD CONSTRAINT fk_entries_created_by FOREIGN KEY (created_by_id) REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE meanings
+    ADD CONSTRAINT fk_meanings_created_by FOREIGN KEY (created_by_id) REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE examples
+    ADD CONSTRAINT fk_examples_created_by FOREIGN KEY (created_by_id) REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE translations
+    ADD CONSTRAINT fk_translations_created_by FOREIGN KEY (created_by_id) REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE change_history
+    ADD CONSTRAINT fk_change_history_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL;
+
+-- Add language foreign key constraints
+ALTER TABLE entries
+    ADD CONSTRAINT fk_entries_source_language FOREIGN KEY (source_language_id) REFERENCES languages(code) ON DELETE SET NULL;
+
+-- Create admin user (password: admin123)
+INSERT IGNORE INTO users (
+    id,
+    username,
+    email,
+    password,
+    avatar,
+    role,
+    is_active,
+    created_at,
+    updated_at
+) VALUES (
+    UUID(),
+    'admin',
+    'admin@trytrago.com',
+    '$2a$10$dBR5d8VTLjQvQOPiwbHCzuQUEVLvtvVSbG2pJUT3c4DHmfVCJNpou', -- 'admin123' hashed with bcrypt
+    '',
+    'ADMIN',
+    TRUE,
+    CURRENT_TIMESTAMP(3),
+    CURRENT_TIMESTAMP(3)
+);
+
+-- Create default user (password: password123)
+INSERT IGNORE INTO users (
+    id,
+    username,
+    email,
+    password,
+    avatar,
+    role,
+    is_active,
+    created_at,
+    updated_at
+) VALUES (
+    UUID(),
+    'user',
+    'user@trytrago.com',
+    '$2a$10$dBR5d8VTLjQvQOPiwbHCzuQUEVLvtvVSbG2pJUT3c4DHmfVCJNpou', -- 'password123' hashed with bcrypt
+    '',
+    'USER',
+    TRUE,
+    CURRENT_TIMESTAMP(3),
+    CURRENT_TIMESTAMP(3)
+);
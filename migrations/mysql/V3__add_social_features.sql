@@ -0,0 +1,69 @@
+-- Add social features to the dictionary
+-- Comments, likes, and user interactions
+
+-- Comments table
+CREATE TABLE IF NOT EXISTS comments (
+  id CHAR(36) PRIMARY KEY DEFAULT (UUID()),
+  user_id CHAR(36) NOT NULL,
+  target_id CHAR(36) NOT NULL,
+  target_type VARCHAR(20) NOT NULL,
+  content TEXT NOT NULL,
+  created_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3),
+  updated_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3),
+  INDEX idx_comments_user_id (user_id),
+  INDEX idx_comments_target_id (target_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Likes table
+CREATE TABLE IF NOT EXISTS likes (
+  id CHAR(36) PRIMARY KEY DEFAULT (UUID()),
+  user_id CHAR(36) NOT NULL,
+  target_id CHAR(36) NOT NULL,
+  target_type VARCHAR(20) NOT NULL,
+  created_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3),
+  INDEX idx_likes_user_id (user_id),
+  INDEX idx_likes_target_id (target_id),
+  UNIQUE INDEX idx_likes_user_target (user_id, target_id, target_type)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Add foreign key constraints
+ALTER TABLE comments
+  ADD CONSTRAINT fk_comments_user
+  FOREIGN KEY (user_id)
+  REFERENCES users(id)
+  ON DELETE CASCADE;
+
+ALTER TABLE likes
+  ADD CONSTRAINT fk_likes_user
+  FOREIGN KEY (user_id)
+  REFERENCES users(id)
+  ON DELETE CASCADE;
+
+-- Update existing tables to support social features
+ALTER TABLE meanings ADD COLUMN likes_count INTEGER DEFAULT 0;
+ALTER TABLE translations ADD COLUMN likes_count INTEGER DEFAULT 0;
+
+-- Keep likes counts up to date; MySQL triggers fire on a single event.
+-- Likes removed by the cascade from users do not fire them, InnoDB skips
+-- triggers for cascaded deletes
+CREATE TRIGGER update_likes_count_on_insert
+AFTER INSERT ON likes
+FOR EACH ROW
+BEGIN
+  IF NEW.target_type = 'meaning' THEN
+    UPDATE meanings SET likes_count = likes_count + 1 WHERE id = NEW.target_id;
+  ELSEIF NEW.target_type = 'translation' THEN
+    UPDATE translations SET likes_count = likes_count + 1 WHERE id = NEW.target_id;
+  END IF;
+END;
+
+CREATE TRIGGER update_likes_count_on_delete
+AFTER DELETE ON likes
+FOR EACH ROW
+BEGIN
+  IF OLD.target_type = 'meaning' THEN
+    UPDATE meanings SET likes_count = likes_count - 1 WHERE id = OLD.target_id;
+  ELSEIF OLD.target_type = 'translation' THEN
+    UPDATE translations SET likes_count = likes_count - 1 WHERE id = OLD.target_id;
+  END IF;
+END;
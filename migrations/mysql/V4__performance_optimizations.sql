@@ -0,0 +1,66 @@
+-- Performance optimization migration
+
+-- Add active column to entries
+ALTER TABLE entries ADD COLUMN active BOOLEAN DEFAULT TRUE;
+
+-- Add indexes for faster lookup; the single column ones exist since V1 and V3
+CREATE INDEX idx_entries_word_type ON entries(word, type);
+
+-- MySQL has no partial indexes, active rows are found through the leading column
+CREATE INDEX idx_entries_active ON entries(active, created_at);
+
+-- Add column for caching counts
+ALTER TABLE entries ADD COLUMN meaning_count INTEGER DEFAULT 0;
+ALTER TABLE meanings ADD COLUMN example_count INTEGER DEFAULT 0;
+ALTER TABLE meanings ADD COLUMN translation_count INTEGER DEFAULT 0;
+ALTER TABLE meanings ADD COLUMN comments_count INTEGER DEFAULT 0;
+ALTER TABLE translations ADD COLUMN comments_count INTEGER DEFAULT 0;
+
+-- Keep meaning counts up to date
+CREATE TRIGGER update_entry_meaning_count_on_insert
+AFTER INSERT ON meanings
+FOR EACH ROW
+UPDATE entries SET meaning_count = meaning_count + 1 WHERE id = NEW.entry_id;
+
+CREATE TRIGGER update_entry_meaning_count_on_delete
+AFTER DELETE ON meanings
+FOR EACH ROW
+UPDATE entries SET meaning_count = meaning_count - 1 WHERE id = OLD.entry_id;
+
+-- Keep example counts up to date
+CREATE TRIGGER update_meaning_example_count_on_insert
+AFTER INSERT ON examples
+FOR EACH ROW
+UPDATE meanings SET example_count = example_count + 1 WHERE id = NEW.meaning_id;
+
+CREATE TRIGGER update_meaning_example_count_on_delete
+AFTER DELETE ON examples
+FOR EACH ROW
+UPDATE meanings SET example_count = example_count - 1 WHERE id = OLD.meaning_id;
+
+-- Keep translation counts up to date
+CREATE TRIGGER update_meaning_translation_count_on_insert
+AFTER INSERT ON translations
+FOR EACH ROW
+UPDATE meanings SET translation_count = translation_count + 1 WHERE id = NEW.meaning_id;
+
+CREATE TRIGGER update_meaning_translation_count_on_delete
+AFTER DELETE ON translations
+FOR EACH ROW
+UPDATE meanings SET translation_count = translation_count - 1 WHERE id = OLD.meaning_id;
+
+-- Add updated_at column triggers
+CREATE TRIGGER update_entries_modified
+BEFORE UPDATE ON entries
+FOR EACH ROW
+SET NEW.updated_at = CURRENT_TIMESTAMP(3);
+
+CREATE TRIGGER update_meanings_modified
+BEFORE UPDATE ON meanings
+FOR EACH ROW
+SET NEW.updated_at = CURRENT_TIMESTAMP(3);
+
+CREATE TRIGGER update_translations_modified
+BEFORE UPDATE ON translations
+FOR EACH ROW
+SET NEW.updated_at = CURRENT_TIMESTAMP(3);
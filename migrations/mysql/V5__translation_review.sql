@@ -0,0 +1,50 @@
+-- Translation review workflow
+-- Contributed translations are proposed, then approved or rejected by a reviewer
+
+-- Review state on translations; existing rows are treated as approved
+ALTER TABLE translations
+  ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'APPROVED',
+  ADD COLUMN supersedes_id CHAR(36),
+  ADD COLUMN reviewed_by_id CHAR(36),
+  ADD COLUMN reviewed_at DATETIME(3),
+  ADD COLUMN review_reason TEXT;
+
+ALTER TABLE translations
+  ADD CONSTRAINT chk_translations_status
+  CHECK (status IN ('PROPOSED', 'APPROVED', 'REJECTED', 'SUPERSEDED'));
+
+ALTER TABLE translations
+  ADD CONSTRAINT fk_translations_supersedes
+  FOREIGN KEY (supersedes_id)
+  REFERENCES translations(id)
+  ON DELETE SET NULL;
+
+ALTER TABLE translations
+  ADD CONSTRAINT fk_translations_reviewed_by
+  FOREIGN KEY (reviewed_by_id)
+  REFERENCES users(id)
+  ON DELETE SET NULL;
+
+-- The review queue is read oldest first; without partial indexes the status
+-- leads
+CREATE INDEX idx_translations_status ON translations(status, created_at);
+
+-- Notifications about review outcomes
+CREATE TABLE IF NOT EXISTS notifications (
+  id CHAR(36) PRIMARY KEY DEFAULT (UUID()),
+  user_id CHAR(36) NOT NULL,
+  type VARCHAR(50) NOT NULL,
+  target_type VARCHAR(20) NOT NULL,
+  target_id CHAR(36) NOT NULL,
+  message TEXT,
+  read_at DATETIME(3),
+  created_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3),
+  INDEX idx_notifications_user_id (user_id, created_at DESC),
+  INDEX idx_notifications_target_id (target_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+ALTER TABLE notifications
+  ADD CONSTRAINT fk_notifications_user
+  FOREIGN KEY (user_id)
+  REFERENCES users(id)
+  ON DELETE CASCADE;
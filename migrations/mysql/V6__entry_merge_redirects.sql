@@ -0,0 +1,15 @@
+-- Duplicate entry merging
+-- Merged entries leave a redirect so their old IDs keep resolving
+
+CREATE TABLE IF NOT EXISTS entry_redirects (
+  from_id CHAR(36) PRIMARY KEY,
+  to_id CHAR(36) NOT NULL,
+  created_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3),
+  INDEX idx_entry_redirects_to_id (to_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+ALTER TABLE entry_redirects
+  ADD CONSTRAINT fk_entry_redirects_to
+  FOREIGN KEY (to_id)
+  REFERENCES entries(id)
+  ON DELETE CASCADE;
@@ -0,0 +1,36 @@
+-- Homograph numbering
+-- Entries sharing a normalized word, type and source language are told apart by a 1-based index
+
+ALTER TABLE entries
+  ADD COLUMN normalized_word VARCHAR(255),
+  ADD COLUMN homograph_index INTEGER;
+
+-- As close to the application's normalization as MySQL gets: lower case and
+-- collapsed whitespace. There is no NFKC, the application renormalizes words
+-- it saves
+UPDATE entries
+SET normalized_word = LOWER(TRIM(REGEXP_REPLACE(word, '[[:space:]]+', ' ')))
+WHERE normalized_word IS NULL;
+
+-- Existing entries are numbered in creation order
+UPDATE entries e
+JOIN (
+  SELECT id, ROW_NUMBER() OVER (
+    PARTITION BY normalized_word, type, COALESCE(source_language_id, '')
+    ORDER BY created_at, id
+  ) AS position
+  FROM entries
+) numbered ON e.id = numbered.id
+SET e.homograph_index = numbered.position
+WHERE e.homograph_index IS NULL;
+
+ALTER TABLE entries
+  MODIFY normalized_word VARCHAR(255) NOT NULL DEFAULT '',
+  MODIFY homograph_index INTEGER NOT NULL DEFAULT 1;
+
+ALTER TABLE entries
+  ADD CONSTRAINT chk_entries_homograph_index
+  CHECK (homograph_index >= 1);
+
+CREATE UNIQUE INDEX idx_entries_homograph
+  ON entries (normalized_word, type, (COALESCE(source_language_id, '')), homograph_index);
@@ -0,0 +1,7 @@
+-- Etymology and attribution for entries
+-- Imported entries record the dataset they came from and its licence
+
+ALTER TABLE entries
+  ADD COLUMN etymology TEXT,
+  ADD COLUMN source VARCHAR(100),
+  ADD COLUMN license VARCHAR(100);
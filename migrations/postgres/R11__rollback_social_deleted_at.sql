@@ -0,0 +1,8 @@
+-- R11__rollback_social_deleted_at.sql
+-- Rollback script for comment and like deletion timestamps
+
+DROP INDEX IF EXISTS idx_likes_deleted_at;
+DROP INDEX IF EXISTS idx_comments_deleted_at;
+
+ALTER TABLE likes DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE comments DROP COLUMN IF EXISTS deleted_at;
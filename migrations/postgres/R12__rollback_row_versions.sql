@@ -0,0 +1,6 @@
+-- R12__rollback_row_versions.sql
+-- Rollback script for version counters
+
+ALTER TABLE translations DROP COLUMN IF EXISTS version;
+ALTER TABLE meanings DROP COLUMN IF EXISTS version;
+ALTER TABLE entries DROP COLUMN IF EXISTS version;
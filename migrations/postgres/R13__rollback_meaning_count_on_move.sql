@@ -0,0 +1,19 @@
+-- R13__rollback_meaning_count_on_move.sql
+-- Rollback script for meaning counts of moved meanings
+
+DROP TRIGGER IF EXISTS update_entry_meaning_count_trigger ON meanings;
+CREATE TRIGGER update_entry_meaning_count_trigger
+AFTER INSERT OR DELETE ON meanings
+FOR EACH ROW EXECUTE FUNCTION update_entry_meaning_count();
+
+CREATE OR REPLACE FUNCTION update_entry_meaning_count()
+RETURNS TRIGGER AS $$
+BEGIN
+  IF TG_OP = 'INSERT' THEN
+    UPDATE entries SET meaning_count = meaning_count + 1 WHERE id = NEW.entry_id;
+  ELSIF TG_OP = 'DELETE' THEN
+    UPDATE entries SET meaning_count = meaning_count - 1 WHERE id = OLD.entry_id;
+  END IF;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,23 @@
+-- R3__rollback_social_features.sql
+-- Rollback script for the social features migration
+
+-- Drop triggers first
+DROP TRIGGER IF EXISTS update_likes_count_trigger ON likes;
+
+-- Drop functions
+DROP FUNCTION IF EXISTS update_likes_count();
+
+-- Drop counters
+ALTER TABLE translations DROP COLUMN IF EXISTS likes_count;
+ALTER TABLE meanings DROP COLUMN IF EXISTS likes_count;
+
+-- Drop tables
+DROP TABLE IF EXISTS likes CASCADE;
+DROP TABLE IF EXISTS comments CASCADE;
+
+-- Drop indices
+DROP INDEX IF EXISTS idx_comments_user_id CASCADE;
+DROP INDEX IF EXISTS idx_comments_target_id CASCADE;
+DROP INDEX IF EXISTS idx_likes_user_id CASCADE;
+DROP INDEX IF EXISTS idx_likes_target_id CASCADE;
+DROP INDEX IF EXISTS idx_likes_user_target CASCADE;
@@ -0,0 +1,28 @@
+-- R4__rollback_performance_optimizations.sql
+-- Rollback script for performance optimizations
+
+-- Drop triggers
+DROP TRIGGER IF EXISTS update_translations_modified ON translations;
+DROP TRIGGER IF EXISTS update_meanings_modified ON meanings;
+DROP TRIGGER IF EXISTS update_entries_modified ON entries;
+DROP TRIGGER IF EXISTS update_meaning_translation_count_trigger ON translations;
+DROP TRIGGER IF EXISTS update_meaning_example_count_trigger ON examples;
+DROP TRIGGER IF EXISTS update_entry_meaning_count_trigger ON meanings;
+
+-- Drop custom functions
+DROP FUNCTION IF EXISTS update_modified_column();
+DROP FUNCTION IF EXISTS update_meaning_translation_count();
+DROP FUNCTION IF EXISTS update_meaning_example_count();
+DROP FUNCTION IF EXISTS update_entry_meaning_count();
+
+-- Drop indices; the single column ones belong to V1 and V3
+DROP INDEX IF EXISTS idx_entries_active;
+DROP INDEX IF EXISTS idx_entries_word_type;
+
+-- Drop cached counts
+ALTER TABLE translations DROP COLUMN IF EXISTS comments_count;
+ALTER TABLE meanings DROP COLUMN IF EXISTS comments_count;
+ALTER TABLE meanings DROP COLUMN IF EXISTS translation_count;
+ALTER TABLE meanings DROP COLUMN IF EXISTS example_count;
+ALTER TABLE entries DROP COLUMN IF EXISTS meaning_count;
+ALTER TABLE entries DROP COLUMN IF EXISTS active;
@@ -0,0 +1,21 @@
+-- R5__rollback_translation_review.sql
+-- Rollback script for the translation review workflow
+
+-- Drop notifications
+DROP TABLE IF EXISTS notifications CASCADE;
+
+-- Drop indices
+DROP INDEX IF EXISTS idx_translations_proposed;
+DROP INDEX IF EXISTS idx_translations_status;
+
+-- Drop constraints
+ALTER TABLE translations DROP CONSTRAINT IF EXISTS fk_translations_reviewed_by;
+ALTER TABLE translations DROP CONSTRAINT IF EXISTS fk_translations_supersedes;
+ALTER TABLE translations DROP CONSTRAINT IF EXISTS chk_translations_status;
+
+-- Drop review columns
+ALTER TABLE translations DROP COLUMN IF EXISTS review_reason;
+ALTER TABLE translations DROP COLUMN IF EXISTS reviewed_at;
+ALTER TABLE translations DROP COLUMN IF EXISTS reviewed_by_id;
+ALTER TABLE translations DROP COLUMN IF EXISTS supersedes_id;
+ALTER TABLE translations DROP COLUMN IF EXISTS status;
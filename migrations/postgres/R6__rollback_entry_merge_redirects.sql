@@ -0,0 +1,5 @@
+-- R6__rollback_entry_merge_redirects.sql
+-- Rollback script for duplicate entry merging
+
+DROP INDEX IF EXISTS idx_entry_redirects_to_id;
+DROP TABLE IF EXISTS entry_redirects CASCADE;
@@ -0,0 +1,7 @@
+-- R7__rollback_homograph_numbering.sql
+-- Rollback script for homograph numbering
+
+DROP INDEX IF EXISTS idx_entries_homograph;
+ALTER TABLE entries DROP CONSTRAINT IF EXISTS chk_entries_homograph_index;
+ALTER TABLE entries DROP COLUMN IF EXISTS homograph_index;
+ALTER TABLE entries DROP COLUMN IF EXISTS normalized_word;
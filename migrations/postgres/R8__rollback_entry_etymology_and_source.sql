@@ -0,0 +1,6 @@
+-- R8__rollback_entry_etymology_and_source.sql
+-- Rollback script for entry etymology and attribution
+
+ALTER TABLE entries DROP COLUMN IF EXISTS license;
+ALTER TABLE entries DROP COLUMN IF EXISTS source;
+ALTER TABLE entries DROP COLUMN IF EXISTS etymology;
@@ -0,0 +1,7 @@
+-- Change history outlives the entries it describes
+-- Deletions are recorded in the history so incremental backups can replay
+-- them; the rows must not be cascaded away together with the entry
+
+ALTER TABLE change_history DROP CONSTRAINT IF EXISTS change_history_entry_id_fkey;
+
+CREATE INDEX IF NOT EXISTS idx_change_history_action ON change_history(action, created_at);
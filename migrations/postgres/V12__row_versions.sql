@@ -0,0 +1,7 @@
+-- Version counters for optimistic concurrency
+-- Each write to an entry, meaning or translation moves its version on. Writers
+-- state the version they read and fail when it has changed in the meantime
+
+ALTER TABLE entries ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE meanings ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE translations ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
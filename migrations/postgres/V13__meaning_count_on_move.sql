@@ -0,0 +1,25 @@
+-- Meaning counts for meanings that move between entries
+-- Merging entries moves meanings with an update, which the counter trigger of
+-- V4 does not see. Counts left wrong by earlier merges are recomputed
+
+CREATE OR REPLACE FUNCTION update_entry_meaning_count()
+RETURNS TRIGGER AS $$
+BEGIN
+  IF TG_OP = 'INSERT' THEN
+    UPDATE entries SET meaning_count = meaning_count + 1 WHERE id = NEW.entry_id;
+  ELSIF TG_OP = 'DELETE' THEN
+    UPDATE entries SET meaning_count = meaning_count - 1 WHERE id = OLD.entry_id;
+  ELSIF NEW.entry_id IS DISTINCT FROM OLD.entry_id THEN
+    UPDATE entries SET meaning_count = meaning_count - 1 WHERE id = OLD.entry_id;
+    UPDATE entries SET meaning_count = meaning_count + 1 WHERE id = NEW.entry_id;
+  END IF;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS update_entry_meaning_count_trigger ON meanings;
+CREATE TRIGGER update_entry_meaning_count_trigger
+AFTER INSERT OR DELETE OR UPDATE OF entry_id ON meanings
+FOR EACH ROW EXECUTE FUNCTION update_entry_meaning_count();
+
+UPDATE entries SET meaning_count = (SELECT COUNT(*) FROM meanings WHERE meanings.entry_id = entries.id);
@@ -0,0 +1,50 @@
+-- Translation review workflow
+-- Contributed translations are proposed, then approved or rejected by a reviewer
+
+-- Review state on translations; existing rows are treated as approved
+ALTER TABLE translations ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'APPROVED';
+ALTER TABLE translations ADD COLUMN IF NOT EXISTS supersedes_id UUID;
+ALTER TABLE translations ADD COLUMN IF NOT EXISTS reviewed_by_id UUID;
+ALTER TABLE translations ADD COLUMN IF NOT EXISTS reviewed_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE translations ADD COLUMN IF NOT EXISTS review_reason TEXT;
+
+ALTER TABLE translations
+  ADD CONSTRAINT chk_translations_status
+  CHECK (status IN ('PROPOSED', 'APPROVED', 'REJECTED', 'SUPERSEDED'));
+
+ALTER TABLE translations
+  ADD CONSTRAINT fk_translations_supersedes
+  FOREIGN KEY (supersedes_id)
+  REFERENCES translations(id)
+  ON DELETE SET NULL;
+
+ALTER TABLE translations
+  ADD CONSTRAINT fk_translations_reviewed_by
+  FOREIGN KEY (reviewed_by_id)
+  REFERENCES users(id)
+  ON DELETE SET NULL;
+
+-- The review queue is read oldest first
+CREATE INDEX IF NOT EXISTS idx_translations_status ON translations(status);
+CREATE INDEX IF NOT EXISTS idx_translations_proposed ON translations(created_at) WHERE status = 'PROPOSED';
+
+-- Notifications about review outcomes
+CREATE TABLE IF NOT EXISTS notifications (
+  id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  user_id UUID NOT NULL,
+  type VARCHAR(50) NOT NULL,
+  target_type VARCHAR(20) NOT NULL,
+  target_id UUID NOT NULL,
+  message TEXT,
+  read_at TIMESTAMP WITH TIME ZONE,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id ON notifications(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_notifications_target_id ON notifications(target_id);
+
+ALTER TABLE notifications
+  ADD CONSTRAINT fk_notifications_user
+  FOREIGN KEY (user_id)
+  REFERENCES users(id)
+  ON DELETE CASCADE;
@@ -0,0 +1,16 @@
+-- Duplicate entry merging
+-- Merged entries leave a redirect so their old IDs keep resolving
+
+CREATE TABLE IF NOT EXISTS entry_redirects (
+  from_id UUID PRIMARY KEY,
+  to_id UUID NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_entry_redirects_to_id ON entry_redirects(to_id);
+
+ALTER TABLE entry_redirects
+  ADD CONSTRAINT fk_entry_redirects_to
+  FOREIGN KEY (to_id)
+  REFERENCES entries(id)
+  ON DELETE CASCADE;
@@ -0,0 +1,34 @@
+-- Homograph numbering
+-- Entries sharing a normalized word, type and source language are told apart by a 1-based index
+
+ALTER TABLE entries ADD COLUMN IF NOT EXISTS normalized_word VARCHAR(255);
+ALTER TABLE entries ADD COLUMN IF NOT EXISTS homograph_index INTEGER;
+
+-- Same normalization as the application: NFKC, lower case, collapsed whitespace
+UPDATE entries
+SET normalized_word = LOWER(BTRIM(REGEXP_REPLACE(NORMALIZE(word, NFKC), '\s+', ' ', 'g')))
+WHERE normalized_word IS NULL;
+
+-- Existing entries are numbered in creation order
+UPDATE entries e
+SET homograph_index = numbered.position
+FROM (
+  SELECT id, ROW_NUMBER() OVER (
+    PARTITION BY normalized_word, type, COALESCE(source_language_id, '')
+    ORDER BY created_at, id
+  ) AS position
+  FROM entries
+) numbered
+WHERE e.id = numbered.id AND e.homograph_index IS NULL;
+
+ALTER TABLE entries ALTER COLUMN normalized_word SET DEFAULT '';
+ALTER TABLE entries ALTER COLUMN normalized_word SET NOT NULL;
+ALTER TABLE entries ALTER COLUMN homograph_index SET DEFAULT 1;
+ALTER TABLE entries ALTER COLUMN homograph_index SET NOT NULL;
+
+ALTER TABLE entries
+  ADD CONSTRAINT chk_entries_homograph_index
+  CHECK (homograph_index >= 1);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_entries_homograph
+  ON entries (normalized_word, type, COALESCE(source_language_id, ''), homograph_index);
@@ -0,0 +1,6 @@
+-- Etymology and attribution for entries
+-- Imported entries record the dataset they came from and its licence
+
+ALTER TABLE entries ADD COLUMN IF NOT EXISTS etymology TEXT;
+ALTER TABLE entries ADD COLUMN IF NOT EXISTS source VARCHAR(100);
+ALTER TABLE entries ADD COLUMN IF NOT EXISTS license VARCHAR(100);
@@ -0,0 +1,4 @@
+-- Usage labels for meanings
+-- Labels such as "formal" or "medicine" are stored comma separated
+
+ALTER TABLE meanings ADD COLUMN IF NOT EXISTS labels VARCHAR(255);
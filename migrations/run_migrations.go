@@ -2,22 +2,37 @@ package migrations
 
 import (
 	"context"
+	"embed"
 	"fmt"
-	"os"
-	"path/filepath"
+	"io/fs"
 	"strings"
 
+	"github.com/valpere/trytrago/domain/database/repository"
 	"github.com/valpere/trytrago/domain/logging"
 	"github.com/valpere/trytrago/infrastructure/migration"
 )
 
-// RunMigrations executes all pending database migrations
-func RunMigrations(ctx context.Context, migrator *migration.Migrator, logger logging.Logger) error {
-	logger.Info("running database migrations")
+// Files holds the migrations compiled into the binary, one directory per
+// dialect, so deployments do not need the .sql files on disk
+//
+//go:embed postgres/*.sql mysql/*.sql sqlite/*.sql
+var Files embed.FS
 
-	// Get the migration directory path
-	migrationsDir := getMigrationsDirectory()
-	logger.Debug("migrations directory", logging.String("path", migrationsDir))
+// NewHelper creates a migration helper that reads the migrations compiled
+// into the binary
+func NewHelper(repo repository.Repository, logger logging.Logger) (*migration.Helper, error) {
+	helper, err := migration.NewHelper(repo, logger)
+	if err != nil {
+		return nil, err
+	}
+	helper.SetFS(Files)
+	return helper, nil
+}
+
+// RunMigrations executes all pending database migrations in migrationsDir,
+// a path within the file system the migrator reads from
+func RunMigrations(ctx context.Context, migrator *migration.Migrator, migrationsDir string, logger logging.Logger) error {
+	logger.Info("running database migrations")
 
 	// Ensure the migration table exists
 	if err := migrator.EnsureMigrationTable(); err != nil {
@@ -57,27 +72,6 @@ func RunMigrations(ctx context.Context, migrator *migration.Migrator, logger log
 	return nil
 }
 
-// getMigrationsDirectory returns the path to the migrations directory
-func getMigrationsDirectory() string {
-	// Check if running in development mode (current directory)
-	if _, err := os.Stat("migrations"); err == nil {
-		return "migrations"
-	}
-
-	// Try relative to executable
-	exePath, err := os.Executable()
-	if err == nil {
-		exeDir := filepath.Dir(exePath)
-		migrationPath := filepath.Join(exeDir, "migrations")
-		if _, err := os.Stat(migrationPath); err == nil {
-			return migrationPath
-		}
-	}
-
-	// Default to /app/migrations (Docker environment)
-	return "/app/migrations"
-}
-
 // countAppliedMigrations counts the number of applied migrations
 func countAppliedMigrations(status []map[string]interface{}) int {
 	count := 0
@@ -89,10 +83,11 @@ func countAppliedMigrations(status []map[string]interface{}) int {
 	return count
 }
 
-// GenerateRollbackScript generates SQL to roll back a specific migration
-func GenerateRollbackScript(version int64) (string, error) {
-	migrationsDir := getMigrationsDirectory()
-	files, err := os.ReadDir(migrationsDir)
+// GenerateRollbackScript generates SQL to roll back a specific migration of
+// the given dialect from the migration files in fsys
+func GenerateRollbackScript(fsys fs.FS, version int64, dialect string) (string, error) {
+	migrationsFS := migration.DialectFS(fsys, dialect)
+	files, err := fs.ReadDir(migrationsFS, ".")
 	if err != nil {
 		return "", fmt.Errorf("failed to read migrations directory: %w", err)
 	}
@@ -115,7 +110,7 @@ func GenerateRollbackScript(version int64) (string, error) {
 	}
 
 	// Generate a rollback script based on the migration
-	rollbackSQL, err := generateRollbackSQL(filepath.Join(migrationsDir, migrationFilename))
+	rollbackSQL, err := generateRollbackSQL(migrationsFS, migrationFilename)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate rollback SQL: %w", err)
 	}
@@ -124,8 +119,8 @@ func GenerateRollbackScript(version int64) (string, error) {
 }
 
 // generateRollbackSQL analyzes a migration file and generates rollback SQL
-func generateRollbackSQL(filename string) (string, error) {
-	content, err := os.ReadFile(filename)
+func generateRollbackSQL(fsys fs.FS, filename string) (string, error) {
+	content, err := fs.ReadFile(fsys, filename)
 	if err != nil {
 		return "", fmt.Errorf("failed to read migration file: %w", err)
 	}
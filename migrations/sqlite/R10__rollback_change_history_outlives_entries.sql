@@ -0,0 +1,24 @@
+-- R10__rollback_change_history_outlives_entries.sql
+-- Rollback script for change history that outlives entries
+
+-- History of entries that no longer exist cannot be kept under the constraint,
+-- which comes back by rebuilding the table
+CREATE TABLE change_history_rebuilt (
+    id VARCHAR(36) PRIMARY KEY,
+    entry_id VARCHAR(36) NOT NULL REFERENCES entries(id) ON DELETE CASCADE,
+    action VARCHAR(20) NOT NULL,
+    data JSON NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    user_id VARCHAR(36)
+        CONSTRAINT fk_change_history_user REFERENCES users(id) ON DELETE SET NULL
+);
+
+INSERT INTO change_history_rebuilt (id, entry_id, action, data, created_at, user_id)
+SELECT id, entry_id, action, data, created_at, user_id FROM change_history
+WHERE entry_id IN (SELECT id FROM entries);
+
+DROP TABLE change_history;
+ALTER TABLE change_history_rebuilt RENAME TO change_history;
+
+CREATE INDEX IF NOT EXISTS idx_change_history_entry_id ON change_history (entry_id);
+CREATE INDEX IF NOT EXISTS idx_change_history_created_at ON change_history (created_at DESC);
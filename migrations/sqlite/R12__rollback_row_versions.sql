@@ -0,0 +1,6 @@
+-- R12__rollback_row_versions.sql
+-- Rollback script for version counters
+
+ALTER TABLE translations DROP COLUMN version;
+ALTER TABLE meanings DROP COLUMN version;
+ALTER TABLE entries DROP COLUMN version;
@@ -0,0 +1,15 @@
+-- R2__rollback_user_tables.sql
+-- Rollback script for the user tables migration
+
+-- First remove the columns referencing users
+ALTER TABLE change_history DROP COLUMN user_id;
+ALTER TABLE translations DROP COLUMN created_by_id;
+ALTER TABLE examples DROP COLUMN created_by_id;
+ALTER TABLE meanings DROP COLUMN created_by_id;
+ALTER TABLE entries DROP COLUMN created_by_id;
+
+-- Drop user-related tables; their indexes go with them
+DROP TABLE IF EXISTS user_stats;
+DROP TABLE IF EXISTS user_preferences;
+DROP TABLE IF EXISTS auth_tokens;
+DROP TABLE IF EXISTS users;
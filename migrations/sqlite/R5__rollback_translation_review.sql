@@ -0,0 +1,16 @@
+-- R5__rollback_translation_review.sql
+-- Rollback script for the translation review workflow
+
+-- Drop notifications
+DROP TABLE IF EXISTS notifications;
+
+-- Drop indices
+DROP INDEX IF EXISTS idx_translations_proposed;
+DROP INDEX IF EXISTS idx_translations_status;
+
+-- Drop review columns; their constraints go with them
+ALTER TABLE translations DROP COLUMN review_reason;
+ALTER TABLE translations DROP COLUMN reviewed_at;
+ALTER TABLE translations DROP COLUMN reviewed_by_id;
+ALTER TABLE translations DROP COLUMN supersedes_id;
+ALTER TABLE translations DROP COLUMN status;
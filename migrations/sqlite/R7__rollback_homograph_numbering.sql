@@ -0,0 +1,6 @@
+-- R7__rollback_homograph_numbering.sql
+-- Rollback script for homograph numbering
+
+DROP INDEX IF EXISTS idx_entries_homograph;
+ALTER TABLE entries DROP COLUMN homograph_index;
+ALTER TABLE entries DROP COLUMN normalized_word;
@@ -0,0 +1,6 @@
+-- R8__rollback_entry_etymology_and_source.sql
+-- Rollback script for entry etymology and attribution
+
+ALTER TABLE entries DROP COLUMN license;
+ALTER TABLE entries DROP COLUMN source;
+ALTER TABLE entries DROP COLUMN etymology;
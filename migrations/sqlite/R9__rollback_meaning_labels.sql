@@ -0,0 +1,4 @@
+-- R9__rollback_meaning_labels.sql
+-- Rollback script for meaning usage labels
+
+ALTER TABLE meanings DROP COLUMN labels;
@@ -0,0 +1,25 @@
+-- Change history outlives the entries it describes
+-- Deletions are recorded in the history so incremental backups can replay
+-- them; the rows must not be cascaded away together with the entry
+--
+-- SQLite cannot drop a constraint, the table is rebuilt without it
+
+CREATE TABLE change_history_rebuilt (
+    id VARCHAR(36) PRIMARY KEY,
+    entry_id VARCHAR(36) NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    data JSON NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    user_id VARCHAR(36)
+        CONSTRAINT fk_change_history_user REFERENCES users(id) ON DELETE SET NULL
+);
+
+INSERT INTO change_history_rebuilt (id, entry_id, action, data, created_at, user_id)
+SELECT id, entry_id, action, data, created_at, user_id FROM change_history;
+
+DROP TABLE change_history;
+ALTER TABLE change_history_rebuilt RENAME TO change_history;
+
+CREATE INDEX IF NOT EXISTS idx_change_history_entry_id ON change_history (entry_id);
+CREATE INDEX IF NOT EXISTS idx_change_history_created_at ON change_history (created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_change_history_action ON change_history(action, created_at);
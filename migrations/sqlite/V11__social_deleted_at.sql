@@ -0,0 +1,8 @@
+-- Deletion timestamps for comments and likes
+-- The comment and like models carry deleted_at, and like counts skip rows that have it set
+
+ALTER TABLE comments ADD COLUMN deleted_at DATETIME;
+ALTER TABLE likes ADD COLUMN deleted_at DATETIME;
+
+CREATE INDEX IF NOT EXISTS idx_comments_deleted_at ON comments(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_likes_deleted_at ON likes(deleted_at);
@@ -0,0 +1,13 @@
+-- Meaning counts for meanings that move between entries
+-- Merging entries moves meanings with an update, which the counter triggers of
+-- V4 do not see. Counts left wrong by earlier merges are recomputed
+
+CREATE TRIGGER IF NOT EXISTS update_entry_meaning_count_on_move
+AFTER UPDATE OF entry_id ON meanings
+FOR EACH ROW WHEN NEW.entry_id <> OLD.entry_id
+BEGIN
+  UPDATE entries SET meaning_count = meaning_count - 1 WHERE id = OLD.entry_id;
+  UPDATE entries SET meaning_count = meaning_count + 1 WHERE id = NEW.entry_id;
+END;
+
+UPDATE entries SET meaning_count = (SELECT COUNT(*) FROM meanings WHERE meanings.entry_id = entries.id);
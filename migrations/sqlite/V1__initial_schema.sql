@@ -0,0 +1,125 @@
+-- V1__initial_schema.sql
+-- Initial schema for TryTraGo dictionary database (SQLite 3.35 or later)
+--
+-- SQLite cannot add constraints to existing tables, so foreign keys are
+-- declared with their columns: languages is created before entries, and the
+-- created_by_id and user_id columns referencing users arrive with V2
+
+-- Create parts_of_speech table
+CREATE TABLE IF NOT EXISTS parts_of_speech (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(50) NOT NULL UNIQUE,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create languages table for reference
+CREATE TABLE IF NOT EXISTS languages (
+    code VARCHAR(5) PRIMARY KEY,  -- ISO 639-1 code
+    name VARCHAR(100) NOT NULL,
+    native_name VARCHAR(100) NOT NULL,
+    rtl BOOLEAN NOT NULL DEFAULT FALSE,
+    active BOOLEAN NOT NULL DEFAULT TRUE
+);
+
+-- Create entries table
+CREATE TABLE IF NOT EXISTS entries (
+    id VARCHAR(36) PRIMARY KEY,
+    word VARCHAR(255) NOT NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('WORD', 'COMPOUND_WORD', 'PHRASE')),
+    pronunciation VARCHAR(255),
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    source_language_id VARCHAR(5)
+        CONSTRAINT fk_entries_source_language REFERENCES languages(code) ON DELETE SET NULL
+);
+
+-- Add index for word lookups (case-insensitive)
+CREATE INDEX IF NOT EXISTS idx_entries_word ON entries (LOWER(word));
+CREATE INDEX IF NOT EXISTS idx_entries_type ON entries (type);
+CREATE INDEX IF NOT EXISTS idx_entries_created_at ON entries (created_at DESC);
+
+-- Create meanings table
+CREATE TABLE IF NOT EXISTS meanings (
+    id VARCHAR(36) PRIMARY KEY,
+    entry_id VARCHAR(36) NOT NULL REFERENCES entries(id) ON DELETE CASCADE,
+    part_of_speech_id VARCHAR(36) NOT NULL REFERENCES parts_of_speech(id),
+    description TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add indexes for meanings
+CREATE INDEX IF NOT EXISTS idx_meanings_entry_id ON meanings (entry_id);
+CREATE INDEX IF NOT EXISTS idx_meanings_part_of_speech_id ON meanings (part_of_speech_id);
+
+-- Create examples table
+CREATE TABLE IF NOT EXISTS examples (
+    id VARCHAR(36) PRIMARY KEY,
+    meaning_id VARCHAR(36) NOT NULL REFERENCES meanings(id) ON DELETE CASCADE,
+    text TEXT NOT NULL,
+    context TEXT,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add index for examples
+CREATE INDEX IF NOT EXISTS idx_examples_meaning_id ON examples (meaning_id);
+
+-- Create translations table
+-- Ordinary SQLite tables have no full-text index, text searches scan
+CREATE TABLE IF NOT EXISTS translations (
+    id VARCHAR(36) PRIMARY KEY,
+    meaning_id VARCHAR(36) NOT NULL REFERENCES meanings(id) ON DELETE CASCADE,
+    language_id VARCHAR(5) NOT NULL,  -- ISO 639-1 code
+    text TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add indexes for translations
+CREATE INDEX IF NOT EXISTS idx_translations_meaning_id ON translations (meaning_id);
+CREATE INDEX IF NOT EXISTS idx_translations_language_id ON translations (language_id);
+
+-- Create change_history table
+CREATE TABLE IF NOT EXISTS change_history (
+    id VARCHAR(36) PRIMARY KEY,
+    entry_id VARCHAR(36) NOT NULL REFERENCES entries(id) ON DELETE CASCADE,
+    action VARCHAR(20) NOT NULL,
+    data JSON NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add index for change history
+CREATE INDEX IF NOT EXISTS idx_change_history_entry_id ON change_history (entry_id);
+CREATE INDEX IF NOT EXISTS idx_change_history_created_at ON change_history (created_at DESC);
+
+-- Insert default parts of speech with random version 4 UUIDs
+INSERT OR IGNORE INTO parts_of_speech (id, name, created_at, updated_at)
+SELECT
+    lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' || substr(hex(randomblob(2)), 2) || '-' || substr('89AB', 1 + (abs(random()) % 4), 1) || substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6))),
+    column1, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+FROM (VALUES
+    ('noun'), ('verb'), ('adjective'), ('adverb'), ('pronoun'), ('preposition'),
+    ('conjunction'), ('interjection'), ('article'), ('numeral'), ('determiner'), ('particle')
+);
+
+-- Insert default languages
+INSERT OR IGNORE INTO languages (code, name, native_name, rtl, active) VALUES
+    ('en', 'English', 'English', FALSE, TRUE),
+    ('es', 'Spanish', 'Español', FALSE, TRUE),
+    ('fr', 'French', 'Français', FALSE, TRUE),
+    ('de', 'German', 'Deutsch', FALSE, TRUE),
+    ('it', 'Italian', 'Italiano', FALSE, TRUE),
+    ('pt', 'Portuguese', 'Português', FALSE, TRUE),
+    ('ru', 'Russian', 'Русский', FALSE, TRUE),
+    ('zh', 'Chinese', '中文', FALSE, TRUE),
+    ('ja', 'Japanese', '日本語', FALSE, TRUE),
+    ('ko', 'Korean', '한국어', FALSE, TRUE),
+    ('ar', 'Arabic', 'العربية', TRUE, TRUE),
+    ('hi', 'Hindi', 'हिन्दी', FALSE, TRUE),
+    ('tr', 'Turkish', 'Türkçe', FALSE, TRUE),
+    ('nl', 'Dutch', 'Nederlands', FALSE, TRUE),
+    ('sv', 'Swedish', 'Svenska', FALSE, TRUE),
+    ('pl', 'Polish', 'Polski', FALSE, TRUE),
+    ('uk', 'Ukrainian', 'Українська', FALSE, TRUE);
@@ -0,0 +1,108 @@
+-- V2__add_user_tables.sql
+-- User management tables for TryTraGo dictionary database
+
+-- Create users table
+CREATE TABLE IF NOT EXISTS users (
+    id VARCHAR(36) PRIMARY KEY,
+    username VARCHAR(50) NOT NULL UNIQUE,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    password VARCHAR(255) NOT NULL, -- Stored as bcrypt hash
+    avatar VARCHAR(255),
+    role VARCHAR(20) NOT NULL DEFAULT 'USER',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_login DATETIME
+);
+
+-- Add indexes for users
+CREATE INDEX IF NOT EXISTS idx_users_username ON users (LOWER(username));
+CREATE INDEX IF NOT EXISTS idx_users_email ON users (LOWER(email));
+CREATE INDEX IF NOT EXISTS idx_users_role ON users (role);
+
+-- Create auth_tokens table
+CREATE TABLE IF NOT EXISTS auth_tokens (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    access_token VARCHAR(500) NOT NULL,
+    refresh_token VARCHAR(500) NOT NULL,
+    expires_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    revoked_at DATETIME,
+    user_agent VARCHAR(255),
+    client_ip VARCHAR(45)
+);
+
+-- Add indexes for auth_tokens
+CREATE INDEX IF NOT EXISTS idx_auth_tokens_user_id ON auth_tokens (user_id);
+CREATE INDEX IF NOT EXISTS idx_auth_tokens_refresh_token ON auth_tokens (refresh_token);
+CREATE INDEX IF NOT EXISTS idx_auth_tokens_expires_at ON auth_tokens (expires_at);
+
+-- Create user_preferences table
+CREATE TABLE IF NOT EXISTS user_preferences (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    default_language VARCHAR(5) NOT NULL DEFAULT 'en' REFERENCES languages(code),
+    theme_preference VARCHAR(20) NOT NULL DEFAULT 'system',
+    email_notify BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create user_stats table
+CREATE TABLE IF NOT EXISTS user_stats (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    entries_created INT NOT NULL DEFAULT 0,
+    entries_updated INT NOT NULL DEFAULT 0,
+    meanings_added INT NOT NULL DEFAULT 0,
+    translations_added INT NOT NULL DEFAULT 0,
+    comments_posted INT NOT NULL DEFAULT 0,
+    likes_given INT NOT NULL DEFAULT 0,
+    reputation_points INT NOT NULL DEFAULT 0,
+    last_activity_at DATETIME,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add the columns referencing users to existing tables
+ALTER TABLE entries ADD COLUMN created_by_id VARCHAR(36)
+    CONSTRAINT fk_entries_created_by REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE meanings ADD COLUMN created_by_id VARCHAR(36)
+    CONSTRAINT fk_meanings_created_by REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE examples ADD COLUMN created_by_id VARCHAR(36)
+    CONSTRAINT fk_examples_created_by REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE translations ADD COLUMN created_by_id VARCHAR(36)
+    CONSTRAINT fk_translations_created_by REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE change_history ADD COLUMN user_id VARCHAR(36)
+    CONSTRAINT fk_change_history_user REFERENCES users(id) ON DELETE SET NULL;
+
+-- Create admin user (password: admin123) and default user (password: password123)
+INSERT OR IGNORE INTO users (
+    id,
+    username,
+    email,
+    password,
+    avatar,
+    role,
+    is_active,
+    created_at,
+    updated_at
+)
+SELECT
+    lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' || substr(hex(randomblob(2)), 2) || '-' || substr('89AB', 1 + (abs(random()) % 4), 1) || substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6))),
+    column1, column2,
+    '$2a$10$dBR5d8VTLjQvQOPiwbHCzuQUEVLvtvVSbG2pJUT3c4DHmfVCJNpou', -- hashed with bcrypt
+    '',
+    column3,
+    TRUE,
+    CURRENT_TIMESTAMP,
+    CURRENT_TIMESTAMP
+FROM (VALUES
+    ('admin', 'admin@trytrago.com', 'ADMIN'),
+    ('user', 'user@trytrago.com', 'USER')
+);
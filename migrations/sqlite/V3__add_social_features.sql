@@ -0,0 +1,58 @@
+-- Add social features to the dictionary
+-- Comments, likes, and user interactions
+
+-- Comments table
+CREATE TABLE IF NOT EXISTS comments (
+  id VARCHAR(36) PRIMARY KEY DEFAULT (lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' || substr(hex(randomblob(2)), 2) || '-' || substr('89AB', 1 + (abs(random()) % 4), 1) || substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6)))),
+  user_id VARCHAR(36) NOT NULL
+    CONSTRAINT fk_comments_user REFERENCES users(id) ON DELETE CASCADE,
+  target_id VARCHAR(36) NOT NULL,
+  target_type VARCHAR(20) NOT NULL,
+  content TEXT NOT NULL,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes for comments
+CREATE INDEX IF NOT EXISTS idx_comments_user_id ON comments(user_id);
+CREATE INDEX IF NOT EXISTS idx_comments_target_id ON comments(target_id);
+
+-- Likes table
+CREATE TABLE IF NOT EXISTS likes (
+  id VARCHAR(36) PRIMARY KEY DEFAULT (lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' || substr(hex(randomblob(2)), 2) || '-' || substr('89AB', 1 + (abs(random()) % 4), 1) || substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6)))),
+  user_id VARCHAR(36) NOT NULL
+    CONSTRAINT fk_likes_user REFERENCES users(id) ON DELETE CASCADE,
+  target_id VARCHAR(36) NOT NULL,
+  target_type VARCHAR(20) NOT NULL,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes for likes
+CREATE INDEX IF NOT EXISTS idx_likes_user_id ON likes(user_id);
+CREATE INDEX IF NOT EXISTS idx_likes_target_id ON likes(target_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_likes_user_target ON likes(user_id, target_id, target_type);
+
+-- Update existing tables to support social features
+ALTER TABLE meanings ADD COLUMN likes_count INTEGER DEFAULT 0;
+ALTER TABLE translations ADD COLUMN likes_count INTEGER DEFAULT 0;
+
+-- Keep likes counts up to date; SQLite triggers fire on a single event
+CREATE TRIGGER IF NOT EXISTS update_likes_count_on_insert
+AFTER INSERT ON likes
+FOR EACH ROW
+BEGIN
+  UPDATE meanings SET likes_count = likes_count + 1
+  WHERE NEW.target_type = 'meaning' AND id = NEW.target_id;
+  UPDATE translations SET likes_count = likes_count + 1
+  WHERE NEW.target_type = 'translation' AND id = NEW.target_id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS update_likes_count_on_delete
+AFTER DELETE ON likes
+FOR EACH ROW
+BEGIN
+  UPDATE meanings SET likes_count = likes_count - 1
+  WHERE OLD.target_type = 'meaning' AND id = OLD.target_id;
+  UPDATE translations SET likes_count = likes_count - 1
+  WHERE OLD.target_type = 'translation' AND id = OLD.target_id;
+END;
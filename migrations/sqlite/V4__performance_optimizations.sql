@@ -0,0 +1,91 @@
+-- Performance optimization migration
+
+-- Add active column to entries
+ALTER TABLE entries ADD COLUMN active BOOLEAN DEFAULT TRUE;
+
+-- Add indexes for faster lookup
+CREATE INDEX IF NOT EXISTS idx_entries_word ON entries(word);
+CREATE INDEX IF NOT EXISTS idx_entries_word_type ON entries(word, type);
+CREATE INDEX IF NOT EXISTS idx_meanings_entry_id ON meanings(entry_id);
+CREATE INDEX IF NOT EXISTS idx_translations_meaning_id ON translations(meaning_id);
+CREATE INDEX IF NOT EXISTS idx_translations_language_id ON translations(language_id);
+CREATE INDEX IF NOT EXISTS idx_comments_target_id ON comments(target_id);
+CREATE INDEX IF NOT EXISTS idx_likes_target_id ON likes(target_id);
+
+-- Add partial indexes for common queries
+CREATE INDEX IF NOT EXISTS idx_entries_active ON entries(created_at) WHERE active = TRUE;
+
+-- Add column for caching counts
+ALTER TABLE entries ADD COLUMN meaning_count INTEGER DEFAULT 0;
+ALTER TABLE meanings ADD COLUMN example_count INTEGER DEFAULT 0;
+ALTER TABLE meanings ADD COLUMN translation_count INTEGER DEFAULT 0;
+ALTER TABLE meanings ADD COLUMN comments_count INTEGER DEFAULT 0;
+ALTER TABLE translations ADD COLUMN comments_count INTEGER DEFAULT 0;
+
+-- Keep meaning counts up to date
+CREATE TRIGGER IF NOT EXISTS update_entry_meaning_count_on_insert
+AFTER INSERT ON meanings
+FOR EACH ROW
+BEGIN
+  UPDATE entries SET meaning_count = meaning_count + 1 WHERE id = NEW.entry_id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS update_entry_meaning_count_on_delete
+AFTER DELETE ON meanings
+FOR EACH ROW
+BEGIN
+  UPDATE entries SET meaning_count = meaning_count - 1 WHERE id = OLD.entry_id;
+END;
+
+-- Keep example counts up to date
+CREATE TRIGGER IF NOT EXISTS update_meaning_example_count_on_insert
+AFTER INSERT ON examples
+FOR EACH ROW
+BEGIN
+  UPDATE meanings SET example_count = example_count + 1 WHERE id = NEW.meaning_id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS update_meaning_example_count_on_delete
+AFTER DELETE ON examples
+FOR EACH ROW
+BEGIN
+  UPDATE meanings SET example_count = example_count - 1 WHERE id = OLD.meaning_id;
+END;
+
+-- Keep translation counts up to date
+CREATE TRIGGER IF NOT EXISTS update_meaning_translation_count_on_insert
+AFTER INSERT ON translations
+FOR EACH ROW
+BEGIN
+  UPDATE meanings SET translation_count = translation_count + 1 WHERE id = NEW.meaning_id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS update_meaning_translation_count_on_delete
+AFTER DELETE ON translations
+FOR EACH ROW
+BEGIN
+  UPDATE meanings SET translation_count = translation_count - 1 WHERE id = OLD.meaning_id;
+END;
+
+-- Add updated_at column triggers. SQLite cannot assign to NEW, so the row is
+-- touched again after the update; recursive triggers are off by default
+CREATE TRIGGER IF NOT EXISTS update_entries_modified
+AFTER UPDATE ON entries
+FOR EACH ROW
+BEGIN
+  UPDATE entries SET updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now') WHERE id = NEW.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS update_meanings_modified
+AFTER UPDATE ON meanings
+FOR EACH ROW
+BEGIN
+  UPDATE meanings SET updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now') WHERE id = NEW.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS update_translations_modified
+AFTER UPDATE ON translations
+FOR EACH ROW
+BEGIN
+  UPDATE translations SET updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now') WHERE id = NEW.id;
+END;
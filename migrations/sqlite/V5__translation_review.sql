@@ -0,0 +1,32 @@
+-- Translation review workflow
+-- Contributed translations are proposed, then approved or rejected by a reviewer
+
+-- Review state on translations; existing rows are treated as approved
+ALTER TABLE translations ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'APPROVED'
+  CONSTRAINT chk_translations_status CHECK (status IN ('PROPOSED', 'APPROVED', 'REJECTED', 'SUPERSEDED'));
+ALTER TABLE translations ADD COLUMN supersedes_id VARCHAR(36)
+  CONSTRAINT fk_translations_supersedes REFERENCES translations(id) ON DELETE SET NULL;
+ALTER TABLE translations ADD COLUMN reviewed_by_id VARCHAR(36)
+  CONSTRAINT fk_translations_reviewed_by REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE translations ADD COLUMN reviewed_at DATETIME;
+ALTER TABLE translations ADD COLUMN review_reason TEXT;
+
+-- The review queue is read oldest first
+CREATE INDEX IF NOT EXISTS idx_translations_status ON translations(status);
+CREATE INDEX IF NOT EXISTS idx_translations_proposed ON translations(created_at) WHERE status = 'PROPOSED';
+
+-- Notifications about review outcomes
+CREATE TABLE IF NOT EXISTS notifications (
+  id VARCHAR(36) PRIMARY KEY DEFAULT (lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' || substr(hex(randomblob(2)), 2) || '-' || substr('89AB', 1 + (abs(random()) % 4), 1) || substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6)))),
+  user_id VARCHAR(36) NOT NULL
+    CONSTRAINT fk_notifications_user REFERENCES users(id) ON DELETE CASCADE,
+  type VARCHAR(50) NOT NULL,
+  target_type VARCHAR(20) NOT NULL,
+  target_id VARCHAR(36) NOT NULL,
+  message TEXT,
+  read_at DATETIME,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id ON notifications(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_notifications_target_id ON notifications(target_id);
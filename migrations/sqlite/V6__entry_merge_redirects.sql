@@ -0,0 +1,11 @@
+-- Duplicate entry merging
+-- Merged entries leave a redirect so their old IDs keep resolving
+
+CREATE TABLE IF NOT EXISTS entry_redirects (
+  from_id VARCHAR(36) PRIMARY KEY,
+  to_id VARCHAR(36) NOT NULL
+    CONSTRAINT fk_entry_redirects_to REFERENCES entries(id) ON DELETE CASCADE,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_entry_redirects_to_id ON entry_redirects(to_id);
@@ -0,0 +1,26 @@
+-- Homograph numbering
+-- Entries sharing a normalized word, type and source language are told apart by a 1-based index
+
+ALTER TABLE entries ADD COLUMN normalized_word VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE entries ADD COLUMN homograph_index INTEGER NOT NULL DEFAULT 1
+  CONSTRAINT chk_entries_homograph_index CHECK (homograph_index >= 1);
+
+-- As close to the application's normalization as SQLite gets: lower case and
+-- trimmed. There is no NFKC or whitespace collapsing, and LOWER only folds
+-- ASCII; the application renormalizes words it saves
+UPDATE entries SET normalized_word = LOWER(TRIM(word));
+
+-- Existing entries are numbered in creation order
+UPDATE entries
+SET homograph_index = numbered.position
+FROM (
+  SELECT id, ROW_NUMBER() OVER (
+    PARTITION BY normalized_word, type, COALESCE(source_language_id, '')
+    ORDER BY created_at, id
+  ) AS position
+  FROM entries
+) numbered
+WHERE entries.id = numbered.id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_entries_homograph
+  ON entries (normalized_word, type, COALESCE(source_language_id, ''), homograph_index);
@@ -0,0 +1,6 @@
+-- Etymology and attribution for entries
+-- Imported entries record the dataset they came from and its licence
+
+ALTER TABLE entries ADD COLUMN etymology TEXT;
+ALTER TABLE entries ADD COLUMN source VARCHAR(100);
+ALTER TABLE entries ADD COLUMN license VARCHAR(100);
@@ -55,8 +55,8 @@ func (m *MockEntryService) UpdateEntry(ctx context.Context, id uuid.UUID, req *r
 	return args.Get(0).(*response.EntryResponse), args.Error(1)
 }
 
-func (m *MockEntryService) DeleteEntry(ctx context.Context, id uuid.UUID) error {
-	args := m.Called(ctx, id)
+func (m *MockEntryService) DeleteEntry(ctx context.Context, id uuid.UUID, version int) error {
+	args := m.Called(ctx, id, version)
 	return args.Error(0)
 }
 
@@ -84,8 +84,8 @@ func (m *MockEntryService) UpdateMeaning(ctx context.Context, id uuid.UUID, req
 	return args.Get(0).(*response.MeaningResponse), args.Error(1)
 }
 
-func (m *MockEntryService) DeleteMeaning(ctx context.Context, id uuid.UUID) error {
-	args := m.Called(ctx, id)
+func (m *MockEntryService) DeleteMeaning(ctx context.Context, id uuid.UUID, version int) error {
+	args := m.Called(ctx, id, version)
 	return args.Error(0)
 }
 
@@ -130,8 +130,8 @@ func (m *MockTranslationService) UpdateTranslation(ctx context.Context, id uuid.
 	return args.Get(0).(*response.TranslationResponse), args.Error(1)
 }
 
-func (m *MockTranslationService) DeleteTranslation(ctx context.Context, id uuid.UUID) error {
-	args := m.Called(ctx, id)
+func (m *MockTranslationService) DeleteTranslation(ctx context.Context, id uuid.UUID, version int) error {
+	args := m.Called(ctx, id, version)
 	return args.Error(0)
 }
 
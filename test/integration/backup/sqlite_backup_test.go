@@ -0,0 +1,234 @@
+package backup_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valpere/trytrago/domain/database"
+	"github.com/valpere/trytrago/domain/database/repository"
+	"github.com/valpere/trytrago/domain/database/repository/sqlite"
+	"github.com/valpere/trytrago/domain/logging"
+	"github.com/valpere/trytrago/infrastructure/backup"
+)
+
+// newSQLiteRepository opens an empty SQLite database with the dictionary schema
+func newSQLiteRepository(t *testing.T, name string) repository.Repository {
+	ctx := context.Background()
+	repo, err := sqlite.NewRepository(ctx, repository.Options{
+		Driver:   "sqlite",
+		Database: filepath.Join(t.TempDir(), name),
+	})
+	require.NoError(t, err, "Failed to create repository")
+	t.Cleanup(func() { repo.Close() })
+
+	db, err := repo.GetDB()
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(
+		&database.PartOfSpeech{},
+		&database.Entry{},
+		&database.Meaning{},
+		&database.Example{},
+		&database.Translation{},
+		&database.ChangeHistory{},
+		&database.EntryRedirect{},
+	), "Failed to create database schema")
+
+	return repo
+}
+
+// newEntry builds an entry with one meaning and one translation
+func newEntry(word string, pos uuid.UUID) *database.Entry {
+	return &database.Entry{
+		Word:             word,
+		Type:             database.WordType,
+		SourceLanguageID: "en",
+		Meanings: []database.Meaning{{
+			PartOfSpeechId: pos,
+			Description:    word + " meaning",
+			Examples:       []database.Example{{Text: "a " + word}},
+			Translations:   []database.Translation{{LanguageID: "fr", Text: word + " (fr)"}},
+		}},
+	}
+}
+
+func TestSQLiteIncrementalBackup(t *testing.T) {
+	if os.Getenv("INTEGRATION_TEST") != "true" {
+		t.Skip("Skipping integration tests. Set INTEGRATION_TEST=true to run")
+	}
+
+	ctx := context.Background()
+	opts := logging.NewDefaultOptions()
+	opts.Level = logging.WarnLevel
+	logger, err := logging.NewLogger(opts)
+	require.NoError(t, err)
+
+	source := newSQLiteRepository(t, "source.db")
+	manager := backup.NewManager(source, logger)
+
+	noun, err := source.GetOrCreatePartOfSpeech(ctx, "noun")
+	require.NoError(t, err)
+	kept, deleted, merged := newEntry("kept", noun.ID), newEntry("deleted", noun.ID), newEntry("merged", noun.ID)
+	for _, entry := range []*database.Entry{kept, deleted, merged} {
+		require.NoError(t, source.CreateEntry(ctx, entry))
+	}
+
+	full, err := manager.Create(ctx, backup.Options{})
+	require.NoError(t, err)
+	assert.Equal(t, backup.TypeFull, full.Manifest.Type)
+	assert.Len(t, full.Entries, 3)
+	assert.Len(t, full.Translations, 3)
+
+	// Timestamps of later changes must not collide with the backup
+	time.Sleep(10 * time.Millisecond)
+
+	kept.Pronunciation = "kɛpt"
+	require.NoError(t, source.UpdateEntry(ctx, kept))
+	added := newEntry("added", noun.ID)
+	require.NoError(t, source.CreateEntry(ctx, added))
+	require.NoError(t, source.DeleteEntry(ctx, deleted.ID, 0))
+	require.NoError(t, source.MergeEntries(ctx, merged.ID, kept.ID, uuid.New()))
+
+	incremental, err := manager.Create(ctx, backup.Options{Since: &full.Manifest.CreatedAt, ParentID: full.Manifest.ID})
+	require.NoError(t, err)
+	assert.Equal(t, backup.TypeIncremental, incremental.Manifest.Type)
+	assert.Equal(t, full.Manifest.ID, incremental.Manifest.ParentID)
+	require.Len(t, incremental.Examples, 2)
+	for _, example := range incremental.Examples {
+		assert.NotEqual(t, merged.Meanings[0].Examples[0].ID, example.ID, "Unchanged rows are left out")
+	}
+	assert.ElementsMatch(t, []uuid.UUID{kept.ID, added.ID}, []uuid.UUID{incremental.Entries[0].ID, incremental.Entries[1].ID})
+	require.Len(t, incremental.Deletions, 2)
+	assert.Equal(t, deleted.ID, incremental.Deletions[0].EntryID)
+	assert.Nil(t, incremental.Deletions[0].MergedInto)
+	assert.Equal(t, merged.ID, incremental.Deletions[1].EntryID)
+	assert.Equal(t, kept.ID, *incremental.Deletions[1].MergedInto)
+
+	// Round trip through files and restore the chain into an empty database
+	dir := t.TempDir()
+	require.NoError(t, backup.WriteFile(filepath.Join(dir, "full.json"), full, false))
+	require.NoError(t, backup.WriteFile(filepath.Join(dir, "incremental.json.gz"), incremental, true))
+	var files []*backup.Backup
+	for _, name := range []string{"incremental.json.gz", "full.json"} {
+		b, err := backup.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		files = append(files, b)
+	}
+	chain, err := backup.Chain(files)
+	require.NoError(t, err)
+
+	target := newSQLiteRepository(t, "target.db")
+	targetNoun, err := target.GetOrCreatePartOfSpeech(ctx, "noun")
+	require.NoError(t, err)
+	require.NoError(t, backup.NewManager(target, logger).Restore(ctx, chain))
+
+	restored, err := target.GetEntryByID(ctx, kept.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "kɛpt", restored.Pronunciation)
+	require.Len(t, restored.Meanings, 2, "The merged meaning moved over")
+	for _, meaning := range restored.Meanings {
+		assert.Equal(t, targetNoun.ID, meaning.PartOfSpeechId, "Parts of speech are matched by name")
+		assert.Len(t, meaning.Examples, 1)
+		assert.Len(t, meaning.Translations, 1)
+	}
+
+	_, err = target.GetEntryByID(ctx, added.ID)
+	assert.NoError(t, err)
+	_, err = target.GetEntryByID(ctx, deleted.ID)
+	assert.ErrorIs(t, err, database.ErrEntryNotFound)
+	_, err = target.GetEntryByID(ctx, merged.ID)
+	assert.ErrorIs(t, err, database.ErrEntryNotFound)
+
+	redirect, err := target.GetEntryRedirect(ctx, merged.ID)
+	require.NoError(t, err)
+	assert.Equal(t, kept.ID, redirect.ToID)
+
+	// Replaying the chain again changes nothing
+	require.NoError(t, backup.NewManager(target, logger).Restore(ctx, chain))
+	restored, err = target.GetEntryByID(ctx, kept.ID)
+	require.NoError(t, err)
+	assert.Len(t, restored.Meanings, 2)
+}
+
+func TestSQLiteIncrementalBackupChildDeletions(t *testing.T) {
+	if os.Getenv("INTEGRATION_TEST") != "true" {
+		t.Skip("Skipping integration tests. Set INTEGRATION_TEST=true to run")
+	}
+
+	ctx := context.Background()
+	opts := logging.NewDefaultOptions()
+	opts.Level = logging.WarnLevel
+	logger, err := logging.NewLogger(opts)
+	require.NoError(t, err)
+
+	source := newSQLiteRepository(t, "source.db")
+	manager := backup.NewManager(source, logger)
+
+	noun, err := source.GetOrCreatePartOfSpeech(ctx, "noun")
+	require.NoError(t, err)
+	entry := newEntry("bank", noun.ID)
+	entry.Meanings = append(entry.Meanings, newEntry("shore", noun.ID).Meanings...)
+	entry.Meanings[0].Examples = append(entry.Meanings[0].Examples, database.Example{Text: "a second bank"})
+	entry.Meanings[0].Translations = append(entry.Meanings[0].Translations, database.Translation{LanguageID: "de", Text: "Bank"})
+	pruned := newEntry("pruned", noun.ID)
+	for _, e := range []*database.Entry{entry, pruned} {
+		require.NoError(t, source.CreateEntry(ctx, e))
+	}
+
+	full, err := manager.Create(ctx, backup.Options{})
+	require.NoError(t, err)
+	time.Sleep(10 * time.Millisecond)
+
+	// Delete one of each kind of child through every path that removes them
+	kept := entry.Meanings[0]
+	require.NoError(t, source.DeleteTranslation(ctx, kept.Translations[1].ID, 0))
+	kept.Examples = kept.Examples[:1]
+	kept.Translations = nil
+	kept.Version = 0
+	require.NoError(t, source.UpdateMeaning(ctx, &kept))
+	require.NoError(t, source.DeleteMeaning(ctx, entry.Meanings[1].ID, 0))
+	pruned.Meanings = nil
+	pruned.Version = 0
+	require.NoError(t, source.ReplaceEntry(ctx, pruned))
+
+	incremental, err := manager.Create(ctx, backup.Options{Since: &full.Manifest.CreatedAt, ParentID: full.Manifest.ID})
+	require.NoError(t, err)
+	assert.Empty(t, incremental.Deletions)
+	require.Len(t, incremental.Removals, 4)
+	assert.Equal(t, []uuid.UUID{entry.Meanings[0].Translations[1].ID}, incremental.Removals[0].TranslationIDs)
+	assert.Equal(t, []uuid.UUID{entry.Meanings[0].Examples[1].ID}, incremental.Removals[1].ExampleIDs)
+	assert.Equal(t, []uuid.UUID{entry.Meanings[1].ID}, incremental.Removals[2].MeaningIDs)
+	assert.Equal(t, pruned.ID, incremental.Removals[3].EntryID)
+
+	target := newSQLiteRepository(t, "target.db")
+	_, err = target.GetOrCreatePartOfSpeech(ctx, "noun")
+	require.NoError(t, err)
+	chain, err := backup.Chain([]*backup.Backup{full, incremental})
+	require.NoError(t, err)
+	require.NoError(t, backup.NewManager(target, logger).Restore(ctx, chain))
+
+	restored, err := target.GetEntryByID(ctx, entry.ID)
+	require.NoError(t, err)
+	require.Len(t, restored.Meanings, 1, "The deleted meaning stays deleted")
+	assert.Equal(t, kept.ID, restored.Meanings[0].ID)
+	require.Len(t, restored.Meanings[0].Examples, 1)
+	assert.Equal(t, kept.Examples[0].ID, restored.Meanings[0].Examples[0].ID)
+	require.Len(t, restored.Meanings[0].Translations, 1)
+	assert.Equal(t, entry.Meanings[0].Translations[0].ID, restored.Meanings[0].Translations[0].ID)
+
+	restored, err = target.GetEntryByID(ctx, pruned.ID)
+	require.NoError(t, err)
+	assert.Empty(t, restored.Meanings)
+
+	db, err := target.GetDB()
+	require.NoError(t, err)
+	var orphans int64
+	require.NoError(t, db.Model(&database.Example{}).Where("meaning_id = ?", entry.Meanings[1].ID).Count(&orphans).Error)
+	assert.Zero(t, orphans, "Children of the deleted meaning go with it")
+}
@@ -0,0 +1,121 @@
+package migration_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valpere/trytrago/domain/database/repository"
+	"github.com/valpere/trytrago/domain/database/repository/sqlite"
+	"github.com/valpere/trytrago/domain/logging"
+	"github.com/valpere/trytrago/infrastructure/migration"
+)
+
+// writeMigration writes a migration file into dir
+func writeMigration(t *testing.T, dir, name, sql string) {
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(sql), 0o644))
+}
+
+func TestSQLiteMigrationChecksums(t *testing.T) {
+	if os.Getenv("INTEGRATION_TEST") != "true" {
+		t.Skip("Skipping integration tests. Set INTEGRATION_TEST=true to run")
+	}
+
+	ctx := context.Background()
+	logOpts := logging.NewDefaultOptions()
+	logOpts.Level = logging.WarnLevel
+	logger, err := logging.NewLogger(logOpts)
+	require.NoError(t, err)
+
+	repo, err := sqlite.NewRepository(ctx, repository.Options{
+		Driver:   "sqlite",
+		Database: filepath.Join(t.TempDir(), "migrate.db"),
+	})
+	require.NoError(t, err, "Failed to create repository")
+	defer repo.Close()
+
+	dir := t.TempDir()
+	writeMigration(t, dir, "V1__create_words.sql", "CREATE TABLE words (id INTEGER PRIMARY KEY, word TEXT);")
+	writeMigration(t, dir, "V3__create_notes.sql", "CREATE TABLE notes (id INTEGER PRIMARY KEY, note TEXT);")
+
+	migrator := migration.NewMigrator(repo, logger)
+	require.NoError(t, migrator.Migrate(ctx, dir))
+
+	applied, err := migrator.GetAppliedMigrations()
+	require.NoError(t, err)
+	require.Len(t, applied, 2)
+	assert.Len(t, applied[0].Checksum, 64)
+
+	report, err := migrator.Verify(ctx, dir)
+	require.NoError(t, err)
+	assert.True(t, report.OK())
+	assert.Equal(t, 2, report.Applied)
+
+	// Edit an applied migration, remove another and add one below them
+	writeMigration(t, dir, "V1__create_words.sql", "CREATE TABLE words (id INTEGER PRIMARY KEY, word TEXT, lang TEXT);")
+	require.NoError(t, os.Remove(filepath.Join(dir, "V3__create_notes.sql")))
+	writeMigration(t, dir, "V2__create_tags.sql", "CREATE TABLE tags (id INTEGER PRIMARY KEY);")
+
+	report, err = migrator.Verify(ctx, dir)
+	require.NoError(t, err)
+	assert.False(t, report.OK())
+	require.Len(t, report.Modified, 1)
+	assert.Equal(t, int64(1), report.Modified[0].Version)
+	assert.Equal(t, applied[0].Checksum, report.Modified[0].AppliedChecksum)
+	require.Len(t, report.Missing, 1)
+	assert.Equal(t, int64(3), report.Missing[0].Version)
+	require.Len(t, report.OutOfOrder, 1)
+	assert.Equal(t, int64(2), report.OutOfOrder[0].Version)
+
+	// Refused by default, nothing is applied
+	err = migrator.Migrate(ctx, dir)
+	require.ErrorIs(t, err, migration.ErrChecksumMismatch)
+	db, err := repo.GetDB()
+	require.NoError(t, err)
+	assert.False(t, db.Migrator().HasTable("tags"))
+
+	// Warnings only
+	migrator.SetChecksumMode(migration.ChecksumWarn)
+	require.NoError(t, migrator.Migrate(ctx, dir))
+	assert.True(t, db.Migrator().HasTable("tags"))
+
+	// Migrations applied before checksums were kept adopt their file's checksum
+	require.NoError(t, db.Model(&migration.MigrationRecord{}).Where("version = ?", 2).Update("checksum", "").Error)
+	report, err = migrator.Verify(ctx, dir)
+	require.NoError(t, err)
+	require.Len(t, report.Unrecorded, 1)
+	require.NoError(t, migrator.Migrate(ctx, dir))
+	report, err = migrator.Verify(ctx, dir)
+	require.NoError(t, err)
+	assert.Empty(t, report.Unrecorded)
+}
+
+func TestSQLiteMigrationTableUpgrade(t *testing.T) {
+	if os.Getenv("INTEGRATION_TEST") != "true" {
+		t.Skip("Skipping integration tests. Set INTEGRATION_TEST=true to run")
+	}
+
+	ctx := context.Background()
+	logger, err := logging.NewLogger(logging.NewDefaultOptions())
+	require.NoError(t, err)
+
+	repo, err := sqlite.NewRepository(ctx, repository.Options{
+		Driver:   "sqlite",
+		Database: filepath.Join(t.TempDir(), "legacy.db"),
+	})
+	require.NoError(t, err, "Failed to create repository")
+	defer repo.Close()
+
+	// A migrations table from before checksums were kept
+	db, err := repo.GetDB()
+	require.NoError(t, err)
+	require.NoError(t, db.Exec("CREATE TABLE migration_records (version INTEGER PRIMARY KEY, description TEXT, applied_at DATETIME)").Error)
+
+	migrator := migration.NewMigrator(repo, logger)
+	require.NoError(t, migrator.EnsureMigrationTable())
+	assert.True(t, db.Migrator().HasColumn(&migration.MigrationRecord{}, "Checksum"))
+}
@@ -0,0 +1,231 @@
+package migration_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valpere/trytrago/domain/database"
+	"github.com/valpere/trytrago/domain/database/repository"
+	"github.com/valpere/trytrago/domain/database/repository/sqlite"
+	"github.com/valpere/trytrago/domain/logging"
+	"github.com/valpere/trytrago/infrastructure/migration"
+)
+
+// migrationsDir is the repository's migrations directory
+const migrationsDir = "../../../migrations"
+
+func TestSQLiteDialectMigrations(t *testing.T) {
+	if os.Getenv("INTEGRATION_TEST") != "true" {
+		t.Skip("Skipping integration tests. Set INTEGRATION_TEST=true to run")
+	}
+
+	ctx := context.Background()
+	logOpts := logging.NewDefaultOptions()
+	logOpts.Level = logging.WarnLevel
+	logger, err := logging.NewLogger(logOpts)
+	require.NoError(t, err)
+
+	repo, err := sqlite.NewRepository(ctx, repository.Options{
+		Driver:   "sqlite",
+		Database: filepath.Join(t.TempDir(), "dialect.db"),
+	})
+	require.NoError(t, err, "Failed to create repository")
+	defer repo.Close()
+
+	migrator := migration.NewMigrator(repo, logger)
+	assert.Equal(t, "sqlite", migrator.Dialect())
+	assert.Equal(t, filepath.Join(migrationsDir, "sqlite"), migration.DialectDir(migrationsDir, migrator.Dialect()))
+	assert.Equal(t, migrationsDir, migration.DialectDir(migrationsDir, "oracle"), "Falls back to the directory itself")
+
+	require.NoError(t, migrator.Migrate(ctx, migrationsDir))
+	report, err := migrator.Verify(ctx, migrationsDir)
+	require.NoError(t, err)
+	assert.True(t, report.OK())
+	assert.Zero(t, report.Pending)
+
+	// Seeded reference data
+	noun, err := repo.GetOrCreatePartOfSpeech(ctx, "noun")
+	require.NoError(t, err)
+	db, err := repo.GetDB()
+	require.NoError(t, err)
+	var languages int64
+	require.NoError(t, db.Table("languages").Count(&languages).Error)
+	assert.Equal(t, int64(17), languages)
+
+	// Counters are kept by triggers
+	entry := &database.Entry{
+		Word:             "bank",
+		Type:             database.WordType,
+		SourceLanguageID: "en",
+		Meanings: []database.Meaning{{
+			PartOfSpeechId: noun.ID,
+			Description:    "a financial institution",
+			Examples:       []database.Example{{Text: "the bank opens at nine"}},
+			Translations: []database.Translation{
+				{LanguageID: "fr", Text: "banque"},
+				{LanguageID: "de", Text: "Bank"},
+			},
+		}},
+	}
+	require.NoError(t, repo.CreateEntry(ctx, entry))
+
+	var counts struct {
+		MeaningCount     int
+		ExampleCount     int
+		TranslationCount int
+	}
+	require.NoError(t, db.Raw(`SELECT e.meaning_count, m.example_count, m.translation_count
+		FROM entries e JOIN meanings m ON m.entry_id = e.id WHERE e.id = ?`, entry.ID).Scan(&counts).Error)
+	assert.Equal(t, 1, counts.MeaningCount)
+	assert.Equal(t, 1, counts.ExampleCount)
+	assert.Equal(t, 2, counts.TranslationCount)
+
+	require.NoError(t, db.Exec("DELETE FROM translations WHERE meaning_id = ? AND language_id = 'de'", entry.Meanings[0].ID).Error)
+	require.NoError(t, db.Raw("SELECT translation_count FROM meanings WHERE id = ?", entry.Meanings[0].ID).Scan(&counts.TranslationCount).Error)
+	assert.Equal(t, 1, counts.TranslationCount)
+
+	// History survives the entry it describes
+	require.NoError(t, db.Exec(`INSERT INTO change_history (id, entry_id, action, data) VALUES ('h1', ?, 'DELETE', '{}')`, entry.ID).Error)
+	require.NoError(t, db.Exec("DELETE FROM entries WHERE id = ?", entry.ID).Error)
+	var history int64
+	require.NoError(t, db.Table("change_history").Count(&history).Error)
+	assert.Equal(t, int64(1), history)
+}
+
+func TestSQLiteMigrateTo(t *testing.T) {
+	if os.Getenv("INTEGRATION_TEST") != "true" {
+		t.Skip("Skipping integration tests. Set INTEGRATION_TEST=true to run")
+	}
+
+	ctx := context.Background()
+	logOpts := logging.NewDefaultOptions()
+	logOpts.Level = logging.WarnLevel
+	logger, err := logging.NewLogger(logOpts)
+	require.NoError(t, err)
+
+	repo, err := sqlite.NewRepository(ctx, repository.Options{
+		Driver:   "sqlite",
+		Database: filepath.Join(t.TempDir(), "target.db"),
+	})
+	require.NoError(t, err, "Failed to create repository")
+	defer repo.Close()
+	db, err := repo.GetDB()
+	require.NoError(t, err)
+
+	migrator := migration.NewMigrator(repo, logger)
+	versions := func() []int64 {
+		applied, err := migrator.GetAppliedMigrations()
+		require.NoError(t, err)
+		var versions []int64
+		for _, record := range applied {
+			versions = append(versions, record.Version)
+		}
+		return versions
+	}
+
+	steps, err := migrator.Plan(ctx, migrationsDir, 3)
+	require.NoError(t, err)
+	require.Len(t, steps, 3)
+	assert.Equal(t, migration.Up, steps[0].Direction)
+	assert.Equal(t, "initial schema", steps[0].Migration.Description)
+	assert.Empty(t, versions(), "Planning changes nothing")
+
+	require.NoError(t, migrator.MigrateTo(ctx, migrationsDir, 10))
+	assert.Len(t, versions(), 10)
+
+	steps, err = migrator.Plan(ctx, migrationsDir, 4)
+	require.NoError(t, err)
+	require.Len(t, steps, 6)
+	assert.Equal(t, migration.Down, steps[0].Direction)
+	assert.Equal(t, int64(10), steps[0].Migration.Version)
+	assert.Equal(t, int64(5), steps[5].Migration.Version)
+
+	require.NoError(t, migrator.MigrateTo(ctx, migrationsDir, 4))
+	assert.Equal(t, []int64{1, 2, 3, 4}, versions())
+	assert.False(t, db.Migrator().HasTable("notifications"))
+	assert.False(t, db.Migrator().HasColumn("translations", "status"))
+	assert.True(t, db.Migrator().HasColumn("meanings", "translation_count"))
+
+	// Back up again, and all the way down
+	require.NoError(t, migrator.MigrateTo(ctx, migrationsDir, 10))
+	report, err := migrator.Verify(ctx, migrationsDir)
+	require.NoError(t, err)
+	assert.True(t, report.OK())
+
+	require.NoError(t, migrator.MigrateTo(ctx, migrationsDir, 0))
+	assert.Empty(t, versions())
+	assert.False(t, db.Migrator().HasTable("entries"))
+
+	_, err = migrator.Plan(ctx, migrationsDir, 99)
+	assert.Error(t, err)
+
+	// A migration without a rollback file cannot be rolled back
+	dir := t.TempDir()
+	writeMigration(t, dir, "V1__create_words.sql", "CREATE TABLE words (id INTEGER PRIMARY KEY);")
+	writeMigration(t, dir, "R1__rollback_create_words.sql", "DROP TABLE words;")
+	writeMigration(t, dir, "V2__create_notes.sql", "CREATE TABLE notes (id INTEGER PRIMARY KEY);")
+	require.NoError(t, migrator.MigrateTo(ctx, dir, 2))
+	_, err = migrator.Plan(ctx, dir, 0)
+	assert.ErrorContains(t, err, "no R2 file")
+
+	// A failing step leaves the earlier steps applied and its own undone
+	writeMigration(t, dir, "V3__broken.sql", "CREATE TABLE tags (id INTEGER PRIMARY KEY); INSERT INTO missing VALUES (1);")
+	require.Error(t, migrator.MigrateTo(ctx, dir, 3))
+	assert.False(t, db.Migrator().HasTable("tags"))
+	assert.Equal(t, []int64{1, 2}, versions())
+}
+
+func TestSQLiteRollback(t *testing.T) {
+	if os.Getenv("INTEGRATION_TEST") != "true" {
+		t.Skip("Skipping integration tests. Set INTEGRATION_TEST=true to run")
+	}
+
+	ctx := context.Background()
+	logOpts := logging.NewDefaultOptions()
+	logOpts.Level = logging.WarnLevel
+	logger, err := logging.NewLogger(logOpts)
+	require.NoError(t, err)
+
+	repo, err := sqlite.NewRepository(ctx, repository.Options{
+		Driver:   "sqlite",
+		Database: filepath.Join(t.TempDir(), "target.db"),
+	})
+	require.NoError(t, err, "Failed to create repository")
+	defer repo.Close()
+	db, err := repo.GetDB()
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	writeMigration(t, dir, "V1__create_words.sql", "CREATE TABLE words (id INTEGER PRIMARY KEY);")
+	writeMigration(t, dir, "R1__rollback_create_words.sql", "DROP TABLE words;")
+	writeMigration(t, dir, "V2__add_word_text.sql", "ALTER TABLE words ADD COLUMN text TEXT;")
+	writeMigration(t, dir, "R2__rollback_add_word_text.sql", "ALTER TABLE words DROP COLUMN text;")
+
+	migrator := migration.NewMigrator(repo, logger)
+	require.NoError(t, migrator.Migrate(ctx, dir))
+	require.True(t, db.Migrator().HasColumn("words", "text"))
+
+	// The schema change is reverted along with its record
+	require.NoError(t, migrator.Rollback(ctx, dir))
+	assert.False(t, db.Migrator().HasColumn("words", "text"))
+	assert.True(t, db.Migrator().HasTable("words"))
+	applied, err := migrator.GetAppliedMigrations()
+	require.NoError(t, err)
+	require.Len(t, applied, 1)
+	assert.Equal(t, int64(1), applied[0].Version)
+
+	require.NoError(t, migrator.Rollback(ctx, dir))
+	assert.False(t, db.Migrator().HasTable("words"))
+	assert.ErrorContains(t, migrator.Rollback(ctx, dir), "no migrations to roll back")
+
+	// A migration without a rollback file is left applied
+	writeMigration(t, dir, "V3__create_notes.sql", "CREATE TABLE notes (id INTEGER PRIMARY KEY);")
+	require.NoError(t, migrator.Migrate(ctx, dir))
+	assert.ErrorContains(t, migrator.Rollback(ctx, dir), "no R3 file")
+	assert.True(t, db.Migrator().HasTable("notes"))
+}
@@ -0,0 +1,81 @@
+package migration_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valpere/trytrago/domain/database"
+	"github.com/valpere/trytrago/domain/database/repository"
+	"github.com/valpere/trytrago/domain/database/repository/sqlite"
+	"github.com/valpere/trytrago/domain/logging"
+	"github.com/valpere/trytrago/infrastructure/migration"
+)
+
+// word is a model that has drifted from its table
+type word struct {
+	ID       uuid.UUID `gorm:"type:uuid;primary_key"`
+	Text     string    `gorm:"index"`
+	Language string    `gorm:"index"`
+	Slug     string    `gorm:"uniqueIndex"`
+}
+
+func TestSQLiteSchemaDiff(t *testing.T) {
+	if os.Getenv("INTEGRATION_TEST") != "true" {
+		t.Skip("Skipping integration tests. Set INTEGRATION_TEST=true to run")
+	}
+
+	ctx := context.Background()
+	logOpts := logging.NewDefaultOptions()
+	logOpts.Level = logging.WarnLevel
+	logger, err := logging.NewLogger(logOpts)
+	require.NoError(t, err)
+
+	repo, err := sqlite.NewRepository(ctx, repository.Options{
+		Driver:   "sqlite",
+		Database: filepath.Join(t.TempDir(), "diff.db"),
+	})
+	require.NoError(t, err, "Failed to create repository")
+	defer repo.Close()
+
+	migrator := migration.NewMigrator(repo, logger)
+	dir := t.TempDir()
+	writeMigration(t, dir, "V1__create_words.sql", `
+		CREATE TABLE words (id VARCHAR(36) PRIMARY KEY, text TEXT, slug TEXT UNIQUE, archived BOOLEAN);
+		CREATE INDEX idx_words_lookup ON words (text, slug);
+		CREATE INDEX idx_words_lower ON words (LOWER(text));
+		CREATE TABLE notes (id INTEGER PRIMARY KEY);`)
+	require.NoError(t, migrator.Migrate(ctx, dir))
+
+	diff, err := migrator.Diff(ctx, []interface{}{&word{}, &database.Language{}})
+	require.NoError(t, err)
+	assert.False(t, diff.Empty())
+	assert.Equal(t, []string{"languages"}, diff.MissingTables)
+	assert.Equal(t, []string{"notes"}, diff.UnmodeledTables, "Migrator tables are left out")
+	assert.Equal(t, []migration.ColumnDiff{{Table: "words", Column: "language", Type: "string"}}, diff.MissingColumns)
+	require.Len(t, diff.UnmodeledColumns, 1)
+	assert.Equal(t, "archived", diff.UnmodeledColumns[0].Column)
+
+	// text is the leading column of idx_words_lookup and slug is UNIQUE
+	require.Len(t, diff.MissingIndexes, 1)
+	assert.Equal(t, "idx_words_language", diff.MissingIndexes[0].Name)
+	assert.Equal(t, []string{"language"}, diff.MissingIndexes[0].Columns)
+
+	// A database built by the migrations against the application's models
+	migrated, err := sqlite.NewRepository(ctx, repository.Options{
+		Driver:   "sqlite",
+		Database: filepath.Join(t.TempDir(), "migrated.db"),
+	})
+	require.NoError(t, err, "Failed to create repository")
+	defer migrated.Close()
+	migrator = migration.NewMigrator(migrated, logger)
+	require.NoError(t, migrator.Migrate(ctx, migrationsDir))
+	diff, err = migrator.Diff(ctx, []interface{}{&database.Meaning{}})
+	require.NoError(t, err)
+	assert.Contains(t, diff.UnmodeledColumns, migration.ColumnDiff{Table: "meanings", Column: "translation_count", Type: "INTEGER"})
+}
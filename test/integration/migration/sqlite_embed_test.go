@@ -0,0 +1,128 @@
+package migration_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valpere/trytrago/domain/database/repository"
+	"github.com/valpere/trytrago/domain/database/repository/sqlite"
+	"github.com/valpere/trytrago/domain/logging"
+	"github.com/valpere/trytrago/infrastructure/migration"
+	"github.com/valpere/trytrago/migrations"
+)
+
+func TestSQLiteEmbeddedMigrations(t *testing.T) {
+	if os.Getenv("INTEGRATION_TEST") != "true" {
+		t.Skip("Skipping integration tests. Set INTEGRATION_TEST=true to run")
+	}
+
+	ctx := context.Background()
+	logOpts := logging.NewDefaultOptions()
+	logOpts.Level = logging.WarnLevel
+	logger, err := logging.NewLogger(logOpts)
+	require.NoError(t, err)
+
+	repo, err := sqlite.NewRepository(ctx, repository.Options{
+		Driver:   "sqlite",
+		Database: filepath.Join(t.TempDir(), "embedded.db"),
+	})
+	require.NoError(t, err, "Failed to create repository")
+	defer repo.Close()
+
+	embedded := migration.NewMigrator(repo, logger)
+	embedded.SetFS(migrations.Files)
+	onDisk := migration.NewMigrator(repo, logger)
+
+	// The built-in set is the one in the repository
+	fromBinary, err := embedded.LoadMigrationsFromDir(".")
+	require.NoError(t, err)
+	fromDisk, err := onDisk.LoadMigrationsFromDir(migrationsDir)
+	require.NoError(t, err)
+	assert.Equal(t, len(fromDisk), len(fromBinary))
+	for i := range fromDisk {
+		assert.Equal(t, fromDisk[i].Checksum, fromBinary[i].Checksum)
+		assert.Equal(t, fromDisk[i].RollbackSQL, fromBinary[i].RollbackSQL)
+	}
+
+	// Applied from the binary, checked and rolled back from disk
+	require.NoError(t, embedded.MigrateTo(ctx, ".", 10))
+	status, err := embedded.Status(ctx, ".")
+	require.NoError(t, err)
+	require.Len(t, status, len(fromDisk))
+	assert.True(t, status[9]["Applied"].(bool))
+
+	report, err := onDisk.Verify(ctx, migrationsDir)
+	require.NoError(t, err)
+	assert.True(t, report.OK())
+
+	require.NoError(t, onDisk.MigrateTo(ctx, migrationsDir, 5))
+	status, err = embedded.Status(ctx, ".")
+	require.NoError(t, err)
+	assert.False(t, status[5]["Applied"].(bool))
+
+	script, err := migrations.GenerateRollbackScript(migrations.Files, 1, embedded.Dialect())
+	require.NoError(t, err)
+	assert.Contains(t, script, "DROP TABLE")
+
+	_, err = embedded.LoadMigrationsFromDir("missing")
+	assert.Error(t, err)
+}
+
+func TestSQLiteHelperEmbeddedMigrations(t *testing.T) {
+	if os.Getenv("INTEGRATION_TEST") != "true" {
+		t.Skip("Skipping integration tests. Set INTEGRATION_TEST=true to run")
+	}
+
+	ctx := context.Background()
+	logOpts := logging.NewDefaultOptions()
+	logOpts.Level = logging.WarnLevel
+	logger, err := logging.NewLogger(logOpts)
+	require.NoError(t, err)
+
+	repo, err := sqlite.NewRepository(ctx, repository.Options{
+		Driver:   "sqlite",
+		Database: filepath.Join(t.TempDir(), "helper.db"),
+	})
+	require.NoError(t, err, "Failed to create repository")
+	defer repo.Close()
+
+	// Without a directory the helper applies the migrations built into the binary
+	helper, err := migrations.NewHelper(repo, logger)
+	require.NoError(t, err)
+	require.NoError(t, helper.EnsureMigrationsRun(ctx, "", true))
+
+	migrator := migration.NewMigrator(repo, logger)
+	applied, err := migrator.GetAppliedMigrations()
+	require.NoError(t, err)
+	fromDisk, err := migrator.LoadMigrationsFromDir(migrationsDir)
+	require.NoError(t, err)
+	assert.Len(t, applied, len(fromDisk))
+
+	// A directory on disk overrides them
+	other, err := sqlite.NewRepository(ctx, repository.Options{
+		Driver:   "sqlite",
+		Database: filepath.Join(t.TempDir(), "override.db"),
+	})
+	require.NoError(t, err, "Failed to create repository")
+	defer other.Close()
+	dir := t.TempDir()
+	writeMigration(t, dir, "V1__create_words.sql", "CREATE TABLE words (id INTEGER PRIMARY KEY);")
+
+	helper, err = migrations.NewHelper(other, logger)
+	require.NoError(t, err)
+	require.NoError(t, helper.EnsureMigrationsRun(ctx, dir, true))
+	db, err := other.GetDB()
+	require.NoError(t, err)
+	assert.True(t, db.Migrator().HasTable("words"))
+	assert.False(t, db.Migrator().HasTable("entries"))
+
+	// A helper without files needs a directory
+	bare, err := migration.NewHelper(other, logger)
+	require.NoError(t, err)
+	assert.Error(t, bare.EnsureMigrationsRun(ctx, "", true))
+}
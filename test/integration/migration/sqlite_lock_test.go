@@ -0,0 +1,83 @@
+package migration_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valpere/trytrago/domain/database/repository"
+	"github.com/valpere/trytrago/domain/database/repository/sqlite"
+	"github.com/valpere/trytrago/domain/logging"
+	"github.com/valpere/trytrago/infrastructure/migration"
+)
+
+func TestSQLiteMigrationLock(t *testing.T) {
+	if os.Getenv("INTEGRATION_TEST") != "true" {
+		t.Skip("Skipping integration tests. Set INTEGRATION_TEST=true to run")
+	}
+
+	ctx := context.Background()
+	logOpts := logging.NewDefaultOptions()
+	logOpts.Level = logging.ErrorLevel
+	logger, err := logging.NewLogger(logOpts)
+	require.NoError(t, err)
+
+	// Two instances sharing one database file
+	path := filepath.Join(t.TempDir(), "lock.db")
+	open := func() repository.Repository {
+		repo, err := sqlite.NewRepository(ctx, repository.Options{Driver: "sqlite", Database: path})
+		require.NoError(t, err, "Failed to create repository")
+		t.Cleanup(func() { repo.Close() })
+		return repo
+	}
+	first := migration.NewMigrator(open(), logger)
+	second := migration.NewMigrator(open(), logger)
+	second.SetLockTimeout(1500 * time.Millisecond)
+
+	dir := t.TempDir()
+	writeMigration(t, dir, "V1__create_words.sql", "CREATE TABLE words (id INTEGER PRIMARY KEY);")
+
+	unlock, err := first.Lock(ctx)
+	require.NoError(t, err)
+
+	// The second instance gives up while the first holds the lock
+	started := time.Now()
+	err = second.Migrate(ctx, dir)
+	require.ErrorIs(t, err, migration.ErrLockTimeout)
+	assert.GreaterOrEqual(t, time.Since(started), 1500*time.Millisecond)
+	db, err := open().GetDB()
+	require.NoError(t, err)
+	assert.False(t, db.Migrator().HasTable("words"))
+
+	// Released, the second instance migrates and the first finds nothing to do
+	unlock()
+	require.NoError(t, second.Migrate(ctx, dir))
+	require.NoError(t, first.Migrate(ctx, dir))
+	applied, err := first.GetAppliedMigrations()
+	require.NoError(t, err)
+	assert.Len(t, applied, 1)
+
+	// A lease left behind by a crashed instance expires
+	require.NoError(t, db.Create(&migration.MigrationLock{
+		Name:       "migrations",
+		Holder:     "crashed",
+		AcquiredAt: time.Now().UTC().Add(-time.Hour),
+		ExpiresAt:  time.Now().UTC().Add(-time.Minute),
+	}).Error)
+
+	unlock, err = second.Lock(ctx)
+	require.NoError(t, err)
+	var held migration.MigrationLock
+	require.NoError(t, db.First(&held).Error)
+	assert.NotEqual(t, "crashed", held.Holder)
+
+	unlock()
+	var count int64
+	require.NoError(t, db.Model(&migration.MigrationLock{}).Count(&count).Error)
+	assert.Zero(t, count)
+}
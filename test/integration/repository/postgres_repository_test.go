@@ -108,8 +108,8 @@ func (s *PostgresRepositoryTestSuite) setupTestSchema() {
 	err = db.Exec(`DROP TABLE IF EXISTS entries CASCADE`).Error
 	require.NoError(s.T(), err, "Failed to drop entries table")
 
-	err = db.Exec(`DROP TABLE IF EXISTS change_histories CASCADE`).Error
-	require.NoError(s.T(), err, "Failed to drop change_histories table")
+	err = db.Exec(`DROP TABLE IF EXISTS change_history CASCADE`).Error
+	require.NoError(s.T(), err, "Failed to drop change_history table")
 
 	// Create tables
 	err = db.AutoMigrate(&database.Entry{}, &database.Meaning{}, &database.Example{}, &database.Translation{}, &database.ChangeHistory{})
@@ -263,7 +263,7 @@ func (s *PostgresRepositoryTestSuite) TestDeleteEntry() {
 	assert.NoError(s.T(), err, "Failed to create entry")
 
 	// Delete the entry
-	err = s.repo.DeleteEntry(s.ctx, entry.ID)
+	err = s.repo.DeleteEntry(s.ctx, entry.ID, 0)
 	assert.NoError(s.T(), err, "Failed to delete entry")
 
 	// Verify the entry was deleted
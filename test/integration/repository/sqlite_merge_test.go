@@ -0,0 +1,92 @@
+package repository_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valpere/trytrago/domain/database"
+	"github.com/valpere/trytrago/domain/database/repository"
+	"github.com/valpere/trytrago/domain/database/repository/sqlite"
+	"github.com/valpere/trytrago/domain/logging"
+	"github.com/valpere/trytrago/domain/model"
+	"github.com/valpere/trytrago/migrations"
+)
+
+// TestSQLiteMergeMeaningCount checks that merging keeps the cached meaning
+// counts of the migrated schema right
+func TestSQLiteMergeMeaningCount(t *testing.T) {
+	if os.Getenv("INTEGRATION_TEST") != "true" {
+		t.Skip("Skipping integration tests. Set INTEGRATION_TEST=true to run")
+	}
+
+	ctx := context.Background()
+	logOpts := logging.NewDefaultOptions()
+	logOpts.Level = logging.WarnLevel
+	logger, err := logging.NewLogger(logOpts)
+	require.NoError(t, err)
+
+	repo, err := sqlite.NewRepository(ctx, repository.Options{
+		Driver:   "sqlite",
+		Database: filepath.Join(t.TempDir(), "merge.db"),
+	})
+	require.NoError(t, err, "Failed to create repository")
+	defer repo.Close()
+
+	// The counters are kept by triggers, so the schema comes from the migrations
+	helper, err := migrations.NewHelper(repo, logger)
+	require.NoError(t, err)
+	require.NoError(t, helper.EnsureMigrationsRun(ctx, "", true))
+	db, err := repo.GetDB()
+	require.NoError(t, err)
+	var noun database.PartOfSpeech
+	require.NoError(t, db.Where("name = ?", "noun").First(&noun).Error)
+
+	entry := func(word string, meanings int) *database.Entry {
+		e := &database.Entry{
+			ID:               uuid.New(),
+			Word:             word,
+			Type:             database.WordType,
+			SourceLanguageID: "en",
+			CreatedAt:        time.Now().UTC(),
+			UpdatedAt:        time.Now().UTC(),
+		}
+		for i := 0; i < meanings; i++ {
+			e.Meanings = append(e.Meanings, database.Meaning{
+				ID:             uuid.New(),
+				EntryID:        e.ID,
+				PartOfSpeechId: noun.ID,
+				Description:    word,
+				CreatedAt:      time.Now().UTC(),
+				UpdatedAt:      time.Now().UTC(),
+			})
+		}
+		return e
+	}
+	source := entry("merge_count_source", 2)
+	target := entry("merge_count_target", 1)
+	require.NoError(t, repo.CreateEntry(ctx, source))
+	require.NoError(t, repo.CreateEntry(ctx, target))
+
+	meaningCount := func(id uuid.UUID) int {
+		var count int
+		require.NoError(t, db.Raw("SELECT meaning_count FROM entries WHERE id = ?", id).Scan(&count).Error)
+		return count
+	}
+	require.Equal(t, 1, meaningCount(target.ID))
+
+	user := &model.User{ID: uuid.New(), Username: "merger", Email: "merger@example.com", Password: "x", Role: model.RoleAdmin}
+	require.NoError(t, repo.CreateUser(ctx, user))
+	require.NoError(t, repo.MergeEntries(ctx, source.ID, target.ID, user.ID))
+
+	assert.Equal(t, 3, meaningCount(target.ID), "Moved meanings should count towards the target")
+	merged, err := repo.GetEntryByID(ctx, target.ID)
+	require.NoError(t, err)
+	assert.Len(t, merged.Meanings, 3)
+}
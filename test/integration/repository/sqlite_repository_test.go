@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
 
 	"github.com/valpere/trytrago/domain/database"
 	"github.com/valpere/trytrago/domain/database/repository"
@@ -66,7 +67,7 @@ func (s *SQLiteRepositoryTestSuite) setupTestSchema() {
 	require.NoError(s.T(), err, "Failed to get database connection")
 
 	// Create tables using auto-migrate
-	err = db.AutoMigrate(&database.Entry{}, &database.Meaning{}, &database.Example{}, &database.Translation{}, &database.ChangeHistory{})
+	err = db.AutoMigrate(&database.Entry{}, &database.Meaning{}, &database.Example{}, &database.Translation{}, &database.ChangeHistory{}, &database.EntryRedirect{}, &database.PartOfSpeech{})
 	require.NoError(s.T(), err, "Failed to create database schema")
 }
 
@@ -288,7 +289,7 @@ func (s *SQLiteRepositoryTestSuite) TestDeleteEntry() {
 	assert.NoError(s.T(), err, "Failed to create entry")
 
 	// Delete the entry
-	err = s.repo.DeleteEntry(s.ctx, entry.ID)
+	err = s.repo.DeleteEntry(s.ctx, entry.ID, 0)
 	assert.NoError(s.T(), err, "Failed to delete entry")
 
 	// Verify the entry was deleted
@@ -395,6 +396,30 @@ func (s *SQLiteRepositoryTestSuite) TestListEntries() {
 		assert.NoError(s.T(), err, "Failed to list entries for second page")
 		assert.NotEmpty(s.T(), nextResults, "Second page should not be empty")
 	})
+
+	// Test keyset pagination in ID order, as used by exports
+	s.Run("Keyset", func() {
+		params := repository.ListParams{
+			Limit:   2,
+			SortBy:  "id",
+			Filters: map[string]interface{}{"word LIKE ?": "%sqlite_list_test_%"},
+		}
+
+		var seen []uuid.UUID
+		for {
+			results, err := s.repo.ListEntries(s.ctx, params)
+			require.NoError(s.T(), err, "Failed to list entries by key")
+			for _, entry := range results {
+				seen = append(seen, entry.ID)
+			}
+			if len(results) < params.Limit {
+				break
+			}
+			params.Filters["id > ?"] = results[len(results)-1].ID
+		}
+
+		assert.ElementsMatch(s.T(), []uuid.UUID{entries[0].ID, entries[1].ID, entries[2].ID}, seen, "Every entry comes exactly once")
+	})
 }
 
 // TestFindTranslations tests the FindTranslations method
@@ -536,6 +561,321 @@ func (s *SQLiteRepositoryTestSuite) TestRecordAndRetrieveChangeHistory() {
 	assert.Equal(s.T(), "create", history[1].Action, "Oldest change should be last")
 }
 
+// TestHomographNumbering tests that entries sharing a headword are numbered
+func (s *SQLiteRepositoryTestSuite) TestHomographNumbering() {
+	first := &database.Entry{Word: "homograph_bank", Type: database.WordType, SourceLanguageID: "en"}
+	second := &database.Entry{Word: " Homograph_Bank", Type: database.WordType, SourceLanguageID: "en"}
+	otherType := &database.Entry{Word: "homograph_bank", Type: database.PhraseType, SourceLanguageID: "en"}
+
+	require.NoError(s.T(), s.repo.CreateEntry(s.ctx, first), "Failed to create first homograph")
+	require.NoError(s.T(), s.repo.CreateEntry(s.ctx, second), "Failed to create second homograph")
+	require.NoError(s.T(), s.repo.CreateEntry(s.ctx, otherType), "Failed to create entry of another type")
+
+	assert.Equal(s.T(), 1, first.HomographIndex)
+	assert.Equal(s.T(), 2, second.HomographIndex)
+	assert.Equal(s.T(), 1, otherType.HomographIndex, "Numbering is per entry type")
+	assert.Equal(s.T(), "homograph_bank", second.NormalizedWord)
+
+	// Entries learn how many homographs they have when saved and loaded
+	assert.Equal(s.T(), 2, second.Homographs)
+	assert.Equal(s.T(), 1, otherType.Homographs, "An entry without homographs counts only itself")
+	loaded, err := s.repo.GetEntryByID(s.ctx, first.ID)
+	require.NoError(s.T(), err, "Failed to get first homograph")
+	assert.Equal(s.T(), 2, loaded.Homographs)
+	listed, err := s.repo.GetEntriesByIDs(s.ctx, []uuid.UUID{first.ID, otherType.ID})
+	require.NoError(s.T(), err, "Failed to get homographs by ID")
+	for _, entry := range listed {
+		if entry.ID == first.ID {
+			assert.Equal(s.T(), 2, entry.Homographs)
+		} else {
+			assert.Equal(s.T(), 1, entry.Homographs)
+		}
+	}
+
+	// An explicit index that is already taken is rejected
+	clash := &database.Entry{Word: "homograph_bank", Type: database.WordType, SourceLanguageID: "en", HomographIndex: 2}
+	err = s.repo.CreateEntry(s.ctx, clash)
+	assert.True(s.T(), database.IsDuplicateError(err), "Expected duplicate error, got %v", err)
+}
+
+// TestCreateEntries tests inserting a batch of entries in one transaction
+func (s *SQLiteRepositoryTestSuite) TestCreateEntries() {
+	pos, err := s.repo.GetOrCreatePartOfSpeech(s.ctx, " Noun ")
+	require.NoError(s.T(), err, "Failed to create part of speech")
+	assert.Equal(s.T(), "noun", pos.Name)
+
+	again, err := s.repo.GetOrCreatePartOfSpeech(s.ctx, "noun")
+	require.NoError(s.T(), err, "Failed to look up part of speech")
+	assert.Equal(s.T(), pos.ID, again.ID, "Existing part of speech should be reused")
+
+	entries := []*database.Entry{
+		{
+			Word: "bulk_bank",
+			Type: database.WordType,
+			Meanings: []database.Meaning{{
+				PartOfSpeechId: pos.ID,
+				Description:    "Financial institution",
+				Translations:   []database.Translation{{LanguageID: "fr", Text: "banque", Status: database.TranslationApproved}},
+			}},
+		},
+		{Word: "bulk_bank", Type: database.WordType},
+	}
+	require.NoError(s.T(), s.repo.CreateEntries(s.ctx, entries), "Failed to create entries")
+	assert.Equal(s.T(), 1, entries[0].HomographIndex)
+	assert.Equal(s.T(), 2, entries[1].HomographIndex, "Homographs in one batch are numbered in order")
+
+	stored, err := s.repo.GetEntryByID(s.ctx, entries[0].ID)
+	require.NoError(s.T(), err, "Failed to get imported entry")
+	require.Len(s.T(), stored.Meanings, 1)
+	require.Len(s.T(), stored.Meanings[0].Translations, 1)
+	assert.Nil(s.T(), stored.Meanings[0].Translations[0].CreatedByID)
+
+	// A clash rolls back the whole batch
+	clash := []*database.Entry{
+		{Word: "bulk_river", Type: database.WordType},
+		{Word: "bulk_bank", Type: database.WordType, HomographIndex: 1},
+	}
+	err = s.repo.CreateEntries(s.ctx, clash)
+	assert.True(s.T(), database.IsDuplicateError(err), "Expected duplicate error, got %v", err)
+
+	_, err = s.repo.GetEntryByID(s.ctx, clash[0].ID)
+	assert.ErrorIs(s.T(), err, database.ErrEntryNotFound, "Batch should have been rolled back")
+}
+
+// TestMergeEntries tests merging one entry into another
+func (s *SQLiteRepositoryTestSuite) TestMergeEntries() {
+	sourceID := uuid.New()
+	targetID := uuid.New()
+	meaningID := uuid.New()
+
+	source := &database.Entry{
+		ID:        sourceID,
+		Word:      "merge_source",
+		Type:      database.WordType,
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
+		Meanings: []database.Meaning{
+			{
+				ID:             meaningID,
+				EntryID:        sourceID,
+				Description:    "Meaning to move",
+				PartOfSpeechId: uuid.New(),
+				CreatedAt:      time.Now().UTC(),
+				UpdatedAt:      time.Now().UTC(),
+			},
+		},
+	}
+	target := &database.Entry{
+		ID:        targetID,
+		Word:      "merge_target",
+		Type:      database.WordType,
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
+	}
+
+	require.NoError(s.T(), s.repo.CreateEntry(s.ctx, source), "Failed to create source entry")
+	require.NoError(s.T(), s.repo.CreateEntry(s.ctx, target), "Failed to create target entry")
+
+	// Merge the source into the target
+	err := s.repo.MergeEntries(s.ctx, sourceID, targetID, uuid.New())
+	require.NoError(s.T(), err, "Failed to merge entries")
+
+	// The meaning should now belong to the target
+	merged, err := s.repo.GetEntryByID(s.ctx, targetID)
+	require.NoError(s.T(), err, "Failed to get target entry")
+	require.Len(s.T(), merged.Meanings, 1, "Target should have the moved meaning")
+	assert.Equal(s.T(), meaningID, merged.Meanings[0].ID)
+
+	// The source should be gone but still resolvable through a redirect
+	_, err = s.repo.GetEntryByID(s.ctx, sourceID)
+	assert.Error(s.T(), err, "Source entry should be deleted")
+
+	redirect, err := s.repo.GetEntryRedirect(s.ctx, sourceID)
+	require.NoError(s.T(), err, "Failed to get entry redirect")
+	assert.Equal(s.T(), targetID, redirect.ToID)
+
+	// Merging an entry into itself is rejected
+	err = s.repo.MergeEntries(s.ctx, targetID, targetID, uuid.New())
+	assert.ErrorIs(s.T(), err, database.ErrInvalidInput)
+}
+
+// TestReplaceEntry tests that replacing an entry tree updates, creates and
+// deletes its children to match
+func (s *SQLiteRepositoryTestSuite) TestReplaceEntry() {
+	entryID := uuid.New()
+	keptID, droppedID := uuid.New(), uuid.New()
+	exampleID, translationID := uuid.New(), uuid.New()
+
+	entry := &database.Entry{
+		ID:   entryID,
+		Word: "replace_test",
+		Type: database.WordType,
+		Meanings: []database.Meaning{
+			{
+				ID:          keptID,
+				Description: "Kept meaning",
+				Examples:    []database.Example{{ID: exampleID, Text: "Dropped example"}},
+				Translations: []database.Translation{
+					{ID: translationID, LanguageID: "fr", Text: "gardé"},
+				},
+			},
+			{
+				ID:           droppedID,
+				Description:  "Dropped meaning",
+				Translations: []database.Translation{{ID: uuid.New(), LanguageID: "fr", Text: "perdu"}},
+			},
+		},
+	}
+	require.NoError(s.T(), s.repo.CreateEntry(s.ctx, entry), "Failed to create entry")
+
+	// Keep one meaning and its translation, drop its example, add a new one
+	entry.Meanings = []database.Meaning{
+		{
+			ID:          keptID,
+			Description: "Kept meaning, reworded",
+			Examples:    []database.Example{{Text: "New example"}},
+			Translations: []database.Translation{
+				{ID: translationID, LanguageID: "fr", Text: "conservé"},
+			},
+		},
+	}
+	require.NoError(s.T(), s.repo.ReplaceEntry(s.ctx, entry), "Failed to replace entry")
+
+	replaced, err := s.repo.GetEntryByID(s.ctx, entryID)
+	require.NoError(s.T(), err, "Failed to retrieve replaced entry")
+	require.Len(s.T(), replaced.Meanings, 1)
+	meaning := replaced.Meanings[0]
+	assert.Equal(s.T(), keptID, meaning.ID)
+	assert.Equal(s.T(), "Kept meaning, reworded", meaning.Description)
+	require.Len(s.T(), meaning.Examples, 1)
+	assert.NotEqual(s.T(), exampleID, meaning.Examples[0].ID)
+	require.Len(s.T(), meaning.Translations, 1)
+	assert.Equal(s.T(), "conservé", meaning.Translations[0].Text)
+
+	db, err := s.repo.GetDB()
+	require.NoError(s.T(), err)
+	var orphans int64
+	require.NoError(s.T(), db.Model(&database.Translation{}).Where("meaning_id = ?", droppedID).Count(&orphans).Error)
+	assert.Zero(s.T(), orphans, "Translations of a dropped meaning should be deleted")
+
+	// Children of another entry cannot be pulled in
+	other := &database.Entry{
+		ID:       uuid.New(),
+		Word:     "replace_other",
+		Type:     database.WordType,
+		Meanings: []database.Meaning{{ID: uuid.New(), Description: "Other meaning"}},
+	}
+	require.NoError(s.T(), s.repo.CreateEntry(s.ctx, other), "Failed to create other entry")
+	entry.Meanings = append(entry.Meanings, database.Meaning{ID: other.Meanings[0].ID, Description: "Stolen"})
+	err = s.repo.ReplaceEntry(s.ctx, entry)
+	assert.ErrorIs(s.T(), err, database.ErrInvalidInput)
+
+	err = s.repo.ReplaceEntry(s.ctx, &database.Entry{ID: uuid.New(), Word: "missing", Type: database.WordType})
+	assert.ErrorIs(s.T(), err, database.ErrEntryNotFound)
+}
+
+// TestWithTx tests that operations through WithTx are committed or rolled back with their transaction
+func (s *SQLiteRepositoryTestSuite) TestWithTx() {
+	committed := &database.Entry{
+		ID:       uuid.New(),
+		Word:     "tx_committed",
+		Type:     database.WordType,
+		Meanings: []database.Meaning{{ID: uuid.New(), Description: "Committed meaning"}},
+	}
+	err := s.repo.WithTransaction(s.ctx, func(tx *gorm.DB) error {
+		return s.repo.WithTx(tx).CreateEntry(s.ctx, committed)
+	})
+	require.NoError(s.T(), err, "Failed to commit transaction")
+
+	meaning, err := s.repo.GetMeaningByID(s.ctx, committed.Meanings[0].ID)
+	require.NoError(s.T(), err, "Failed to retrieve meaning")
+	assert.Equal(s.T(), committed.ID, meaning.EntryID)
+
+	// A later failure undoes the writes made before it
+	rolledBack := &database.Entry{ID: uuid.New(), Word: "tx_rolled_back", Type: database.WordType}
+	err = s.repo.WithTransaction(s.ctx, func(tx *gorm.DB) error {
+		txRepo := s.repo.WithTx(tx)
+		if err := txRepo.CreateEntry(s.ctx, rolledBack); err != nil {
+			return err
+		}
+		_, err := txRepo.GetMeaningByID(s.ctx, uuid.New())
+		return err
+	})
+	assert.ErrorIs(s.T(), err, database.ErrMeaningNotFound)
+
+	_, err = s.repo.GetEntryByID(s.ctx, rolledBack.ID)
+	assert.ErrorIs(s.T(), err, database.ErrEntryNotFound, "Entry should be rolled back")
+}
+
+// TestVersionConflict tests that a write based on a version that has since
+// moved on is refused, for entries and for their meanings and translations
+func (s *SQLiteRepositoryTestSuite) TestVersionConflict() {
+	meaningID, translationID := uuid.New(), uuid.New()
+	entry := &database.Entry{
+		ID:   uuid.New(),
+		Word: "version_test",
+		Type: database.WordType,
+		Meanings: []database.Meaning{{
+			ID:           meaningID,
+			Description:  "Versioned meaning",
+			Translations: []database.Translation{{ID: translationID, LanguageID: "fr", Text: "versionné"}},
+		}},
+	}
+	require.NoError(s.T(), s.repo.CreateEntry(s.ctx, entry), "Failed to create entry")
+	assert.Equal(s.T(), 1, entry.Version)
+
+	// Changing the entry alone leaves its children at their versions
+	entry.Pronunciation = "ver-shun"
+	require.NoError(s.T(), s.repo.UpdateEntry(s.ctx, entry), "Failed to update entry")
+	assert.Equal(s.T(), 2, entry.Version)
+	assert.Equal(s.T(), 1, entry.Meanings[0].Version)
+
+	stale := &database.Entry{ID: entry.ID, Word: entry.Word, Type: entry.Type, Version: 1}
+	assert.ErrorIs(s.T(), s.repo.UpdateEntry(s.ctx, stale), database.ErrVersionConflict)
+
+	// Writing a meaning moves its entry on as well
+	meaning, err := s.repo.GetMeaningByID(s.ctx, meaningID)
+	require.NoError(s.T(), err)
+	meaning.Description = "Reworded meaning"
+	require.NoError(s.T(), s.repo.UpdateMeaning(s.ctx, meaning), "Failed to update meaning")
+	assert.Equal(s.T(), 2, meaning.Version)
+
+	meaning.Version = 1
+	assert.ErrorIs(s.T(), s.repo.UpdateMeaning(s.ctx, meaning), database.ErrVersionConflict)
+
+	stored, err := s.repo.GetEntryByID(s.ctx, entry.ID)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), 3, stored.Version)
+	assert.Equal(s.T(), "Reworded meaning", stored.Meanings[0].Description)
+	assert.ErrorIs(s.T(), s.repo.DeleteEntry(s.ctx, entry.ID, 2), database.ErrVersionConflict)
+
+	translation, err := s.repo.GetTranslationByID(s.ctx, translationID)
+	require.NoError(s.T(), err)
+	translation.Text = "révisé"
+	translation.Version = 2
+	assert.ErrorIs(s.T(), s.repo.UpdateTranslation(s.ctx, translation), database.ErrVersionConflict)
+	translation.Version = 1
+	require.NoError(s.T(), s.repo.UpdateTranslation(s.ctx, translation), "Failed to update translation")
+	assert.ErrorIs(s.T(), s.repo.DeleteTranslation(s.ctx, translationID, 1), database.ErrVersionConflict)
+
+	// A meaning is read with its translations, so it moves on with them
+	meaning, err = s.repo.GetMeaningByID(s.ctx, meaningID)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), 3, meaning.Version)
+	assert.ErrorIs(s.T(), s.repo.DeleteMeaning(s.ctx, meaningID, 2), database.ErrVersionConflict)
+
+	// Deleting the meaning takes its translation with it
+	require.NoError(s.T(), s.repo.DeleteMeaning(s.ctx, meaningID, 3), "Failed to delete meaning")
+	_, err = s.repo.GetTranslationByID(s.ctx, translationID)
+	assert.ErrorIs(s.T(), err, database.ErrNotFound)
+	assert.ErrorIs(s.T(), s.repo.DeleteMeaning(s.ctx, meaningID, 0), database.ErrMeaningNotFound)
+
+	stored, err = s.repo.GetEntryByID(s.ctx, entry.ID)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), 5, stored.Version)
+	require.NoError(s.T(), s.repo.DeleteEntry(s.ctx, entry.ID, stored.Version), "Failed to delete entry")
+}
+
 // TestSQLiteRepository runs the test suite
 func TestSQLiteRepository(t *testing.T) {
 	// Skip tests if we're not in integration test mode
@@ -0,0 +1,142 @@
+package transfer_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valpere/trytrago/domain"
+	"github.com/valpere/trytrago/domain/database"
+	"github.com/valpere/trytrago/domain/database/repository"
+	"github.com/valpere/trytrago/domain/logging"
+	"github.com/valpere/trytrago/infrastructure/transfer"
+)
+
+// openSQLite opens a SQLite database through its connection URL
+func openSQLite(t *testing.T, path string) repository.Repository {
+	opts, err := repository.ParseDSN("sqlite://" + path)
+	require.NoError(t, err)
+	repo, err := domain.NewRepository(context.Background(), opts)
+	require.NoError(t, err, "Failed to create repository")
+	t.Cleanup(func() { repo.Close() })
+	return repo
+}
+
+func TestSQLiteCopy(t *testing.T) {
+	if os.Getenv("INTEGRATION_TEST") != "true" {
+		t.Skip("Skipping integration tests. Set INTEGRATION_TEST=true to run")
+	}
+
+	ctx := context.Background()
+	logOpts := logging.NewDefaultOptions()
+	logOpts.Level = logging.WarnLevel
+	logger, err := logging.NewLogger(logOpts)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	src := openSQLite(t, filepath.Join(dir, "source.db"))
+	db, err := src.GetDB()
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&database.PartOfSpeech{}, &database.Entry{}, &database.Meaning{}, &database.Example{}, &database.Translation{}))
+
+	noun, err := src.GetOrCreatePartOfSpeech(ctx, "noun")
+	require.NoError(t, err)
+	var entries []*database.Entry
+	for _, word := range []string{"apple", "bank", "cat", "dog", "egg"} {
+		entries = append(entries, &database.Entry{
+			Word:             word,
+			Type:             database.WordType,
+			SourceLanguageID: "en",
+			Meanings: []database.Meaning{{
+				PartOfSpeechId: noun.ID,
+				Description:    word,
+				Labels:         "informal",
+				Translations:   []database.Translation{{LanguageID: "fr", Text: word}},
+			}},
+		})
+	}
+	require.NoError(t, src.CreateEntries(ctx, entries))
+
+	// A translation replacing one that sorts after it by key
+	older := entries[0].Meanings[0].Translations[0]
+	newer := database.Translation{
+		ID:           uuid.MustParse("00000000-0000-4000-8000-000000000001"),
+		MeaningID:    older.MeaningID,
+		LanguageID:   "fr",
+		Text:         "pomme",
+		SupersedesID: &older.ID,
+	}
+	require.NoError(t, db.Create(&newer).Error)
+
+	// The destination has its own seeded part of speech
+	dst := openSQLite(t, filepath.Join(dir, "target.db"))
+	target, err := dst.GetDB()
+	require.NoError(t, err)
+	require.NoError(t, target.AutoMigrate(&database.PartOfSpeech{}))
+	_, err = dst.GetOrCreatePartOfSpeech(ctx, "noun")
+	require.NoError(t, err)
+
+	copier, err := transfer.NewCopier(src, dst, logger)
+	require.NoError(t, err)
+
+	// Interrupt the copy half way through the entries
+	interrupted := errors.New("interrupted")
+	var last transfer.Checkpoint
+	err = copier.Copy(ctx, transfer.Options{
+		BatchSize: 2,
+		OnCheckpoint: func(checkpoint transfer.Checkpoint) error {
+			last = checkpoint
+			if checkpoint.Table == "entries" && checkpoint.Copied == 4 {
+				return interrupted
+			}
+			return nil
+		},
+	})
+	require.ErrorIs(t, err, interrupted)
+	assert.Equal(t, []string{"languages", "users", "parts_of_speech"}, last.Completed)
+	assert.NotEmpty(t, last.LastKey)
+
+	var copied []transfer.Progress
+	err = copier.Copy(ctx, transfer.Options{
+		BatchSize:  2,
+		Resume:     &last,
+		OnProgress: func(p transfer.Progress) { copied = append(copied, p) },
+	})
+	require.NoError(t, err)
+	assert.Equal(t, transfer.Progress{Table: "entries", Copied: 5, Total: 5}, copied[0], "Resumed after the last checkpoint")
+
+	reports, err := copier.Verify(ctx, 2)
+	require.NoError(t, err)
+	byTable := make(map[string]transfer.TableReport)
+	for _, report := range reports {
+		byTable[report.Table] = report
+		assert.True(t, report.OK(), report.Table)
+	}
+	assert.True(t, byTable["users"].Skipped)
+	assert.Equal(t, int64(5), byTable["entries"].MatchedRows)
+	assert.Equal(t, int64(6), byTable["translations"].MatchedRows)
+	assert.Equal(t, byTable["meanings"].SourceChecksum, byTable["meanings"].TargetChecksum)
+
+	translation, err := dst.GetTranslationByID(ctx, newer.ID)
+	require.NoError(t, err)
+	require.NotNil(t, translation.SupersedesID)
+	assert.Equal(t, older.ID, *translation.SupersedesID)
+
+	// Changes after the copy are caught
+	require.NoError(t, db.Model(&database.Entry{}).Where("id = ?", entries[1].ID).Update("word", "river bank").Error)
+	reports, err = copier.Verify(ctx, 0)
+	require.NoError(t, err)
+	for _, report := range reports {
+		if report.Table == "entries" {
+			assert.False(t, report.OK())
+			assert.Equal(t, int64(1), report.Different)
+			assert.Equal(t, []string{entries[1].ID.String()}, report.Mismatches)
+		}
+	}
+}
@@ -39,8 +39,41 @@ func (m *MockRepository) UpdateEntry(ctx context.Context, entry *database.Entry)
 	return args.Error(0)
 }
 
-func (m *MockRepository) DeleteEntry(ctx context.Context, id uuid.UUID) error {
+func (m *MockRepository) ReplaceEntry(ctx context.Context, entry *database.Entry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetMeaningByID(ctx context.Context, id uuid.UUID) (*database.Meaning, error) {
 	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*database.Meaning), args.Error(1)
+}
+
+func (m *MockRepository) DeleteEntry(ctx context.Context, id uuid.UUID, version int) error {
+	args := m.Called(ctx, id, version)
+	return args.Error(0)
+}
+
+func (m *MockRepository) UpdateMeaning(ctx context.Context, meaning *database.Meaning) error {
+	args := m.Called(ctx, meaning)
+	return args.Error(0)
+}
+
+func (m *MockRepository) DeleteMeaning(ctx context.Context, id uuid.UUID, version int) error {
+	args := m.Called(ctx, id, version)
+	return args.Error(0)
+}
+
+func (m *MockRepository) UpdateTranslation(ctx context.Context, translation *database.Translation) error {
+	args := m.Called(ctx, translation)
+	return args.Error(0)
+}
+
+func (m *MockRepository) DeleteTranslation(ctx context.Context, id uuid.UUID, version int) error {
+	args := m.Called(ctx, id, version)
 	return args.Error(0)
 }
 
@@ -52,6 +85,47 @@ func (m *MockRepository) ListEntries(ctx context.Context, params repository.List
 	return args.Get(0).([]database.Entry), args.Error(1)
 }
 
+func (m *MockRepository) IterateEntries(ctx context.Context, params repository.IterateParams, fn func(batch []database.Entry) error) error {
+	args := m.Called(ctx, params, fn)
+	return args.Error(0)
+}
+
+func (m *MockRepository) CreateEntries(ctx context.Context, entries []*database.Entry) error {
+	args := m.Called(ctx, entries)
+	return args.Error(0)
+}
+
+// Part of speech operations
+func (m *MockRepository) GetOrCreatePartOfSpeech(ctx context.Context, name string) (*database.PartOfSpeech, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*database.PartOfSpeech), args.Error(1)
+}
+
+func (m *MockRepository) ListPartsOfSpeech(ctx context.Context) ([]database.PartOfSpeech, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return []database.PartOfSpeech{}, args.Error(1)
+	}
+	return args.Get(0).([]database.PartOfSpeech), args.Error(1)
+}
+
+// Merge operations
+func (m *MockRepository) MergeEntries(ctx context.Context, sourceID, targetID, userID uuid.UUID) error {
+	args := m.Called(ctx, sourceID, targetID, userID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetEntryRedirect(ctx context.Context, id uuid.UUID) (*database.EntryRedirect, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*database.EntryRedirect), args.Error(1)
+}
+
 // Translation operations
 func (m *MockRepository) FindTranslations(ctx context.Context, word string, langID string) ([]database.Translation, error) {
 	args := m.Called(ctx, word, langID)
@@ -61,6 +135,33 @@ func (m *MockRepository) FindTranslations(ctx context.Context, word string, lang
 	return args.Get(0).([]database.Translation), args.Error(1)
 }
 
+// Review operations
+func (m *MockRepository) GetTranslationByID(ctx context.Context, id uuid.UUID) (*database.Translation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*database.Translation), args.Error(1)
+}
+
+func (m *MockRepository) ListTranslationsByStatus(ctx context.Context, status database.TranslationStatus, params repository.ListParams) ([]database.Translation, error) {
+	args := m.Called(ctx, status, params)
+	if args.Get(0) == nil {
+		return []database.Translation{}, args.Error(1)
+	}
+	return args.Get(0).([]database.Translation), args.Error(1)
+}
+
+func (m *MockRepository) CountTranslationsByStatus(ctx context.Context, status database.TranslationStatus, filters map[string]interface{}) (int64, error) {
+	args := m.Called(ctx, status, filters)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRepository) ReviewTranslation(ctx context.Context, translation *database.Translation) error {
+	args := m.Called(ctx, translation)
+	return args.Error(0)
+}
+
 // History operations
 func (m *MockRepository) RecordChange(ctx context.Context, change *database.ChangeHistory) error {
 	args := m.Called(ctx, change)
@@ -174,6 +275,77 @@ func (m *MockRepository) CountLikes(ctx context.Context, targetType string, targ
 	return args.Get(0).(int64), args.Error(1)
 }
 
+// Batch loading operations
+func (m *MockRepository) GetEntriesByIDs(ctx context.Context, ids []uuid.UUID) ([]database.Entry, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]database.Entry), args.Error(1)
+}
+
+func (m *MockRepository) ListMeaningsByEntryIDs(ctx context.Context, entryIDs []uuid.UUID) ([]database.Meaning, error) {
+	args := m.Called(ctx, entryIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]database.Meaning), args.Error(1)
+}
+
+func (m *MockRepository) ListTranslationsByMeaningIDs(ctx context.Context, meaningIDs []uuid.UUID) ([]database.Translation, error) {
+	args := m.Called(ctx, meaningIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]database.Translation), args.Error(1)
+}
+
+func (m *MockRepository) ListCommentsByTargets(ctx context.Context, targetType string, targetIDs []uuid.UUID) ([]model.Comment, error) {
+	args := m.Called(ctx, targetType, targetIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Comment), args.Error(1)
+}
+
+func (m *MockRepository) CountLikesByTargets(ctx context.Context, targetType string, targetIDs []uuid.UUID) (map[uuid.UUID]int64, error) {
+	args := m.Called(ctx, targetType, targetIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]int64), args.Error(1)
+}
+
+func (m *MockRepository) GetUsersByIDs(ctx context.Context, ids []uuid.UUID) ([]model.User, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.User), args.Error(1)
+}
+
+func (m *MockRepository) ListLanguages(ctx context.Context) ([]database.Language, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]database.Language), args.Error(1)
+}
+
+// Notification operations
+func (m *MockRepository) CreateNotification(ctx context.Context, notification *model.Notification) error {
+	args := m.Called(ctx, notification)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ListNotifications(ctx context.Context, userID uuid.UUID, params repository.ListParams) ([]model.Notification, error) {
+	args := m.Called(ctx, userID, params)
+	if args.Get(0) == nil {
+		return []model.Notification{}, args.Error(1)
+	}
+	return args.Get(0).([]model.Notification), args.Error(1)
+}
+
 // Maintenance operations
 func (m *MockRepository) Ping(ctx context.Context) error {
 	args := m.Called(ctx)
@@ -193,7 +365,17 @@ func (m *MockRepository) GetDB() (*gorm.DB, error) {
 	return args.Get(0).(*gorm.DB), args.Error(1)
 }
 
+// WithTransaction returns the configured error, or runs fn with a nil
+// transaction when there is none, so code under test sees its own errors
 func (m *MockRepository) WithTransaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
 	args := m.Called(ctx, fn)
-	return args.Error(0)
+	if err := args.Error(0); err != nil {
+		return err
+	}
+	return fn(nil)
+}
+
+func (m *MockRepository) WithTx(tx *gorm.DB) repository.Repository {
+	args := m.Called(tx)
+	return args.Get(0).(repository.Repository)
 }
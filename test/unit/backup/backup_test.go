@@ -0,0 +1,105 @@
+package backup_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valpere/trytrago/domain/database"
+	"github.com/valpere/trytrago/infrastructure/backup"
+)
+
+func manifest(id, parentID string) *backup.Backup {
+	b := &backup.Backup{Manifest: backup.Manifest{
+		ID:        id,
+		Type:      backup.TypeIncremental,
+		Format:    backup.FormatVersion,
+		ParentID:  parentID,
+		CreatedAt: time.Now().UTC(),
+	}}
+	if parentID == "" {
+		b.Manifest.Type = backup.TypeFull
+	}
+	return b
+}
+
+func ids(chain []*backup.Backup) []string {
+	var result []string
+	for _, b := range chain {
+		result = append(result, b.Manifest.ID)
+	}
+	return result
+}
+
+func TestChain(t *testing.T) {
+	full := manifest("full", "")
+	first := manifest("first", "full")
+	second := manifest("second", "first")
+
+	chain, err := backup.Chain([]*backup.Backup{second, full, first})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"full", "first", "second"}, ids(chain))
+
+	// Incremental backups can be replayed on top of a live database
+	chain, err = backup.Chain([]*backup.Backup{second, first})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "second"}, ids(chain))
+
+	tests := []struct {
+		name    string
+		backups []*backup.Backup
+		message string
+	}{
+		{"empty", nil, "no backups"},
+		{"gap", []*backup.Backup{full, second}, "both start a chain"},
+		{"two full backups", []*backup.Backup{full, manifest("other", "")}, "both start a chain"},
+		{"branch", []*backup.Backup{full, first, manifest("sibling", "full")}, "both based on full"},
+		{"duplicate", []*backup.Backup{full, full}, "listed twice"},
+		{"cycle", []*backup.Backup{manifest("a", "b"), manifest("b", "a")}, "cycle"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := backup.Chain(tt.backups)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.message)
+		})
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	dir := t.TempDir()
+	since := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	target := uuid.New()
+
+	full := manifest("20261001T120000Z-aaaaaaaa", "")
+	full.Manifest.CreatedAt = since
+	full.Entries = []database.Entry{{ID: uuid.New(), Word: "bank", Type: database.WordType}}
+	require.NoError(t, backup.WriteFile(filepath.Join(dir, "full.json"), full, false))
+
+	incremental := manifest("20261002T120000Z-bbbbbbbb", full.Manifest.ID)
+	incremental.Manifest.Since = &since
+	incremental.Manifest.CreatedAt = since.Add(24 * time.Hour)
+	incremental.Deletions = []backup.Deletion{{EntryID: uuid.New(), MergedInto: &target, DeletedAt: since}}
+	require.NoError(t, backup.WriteFile(filepath.Join(dir, "incremental.json.gz"), incremental, true))
+
+	read, err := backup.ReadFile(filepath.Join(dir, "incremental.json.gz"))
+	require.NoError(t, err)
+	assert.Equal(t, incremental.Manifest.ParentID, read.Manifest.ParentID)
+	assert.Equal(t, incremental.Deletions, read.Deletions)
+
+	path, latest, err := backup.LatestManifest(dir)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "incremental.json.gz"), path)
+	assert.Equal(t, incremental.Manifest.ID, latest.ID)
+
+	path, found, err := backup.FindManifest(dir, full.Manifest.ID)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "full.json"), path)
+	assert.True(t, since.Equal(found.CreatedAt))
+
+	_, _, err = backup.FindManifest(dir, "missing")
+	assert.ErrorIs(t, err, backup.ErrNotFound)
+}
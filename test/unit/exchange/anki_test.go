@@ -0,0 +1,141 @@
+package exchange_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valpere/trytrago/infrastructure/exchange"
+)
+
+// openDeck unpacks a deck package and opens its collection
+func openDeck(t *testing.T, data []byte) (*sql.DB, map[string][]byte) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	files := make(map[string][]byte)
+	for _, file := range archive.File {
+		r, err := file.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(r)
+		require.NoError(t, err)
+		r.Close()
+		files[file.Name] = content
+	}
+
+	path := filepath.Join(t.TempDir(), "collection.anki2")
+	require.NoError(t, os.WriteFile(path, files["collection.anki2"], 0o600))
+	db, err := sql.Open("sqlite3", path)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db, files
+}
+
+func TestAnkiWriter(t *testing.T) {
+	audioDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(audioDir, "en"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(audioDir, "en", "bank.ogg"), []byte("recording"), 0o600))
+
+	var out bytes.Buffer
+	writer, err := exchange.NewAnkiWriter(&out, exchange.AnkiOptions{DeckName: "English", AudioDir: audioDir})
+	require.NoError(t, err)
+
+	require.NoError(t, writer.Write(&exchange.Record{
+		ID:               "11111111-1111-1111-1111-111111111111",
+		Word:             "bank",
+		Type:             "WORD",
+		SourceLanguageID: "en",
+		Pronunciation:    "bæŋk",
+		Meanings: []exchange.RecordMeaning{
+			{
+				PartOfSpeech: "noun",
+				Description:  "side of a <river>",
+				Examples:     []string{"the river bank"},
+				Translations: []exchange.RecordTranslation{{LanguageID: "fr", Text: "rive"}},
+			},
+			{
+				PartOfSpeech: "noun",
+				Description:  "financial institution",
+				Translations: []exchange.RecordTranslation{{LanguageID: "fr", Text: "banque"}, {LanguageID: "fr", Text: "rive"}},
+			},
+		},
+	}))
+	require.NoError(t, writer.Write(&exchange.Record{
+		Word:             "serendipity",
+		Type:             "WORD",
+		SourceLanguageID: "en",
+		Meanings:         []exchange.RecordMeaning{{Description: "a happy accident"}},
+	}))
+	require.NoError(t, writer.Close())
+
+	db, files := openDeck(t, out.Bytes())
+
+	var manifest map[string]string
+	require.NoError(t, json.Unmarshal(files["media"], &manifest))
+	assert.Equal(t, map[string]string{"0": "trytrago-11111111-1111-1111-1111-111111111111.ogg"}, manifest)
+	assert.Equal(t, []byte("recording"), files["0"])
+
+	rows, err := db.Query(`SELECT guid, flds, sfld FROM notes ORDER BY id`)
+	require.NoError(t, err)
+	var notes [][]string
+	for rows.Next() {
+		var guid, fields, sortField string
+		require.NoError(t, rows.Scan(&guid, &fields, &sortField))
+		notes = append(notes, append([]string{guid, sortField}, strings.Split(fields, "\x1f")...))
+	}
+	require.NoError(t, rows.Err())
+	require.Len(t, notes, 2)
+
+	bank := notes[0]
+	assert.Equal(t, "11111111-1111-1111-1111-111111111111", bank[0], "Notes keep the entry identity across exports")
+	assert.Equal(t, "bank", bank[1])
+	assert.Equal(t, "bæŋk", bank[3])
+	assert.Equal(t, "rive; banque", bank[4])
+	assert.Contains(t, bank[5], "side of a &lt;river&gt;")
+	assert.Contains(t, bank[5], `<div class="example">the river bank</div>`)
+	assert.Equal(t, "[sound:trytrago-11111111-1111-1111-1111-111111111111.ogg]", bank[6])
+	assert.Empty(t, notes[1][6], "No recording, no sound")
+
+	var cards, reverse int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*), SUM(ord) FROM cards`).Scan(&cards, &reverse))
+	assert.Equal(t, 3, cards, "An entry without translations gets no reverse card")
+	assert.Equal(t, 1, reverse)
+
+	var decks, models string
+	require.NoError(t, db.QueryRow(`SELECT decks, models FROM col`).Scan(&decks, &models))
+	assert.Contains(t, decks, `"name":"English"`)
+	assert.Contains(t, models, "Translation → Word")
+}
+
+func TestAnkiWriterTemplates(t *testing.T) {
+	var out bytes.Buffer
+	writer, err := exchange.NewAnkiWriter(&out, exchange.AnkiOptions{Templates: []string{exchange.AnkiReverse}})
+	require.NoError(t, err)
+	require.NoError(t, writer.Write(&exchange.Record{
+		Word:     "cat",
+		Meanings: []exchange.RecordMeaning{{Translations: []exchange.RecordTranslation{{LanguageID: "fr", Text: "chat"}}}},
+	}))
+	require.NoError(t, writer.Close())
+
+	db, _ := openDeck(t, out.Bytes())
+	var cards, ord int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*), MAX(ord) FROM cards`).Scan(&cards, &ord))
+	assert.Equal(t, 1, cards)
+	assert.Equal(t, 0, ord)
+
+	var models string
+	require.NoError(t, db.QueryRow(`SELECT models FROM col`).Scan(&models))
+	assert.NotContains(t, models, "Word → Translation")
+
+	_, err = exchange.NewAnkiWriter(&out, exchange.AnkiOptions{Templates: []string{"sideways"}})
+	assert.Error(t, err)
+}
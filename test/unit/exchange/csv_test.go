@@ -0,0 +1,118 @@
+package exchange_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valpere/trytrago/infrastructure/exchange"
+)
+
+// readAll drains a record reader, collecting records and row errors
+func readAll(t *testing.T, reader exchange.RecordReader) ([]*exchange.Record, []*exchange.RowError) {
+	var records []*exchange.Record
+	var rowErrs []*exchange.RowError
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return records, rowErrs
+		}
+		var rowErr *exchange.RowError
+		if errors.As(err, &rowErr) {
+			rowErrs = append(rowErrs, rowErr)
+			continue
+		}
+		require.NoError(t, err)
+		records = append(records, record)
+	}
+}
+
+func TestCSVReaderHeaderNames(t *testing.T) {
+	input := "word,type,part_of_speech,description,examples,translation.fr\n" +
+		"bank,WORD,noun,side of a river,the river bank|a steep bank,rive\n" +
+		"Bank,WORD,noun,financial institution,,banque|établissement\n" +
+		"run,,verb,move fast,,courir\n"
+
+	reader, err := exchange.NewCSVReader(strings.NewReader(input), nil)
+	require.NoError(t, err)
+
+	records, rowErrs := readAll(t, reader)
+	require.Empty(t, rowErrs)
+	require.Len(t, records, 2, "Consecutive rows with the same word should be grouped")
+
+	bank := records[0]
+	assert.Equal(t, 2, bank.Position)
+	assert.Equal(t, "bank", bank.Word)
+	require.Len(t, bank.Meanings, 2)
+	assert.Equal(t, []string{"the river bank", "a steep bank"}, bank.Meanings[0].Examples)
+	assert.Len(t, bank.Meanings[1].Translations, 2)
+	assert.Equal(t, "fr", bank.Meanings[1].Translations[0].LanguageID)
+
+	run := records[1]
+	assert.Equal(t, 4, run.Position)
+	assert.Equal(t, "WORD", run.Type, "Type should default to WORD")
+}
+
+func TestCSVReaderMapping(t *testing.T) {
+	mapping, err := exchange.LoadCSVMapping(strings.NewReader(`{
+		"delimiter": "\t",
+		"columns": {"word": "Headword", "description": "Definition", "translation.de": "German"},
+		"defaults": {"part_of_speech": "noun", "source_language_id": "en"}
+	}`))
+	require.NoError(t, err)
+
+	input := "Headword\tDefinition\tGerman\nhouse\ta building\tHaus\n"
+	reader, err := exchange.NewCSVReader(strings.NewReader(input), mapping)
+	require.NoError(t, err)
+
+	records, _ := readAll(t, reader)
+	require.Len(t, records, 1)
+	assert.Equal(t, "en", records[0].SourceLanguageID)
+	require.Len(t, records[0].Meanings, 1)
+	assert.Equal(t, "noun", records[0].Meanings[0].PartOfSpeech)
+	assert.Equal(t, "Haus", records[0].Meanings[0].Translations[0].Text)
+}
+
+func TestCSVReaderNoHeader(t *testing.T) {
+	mapping := &exchange.CSVMapping{
+		NoHeader: true,
+		Columns:  map[string]string{"word": "1", "translation.es": "2"},
+		Defaults: map[string]string{"part_of_speech": "noun"},
+	}
+
+	reader, err := exchange.NewCSVReader(strings.NewReader("cat,gato\ndog,perro\n"), mapping)
+	require.NoError(t, err)
+
+	records, _ := readAll(t, reader)
+	require.Len(t, records, 2)
+	assert.Equal(t, "dog", records[1].Word)
+	assert.Equal(t, 2, records[1].Position)
+}
+
+func TestCSVReaderRowErrors(t *testing.T) {
+	input := "word,description\ngood,fine\nbad,\"broken\"quote\nalso good,fine\n"
+
+	reader, err := exchange.NewCSVReader(strings.NewReader(input), nil)
+	require.NoError(t, err)
+
+	records, rowErrs := readAll(t, reader)
+	assert.Len(t, records, 2, "Reading should continue after a malformed row")
+	require.Len(t, rowErrs, 1)
+	assert.Equal(t, 3, rowErrs[0].Position)
+}
+
+func TestCSVMappingValidation(t *testing.T) {
+	_, err := exchange.LoadCSVMapping(strings.NewReader(`{"columns": {"meaning": "A"}}`))
+	assert.Error(t, err, "Unknown fields should be rejected")
+
+	_, err = exchange.NewCSVReader(strings.NewReader("headword\nbank\n"), nil)
+	assert.Error(t, err, "A word column is required")
+
+	_, err = exchange.NewCSVReader(strings.NewReader("word\n"), &exchange.CSVMapping{
+		Columns: map[string]string{"word": "Missing"},
+	})
+	assert.Error(t, err, "Mapped columns must exist")
+}
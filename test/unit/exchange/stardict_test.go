@@ -0,0 +1,238 @@
+package exchange_test
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valpere/trytrago/infrastructure/exchange"
+)
+
+// writeStarDict writes raw StarDict files for reader tests
+func writeStarDict(t *testing.T, ifo string, words []string, articles []string) string {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "sample")
+
+	var idx, dict bytes.Buffer
+	for i, word := range words {
+		idx.WriteString(word)
+		idx.WriteByte(0)
+		binary.Write(&idx, binary.BigEndian, uint32(dict.Len()))
+		binary.Write(&idx, binary.BigEndian, uint32(len(articles[i])))
+		dict.WriteString(articles[i])
+	}
+
+	require.NoError(t, os.WriteFile(base+".ifo", []byte(ifo), 0o644))
+	require.NoError(t, os.WriteFile(base+".idx", idx.Bytes(), 0o644))
+	require.NoError(t, os.WriteFile(base+".dict", dict.Bytes(), 0o644))
+	return base + ".ifo"
+}
+
+func TestStarDictReaderBilingual(t *testing.T) {
+	ifo := "StarDict's dict ifo file\nversion=2.4.2\nwordcount=2\nbookname=Sample\nsametypesequence=m\n"
+	path := writeStarDict(t, ifo,
+		[]string{"bank", "look up"},
+		[]string{
+			"n.\n1. banque; établissement\nHe went to the bank.\n2. rive\nv.\n3. compter sur",
+			"chercher",
+		})
+
+	reader, err := exchange.NewStarDictReader(path, exchange.StarDictImportOptions{
+		SourceLanguageID:    "en",
+		TargetLanguageID:    "fr",
+		DefaultPartOfSpeech: "unspecified",
+	})
+	require.NoError(t, err)
+	defer reader.Close()
+
+	records, rowErrs := readAll(t, reader)
+	require.Empty(t, rowErrs)
+	require.Len(t, records, 2)
+
+	bank := records[0]
+	assert.Equal(t, "bank", bank.Word)
+	assert.Equal(t, "WORD", bank.Type)
+	assert.Equal(t, "en", bank.SourceLanguageID)
+	require.Len(t, bank.Meanings, 3)
+	assert.Equal(t, "noun", bank.Meanings[0].PartOfSpeech)
+	assert.Equal(t, []exchange.RecordTranslation{
+		{LanguageID: "fr", Text: "banque"},
+		{LanguageID: "fr", Text: "établissement"},
+	}, bank.Meanings[0].Translations)
+	assert.Equal(t, []string{"He went to the bank."}, bank.Meanings[0].Examples)
+	assert.Equal(t, "noun", bank.Meanings[1].PartOfSpeech)
+	assert.Equal(t, "verb", bank.Meanings[2].PartOfSpeech)
+
+	phrase := records[1]
+	assert.Equal(t, "PHRASE", phrase.Type)
+	require.Len(t, phrase.Meanings, 1)
+	assert.Equal(t, "unspecified", phrase.Meanings[0].PartOfSpeech)
+}
+
+func TestStarDictReaderTypedFields(t *testing.T) {
+	// Without sametypesequence every field carries its type
+	ifo := "StarDict's dict ifo file\nversion=2.4.2\nwordcount=1\nbookname=Sample\n"
+	article := "t" + "bæŋk\x00" + "h" + "adj. <b>steep</b> &amp; high<br>adv. quickly\x00"
+	path := writeStarDict(t, ifo, []string{"bank"}, []string{article})
+
+	reader, err := exchange.NewStarDictReader(path, exchange.StarDictImportOptions{DefaultPartOfSpeech: "unspecified"})
+	require.NoError(t, err)
+	defer reader.Close()
+
+	records, _ := readAll(t, reader)
+	require.Len(t, records, 1)
+	assert.Equal(t, "bæŋk", records[0].Pronunciation)
+	require.Len(t, records[0].Meanings, 2)
+	assert.Equal(t, exchange.RecordMeaning{PartOfSpeech: "adjective", Description: "steep & high"}, records[0].Meanings[0])
+	assert.Equal(t, exchange.RecordMeaning{PartOfSpeech: "adverb", Description: "quickly"}, records[0].Meanings[1])
+}
+
+func TestStarDictReaderNotStarDict(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bad.ifo")
+	require.NoError(t, os.WriteFile(path, []byte("hello"), 0o644))
+
+	_, err := exchange.NewStarDictReader(path, exchange.StarDictImportOptions{})
+	assert.Error(t, err)
+}
+
+func TestStarDictRoundTrip(t *testing.T) {
+	base := filepath.Join(t.TempDir(), "out")
+
+	writer, err := exchange.NewStarDictWriter(base, "Round trip")
+	require.NoError(t, err)
+	require.NoError(t, writer.Write(&exchange.Record{
+		Word: "zebra",
+		Type: "WORD",
+		Meanings: []exchange.RecordMeaning{{
+			PartOfSpeech: "noun",
+			Translations: []exchange.RecordTranslation{{LanguageID: "fr", Text: "zèbre"}},
+		}},
+	}))
+	require.NoError(t, writer.Write(&exchange.Record{
+		Word: "Apple",
+		Type: "WORD",
+		Meanings: []exchange.RecordMeaning{{
+			PartOfSpeech: "noun",
+			Translations: []exchange.RecordTranslation{{LanguageID: "fr", Text: "pomme"}},
+		}},
+	}))
+	require.NoError(t, writer.Close())
+
+	ifo, err := os.ReadFile(base + ".ifo")
+	require.NoError(t, err)
+	assert.Contains(t, string(ifo), "wordcount=2\n")
+	assert.Contains(t, string(ifo), "bookname=Round trip\n")
+
+	// The writer's own layout marks translations with their language
+	reader, err := exchange.NewStarDictReader(base+".ifo", exchange.StarDictImportOptions{})
+	require.NoError(t, err)
+	defer reader.Close()
+
+	records, rowErrs := readAll(t, reader)
+	require.Empty(t, rowErrs)
+	require.Len(t, records, 2)
+	assert.Equal(t, "Apple", records[0].Word, "The index is sorted ignoring case")
+	assert.Equal(t, "zebra", records[1].Word)
+	require.Len(t, records[0].Meanings, 1)
+	assert.Equal(t, "noun", records[0].Meanings[0].PartOfSpeech)
+	assert.Equal(t, []exchange.RecordTranslation{{LanguageID: "fr", Text: "pomme"}}, records[0].Meanings[0].Translations)
+}
+
+func TestStarDictDictzipChunks(t *testing.T) {
+	base := filepath.Join(t.TempDir(), "big")
+
+	// An article larger than one dictzip chunk
+	description := strings.Repeat("a long description ", 5000)
+	writer, err := exchange.NewStarDictWriter(base, "Big")
+	require.NoError(t, err)
+	require.NoError(t, writer.Write(&exchange.Record{
+		Word:     "long",
+		Type:     "WORD",
+		Meanings: []exchange.RecordMeaning{{PartOfSpeech: "noun", Description: description}},
+	}))
+	require.NoError(t, writer.Close())
+
+	data, err := os.ReadFile(base + ".dict.dz")
+	require.NoError(t, err)
+
+	// It is an ordinary gzip file...
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	article, err := io.ReadAll(gz)
+	require.NoError(t, err)
+	assert.Contains(t, string(article), description)
+
+	// ...whose chunks inflate independently of each other
+	require.Equal(t, byte(0x04), data[3]&0x04, "FEXTRA must be set")
+	extra := data[12 : 12+binary.LittleEndian.Uint16(data[10:12])]
+	require.Equal(t, "RA", string(extra[:2]))
+	chunkLength := int(binary.LittleEndian.Uint16(extra[6:8]))
+	chunkCount := int(binary.LittleEndian.Uint16(extra[8:10]))
+	require.Greater(t, chunkCount, 1)
+
+	offset := 12 + len(extra)
+	for i := 0; i < chunkCount; i++ {
+		size := int(binary.LittleEndian.Uint16(extra[10+2*i:]))
+		chunk, _ := io.ReadAll(flate.NewReader(bytes.NewReader(data[offset : offset+size])))
+		expected := article[i*chunkLength : min((i+1)*chunkLength, len(article))]
+		assert.Equal(t, expected, chunk, "chunk %d", i)
+		offset += size
+	}
+}
+
+func TestDictdWriter(t *testing.T) {
+	base := filepath.Join(t.TempDir(), "out")
+
+	writer, err := exchange.NewDictdWriter(base, "Sample")
+	require.NoError(t, err)
+	require.NoError(t, writer.Write(&exchange.Record{
+		Word:          "bank",
+		Type:          "WORD",
+		Pronunciation: "bæŋk",
+		Meanings: []exchange.RecordMeaning{{
+			PartOfSpeech: "noun",
+			Description:  "financial institution",
+			Examples:     []string{"I went to the bank."},
+			Translations: []exchange.RecordTranslation{{LanguageID: "fr", Text: "banque"}},
+		}},
+	}))
+	require.NoError(t, writer.Close())
+
+	dict, err := os.ReadFile(base + ".dict")
+	require.NoError(t, err)
+	index, err := os.ReadFile(base + ".index")
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(index)), "\n")
+	require.Len(t, lines, 4)
+	assert.Equal(t, "bank\tA\t", lines[3][:7], "The first article starts at offset zero")
+
+	// Offsets and lengths are dictd base64 numbers
+	decode := func(s string) int {
+		const alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+		n := 0
+		for _, c := range s {
+			n = n*64 + strings.IndexRune(alphabet, c)
+		}
+		return n
+	}
+	articles := make(map[string]string)
+	for _, line := range lines {
+		fields := strings.Split(line, "\t")
+		require.Len(t, fields, 3)
+		offset, length := decode(fields[1]), decode(fields[2])
+		articles[fields[0]] = string(dict[offset : offset+length])
+	}
+
+	assert.Equal(t, "bank\n   [bæŋk]\n   1. (noun) financial institution\n      fr: banque\n      * I went to the bank.\n", articles["bank"])
+	assert.Equal(t, "00-database-short\n   Sample\n", articles["00-database-short"])
+	assert.Contains(t, articles, "00-database-utf8")
+}
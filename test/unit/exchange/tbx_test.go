@@ -0,0 +1,139 @@
+package exchange_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valpere/trytrago/infrastructure/exchange"
+)
+
+func TestTBXRoundTrip(t *testing.T) {
+	original := []*exchange.Record{
+		{
+			ID:               "4b7e5a5c-0000-4000-8000-000000000001",
+			Word:             "bank",
+			Type:             "WORD",
+			SourceLanguageID: "en",
+			Meanings: []exchange.RecordMeaning{
+				{
+					PartOfSpeech: "noun",
+					Description:  "financial institution",
+					Labels:       []string{"finance"},
+					Examples:     []string{"I went to the bank."},
+					Translations: []exchange.RecordTranslation{
+						{LanguageID: "fr", Text: "banque"},
+						{LanguageID: "de", Text: "Bank"},
+						{LanguageID: "fr", Text: "établissement bancaire"},
+					},
+				},
+				{
+					PartOfSpeech: "noun",
+					Description:  "side of a river",
+					Translations: []exchange.RecordTranslation{{LanguageID: "fr", Text: "rive"}},
+				},
+			},
+		},
+		{Word: "by the way", Type: "PHRASE", SourceLanguageID: "en", Meanings: []exchange.RecordMeaning{{PartOfSpeech: "adverb"}}},
+	}
+
+	var buf bytes.Buffer
+	writer := exchange.NewTBXWriter(&buf, "Round trip")
+	for _, record := range original {
+		require.NoError(t, writer.Write(record))
+	}
+	require.NoError(t, writer.Close())
+
+	output := buf.String()
+	assert.Contains(t, output, `<tbx type="TBX-Basic" style="dca" xml:lang="en" xmlns="urn:iso:std:iso:30042:ed-2">`)
+	assert.Contains(t, output, `<conceptEntry id="c-4b7e5a5c-0000-4000-8000-000000000001-2">`)
+	assert.Contains(t, output, `<termNote type="usageNote">finance</termNote>`)
+	assert.Equal(t, 3, strings.Count(output, "<conceptEntry"), "Each meaning is a concept of its own")
+
+	records, rowErrs := readAll(t, exchange.NewTBXReader(&buf, exchange.TBXImportOptions{}))
+	require.Empty(t, rowErrs)
+	require.Len(t, records, 2, "Concepts of the same term are read back as one entry")
+
+	bank := records[0]
+	assert.Equal(t, "bank", bank.Word)
+	assert.Equal(t, "en", bank.SourceLanguageID)
+	require.Len(t, bank.Meanings, 2)
+	assert.Equal(t, original[0].Meanings[0].Description, bank.Meanings[0].Description)
+	assert.Equal(t, []string{"finance"}, bank.Meanings[0].Labels)
+	assert.Equal(t, []string{"I went to the bank."}, bank.Meanings[0].Examples)
+	assert.ElementsMatch(t, original[0].Meanings[0].Translations, bank.Meanings[0].Translations)
+	assert.Equal(t, original[0].Meanings[1].Translations, bank.Meanings[1].Translations)
+
+	assert.Equal(t, "PHRASE", records[1].Type)
+	assert.Equal(t, "adverb", records[1].Meanings[0].PartOfSpeech)
+}
+
+func TestTBXReaderTBX2008(t *testing.T) {
+	input := `<?xml version="1.0" encoding="UTF-8"?>
+<martif type="TBX" xml:lang="en-US">
+  <martifHeader><fileDesc><sourceDesc><p>CAT tool export</p></sourceDesc></fileDesc></martifHeader>
+  <text>
+    <body>
+      <termEntry id="t1">
+        <descrip type="subjectField">Medicine</descrip>
+        <langSet xml:lang="en-US">
+          <descripGrp><descrip type="definition">a <hi>painful</hi> swelling</descrip></descripGrp>
+          <tig>
+            <term>abscess</term>
+            <termNote type="partOfSpeech">Noun</termNote>
+          </tig>
+          <ntig>
+            <termGrp><term>boil</term></termGrp>
+          </ntig>
+        </langSet>
+        <langSet xml:lang="zh-Hans">
+          <tig><term>脓肿</term></tig>
+        </langSet>
+      </termEntry>
+    </body>
+  </text>
+</martif>`
+
+	reader := exchange.NewTBXReader(strings.NewReader(input), exchange.TBXImportOptions{DefaultPartOfSpeech: "unspecified"})
+	records, rowErrs := readAll(t, reader)
+	require.Empty(t, rowErrs)
+	require.Len(t, records, 2, "Every source term becomes an entry")
+
+	abscess := records[0]
+	assert.Equal(t, "abscess", abscess.Word)
+	assert.Equal(t, "en-us", abscess.SourceLanguageID)
+	assert.Equal(t, 6, abscess.Position)
+	require.Len(t, abscess.Meanings, 1)
+	assert.Equal(t, "noun", abscess.Meanings[0].PartOfSpeech)
+	assert.Equal(t, "a painful swelling", abscess.Meanings[0].Description)
+	assert.Equal(t, []string{"medicine"}, abscess.Meanings[0].Labels)
+	assert.Equal(t, []exchange.RecordTranslation{{LanguageID: "zh", Text: "脓肿"}}, abscess.Meanings[0].Translations)
+
+	assert.Equal(t, "boil", records[1].Word)
+	assert.Equal(t, "unspecified", records[1].Meanings[0].PartOfSpeech)
+}
+
+func TestTBXReaderSourceLanguage(t *testing.T) {
+	input := `<tbx xmlns="urn:iso:std:iso:30042:ed-2" xml:lang="en"><text><body>
+  <conceptEntry>
+    <langSec xml:lang="en"><termSec><term>cat</term></termSec></langSec>
+    <langSec xml:lang="fr"><termSec><term>chat</term></termSec></langSec>
+  </conceptEntry>
+</body></text></tbx>`
+
+	records, _ := readAll(t, exchange.NewTBXReader(strings.NewReader(input), exchange.TBXImportOptions{SourceLanguageID: "FR"}))
+	require.Len(t, records, 1)
+	assert.Equal(t, "chat", records[0].Word)
+	assert.Equal(t, "fr", records[0].SourceLanguageID)
+	assert.Equal(t, []exchange.RecordTranslation{{LanguageID: "en", Text: "cat"}}, records[0].Meanings[0].Translations)
+}
+
+func TestTBXReaderMalformed(t *testing.T) {
+	input := `<tbx xml:lang="en"><text><body><conceptEntry><langSec xml:lang="en"><termSec><term>cat</termSec>`
+
+	_, err := exchange.NewTBXReader(strings.NewReader(input), exchange.TBXImportOptions{}).Read()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid TBX concept")
+}
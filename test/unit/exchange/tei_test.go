@@ -0,0 +1,133 @@
+package exchange_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valpere/trytrago/infrastructure/exchange"
+)
+
+func TestTEIReader(t *testing.T) {
+	input := `<?xml version="1.0" encoding="UTF-8"?>
+<TEI xmlns="http://www.tei-c.org/ns/1.0" xml:lang="en">
+  <teiHeader><fileDesc><titleStmt><title>Sample</title></titleStmt></fileDesc></teiHeader>
+  <text>
+    <body>
+      <entry xml:id="bank" type="mainEntry">
+        <form type="lemma"><orth>bank</orth><pron>bæŋk</pron></form>
+        <gramGrp><gram type="pos">Noun</gram></gramGrp>
+        <sense n="1">
+          <def>the land alongside a <hi>river</hi></def>
+          <cit type="example"><quote>We walked along the bank.</quote></cit>
+          <cit type="translationEquivalent" xml:lang="FR"><form><orth>rive</orth></form></cit>
+        </sense>
+        <sense n="2">
+          <sense>
+            <gramGrp><gram type="pos" norm="verb"/></gramGrp>
+            <def>to deposit money</def>
+            <cit type="translation" xml:lang="de"><quote>einzahlen</quote></cit>
+          </sense>
+        </sense>
+      </entry>
+      <entry xml:lang="fr" type="phrase">
+        <form type="variant"><orth>à la carte</orth></form>
+      </entry>
+    </body>
+  </text>
+</TEI>`
+
+	records, rowErrs := readAll(t, exchange.NewTEIReader(strings.NewReader(input)))
+	require.Empty(t, rowErrs)
+	require.Len(t, records, 2)
+
+	bank := records[0]
+	assert.Equal(t, "bank", bank.Word)
+	assert.Equal(t, "WORD", bank.Type)
+	assert.Equal(t, "en", bank.SourceLanguageID, "Language is inherited from the document")
+	assert.Equal(t, "bæŋk", bank.Pronunciation)
+	assert.Equal(t, 6, bank.Position)
+	require.Len(t, bank.Meanings, 2, "A sense holding only subsenses is not a meaning")
+
+	assert.Equal(t, "noun", bank.Meanings[0].PartOfSpeech)
+	assert.Equal(t, "the land alongside a river", bank.Meanings[0].Description)
+	assert.Equal(t, []string{"We walked along the bank."}, bank.Meanings[0].Examples)
+	assert.Equal(t, []exchange.RecordTranslation{{LanguageID: "fr", Text: "rive"}}, bank.Meanings[0].Translations)
+
+	assert.Equal(t, "verb", bank.Meanings[1].PartOfSpeech)
+	assert.Equal(t, []exchange.RecordTranslation{{LanguageID: "de", Text: "einzahlen"}}, bank.Meanings[1].Translations)
+
+	phrase := records[1]
+	assert.Equal(t, "à la carte", phrase.Word)
+	assert.Equal(t, "PHRASE", phrase.Type)
+	assert.Equal(t, "fr", phrase.SourceLanguageID)
+	assert.Empty(t, phrase.Meanings)
+}
+
+func TestTEIReaderMalformed(t *testing.T) {
+	input := `<TEI xmlns="http://www.tei-c.org/ns/1.0"><text><body><entry><form><orth>bank</form></entry>`
+
+	reader := exchange.NewTEIReader(strings.NewReader(input))
+	_, err := reader.Read()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid TEI entry")
+}
+
+func TestTEIRoundTrip(t *testing.T) {
+	original := []*exchange.Record{
+		{
+			ID:               "4b7e5a5c-0000-4000-8000-000000000001",
+			Word:             "bank",
+			Type:             "WORD",
+			SourceLanguageID: "en",
+			Pronunciation:    "bæŋk",
+			Etymology:        "From Italian banca.",
+			Meanings: []exchange.RecordMeaning{
+				{
+					PartOfSpeech: "noun",
+					Description:  "financial institution & <more>",
+					Labels:       []string{"finance"},
+					Examples:     []string{"I went to the bank."},
+					Translations: []exchange.RecordTranslation{
+						{LanguageID: "fr", Text: "banque"},
+						{LanguageID: "es", Text: "banco"},
+					},
+				},
+			},
+		},
+		{Word: "break down", Type: "COMPOUND_WORD"},
+	}
+
+	var buf bytes.Buffer
+	writer := exchange.NewTEIWriter(&buf, "Round trip")
+	for _, record := range original {
+		require.NoError(t, writer.Write(record))
+	}
+	require.NoError(t, writer.Close())
+
+	output := buf.String()
+	assert.Contains(t, output, `<TEI xmlns="http://www.tei-c.org/ns/1.0">`)
+	assert.Contains(t, output, `xml:id="e-4b7e5a5c-0000-4000-8000-000000000001"`)
+	assert.Contains(t, output, `<title>Round trip</title>`)
+
+	records, rowErrs := readAll(t, exchange.NewTEIReader(&buf))
+	require.Empty(t, rowErrs)
+	require.Len(t, records, 2)
+
+	for i := range records {
+		records[i].Position = 0
+	}
+	original[0].ID = ""
+	assert.Equal(t, original, records)
+}
+
+func TestTEIWriterEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	writer := exchange.NewTEIWriter(&buf, "Empty")
+	require.NoError(t, writer.Close())
+
+	records, _ := readAll(t, exchange.NewTEIReader(&buf))
+	assert.Empty(t, records)
+}
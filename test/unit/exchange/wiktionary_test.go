@@ -0,0 +1,59 @@
+package exchange_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valpere/trytrago/infrastructure/exchange"
+)
+
+func TestWiktionaryReader(t *testing.T) {
+	input := strings.Join([]string{
+		`{"word": "bank", "pos": "noun", "lang_code": "en", "etymology_text": "From Italian banca.",` +
+			`"sounds": [{"audio": "en-us-bank.ogg"}, {"ipa": "/bæŋk/"}],` +
+			`"senses": [{"glosses": ["An institution where one can deposit money."], "examples": [{"text": "I went to the bank."}]},` +
+			`{"glosses": ["The edge of a river or lake."]}],` +
+			`"translations": [{"code": "fr", "word": "rive", "sense": "edge of a river"},` +
+			`{"code": "fr", "word": "banque", "sense": "institution"},` +
+			`{"code": "de", "word": "Bank"},` +
+			`{"code": "nds", "word": "Bank", "sense": "institution"}]}`,
+		`{"word": "Bank", "pos": "noun", "lang_code": "de", "senses": [{"glosses": ["bank"]}]}`,
+		``,
+		`{"word": "quickly", "pos": "adv", "lang_code": "en", "senses": [{"glosses": ["In a quick manner.", "Rapidly."]}]}`,
+		`{"word": "banks", "pos": "noun", "lang_code": "en", "senses": [{"tags": ["form-of"]}]}`,
+		`{"word": "broken`,
+		`{"word": "at all", "pos": "adv", "lang_code": "en", "senses": [{"glosses": ["In any way."]}]}`,
+	}, "\n")
+
+	records, rowErrs := readAll(t, exchange.NewWiktionaryReader(strings.NewReader(input), "EN"))
+	require.Len(t, rowErrs, 1)
+	assert.Equal(t, 6, rowErrs[0].Position)
+	require.Len(t, records, 3, "Other languages and entries without glosses are skipped")
+
+	bank := records[0]
+	assert.Equal(t, 1, bank.Position)
+	assert.Equal(t, "WORD", bank.Type)
+	assert.Equal(t, "en", bank.SourceLanguageID)
+	assert.Equal(t, "/bæŋk/", bank.Pronunciation)
+	assert.Equal(t, "From Italian banca.", bank.Etymology)
+	assert.Equal(t, exchange.WiktionarySource, bank.Source)
+	assert.Equal(t, exchange.WiktionaryLicense, bank.License)
+	require.Len(t, bank.Meanings, 2)
+
+	assert.Equal(t, "noun", bank.Meanings[0].PartOfSpeech)
+	assert.Equal(t, []string{"I went to the bank."}, bank.Meanings[0].Examples)
+	assert.Equal(t, []exchange.RecordTranslation{
+		{LanguageID: "fr", Text: "banque"},
+		{LanguageID: "de", Text: "Bank"},
+	}, bank.Meanings[0].Translations, "Unlabelled translations go to the first meaning")
+	assert.Equal(t, []exchange.RecordTranslation{{LanguageID: "fr", Text: "rive"}}, bank.Meanings[1].Translations)
+
+	quickly := records[1]
+	require.Len(t, quickly.Meanings, 1)
+	assert.Equal(t, "adverb", quickly.Meanings[0].PartOfSpeech)
+	assert.Equal(t, "Rapidly.", quickly.Meanings[0].Description, "A subsense keeps its own gloss")
+
+	assert.Equal(t, "PHRASE", records[2].Type)
+}
@@ -0,0 +1,212 @@
+package graph_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valpere/trytrago/application/service"
+	"github.com/valpere/trytrago/domain/database"
+	"github.com/valpere/trytrago/interface/api/graph"
+	"github.com/valpere/trytrago/test/mocks"
+)
+
+// setupSchema builds a schema over services backed by a mock repository
+func setupSchema(t *testing.T, limits graph.Limits) (*graph.Schema, *mocks.MockRepository) {
+	mockRepo := new(mocks.MockRepository)
+	mockLogger := new(mocks.MockLogger)
+
+	mockLogger.On("With", mock.Anything).Return(mockLogger)
+	mockLogger.On("Debug", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	schema, err := graph.NewSchema(
+		service.NewEntryService(mockRepo, mockLogger),
+		service.NewTranslationService(mockRepo, mockLogger),
+		service.NewGraphService(mockRepo, mockLogger),
+		limits,
+		false,
+		mockLogger,
+	)
+	require.NoError(t, err)
+
+	return schema, mockRepo
+}
+
+// sameIDs matches a slice holding exactly the given IDs, in any order
+func sameIDs(expected ...uuid.UUID) interface{} {
+	return mock.MatchedBy(func(ids []uuid.UUID) bool {
+		if len(ids) != len(expected) {
+			return false
+		}
+		seen := make(map[uuid.UUID]bool, len(ids))
+		for _, id := range ids {
+			seen[id] = true
+		}
+		for _, id := range expected {
+			if !seen[id] {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// errorCode returns the code in the extensions of an error
+func errorCode(err gqlerrors.FormattedError) string {
+	code, _ := err.Extensions["code"].(string)
+	return code
+}
+
+// TestNestedQueryLoadsEachLevelOnce tests that resolvers batch their loads,
+// so a nested query costs one repository call per level, however many parents it has
+func TestNestedQueryLoadsEachLevelOnce(t *testing.T) {
+	schema, mockRepo := setupSchema(t, graph.Limits{MaxDepth: 8, MaxComplexity: 10000})
+
+	bank, bark := uuid.New(), uuid.New()
+	riverSide, moneyPlace, dogSound := uuid.New(), uuid.New(), uuid.New()
+	noun := database.PartOfSpeech{ID: uuid.New(), Name: "noun"}
+
+	mockRepo.On("ListEntries", mock.Anything, mock.Anything).Return([]database.Entry{
+		{ID: bank, Word: "bank", Type: database.WordType, HomographIndex: 1},
+		{ID: bark, Word: "bark", Type: database.WordType, HomographIndex: 1},
+	}, nil).Once()
+	mockRepo.On("ListMeaningsByEntryIDs", mock.Anything, sameIDs(bank, bark)).Return([]database.Meaning{
+		{ID: riverSide, EntryID: bank, PartOfSpeechId: noun.ID, Description: "river side"},
+		{ID: moneyPlace, EntryID: bank, PartOfSpeechId: noun.ID, Description: "money place"},
+		{ID: dogSound, EntryID: bark, PartOfSpeechId: noun.ID, Description: "dog sound"},
+	}, nil).Once()
+	mockRepo.On("ListPartsOfSpeech", mock.Anything).Return([]database.PartOfSpeech{noun}, nil).Once()
+	mockRepo.On("ListTranslationsByMeaningIDs", mock.Anything, sameIDs(riverSide, moneyPlace, dogSound)).Return([]database.Translation{
+		{ID: uuid.New(), MeaningID: riverSide, LanguageID: "fr", Text: "rive", Status: database.TranslationApproved},
+		{ID: uuid.New(), MeaningID: dogSound, LanguageID: "fr", Text: "aboiement", Status: database.TranslationApproved},
+	}, nil).Once()
+	mockRepo.On("ListLanguages", mock.Anything).Return([]database.Language{
+		{Code: "fr", Name: "French", NativeName: "Français"},
+	}, nil).Once()
+
+	result, ok := schema.Execute(context.Background(), &graph.Request{
+		Query: `{ entries { word meanings { description partOfSpeech translations { text language { name } } } } }`,
+	})
+
+	require.True(t, ok)
+	require.Empty(t, result.Errors)
+	entries := result.Data.(map[string]interface{})["entries"].([]interface{})
+	require.Len(t, entries, 2)
+	bankMeanings := entries[0].(map[string]interface{})["meanings"].([]interface{})
+	require.Len(t, bankMeanings, 2)
+	riverSideData := bankMeanings[0].(map[string]interface{})
+	assert.Equal(t, "noun", riverSideData["partOfSpeech"])
+	translation := riverSideData["translations"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "rive", translation["text"])
+	assert.Equal(t, "French", translation["language"].(map[string]interface{})["name"])
+	mockRepo.AssertExpectations(t)
+}
+
+// TestEntryFollowsMergeRedirect tests that an entry merged into another is
+// resolved to the entry that absorbed it, as on the REST and gRPC APIs
+func TestEntryFollowsMergeRedirect(t *testing.T) {
+	schema, mockRepo := setupSchema(t, graph.Limits{MaxDepth: 8, MaxComplexity: 10000})
+
+	merged, kept := uuid.New(), uuid.New()
+	mockRepo.On("GetEntriesByIDs", mock.Anything, sameIDs(merged)).Return([]database.Entry{}, nil).Once()
+	mockRepo.On("GetEntryRedirect", mock.Anything, merged).Return(&database.EntryRedirect{FromID: merged, ToID: kept}, nil).Once()
+	mockRepo.On("GetEntriesByIDs", mock.Anything, sameIDs(kept)).Return([]database.Entry{
+		{ID: kept, Word: "bank", Type: database.WordType, HomographIndex: 1},
+	}, nil).Once()
+
+	result, ok := schema.Execute(context.Background(), &graph.Request{
+		Query: `{ entry(id: "` + merged.String() + `") { id word } }`,
+	})
+
+	require.True(t, ok)
+	require.Empty(t, result.Errors)
+	entry := result.Data.(map[string]interface{})["entry"].(map[string]interface{})
+	assert.Equal(t, kept.String(), entry["id"])
+	assert.Equal(t, "bank", entry["word"])
+	mockRepo.AssertExpectations(t)
+}
+
+// TestQueryLimits tests that queries past the depth or complexity limit are not run
+func TestQueryLimits(t *testing.T) {
+	testCases := []struct {
+		name   string
+		limits graph.Limits
+		query  string
+	}{
+		{
+			name:   "Too deep",
+			limits: graph.Limits{MaxDepth: 4},
+			query:  `{ entries { meanings { entry { meanings { id } } } } }`,
+		},
+		{
+			name:   "Too deep through a fragment",
+			limits: graph.Limits{MaxDepth: 3},
+			query:  `{ entries { ...M } } fragment M on Entry { meanings { entry { id } } }`,
+		},
+		{
+			name:   "Too complex by list size",
+			limits: graph.Limits{MaxComplexity: 500},
+			query:  `{ entries(limit: 100) { meanings { translations { id } } } }`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			schema, mockRepo := setupSchema(t, tc.limits)
+
+			result, ok := schema.Execute(context.Background(), &graph.Request{Query: tc.query})
+
+			assert.False(t, ok)
+			require.Len(t, result.Errors, 1)
+			assert.Equal(t, "QUERY_LIMIT_EXCEEDED", errorCode(result.Errors[0]))
+			mockRepo.AssertNotCalled(t, "ListEntries", mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("Within limits with a variable limit", func(t *testing.T) {
+		schema, mockRepo := setupSchema(t, graph.Limits{MaxDepth: 3, MaxComplexity: 20})
+		mockRepo.On("ListEntries", mock.Anything, mock.Anything).Return([]database.Entry{}, nil).Once()
+
+		result, ok := schema.Execute(context.Background(), &graph.Request{
+			Query:     `query($n: Int) { entries(limit: $n) { id word } }`,
+			Variables: map[string]interface{}{"n": float64(5)},
+		})
+
+		assert.True(t, ok)
+		assert.Empty(t, result.Errors)
+	})
+}
+
+// TestMutations tests that mutations need a user and validate their input
+func TestMutations(t *testing.T) {
+	createEntry := `mutation { createEntry(input: {word: "bank", type: "NOUN"}) { id } }`
+
+	t.Run("Unauthenticated", func(t *testing.T) {
+		schema, mockRepo := setupSchema(t, graph.Limits{})
+
+		result, ok := schema.Execute(context.Background(), &graph.Request{Query: createEntry})
+
+		assert.True(t, ok)
+		require.Len(t, result.Errors, 1)
+		assert.Equal(t, "UNAUTHENTICATED", errorCode(result.Errors[0]))
+		mockRepo.AssertNotCalled(t, "CreateEntry", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Invalid input", func(t *testing.T) {
+		schema, mockRepo := setupSchema(t, graph.Limits{})
+		ctx := graph.WithUserID(context.Background(), uuid.New())
+
+		result, ok := schema.Execute(ctx, &graph.Request{Query: createEntry})
+
+		assert.True(t, ok)
+		require.Len(t, result.Errors, 1)
+		assert.Equal(t, "BAD_USER_INPUT", errorCode(result.Errors[0]))
+		mockRepo.AssertNotCalled(t, "CreateEntry", mock.Anything, mock.Anything)
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -14,6 +15,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/valpere/trytrago/application/dto/request"
 	"github.com/valpere/trytrago/application/dto/response"
+	"github.com/valpere/trytrago/domain/database"
 	"github.com/valpere/trytrago/domain/logging"
 	"github.com/valpere/trytrago/interface/api/rest/handler"
 	"go.uber.org/zap/zapcore"
@@ -49,8 +51,8 @@ func (m *MockEntryService) UpdateEntry(ctx context.Context, id uuid.UUID, req *r
 	return args.Get(0).(*response.EntryResponse), args.Error(1)
 }
 
-func (m *MockEntryService) DeleteEntry(ctx context.Context, id uuid.UUID) error {
-	args := m.Called(ctx, id)
+func (m *MockEntryService) DeleteEntry(ctx context.Context, id uuid.UUID, version int) error {
+	args := m.Called(ctx, id, version)
 	return args.Error(0)
 }
 
@@ -78,8 +80,8 @@ func (m *MockEntryService) UpdateMeaning(ctx context.Context, id uuid.UUID, req
 	return args.Get(0).(*response.MeaningResponse), args.Error(1)
 }
 
-func (m *MockEntryService) DeleteMeaning(ctx context.Context, id uuid.UUID) error {
-	args := m.Called(ctx, id)
+func (m *MockEntryService) DeleteMeaning(ctx context.Context, id uuid.UUID, version int) error {
+	args := m.Called(ctx, id, version)
 	return args.Error(0)
 }
 
@@ -123,14 +125,37 @@ func setupRouter() *gin.Engine {
 	return router
 }
 
-// Basic test for ListEntries
-// TODO
+// Test ListEntries with filters and the default page size
 func TestListEntries(t *testing.T) {
-	// Skip this test for now - we'll fix it later
-	t.Skip("Skipping test until we can properly mock service calls through Gin binding")
+	mockService := new(MockEntryService)
+	router := setupRouter()
+	router.GET("/entries", handler.NewEntryHandler(mockService, new(MockLogger)).ListEntries)
+
+	mockService.On("ListEntries", mock.Anything, mock.MatchedBy(func(req *request.ListEntriesRequest) bool {
+		return req.Limit == 20 && req.Type == "WORD" && req.WordFilter == "bank"
+	})).Return(&response.EntryListResponse{
+		Entries: []*response.EntryResponse{{ID: uuid.New(), Word: "bank", Type: "WORD"}},
+		Total:   1,
+		Limit:   20,
+	}, nil).Once()
 
-	// Basic assertions for the CI to pass
-	assert.True(t, true)
+	req, _ := http.NewRequest("GET", "/entries?type=WORD&word_filter=bank", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp response.EntryListResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Entries, 1)
+	assert.Equal(t, "bank", resp.Entries[0].Word)
+
+	// Invalid parameters never reach the service
+	req, _ = http.NewRequest("GET", "/entries?limit=1000", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertExpectations(t)
 }
 
 // Test GetEntry with valid and invalid input
@@ -265,7 +290,7 @@ func TestDeleteEntry(t *testing.T) {
 	entryID := uuid.New()
 
 	// Setup mock expectations
-	mockService.On("DeleteEntry", mock.Anything, entryID).Return(nil)
+	mockService.On("DeleteEntry", mock.Anything, entryID, 0).Return(nil)
 
 	// Create request
 	req, _ := http.NewRequest("DELETE", "/entries/"+entryID.String(), nil)
@@ -278,3 +303,100 @@ func TestDeleteEntry(t *testing.T) {
 	assert.Equal(t, http.StatusNoContent, w.Code)
 	mockService.AssertExpectations(t)
 }
+
+// TestUpdateEntryIfMatch tests that If-Match reaches the service as the
+// expected version and that a stale version is refused
+func TestUpdateEntryIfMatch(t *testing.T) {
+	entryID := uuid.New()
+	body, _ := json.Marshal(map[string]interface{}{"word": "updated"})
+
+	put := func(h *handler.EntryHandler, ifMatch string) *httptest.ResponseRecorder {
+		router := setupRouter()
+		router.PUT("/entries/:id", h.UpdateEntry)
+		req, _ := http.NewRequest("PUT", "/entries/"+entryID.String(), bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", ifMatch)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Matching version", func(t *testing.T) {
+		mockService := new(MockEntryService)
+		mockService.On("UpdateEntry", mock.Anything, entryID, mock.MatchedBy(func(req *request.UpdateEntryRequest) bool {
+			return req.Version == 3
+		})).Return(&response.EntryResponse{ID: entryID, Word: "updated", Version: 4}, nil)
+
+		w := put(handler.NewEntryHandler(mockService, new(MockLogger)), `"3"`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `"4"`, w.Header().Get("ETag"))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Version tagged with a format", func(t *testing.T) {
+		mockService := new(MockEntryService)
+		mockService.On("UpdateEntry", mock.Anything, entryID, mock.MatchedBy(func(req *request.UpdateEntryRequest) bool {
+			return req.Version == 3
+		})).Return(&response.EntryResponse{ID: entryID, Word: "updated", Version: 4}, nil)
+
+		w := put(handler.NewEntryHandler(mockService, new(MockLogger)), `"3-csv"`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Stale version", func(t *testing.T) {
+		mockService := new(MockEntryService)
+		mockService.On("UpdateEntry", mock.Anything, entryID, mock.Anything).Return(nil, database.ErrVersionConflict)
+
+		w := put(handler.NewEntryHandler(mockService, new(MockLogger)), `"2"`)
+
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	})
+
+	t.Run("Weak tag", func(t *testing.T) {
+		mockService := new(MockEntryService)
+
+		w := put(handler.NewEntryHandler(mockService, new(MockLogger)), `W/"3"`)
+
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+		mockService.AssertNotCalled(t, "UpdateEntry", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+// TestGetEntryConditional tests that a client holding the current entry gets
+// 304 Not Modified, by entity tag or by date
+func TestGetEntryConditional(t *testing.T) {
+	entryID := uuid.New()
+	updatedAt := time.Date(2024, 5, 1, 12, 30, 15, 500, time.UTC)
+
+	mockService := new(MockEntryService)
+	mockService.On("GetEntryByID", mock.Anything, entryID).Return(
+		&response.EntryResponse{ID: entryID, Word: "example", Version: 3, UpdatedAt: updatedAt}, nil)
+	router := setupRouter()
+	router.GET("/entries/:id", handler.NewEntryHandler(mockService, new(MockLogger)).GetEntry)
+
+	get := func(header, value string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", "/entries/"+entryID.String(), nil)
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+	assert.Equal(t, "Wed, 01 May 2024 12:30:15 GMT", w.Header().Get("Last-Modified"))
+
+	w = get("If-None-Match", `W/"2", "3"`)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+
+	assert.Equal(t, http.StatusOK, get("If-None-Match", `"2"`).Code)
+	assert.Equal(t, http.StatusNotModified, get("If-Modified-Since", "Wed, 01 May 2024 12:30:15 GMT").Code)
+	assert.Equal(t, http.StatusOK, get("If-Modified-Since", "Wed, 01 May 2024 12:30:14 GMT").Code)
+}
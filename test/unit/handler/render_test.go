@@ -0,0 +1,282 @@
+package handler_test
+
+import (
+	"encoding/csv"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valpere/trytrago/application/dto/request"
+	"github.com/valpere/trytrago/application/dto/response"
+	"github.com/valpere/trytrago/interface/api/rest/handler"
+	"github.com/valpere/trytrago/interface/api/rest/middleware"
+)
+
+// setupNegotiatedRouter serves the entry reads with content negotiation
+func setupNegotiatedRouter(mockService *MockEntryService) *gin.Engine {
+	h := handler.NewEntryHandler(mockService, new(MockLogger))
+	router := setupRouter()
+	entries := router.Group("/entries", middleware.Negotiate())
+	entries.GET("", h.ListEntries)
+	entries.GET("/:id", h.GetEntry)
+	return router
+}
+
+// testEntry returns an entry with a meaning, for checking how nested members render
+func testEntry(word string) *response.EntryResponse {
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	return &response.EntryResponse{
+		ID:             uuid.New(),
+		Word:           word,
+		Type:           "WORD",
+		HomographIndex: 1,
+		DisplayWord:    word,
+		Meanings: []response.MeaningResponse{{
+			ID:           uuid.New(),
+			PartOfSpeech: "noun",
+			Description:  "financial institution",
+			Labels:       []string{"finance", "formal"},
+		}},
+		Version:   2,
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	}
+}
+
+func get(router *gin.Engine, path, accept string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("GET", path, nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// TestRenderFormats tests that an entry renders in each negotiated format
+// with the member names of its JSON form
+func TestRenderFormats(t *testing.T) {
+	entry := testEntry("bank")
+	mockService := new(MockEntryService)
+	mockService.On("GetEntryByID", mock.Anything, entry.ID).Return(entry, nil)
+	router := setupNegotiatedRouter(mockService)
+	path := "/entries/" + entry.ID.String()
+
+	t.Run("CSV with default columns", func(t *testing.T) {
+		w := get(router, path, "text/csv")
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="entry.csv"`, w.Header().Get("Content-Disposition"))
+		assert.Equal(t, `"2-csv"`, w.Header().Get("ETag"))
+
+		records, err := csv.NewReader(w.Body).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		assert.Equal(t, []string{"id", "word", "type", "source_language_id", "homograph_index", "display_word",
+			"pronunciation", "version", "created_at", "updated_at"}, records[0])
+		assert.Equal(t, []string{entry.ID.String(), "bank", "WORD", "", "1", "bank",
+			"", "2", "2024-05-01T12:00:00Z", "2024-05-01T12:00:00Z"}, records[1])
+	})
+
+	t.Run("CSV with chosen columns", func(t *testing.T) {
+		w := get(router, path+"?format=csv&columns=word,meanings.0.description,meanings.0.labels,missing", "")
+
+		require.Equal(t, http.StatusOK, w.Code)
+		records, err := csv.NewReader(w.Body).ReadAll()
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{
+			{"word", "meanings.0.description", "meanings.0.labels", "missing"},
+			{"bank", "financial institution", "finance|formal", ""},
+		}, records)
+	})
+
+	t.Run("XML", func(t *testing.T) {
+		w := get(router, path, "application/xml")
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/xml; charset=utf-8", w.Header().Get("Content-Type"))
+		body := w.Body.String()
+		assert.True(t, strings.HasPrefix(body, `<?xml version="1.0" encoding="UTF-8"?>`+"\n<entry><id>"+entry.ID.String()+"</id><word>bank</word>"))
+		assert.Contains(t, body, "<meanings><meaning><id>")
+		assert.Contains(t, body, "<labels><label>finance</label><label>formal</label></labels>")
+	})
+
+	t.Run("YAML", func(t *testing.T) {
+		w := get(router, path+"?format=yaml", "")
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/yaml; charset=utf-8", w.Header().Get("Content-Type"))
+		body := w.Body.String()
+		assert.True(t, strings.HasPrefix(body, "id: "+entry.ID.String()+"\nword: bank\ntype: WORD\n"))
+		assert.Contains(t, body, "meanings:\n  - id: ")
+		assert.Contains(t, body, "version: 2\n")
+	})
+
+	t.Run("Errors stay JSON", func(t *testing.T) {
+		w := get(router, "/entries/not-a-uuid", "text/csv")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+	})
+}
+
+// TestETagPerFormat tests that each format of a resource has its own entity
+// tag, so a copy held in one format does not validate another
+func TestETagPerFormat(t *testing.T) {
+	entry := testEntry("bank")
+	mockService := new(MockEntryService)
+	mockService.On("GetEntryByID", mock.Anything, entry.ID).Return(entry, nil)
+	router := setupNegotiatedRouter(mockService)
+	path := "/entries/" + entry.ID.String()
+
+	conditional := func(accept, ifNoneMatch string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", path, nil)
+		req.Header.Set("Accept", accept)
+		req.Header.Set("If-None-Match", ifNoneMatch)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, `"2"`, get(router, path, "application/json").Header().Get("ETag"))
+	assert.Equal(t, `"2-xml"`, get(router, path, "application/xml").Header().Get("ETag"))
+	assert.Equal(t, `"2-yaml"`, get(router, path+"?format=yml", "").Header().Get("ETag"))
+
+	assert.Equal(t, http.StatusOK, conditional("text/csv", `"2"`).Code)
+	assert.Equal(t, http.StatusNotModified, conditional("text/csv", `"2-csv"`).Code)
+	assert.Equal(t, http.StatusOK, conditional("application/json", `"2-csv"`).Code)
+}
+
+// TestCSVFormulaCells tests that text a spreadsheet would evaluate is quoted
+func TestCSVFormulaCells(t *testing.T) {
+	mockService := new(MockEntryService)
+	router := setupNegotiatedRouter(mockService)
+
+	for word, expected := range map[string]string{
+		"=HYPERLINK(\"x\")": "'=HYPERLINK(\"x\")",
+		"@SUM(A1)":          "'@SUM(A1)",
+		"-2+3":              "'-2+3",
+		"-ing":              "-ing",
+		"bank":              "bank",
+	} {
+		entry := testEntry(word)
+		mockService.On("GetEntryByID", mock.Anything, entry.ID).Return(entry, nil)
+
+		w := get(router, "/entries/"+entry.ID.String()+"?format=csv&columns=word", "")
+
+		records, err := csv.NewReader(w.Body).ReadAll()
+		require.NoError(t, err)
+		assert.Equal(t, expected, records[1][0], word)
+	}
+}
+
+// TestRenderListFormats tests that the rows of a list are written inside the
+// other members of the response in every format
+func TestRenderListFormats(t *testing.T) {
+	bank, bark := testEntry("bank"), testEntry("bark")
+	mockService := new(MockEntryService)
+	mockService.On("ListEntries", mock.Anything, mock.Anything).Return(&response.EntryListResponse{
+		Entries: []*response.EntryResponse{bank, bark}, Total: 2, Limit: 20,
+	}, nil)
+	router := setupNegotiatedRouter(mockService)
+
+	t.Run("CSV", func(t *testing.T) {
+		w := get(router, "/entries?format=csv&columns=word", "")
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "word\nbank\nbark\n", w.Body.String())
+	})
+
+	t.Run("XML", func(t *testing.T) {
+		w := get(router, "/entries", "application/xml")
+
+		require.Equal(t, http.StatusOK, w.Code)
+		body := w.Body.String()
+		assert.True(t, strings.HasPrefix(body, `<?xml version="1.0" encoding="UTF-8"?>`+"\n<entry_list><entries><entry><id>"+bank.ID.String()+"</id>"))
+		assert.Contains(t, body, "</entry><entry><id>"+bark.ID.String()+"</id>")
+		assert.True(t, strings.HasSuffix(body, "</entries><total>2</total><limit>20</limit><offset>0</offset></entry_list>"))
+	})
+
+	t.Run("YAML", func(t *testing.T) {
+		w := get(router, "/entries?format=yaml", "")
+
+		require.Equal(t, http.StatusOK, w.Code)
+		body := w.Body.String()
+		assert.True(t, strings.HasPrefix(body, "entries:\n  - id: "+bank.ID.String()+"\n    word: bank\n"))
+		assert.Contains(t, body, "\n    meanings:\n      - id: ")
+		assert.Contains(t, body, "\n  - id: "+bark.ID.String()+"\n")
+		assert.True(t, strings.HasSuffix(body, "\ntotal: 2\nlimit: 20\noffset: 0\n"))
+	})
+
+	t.Run("Empty YAML list", func(t *testing.T) {
+		mockService := new(MockEntryService)
+		mockService.On("ListEntries", mock.Anything, mock.Anything).Return(&response.EntryListResponse{
+			Entries: []*response.EntryResponse{}, Limit: 20,
+		}, nil)
+
+		w := get(setupNegotiatedRouter(mockService), "/entries?format=yaml", "")
+
+		assert.Equal(t, "entries: []\ntotal: 0\nlimit: 20\noffset: 0\n", w.Body.String())
+	})
+}
+
+// TestListEntriesCSVStream tests that a CSV list without a limit pages
+// through every matching entry, each page starting after the last entry sent
+func TestListEntriesCSVStream(t *testing.T) {
+	firstPage := make([]*response.EntryResponse, 100)
+	for i := range firstPage {
+		firstPage[i] = testEntry("word")
+	}
+	last := testEntry("last")
+
+	mockService := new(MockEntryService)
+	mockService.On("ListEntries", mock.Anything, mock.MatchedBy(func(req *request.ListEntriesRequest) bool {
+		return req.AfterID != nil && *req.AfterID == uuid.Nil && req.Offset == 0 && req.Limit == 100 && req.Type == "WORD"
+	})).Return(&response.EntryListResponse{Entries: firstPage}, nil).Once()
+	mockService.On("ListEntries", mock.Anything, mock.MatchedBy(func(req *request.ListEntriesRequest) bool {
+		return req.AfterID != nil && *req.AfterID == firstPage[99].ID && req.Offset == 0
+	})).Return(&response.EntryListResponse{Entries: []*response.EntryResponse{last}}, nil).Once()
+	router := setupNegotiatedRouter(mockService)
+
+	w := get(router, "/entries?type=WORD&columns=id,word", "text/csv")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `attachment; filename="entries.csv"`, w.Header().Get("Content-Disposition"))
+	records, err := csv.NewReader(w.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 102)
+	assert.Equal(t, []string{"id", "word"}, records[0])
+	assert.Equal(t, []string{last.ID.String(), "last"}, records[101])
+	mockService.AssertExpectations(t)
+
+	t.Run("Failure before the first row", func(t *testing.T) {
+		mockService := new(MockEntryService)
+		mockService.On("ListEntries", mock.Anything, mock.Anything).Return(nil, errors.New("database is down"))
+
+		w := get(setupNegotiatedRouter(mockService), "/entries", "text/csv")
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+	})
+
+	t.Run("A limit gives one page", func(t *testing.T) {
+		mockService := new(MockEntryService)
+		mockService.On("ListEntries", mock.Anything, mock.MatchedBy(func(req *request.ListEntriesRequest) bool {
+			return req.Limit == 1
+		})).Return(&response.EntryListResponse{Entries: []*response.EntryResponse{last}, Total: 1, Limit: 1}, nil).Once()
+
+		w := get(setupNegotiatedRouter(mockService), "/entries?limit=1&format=csv&columns=word", "")
+
+		assert.Equal(t, "word\nlast\n", w.Body.String())
+		mockService.AssertExpectations(t)
+	})
+}
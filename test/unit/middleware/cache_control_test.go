@@ -0,0 +1,49 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/valpere/trytrago/interface/api/rest/middleware"
+)
+
+func TestCacheControlMiddleware(t *testing.T) {
+	router := setupRouter()
+	router.Use(middleware.CacheControl("public, max-age=60"))
+
+	router.GET("/found", func(c *gin.Context) {
+		c.String(http.StatusOK, "success")
+	})
+	router.GET("/unchanged", func(c *gin.Context) {
+		c.Status(http.StatusNotModified)
+	})
+	router.GET("/missing", func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Entry not found"})
+	})
+	router.POST("/found", func(c *gin.Context) {
+		c.String(http.StatusOK, "success")
+	})
+
+	tests := []struct {
+		method, path string
+		expected     string
+	}{
+		{"GET", "/found", "public, max-age=60"},
+		{"GET", "/unchanged", "public, max-age=60"},
+		{"GET", "/missing", ""},
+		{"POST", "/found", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expected, w.Header().Get("Cache-Control"))
+		})
+	}
+}
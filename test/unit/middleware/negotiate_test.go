@@ -0,0 +1,62 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/valpere/trytrago/interface/api/rest/middleware"
+)
+
+func TestNegotiateMiddleware(t *testing.T) {
+	router := setupRouter()
+	router.Use(middleware.Negotiate())
+
+	router.GET("/entries", func(c *gin.Context) {
+		c.String(http.StatusOK, middleware.ResponseFormat(c))
+	})
+
+	tests := []struct {
+		name     string
+		query    string
+		accept   string
+		status   int
+		expected string
+	}{
+		{"No Accept header", "", "", http.StatusOK, "json"},
+		{"Any media type", "", "*/*", http.StatusOK, "json"},
+		{"CSV", "", "text/csv", http.StatusOK, "csv"},
+		{"XML by text type", "", "text/xml", http.StatusOK, "xml"},
+		{"YAML alias", "", "application/x-yaml", http.StatusOK, "yaml"},
+		{"Highest quality wins", "", "application/json;q=0.5, application/yaml", http.StatusOK, "yaml"},
+		{"Preferred over JSON", "", "text/csv, application/json;q=0.9", http.StatusOK, "csv"},
+		{"Rated below JSON", "", "application/json, text/csv;q=0.9", http.StatusOK, "json"},
+		{"Browser", "", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", http.StatusOK, "json"},
+		{"HTML only", "", "text/html", http.StatusOK, "json"},
+		{"Outranked by an unsupported type", "", "text/html, text/csv;q=0.9", http.StatusOK, "json"},
+		{"Wildcard stands for JSON", "", "text/*", http.StatusOK, "json"},
+		{"JSON ruled out", "", "application/json;q=0", http.StatusOK, "json"},
+		{"Format parameter overrides Accept", "?format=csv", "application/json", http.StatusOK, "csv"},
+		{"Format parameter alias", "?format=YML", "", http.StatusOK, "yaml"},
+		{"Unknown format parameter", "?format=pdf", "", http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/entries"+tt.query, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+			assert.Equal(t, "Accept", w.Header().Get("Vary"))
+			if tt.status == http.StatusOK {
+				assert.Equal(t, tt.expected, w.Body.String())
+			}
+		})
+	}
+}
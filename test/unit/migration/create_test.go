@@ -0,0 +1,61 @@
+package migration_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valpere/trytrago/infrastructure/migration"
+)
+
+func touch(t *testing.T, path string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte("-- migration\n"), 0o644))
+}
+
+func TestCreateMigrationPerDialect(t *testing.T) {
+	dir := t.TempDir()
+	touch(t, filepath.Join(dir, "postgres", "V1__initial_schema.sql"))
+	touch(t, filepath.Join(dir, "postgres", "V2__add_user_tables.sql"))
+	touch(t, filepath.Join(dir, "mysql", "V1__initial_schema.sql"))
+	touch(t, filepath.Join(dir, "sqlite", "R3__rollback_social_features.sql"))
+
+	paths, err := migration.CreateMigration(dir, "Add user Settings!")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "postgres", "V4__add_user_settings.sql"),
+		filepath.Join(dir, "postgres", "R4__rollback_add_user_settings.sql"),
+		filepath.Join(dir, "mysql", "V4__add_user_settings.sql"),
+		filepath.Join(dir, "mysql", "R4__rollback_add_user_settings.sql"),
+		filepath.Join(dir, "sqlite", "V4__add_user_settings.sql"),
+		filepath.Join(dir, "sqlite", "R4__rollback_add_user_settings.sql"),
+	}, paths)
+
+	content, err := os.ReadFile(paths[0])
+	require.NoError(t, err)
+	assert.Equal(t, "-- Add user settings\n\n", string(content))
+
+	// The next one follows
+	paths, err = migration.CreateMigration(dir, "second")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "postgres", "V5__second.sql"), paths[0])
+}
+
+func TestCreateMigrationFlatDirectory(t *testing.T) {
+	dir := t.TempDir()
+
+	paths, err := migration.CreateMigration(dir, "initial")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "V1__initial.sql"),
+		filepath.Join(dir, "R1__rollback_initial.sql"),
+	}, paths)
+
+	_, err = migration.CreateMigration(dir, "  ---  ")
+	assert.Error(t, err)
+
+	_, err = migration.CreateMigration(filepath.Join(dir, "missing"), "initial")
+	assert.Error(t, err)
+}
@@ -0,0 +1,228 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/valpere/trytrago/application/service"
+	"github.com/valpere/trytrago/domain/database"
+	"github.com/valpere/trytrago/domain/database/repository"
+	"github.com/valpere/trytrago/domain/model"
+	"github.com/valpere/trytrago/infrastructure/auth"
+	"github.com/valpere/trytrago/interface/api/rpc"
+	"github.com/valpere/trytrago/interface/api/rpc/pb"
+	"github.com/valpere/trytrago/test/mocks"
+)
+
+// setupServer serves services backed by a mock repository over an in-memory
+// connection, and returns a client connection to it
+func setupServer(t *testing.T, requireVersion bool) (*grpc.ClientConn, *mocks.MockRepository) {
+	auth.InitJWT("test-secret-key-for-grpc", 1*time.Hour)
+
+	mockRepo := new(mocks.MockRepository)
+	mockLogger := new(mocks.MockLogger)
+
+	mockLogger.On("With", mock.Anything).Return(mockLogger)
+	mockLogger.On("Debug", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Warn", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	server := rpc.NewServer(
+		service.NewEntryService(mockRepo, mockLogger),
+		service.NewTranslationService(mockRepo, mockLogger),
+		service.NewUserService(mockRepo, mockLogger),
+		requireVersion,
+		mockLogger,
+	)
+
+	listener := bufconn.Listen(1024 * 1024)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return conn, mockRepo
+}
+
+// withToken adds a bearer token for the given user to the outgoing metadata
+func withToken(t *testing.T, userID uuid.UUID) context.Context {
+	token, err := auth.GenerateToken(userID.String(), "testuser", "USER")
+	require.NoError(t, err)
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+// TestAuthentication tests that only public methods can be called without a valid token
+func TestAuthentication(t *testing.T) {
+	conn, mockRepo := setupServer(t, false)
+	entries := pb.NewEntryServiceClient(conn)
+	users := pb.NewUserServiceClient(conn)
+
+	t.Run("Protected method without a token", func(t *testing.T) {
+		_, err := entries.CreateEntry(context.Background(), &pb.CreateEntryRequest{Word: "bank", Type: "WORD"})
+
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		mockRepo.AssertNotCalled(t, "CreateEntry", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Protected method with an invalid token", func(t *testing.T) {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer not-a-token")
+
+		_, err := users.GetUser(ctx, &pb.GetUserRequest{})
+
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		mockRepo.AssertNotCalled(t, "GetUserByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Protected method acts on the user of the token", func(t *testing.T) {
+		userID := uuid.New()
+		mockRepo.On("GetUserByID", mock.Anything, userID).
+			Return(&model.User{ID: userID, Username: "testuser", Email: "test@example.com"}, nil).Once()
+
+		user, err := users.GetUser(withToken(t, userID), &pb.GetUserRequest{})
+
+		require.NoError(t, err)
+		assert.Equal(t, userID.String(), user.GetId())
+		assert.Equal(t, "testuser", user.GetUsername())
+	})
+
+	t.Run("Public method without a token", func(t *testing.T) {
+		entryID := uuid.New()
+		mockRepo.On("GetEntryByID", mock.Anything, entryID).
+			Return(&database.Entry{ID: entryID, Word: "bank", Type: database.WordType, HomographIndex: 1}, nil).Once()
+
+		var header metadata.MD
+		entry, err := entries.GetEntry(context.Background(), &pb.GetEntryRequest{Id: entryID.String()}, grpc.Header(&header))
+
+		require.NoError(t, err)
+		assert.Equal(t, "bank", entry.GetWord())
+		assert.NotEmpty(t, header.Get("x-request-id"))
+	})
+}
+
+// TestExportEntries tests that the export streams every page of entries,
+// each page starting after the last entry sent
+func TestExportEntries(t *testing.T) {
+	conn, mockRepo := setupServer(t, false)
+	userID := uuid.New()
+
+	firstPage := make([]database.Entry, 100)
+	for i := range firstPage {
+		firstPage[i] = database.Entry{ID: uuid.New(), Word: "word", Type: database.WordType, HomographIndex: i + 1}
+	}
+	last := database.Entry{ID: uuid.New(), Word: "last", Type: database.WordType, HomographIndex: 1}
+
+	mockRepo.On("ListEntries", mock.Anything, mock.MatchedBy(func(params repository.ListParams) bool {
+		_, keyed := params.Filters["id > ?"]
+		return !keyed && params.SortBy == "id" && params.Limit == 100 && params.Filters["type = ?"] == "WORD"
+	})).Return(firstPage, nil).Once()
+	mockRepo.On("ListEntries", mock.Anything, mock.MatchedBy(func(params repository.ListParams) bool {
+		return params.SortBy == "id" && params.Offset == 0 && params.Filters["id > ?"] == firstPage[99].ID
+	})).Return([]database.Entry{last}, nil).Once()
+
+	stream, err := pb.NewEntryServiceClient(conn).ExportEntries(withToken(t, userID), &pb.ExportEntriesRequest{Type: "WORD"})
+	require.NoError(t, err)
+
+	var received []*pb.Entry
+	for {
+		entry, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		received = append(received, entry)
+	}
+
+	require.Len(t, received, 101)
+	assert.Equal(t, last.ID.String(), received[100].GetId())
+	mockRepo.AssertExpectations(t)
+}
+
+// TestErrorCodes tests that service errors reach clients as matching status codes
+func TestErrorCodes(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("Invalid ID", func(t *testing.T) {
+		conn, _ := setupServer(t, false)
+
+		_, err := pb.NewEntryServiceClient(conn).GetEntry(context.Background(), &pb.GetEntryRequest{Id: "not-a-uuid"})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Invalid request", func(t *testing.T) {
+		conn, mockRepo := setupServer(t, false)
+
+		_, err := pb.NewEntryServiceClient(conn).CreateEntry(withToken(t, userID), &pb.CreateEntryRequest{Word: "bank", Type: "NOUN"})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		mockRepo.AssertNotCalled(t, "CreateEntry", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		conn, mockRepo := setupServer(t, false)
+		entryID := uuid.New()
+		mockRepo.On("GetEntryByID", mock.Anything, entryID).Return(nil, database.ErrNotFound)
+		mockRepo.On("GetEntryRedirect", mock.Anything, entryID).Return(nil, database.ErrNotFound)
+
+		_, err := pb.NewEntryServiceClient(conn).GetEntry(context.Background(), &pb.GetEntryRequest{Id: entryID.String()})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Equal(t, "Entry not found", status.Convert(err).Message())
+	})
+
+	t.Run("Version conflict", func(t *testing.T) {
+		conn, mockRepo := setupServer(t, false)
+		entryID := uuid.New()
+		mockRepo.On("DeleteEntry", mock.Anything, entryID, 2).Return(database.ErrVersionConflict).Once()
+
+		_, err := pb.NewEntryServiceClient(conn).DeleteEntry(withToken(t, userID), &pb.DeleteEntryRequest{Id: entryID.String(), Version: 2})
+
+		assert.Equal(t, codes.Aborted, status.Code(err))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Missing version when required", func(t *testing.T) {
+		conn, mockRepo := setupServer(t, true)
+
+		_, err := pb.NewTranslationServiceClient(conn).DeleteTranslation(withToken(t, userID), &pb.DeleteTranslationRequest{Id: uuid.NewString()})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		mockRepo.AssertNotCalled(t, "DeleteTranslation", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+// TestRateLimit tests that a client past its burst is turned away
+func TestRateLimit(t *testing.T) {
+	conn, _ := setupServer(t, false)
+	entries := pb.NewEntryServiceClient(conn)
+
+	var err error
+	for i := 0; i < 30 && status.Code(err) != codes.ResourceExhausted; i++ {
+		_, err = entries.GetEntry(context.Background(), &pb.GetEntryRequest{Id: "not-a-uuid"})
+	}
+
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
@@ -0,0 +1,156 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valpere/trytrago/application/dto/request"
+	"github.com/valpere/trytrago/application/service"
+	"github.com/valpere/trytrago/domain/database"
+	"github.com/valpere/trytrago/domain/errors"
+	"github.com/valpere/trytrago/domain/model"
+	"github.com/valpere/trytrago/test/mocks"
+)
+
+// setupBatchService sets up a mock repository and logger for batch service tests.
+// The transaction is the mock itself, so every operation reaches mockRepo
+func setupBatchService(t *testing.T, maxOperations int) (service.BatchService, *mocks.MockRepository) {
+	mockRepo := new(mocks.MockRepository)
+	mockLogger := new(mocks.MockLogger)
+
+	mockLogger.On("With", mock.Anything).Return(mockLogger)
+	mockLogger.On("Debug", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	mockRepo.On("WithTransaction", mock.Anything, mock.Anything).Return(nil).Maybe()
+	mockRepo.On("WithTx", mock.Anything).Return(mockRepo).Maybe()
+
+	return service.NewBatchService(mockRepo, maxOperations, mockLogger), mockRepo
+}
+
+// TestExecuteBatch tests ordered execution with references to earlier operations
+func TestExecuteBatch(t *testing.T) {
+	noun := database.PartOfSpeech{ID: uuid.New(), Name: "noun"}
+	userID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		batchService, mockRepo := setupBatchService(t, 10)
+		mockRepo.On("ListPartsOfSpeech", mock.Anything).Return([]database.PartOfSpeech{noun}, nil).Once()
+
+		// Later operations load what the first one created
+		var entry *database.Entry
+		mockRepo.On("CreateEntry", mock.Anything, mock.AnythingOfType("*database.Entry")).
+			Run(func(args mock.Arguments) {
+				entry = args.Get(1).(*database.Entry)
+				mockRepo.On("GetEntryByID", mock.Anything, entry.ID).Return(entry, nil)
+			}).
+			Return(nil).Once()
+		mockRepo.On("UpdateEntry", mock.Anything, mock.AnythingOfType("*database.Entry")).
+			Run(func(args mock.Arguments) {
+				updated := args.Get(1).(*database.Entry)
+				for i := range updated.Meanings {
+					mockRepo.On("GetMeaningByID", mock.Anything, updated.Meanings[i].ID).Return(&updated.Meanings[i], nil)
+				}
+			}).
+			Return(nil).Twice()
+
+		// The first like is new, the second finds it already given
+		var like *model.Like
+		mockRepo.On("GetLike", mock.Anything, userID, "meaning", mock.AnythingOfType("uuid.UUID")).
+			Return(nil, database.ErrNotFound).Once()
+		mockRepo.On("CreateLike", mock.Anything, mock.AnythingOfType("*model.Like")).
+			Run(func(args mock.Arguments) {
+				like = args.Get(1).(*model.Like)
+				mockRepo.On("GetLike", mock.Anything, userID, "meaning", like.TargetID).Return(like, nil).Once()
+			}).
+			Return(nil).Once()
+
+		resp, err := batchService.ExecuteBatch(context.Background(), &request.BatchRequest{
+			UserID: userID,
+			Operations: []request.BatchOperation{
+				{Op: request.BatchCreateEntry, Ref: "bank", Entry: &request.CreateEntryRequest{Word: "bank", Type: "WORD"}},
+				{Op: request.BatchAddMeaning, Ref: "river", EntryID: "$bank", Meaning: &request.CreateMeaningRequest{PartOfSpeechID: noun.ID, Description: "Side of a river"}},
+				{Op: request.BatchAddTranslation, MeaningID: "$river", Translation: &request.CreateTranslationRequest{LanguageID: "fr", Text: "rive"}},
+				{Op: request.BatchLike, TargetType: "meaning", TargetID: "$river"},
+				{Op: request.BatchLike, TargetType: "meaning", TargetID: "$river"},
+			},
+		})
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+
+		require.Len(t, resp.Results, 5)
+		assert.Equal(t, entry.ID, resp.Results[0].ID)
+		assert.Equal(t, "bank", resp.Results[0].Ref)
+		assert.Equal(t, entry.Meanings[0].ID, resp.Results[1].ID)
+		assert.Equal(t, entry.ID, *resp.Results[1].EntryID)
+
+		translations := entry.Meanings[0].Translations
+		require.Len(t, translations, 1)
+		assert.Equal(t, translations[0].ID, resp.Results[2].ID)
+		assert.Equal(t, database.TranslationProposed, translations[0].Status)
+		assert.Equal(t, userID, *translations[0].CreatedByID)
+
+		assert.Equal(t, entry.Meanings[0].ID, like.TargetID)
+		assert.True(t, resp.Results[3].Created)
+		assert.False(t, resp.Results[4].Created)
+		assert.Equal(t, resp.Results[3].ID, resp.Results[4].ID)
+	})
+
+	t.Run("Failed operation", func(t *testing.T) {
+		batchService, mockRepo := setupBatchService(t, 10)
+		mockRepo.On("CreateEntry", mock.Anything, mock.AnythingOfType("*database.Entry")).Return(nil).Once()
+		mockRepo.On("GetTranslationByID", mock.Anything, mock.AnythingOfType("uuid.UUID")).Return(nil, database.ErrNotFound).Once()
+
+		_, err := batchService.ExecuteBatch(context.Background(), &request.BatchRequest{
+			UserID: userID,
+			Operations: []request.BatchOperation{
+				{Op: request.BatchCreateEntry, Entry: &request.CreateEntryRequest{Word: "bank", Type: "WORD"}},
+				{Op: request.BatchLike, TargetType: "translation", TargetID: uuid.New().String()},
+			},
+		})
+
+		var opErr *service.BatchOperationError
+		require.ErrorAs(t, err, &opErr)
+		assert.Equal(t, 1, opErr.Index)
+		assert.Equal(t, request.BatchLike, opErr.Op)
+		assert.True(t, database.IsNotFoundError(err))
+		mockRepo.AssertNotCalled(t, "CreateLike", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Every problem is reported", func(t *testing.T) {
+		batchService, mockRepo := setupBatchService(t, 10)
+
+		_, err := batchService.ExecuteBatch(context.Background(), &request.BatchRequest{
+			UserID: userID,
+			Operations: []request.BatchOperation{
+				{Op: request.BatchAddTranslation, MeaningID: "$later", Translation: &request.CreateTranslationRequest{LanguageID: "fr", Text: "rive"}},
+				{Op: request.BatchCreateEntry, Ref: "later", Entry: &request.CreateEntryRequest{Word: "bank", Type: "WORD"}},
+				{Op: request.BatchLike, TargetType: "meaning", TargetID: "$later"},
+				{Op: request.BatchAddMeaning, EntryID: "not-an-id"},
+			},
+		})
+		require.ErrorIs(t, err, errors.ErrValidation)
+		assert.Contains(t, err.Error(), `operations[0].meaning_id: no earlier operation has ref "later"`)
+		assert.Contains(t, err.Error(), `operations[2].target_id: ref "later" is from a create_entry operation`)
+		assert.Contains(t, err.Error(), `operations[3].entry_id: "not-an-id" is neither a UUID nor a $ref`)
+		assert.Contains(t, err.Error(), "operations[3].meaning is required")
+		mockRepo.AssertNotCalled(t, "WithTransaction", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Too many operations", func(t *testing.T) {
+		batchService, mockRepo := setupBatchService(t, 1)
+
+		create := request.BatchOperation{Op: request.BatchCreateEntry, Entry: &request.CreateEntryRequest{Word: "bank", Type: "WORD"}}
+		_, err := batchService.ExecuteBatch(context.Background(), &request.BatchRequest{
+			UserID:     userID,
+			Operations: []request.BatchOperation{create, create},
+		})
+		assert.ErrorIs(t, err, errors.ErrBatchTooLarge)
+		mockRepo.AssertNotCalled(t, "WithTransaction", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,124 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valpere/trytrago/application/dto/request"
+	"github.com/valpere/trytrago/application/service"
+	"github.com/valpere/trytrago/domain/database"
+	"github.com/valpere/trytrago/test/mocks"
+)
+
+// setupDuplicateService sets up a mock repository and logger for duplicate service tests
+func setupDuplicateService(t *testing.T) (service.DuplicateService, *mocks.MockRepository) {
+	mockRepo := new(mocks.MockRepository)
+	mockLogger := new(mocks.MockLogger)
+
+	mockLogger.On("With", mock.Anything).Return(mockLogger)
+	mockLogger.On("Debug", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Warn", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	return service.NewDuplicateService(mockRepo, mockLogger), mockRepo
+}
+
+// TestFindDuplicates tests exact and near duplicate detection
+func TestFindDuplicates(t *testing.T) {
+	newEntry := func(word string, entryType database.EntryType, lang string) database.Entry {
+		return database.Entry{
+			ID:               uuid.New(),
+			Word:             word,
+			Type:             entryType,
+			SourceLanguageID: lang,
+			CreatedAt:        time.Now().UTC(),
+		}
+	}
+
+	entries := []database.Entry{
+		newEntry("Bank", database.WordType, "en"),
+		newEntry("bank ", database.WordType, "en"),
+		newEntry("bank", database.PhraseType, "en"), // different type
+		newEntry("bank", database.WordType, "de"),   // different language
+		newEntry("receive", database.WordType, "en"),
+		newEntry("recieve", database.WordType, "en"),
+		newEntry("river", database.WordType, "en"),
+	}
+
+	duplicateService, mockRepo := setupDuplicateService(t)
+	mockRepo.On("IterateEntries", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(2).(func([]database.Entry) error)
+			require.NoError(t, fn(entries[:4]))
+			require.NoError(t, fn(entries[4:]))
+		}).
+		Return(nil).Once()
+
+	resp, err := duplicateService.FindDuplicates(context.Background(), &request.DuplicateReportRequest{Threshold: 0.7})
+	require.NoError(t, err)
+
+	assert.Equal(t, len(entries), resp.Scanned)
+	require.Equal(t, 2, resp.Total)
+
+	// Exact matches are reported first
+	assert.Equal(t, "exact", resp.Groups[0].Reason)
+	assert.Len(t, resp.Groups[0].Entries, 2)
+	assert.Equal(t, entries[0].ID, resp.Groups[0].Entries[0].ID)
+
+	assert.Equal(t, "similar", resp.Groups[1].Reason)
+	assert.ElementsMatch(t,
+		[]string{"receive", "recieve"},
+		[]string{resp.Groups[1].Entries[0].Word, resp.Groups[1].Entries[1].Word},
+	)
+
+	mockRepo.AssertExpectations(t)
+}
+
+// TestMergeEntries tests the MergeEntries function
+func TestMergeEntries(t *testing.T) {
+	sourceID := uuid.New()
+	targetID := uuid.New()
+	userID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		duplicateService, mockRepo := setupDuplicateService(t)
+
+		mockRepo.On("MergeEntries", mock.Anything, sourceID, targetID, userID).Return(nil).Once()
+		mockRepo.On("GetEntryByID", mock.Anything, targetID).Return(&database.Entry{
+			ID:   targetID,
+			Word: "bank",
+			Type: database.WordType,
+		}, nil).Once()
+
+		resp, err := duplicateService.MergeEntries(context.Background(), sourceID, &request.MergeEntriesRequest{
+			TargetID: targetID,
+			UserID:   userID,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, targetID, resp.ID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("SourceNotFound", func(t *testing.T) {
+		duplicateService, mockRepo := setupDuplicateService(t)
+
+		mockRepo.On("MergeEntries", mock.Anything, sourceID, targetID, userID).Return(database.ErrEntryNotFound).Once()
+
+		_, err := duplicateService.MergeEntries(context.Background(), sourceID, &request.MergeEntriesRequest{
+			TargetID: targetID,
+			UserID:   userID,
+		})
+
+		require.Error(t, err)
+		assert.True(t, database.IsNotFoundError(err))
+		mockRepo.AssertExpectations(t)
+	})
+}
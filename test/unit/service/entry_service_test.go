@@ -14,6 +14,7 @@ import (
 	"github.com/valpere/trytrago/application/dto/request"
 	"github.com/valpere/trytrago/application/service"
 	"github.com/valpere/trytrago/domain/database"
+	"github.com/valpere/trytrago/domain/database/repository"
 	"github.com/valpere/trytrago/test/mocks"
 )
 
@@ -146,11 +147,22 @@ func TestGetEntryByID(t *testing.T) {
 			name: "EntryNotFound",
 			setupMocks: func(mockRepo *mocks.MockRepository, mockLogger *mocks.MockLogger) {
 				mockRepo.On("GetEntryByID", mock.Anything, testID).Return(nil, database.ErrEntryNotFound).Once()
+				mockRepo.On("GetEntryRedirect", mock.Anything, testID).Return(nil, database.ErrNotFound).Once()
 				mockLogger.On("Error", "failed to get entry", mock.Anything, mock.Anything).Return().Once()
 			},
 			expectedError: true,
 			errorContains: "not found",
 		},
+		{
+			name: "MergedEntryRedirect",
+			setupMocks: func(mockRepo *mocks.MockRepository, mockLogger *mocks.MockLogger) {
+				mergedID := uuid.New()
+				mockRepo.On("GetEntryByID", mock.Anything, testID).Return(nil, database.ErrEntryNotFound).Once()
+				mockRepo.On("GetEntryRedirect", mock.Anything, testID).Return(&database.EntryRedirect{FromID: testID, ToID: mergedID}, nil).Once()
+				mockRepo.On("GetEntryByID", mock.Anything, mergedID).Return(testEntry, nil).Once()
+			},
+			expectedError: false,
+		},
 		{
 			name: "DatabaseError",
 			setupMocks: func(mockRepo *mocks.MockRepository, mockLogger *mocks.MockLogger) {
@@ -204,12 +216,14 @@ func TestUpdateEntry(t *testing.T) {
 	testType := database.WordType
 	updatedType := database.PhraseType
 	testEntry := &database.Entry{
-		ID:            testID,
-		Word:          originalWord,
-		Type:          testType,
-		Pronunciation: "test",
-		CreatedAt:     time.Now().UTC(),
-		UpdatedAt:     time.Now().UTC(),
+		ID:             testID,
+		Word:           originalWord,
+		NormalizedWord: originalWord,
+		Type:           testType,
+		HomographIndex: 2,
+		Pronunciation:  "test",
+		CreatedAt:      time.Now().UTC(),
+		UpdatedAt:      time.Now().UTC(),
 	}
 
 	// Test cases
@@ -224,9 +238,11 @@ func TestUpdateEntry(t *testing.T) {
 			setupMocks: func(mockRepo *mocks.MockRepository, mockLogger *mocks.MockLogger) {
 				mockRepo.On("GetEntryByID", mock.Anything, testID).Return(testEntry, nil).Once()
 				mockRepo.On("UpdateEntry", mock.Anything, mock.MatchedBy(func(entry *database.Entry) bool {
+					// A new headword is renumbered by the repository
 					return entry.ID == testID &&
 						entry.Word == updatedWord &&
-						entry.Type == updatedType
+						entry.Type == updatedType &&
+						entry.HomographIndex == 0
 				})).Return(nil).Once()
 			},
 			expectedError: false,
@@ -291,3 +307,50 @@ func TestUpdateEntry(t *testing.T) {
 		})
 	}
 }
+
+// TestListMeaningsMergedEntry tests that the meanings of a merged entry are
+// listed from the entry it was merged into
+func TestListMeaningsMergedEntry(t *testing.T) {
+	entryService, mockRepo, _ := setupEntryService(t)
+
+	mergedID := uuid.New()
+	target := &database.Entry{ID: uuid.New(), Word: "bank", Type: database.WordType, Version: 3}
+	target.Meanings = []database.Meaning{{ID: uuid.New(), EntryID: target.ID, Description: "river side"}}
+
+	mockRepo.On("GetEntryByID", mock.Anything, mergedID).Return(nil, database.ErrEntryNotFound).Once()
+	mockRepo.On("GetEntryRedirect", mock.Anything, mergedID).Return(&database.EntryRedirect{FromID: mergedID, ToID: target.ID}, nil).Once()
+	mockRepo.On("GetEntryByID", mock.Anything, target.ID).Return(target, nil).Once()
+
+	resp, err := entryService.ListMeanings(context.Background(), mergedID)
+
+	require.NoError(t, err)
+	require.Len(t, resp.Meanings, 1)
+	assert.Equal(t, target.ID, resp.Meanings[0].EntryID)
+	assert.Equal(t, 3, resp.EntryVersion)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestListEntriesAfterID tests that export pages are read in ID order after
+// the last entry sent, whatever order or offset the request asks for
+func TestListEntriesAfterID(t *testing.T) {
+	entryService, mockRepo, _ := setupEntryService(t)
+
+	last := uuid.New()
+	mockRepo.On("ListEntries", mock.Anything, mock.MatchedBy(func(params repository.ListParams) bool {
+		return params.SortBy == "id" && !params.SortDesc && params.Offset == 0 &&
+			params.Filters["id > ?"] == last && params.Filters["type = ?"] == "WORD"
+	})).Return([]database.Entry{{ID: uuid.New(), Word: "bank", Type: database.WordType}}, nil).Once()
+
+	resp, err := entryService.ListEntries(context.Background(), &request.ListEntriesRequest{
+		Limit:    100,
+		Offset:   200,
+		SortBy:   "word",
+		SortDesc: true,
+		Type:     "WORD",
+		AfterID:  &last,
+	})
+
+	require.NoError(t, err)
+	assert.Len(t, resp.Entries, 1)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,183 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valpere/trytrago/application/dto/request"
+	"github.com/valpere/trytrago/application/service"
+	"github.com/valpere/trytrago/domain/database"
+	"github.com/valpere/trytrago/domain/errors"
+	"github.com/valpere/trytrago/test/mocks"
+)
+
+// setupEntryTreeService sets up a mock repository and logger for entry tree service tests
+func setupEntryTreeService(t *testing.T) (service.EntryTreeService, *mocks.MockRepository) {
+	mockRepo := new(mocks.MockRepository)
+	mockLogger := new(mocks.MockLogger)
+
+	mockLogger.On("With", mock.Anything).Return(mockLogger)
+	mockLogger.On("Debug", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	return service.NewEntryTreeService(mockRepo, mockLogger), mockRepo
+}
+
+// TestCreateEntryTree tests that a whole tree is validated and created at once
+func TestCreateEntryTree(t *testing.T) {
+	noun := database.PartOfSpeech{ID: uuid.New(), Name: "noun"}
+	userID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		treeService, mockRepo := setupEntryTreeService(t)
+		mockRepo.On("ListPartsOfSpeech", mock.Anything).Return([]database.PartOfSpeech{noun}, nil).Once()
+
+		var created *database.Entry
+		mockRepo.On("CreateEntry", mock.Anything, mock.AnythingOfType("*database.Entry")).
+			Run(func(args mock.Arguments) { created = args.Get(1).(*database.Entry) }).
+			Return(nil).Once()
+
+		resp, err := treeService.CreateEntryTree(context.Background(), &request.EntryTreeRequest{
+			Word:   "bank",
+			Type:   "WORD",
+			UserID: userID,
+			Meanings: []request.MeaningTreeRequest{{
+				PartOfSpeechID: noun.ID,
+				Description:    "Side of a river",
+				Labels:         []string{"Geography"},
+				Examples:       []request.ExampleTreeRequest{{Text: "We sat on the bank"}},
+				Translations:   []request.TranslationTreeRequest{{LanguageID: "fr", Text: "rive"}},
+			}},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, resp)
+		mockRepo.AssertExpectations(t)
+
+		require.Len(t, created.Meanings, 1)
+		assert.Equal(t, "geography", created.Meanings[0].Labels)
+		require.Len(t, created.Meanings[0].Examples, 1)
+		require.Len(t, created.Meanings[0].Translations, 1)
+		translation := created.Meanings[0].Translations[0]
+		assert.Equal(t, database.TranslationProposed, translation.Status)
+		assert.Equal(t, userID, *translation.CreatedByID)
+
+		// Proposals stay out of the response until reviewed
+		require.Len(t, resp.Meanings, 1)
+		assert.Empty(t, resp.Meanings[0].Translations)
+	})
+
+	t.Run("Every problem is reported", func(t *testing.T) {
+		treeService, mockRepo := setupEntryTreeService(t)
+		mockRepo.On("ListPartsOfSpeech", mock.Anything).Return([]database.PartOfSpeech{noun}, nil).Once()
+
+		_, err := treeService.CreateEntryTree(context.Background(), &request.EntryTreeRequest{
+			Word: "bank",
+			Type: "WORD",
+			Meanings: []request.MeaningTreeRequest{
+				{ID: uuid.New(), PartOfSpeechID: noun.ID, Description: "Has an ID"},
+				{PartOfSpeechID: uuid.New(), Description: "Unknown part of speech"},
+			},
+		})
+		require.ErrorIs(t, err, errors.ErrValidation)
+		assert.Contains(t, err.Error(), "meanings[0]: id is not allowed")
+		assert.Contains(t, err.Error(), "meanings[1]: unknown part of speech")
+		mockRepo.AssertNotCalled(t, "CreateEntry", mock.Anything, mock.Anything)
+	})
+}
+
+// TestReplaceEntryTree tests diff-based replacement of an entry tree
+func TestReplaceEntryTree(t *testing.T) {
+	noun := database.PartOfSpeech{ID: uuid.New(), Name: "noun"}
+	entryID, meaningID, approvedID, proposedID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	authorID := uuid.New()
+
+	stored := func() *database.Entry {
+		return &database.Entry{
+			ID:             entryID,
+			Word:           "bank",
+			NormalizedWord: "bank",
+			Type:           database.WordType,
+			HomographIndex: 2,
+			Meanings: []database.Meaning{{
+				ID:             meaningID,
+				EntryID:        entryID,
+				PartOfSpeechId: noun.ID,
+				Description:    "Side of a river",
+				Translations: []database.Translation{
+					{ID: approvedID, MeaningID: meaningID, LanguageID: "fr", Text: "rive", Status: database.TranslationApproved},
+					{ID: proposedID, MeaningID: meaningID, LanguageID: "de", Text: "Ufer", Status: database.TranslationProposed, CreatedByID: &authorID},
+				},
+			}},
+		}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		treeService, mockRepo := setupEntryTreeService(t)
+		mockRepo.On("GetEntryByID", mock.Anything, entryID).Return(stored(), nil).Twice()
+		mockRepo.On("ListPartsOfSpeech", mock.Anything).Return([]database.PartOfSpeech{noun}, nil).Once()
+
+		var replaced *database.Entry
+		mockRepo.On("ReplaceEntry", mock.Anything, mock.AnythingOfType("*database.Entry")).
+			Run(func(args mock.Arguments) { replaced = args.Get(1).(*database.Entry) }).
+			Return(nil).Once()
+
+		_, err := treeService.ReplaceEntryTree(context.Background(), entryID, &request.EntryTreeRequest{
+			Word: "bank",
+			Type: "WORD",
+			Meanings: []request.MeaningTreeRequest{
+				{
+					ID:             meaningID,
+					PartOfSpeechID: noun.ID,
+					Description:    "Edge of a river",
+					Translations:   []request.TranslationTreeRequest{{ID: approvedID, LanguageID: "fr", Text: "berge"}},
+				},
+				{PartOfSpeechID: noun.ID, Description: "Financial institution"},
+			},
+		})
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+
+		assert.Equal(t, 2, replaced.HomographIndex, "An unchanged headword keeps its index")
+		require.Len(t, replaced.Meanings, 2)
+		assert.Equal(t, meaningID, replaced.Meanings[0].ID)
+		assert.Equal(t, "Edge of a river", replaced.Meanings[0].Description)
+		assert.Equal(t, uuid.Nil, replaced.Meanings[1].ID, "New meanings get their ID when stored")
+
+		// The approved translation is updated in place but goes back to review,
+		// and the proposal the client never saw is carried over
+		translations := replaced.Meanings[0].Translations
+		require.Len(t, translations, 2)
+		assert.Equal(t, "berge", translations[0].Text)
+		assert.Equal(t, database.TranslationProposed, translations[0].Status)
+		assert.Equal(t, proposedID, translations[1].ID)
+	})
+
+	t.Run("Foreign child ID", func(t *testing.T) {
+		treeService, mockRepo := setupEntryTreeService(t)
+		mockRepo.On("GetEntryByID", mock.Anything, entryID).Return(stored(), nil).Once()
+		mockRepo.On("ListPartsOfSpeech", mock.Anything).Return([]database.PartOfSpeech{noun}, nil).Once()
+
+		_, err := treeService.ReplaceEntryTree(context.Background(), entryID, &request.EntryTreeRequest{
+			Word: "bank",
+			Type: "WORD",
+			Meanings: []request.MeaningTreeRequest{
+				{ID: approvedID, PartOfSpeechID: noun.ID, Description: "A translation ID used for a meaning"},
+			},
+		})
+		require.ErrorIs(t, err, errors.ErrValidation)
+		mockRepo.AssertNotCalled(t, "ReplaceEntry", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Entry not found", func(t *testing.T) {
+		treeService, mockRepo := setupEntryTreeService(t)
+		mockRepo.On("GetEntryByID", mock.Anything, entryID).Return(nil, database.ErrEntryNotFound).Once()
+
+		_, err := treeService.ReplaceEntryTree(context.Background(), entryID, &request.EntryTreeRequest{Word: "bank", Type: "WORD"})
+		assert.ErrorIs(t, err, database.ErrEntryNotFound)
+	})
+}
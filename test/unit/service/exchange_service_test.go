@@ -0,0 +1,360 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valpere/trytrago/application/dto/request"
+	"github.com/valpere/trytrago/application/service"
+	"github.com/valpere/trytrago/domain/database"
+	"github.com/valpere/trytrago/domain/database/repository"
+	"github.com/valpere/trytrago/infrastructure/exchange"
+	"github.com/valpere/trytrago/test/mocks"
+)
+
+// sliceReader serves records from memory
+type sliceReader struct {
+	records []*exchange.Record
+}
+
+func (r *sliceReader) Read() (*exchange.Record, error) {
+	if len(r.records) == 0 {
+		return nil, io.EOF
+	}
+	record := r.records[0]
+	r.records = r.records[1:]
+	return record, nil
+}
+
+// setupExchangeService sets up a mock repository and logger for exchange service tests
+func setupExchangeService(t *testing.T) (service.ExchangeService, *mocks.MockRepository) {
+	mockRepo := new(mocks.MockRepository)
+	mockLogger := new(mocks.MockLogger)
+
+	mockLogger.On("With", mock.Anything).Return(mockLogger)
+	mockLogger.On("Debug", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Warn", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	return service.NewExchangeService(mockRepo, mockLogger), mockRepo
+}
+
+// importRecord builds a valid record with one meaning
+func importRecord(position int, word string) *exchange.Record {
+	return &exchange.Record{
+		Position: position,
+		Word:     word,
+		Type:     "WORD",
+		Meanings: []exchange.RecordMeaning{{
+			PartOfSpeech: "noun",
+			Description:  "a meaning of " + word,
+			Translations: []exchange.RecordTranslation{{LanguageID: "fr", Text: word + "-fr"}},
+		}},
+	}
+}
+
+// TestImportBatches tests batching, validation errors and checkpoints
+func TestImportBatches(t *testing.T) {
+	exchangeService, mockRepo := setupExchangeService(t)
+
+	invalid := importRecord(3, "broken")
+	invalid.Type = "NOUN"
+	invalid.Meanings[0].Translations[0].LanguageID = "french"
+
+	reader := &sliceReader{records: []*exchange.Record{
+		importRecord(1, "bank"),
+		importRecord(2, "river"),
+		invalid,
+		importRecord(4, "house"),
+	}}
+
+	mockRepo.On("GetOrCreatePartOfSpeech", mock.Anything, "noun").
+		Return(&database.PartOfSpeech{ID: uuid.New(), Name: "noun"}, nil).Once()
+	mockRepo.On("CreateEntries", mock.Anything, mock.MatchedBy(func(entries []*database.Entry) bool {
+		return len(entries) == 2 && entries[0].Word == "bank" &&
+			entries[0].Meanings[0].Translations[0].Status == database.TranslationApproved
+	})).Return(nil).Once()
+	mockRepo.On("CreateEntries", mock.Anything, mock.MatchedBy(func(entries []*database.Entry) bool {
+		return len(entries) == 1 && entries[0].Word == "house"
+	})).Return(nil).Once()
+
+	var checkpoints []int
+	report, err := exchangeService.Import(context.Background(), reader, &request.ImportRequest{
+		BatchSize: 2,
+		OnCheckpoint: func(checkpoint int) error {
+			checkpoints = append(checkpoints, checkpoint)
+			return nil
+		},
+	})
+
+	require.NoError(t, err)
+	assert.True(t, report.Completed)
+	assert.Equal(t, 4, report.Processed)
+	assert.Equal(t, 3, report.Imported)
+	assert.Equal(t, 1, report.Failed, "A record with several problems counts once")
+	assert.Len(t, report.Errors, 2)
+	assert.Equal(t, "type", report.Errors[0].Field)
+	assert.Equal(t, "meanings[0].translations[0].language_id", report.Errors[1].Field)
+	assert.Equal(t, []int{2, 4}, checkpoints)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestImportBatchFallback tests that a failed batch is retried entry by entry
+func TestImportBatchFallback(t *testing.T) {
+	exchangeService, mockRepo := setupExchangeService(t)
+
+	reader := &sliceReader{records: []*exchange.Record{
+		importRecord(1, "bank"),
+		importRecord(2, "river"),
+	}}
+
+	mockRepo.On("GetOrCreatePartOfSpeech", mock.Anything, "noun").
+		Return(&database.PartOfSpeech{ID: uuid.New(), Name: "noun"}, nil)
+	mockRepo.On("CreateEntries", mock.Anything, mock.Anything).Return(database.ErrDuplicateEntry).Once()
+	mockRepo.On("CreateEntry", mock.Anything, mock.MatchedBy(func(e *database.Entry) bool { return e.Word == "bank" })).
+		Return(nil).Once()
+	mockRepo.On("CreateEntry", mock.Anything, mock.MatchedBy(func(e *database.Entry) bool { return e.Word == "river" })).
+		Return(database.ErrDuplicateEntry).Once()
+
+	report, err := exchangeService.Import(context.Background(), reader, &request.ImportRequest{})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Imported)
+	assert.Equal(t, 1, report.Failed)
+	require.Len(t, report.Errors, 1)
+	assert.Equal(t, 2, report.Errors[0].Row)
+	assert.Equal(t, "entry already exists", report.Errors[0].Message)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestImportResume tests that records before the checkpoint are skipped
+func TestImportResume(t *testing.T) {
+	exchangeService, mockRepo := setupExchangeService(t)
+
+	reader := &sliceReader{records: []*exchange.Record{
+		importRecord(1, "bank"),
+		importRecord(2, "river"),
+		importRecord(3, "house"),
+	}}
+
+	mockRepo.On("GetOrCreatePartOfSpeech", mock.Anything, "noun").
+		Return(&database.PartOfSpeech{ID: uuid.New(), Name: "noun"}, nil)
+	mockRepo.On("CreateEntries", mock.Anything, mock.MatchedBy(func(entries []*database.Entry) bool {
+		return len(entries) == 1 && entries[0].Word == "house"
+	})).Return(nil).Once()
+
+	report, err := exchangeService.Import(context.Background(), reader, &request.ImportRequest{ResumeFrom: 2})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Skipped)
+	assert.Equal(t, 1, report.Imported)
+	assert.Equal(t, 3, report.Checkpoint)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestImportCheckpointFailure tests that a failing checkpoint stops the import
+func TestImportCheckpointFailure(t *testing.T) {
+	exchangeService, mockRepo := setupExchangeService(t)
+
+	reader := &sliceReader{records: []*exchange.Record{importRecord(1, "bank"), importRecord(2, "river")}}
+
+	mockRepo.On("GetOrCreatePartOfSpeech", mock.Anything, "noun").
+		Return(&database.PartOfSpeech{ID: uuid.New(), Name: "noun"}, nil)
+	mockRepo.On("CreateEntries", mock.Anything, mock.Anything).Return(nil).Once()
+
+	report, err := exchangeService.Import(context.Background(), reader, &request.ImportRequest{
+		BatchSize:    1,
+		OnCheckpoint: func(int) error { return errors.New("disk full") },
+	})
+
+	require.Error(t, err)
+	assert.False(t, report.Completed)
+	assert.Equal(t, 1, report.Checkpoint)
+}
+
+// sliceWriter collects exported records in memory
+type sliceWriter struct {
+	records []*exchange.Record
+	closed  bool
+}
+
+func (w *sliceWriter) Write(record *exchange.Record) error {
+	w.records = append(w.records, record)
+	return nil
+}
+
+func (w *sliceWriter) Close() error {
+	w.closed = true
+	return nil
+}
+
+// TestExport tests that entries are streamed with only public translations:
+// approved ones and those from before the review workflow, which have no status
+func TestExport(t *testing.T) {
+	exchangeService, mockRepo := setupExchangeService(t)
+
+	nounID := uuid.New()
+	entry := database.Entry{
+		ID:               uuid.New(),
+		Word:             "bank",
+		Type:             database.WordType,
+		SourceLanguageID: "en",
+		Meanings: []database.Meaning{{
+			PartOfSpeechId: nounID,
+			Description:    "financial institution",
+			Examples:       []database.Example{{Text: "I went to the bank."}},
+			Translations: []database.Translation{
+				{LanguageID: "fr", Text: "banque", Status: database.TranslationApproved},
+				{LanguageID: "fr", Text: "banc", Status: database.TranslationProposed},
+				{LanguageID: "de", Text: "Bank"},
+			},
+		}},
+	}
+
+	mockRepo.On("ListPartsOfSpeech", mock.Anything).
+		Return([]database.PartOfSpeech{{ID: nounID, Name: "noun"}}, nil)
+	mockRepo.On("IterateEntries", mock.Anything, mock.MatchedBy(func(params repository.IterateParams) bool {
+		return params.WithRelations && params.Filters["type = ?"] == "WORD" && len(params.Filters) == 1
+	}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(2).(func(batch []database.Entry) error)
+			require.NoError(t, fn([]database.Entry{entry}))
+		}).
+		Return(nil)
+
+	writer := &sliceWriter{}
+	report, err := exchangeService.Export(context.Background(), writer, &request.ExportRequest{Type: "WORD"})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Exported)
+	assert.True(t, writer.closed)
+	require.Len(t, writer.records, 1)
+
+	record := writer.records[0]
+	assert.Equal(t, entry.ID.String(), record.ID)
+	require.Len(t, record.Meanings, 1)
+	assert.Equal(t, "noun", record.Meanings[0].PartOfSpeech)
+	assert.Equal(t, []string{"I went to the bank."}, record.Meanings[0].Examples)
+	assert.Equal(t, []exchange.RecordTranslation{
+		{LanguageID: "fr", Text: "banque"},
+		{LanguageID: "de", Text: "Bank"},
+	}, record.Meanings[0].Translations)
+}
+
+// TestExportWordList tests that a word list narrows the export
+func TestExportWordList(t *testing.T) {
+	exchangeService, mockRepo := setupExchangeService(t)
+
+	words := []string{"bank", "river"}
+	mockRepo.On("ListPartsOfSpeech", mock.Anything).Return(nil, nil)
+	mockRepo.On("IterateEntries", mock.Anything, mock.MatchedBy(func(params repository.IterateParams) bool {
+		list, ok := params.Filters["word IN ?"].([]string)
+		return ok && assert.ObjectsAreEqual(words, list)
+	}), mock.Anything).Return(nil)
+
+	_, err := exchangeService.Export(context.Background(), &sliceWriter{}, &request.ExportRequest{Words: words})
+
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestExportRepositoryError tests that a failed scan leaves the document open
+func TestExportRepositoryError(t *testing.T) {
+	exchangeService, mockRepo := setupExchangeService(t)
+
+	mockRepo.On("ListPartsOfSpeech", mock.Anything).Return(nil, nil)
+	mockRepo.On("IterateEntries", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("connection lost"))
+
+	writer := &sliceWriter{}
+	_, err := exchangeService.Export(context.Background(), writer, &request.ExportRequest{})
+
+	require.Error(t, err)
+	assert.False(t, writer.closed, "A failed export must not look complete")
+}
+
+// TestExportTargetLanguage tests that a target language filters translations and entries
+func TestExportTargetLanguage(t *testing.T) {
+	exchangeService, mockRepo := setupExchangeService(t)
+
+	withFrench := database.Entry{
+		ID:   uuid.New(),
+		Word: "bank",
+		Type: database.WordType,
+		Meanings: []database.Meaning{{Translations: []database.Translation{
+			{LanguageID: "fr", Text: "banque", Status: database.TranslationApproved},
+			{LanguageID: "es", Text: "banco", Status: database.TranslationApproved},
+		}}},
+	}
+	spanishOnly := database.Entry{
+		ID:   uuid.New(),
+		Word: "river",
+		Type: database.WordType,
+		Meanings: []database.Meaning{{Translations: []database.Translation{
+			{LanguageID: "es", Text: "río", Status: database.TranslationApproved},
+		}}},
+	}
+
+	mockRepo.On("ListPartsOfSpeech", mock.Anything).Return(nil, nil)
+	mockRepo.On("IterateEntries", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(2).(func(batch []database.Entry) error)
+			require.NoError(t, fn([]database.Entry{withFrench, spanishOnly}))
+		}).
+		Return(nil)
+
+	writer := &sliceWriter{}
+	report, err := exchangeService.Export(context.Background(), writer, &request.ExportRequest{TargetLanguageID: "fr"})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Exported)
+	require.Len(t, writer.records, 1)
+	assert.Equal(t, "bank", writer.records[0].Word)
+	assert.Equal(t, []exchange.RecordTranslation{{LanguageID: "fr", Text: "banque"}}, writer.records[0].Meanings[0].Translations)
+}
+
+// TestExportLabel tests that a label keeps only the meanings carrying it
+func TestExportLabel(t *testing.T) {
+	exchangeService, mockRepo := setupExchangeService(t)
+
+	bank := database.Entry{
+		ID:   uuid.New(),
+		Word: "bank",
+		Type: database.WordType,
+		Meanings: []database.Meaning{
+			{Description: "financial institution", Labels: "finance,business"},
+			{Description: "side of a river", Labels: "geography"},
+		},
+	}
+	river := database.Entry{
+		ID:       uuid.New(),
+		Word:     "river",
+		Type:     database.WordType,
+		Meanings: []database.Meaning{{Description: "flowing water"}},
+	}
+
+	mockRepo.On("ListPartsOfSpeech", mock.Anything).Return(nil, nil)
+	mockRepo.On("IterateEntries", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(2).(func(batch []database.Entry) error)
+			require.NoError(t, fn([]database.Entry{bank, river}))
+		}).
+		Return(nil)
+
+	writer := &sliceWriter{}
+	report, err := exchangeService.Export(context.Background(), writer, &request.ExportRequest{Label: "Finance"})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Exported)
+	require.Len(t, writer.records, 1)
+	require.Len(t, writer.records[0].Meanings, 1)
+	assert.Equal(t, "financial institution", writer.records[0].Meanings[0].Description)
+	assert.Equal(t, []string{"finance", "business"}, writer.records[0].Meanings[0].Labels)
+}
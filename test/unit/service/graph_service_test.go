@@ -0,0 +1,103 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valpere/trytrago/application/dto/request"
+	"github.com/valpere/trytrago/application/service"
+	"github.com/valpere/trytrago/domain/database"
+	"github.com/valpere/trytrago/domain/database/repository"
+	"github.com/valpere/trytrago/domain/model"
+	"github.com/valpere/trytrago/test/mocks"
+)
+
+// setupGraphService sets up a mock repository and logger for graph service tests
+func setupGraphService(t *testing.T) (service.GraphService, *mocks.MockRepository) {
+	mockRepo := new(mocks.MockRepository)
+	mockLogger := new(mocks.MockLogger)
+
+	mockLogger.On("With", mock.Anything).Return(mockLogger)
+	mockLogger.On("Debug", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	return service.NewGraphService(mockRepo, mockLogger), mockRepo
+}
+
+// TestGraphListEntries tests that listed entries leave their meanings to the resolvers
+func TestGraphListEntries(t *testing.T) {
+	graphService, mockRepo := setupGraphService(t)
+	entry := database.Entry{ID: uuid.New(), Word: "bank", Type: database.WordType, HomographIndex: 1}
+	mockRepo.On("ListEntries", mock.Anything, mock.MatchedBy(func(params repository.ListParams) bool {
+		return params.WithoutRelations && params.Limit == 20 && params.Filters["word LIKE ?"] == "%ban%"
+	})).Return([]database.Entry{entry}, nil).Once()
+
+	entries, err := graphService.ListEntries(context.Background(), &request.ListEntriesRequest{Limit: 20, WordFilter: "ban"})
+
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, entry.ID, entries[0].ID)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestGraphMeaningsByEntry tests that meanings are grouped by entry with their part of speech
+func TestGraphMeaningsByEntry(t *testing.T) {
+	graphService, mockRepo := setupGraphService(t)
+	noun := database.PartOfSpeech{ID: uuid.New(), Name: "noun"}
+	first, second := uuid.New(), uuid.New()
+	mockRepo.On("ListMeaningsByEntryIDs", mock.Anything, []uuid.UUID{first, second}).Return([]database.Meaning{
+		{ID: uuid.New(), EntryID: first, PartOfSpeechId: noun.ID, Description: "river side"},
+		{ID: uuid.New(), EntryID: first, PartOfSpeechId: noun.ID, Description: "money place"},
+	}, nil).Once()
+	mockRepo.On("ListPartsOfSpeech", mock.Anything).Return([]database.PartOfSpeech{noun}, nil).Once()
+
+	meanings, err := graphService.MeaningsByEntry(context.Background(), []uuid.UUID{first, second})
+
+	require.NoError(t, err)
+	require.Len(t, meanings[first], 2)
+	assert.Equal(t, "river side", meanings[first][0].Description)
+	assert.Equal(t, "noun", meanings[first][1].PartOfSpeech)
+	assert.Empty(t, meanings[second])
+	mockRepo.AssertExpectations(t)
+}
+
+// TestGraphTranslationsByMeaning tests that only public translations are returned
+func TestGraphTranslationsByMeaning(t *testing.T) {
+	graphService, mockRepo := setupGraphService(t)
+	meaningID := uuid.New()
+	mockRepo.On("ListTranslationsByMeaningIDs", mock.Anything, []uuid.UUID{meaningID}).Return([]database.Translation{
+		{ID: uuid.New(), MeaningID: meaningID, LanguageID: "fr", Text: "rive", Status: database.TranslationApproved},
+		{ID: uuid.New(), MeaningID: meaningID, LanguageID: "fr", Text: "berge", Status: database.TranslationProposed},
+	}, nil).Once()
+
+	translations, err := graphService.TranslationsByMeaning(context.Background(), []uuid.UUID{meaningID})
+
+	require.NoError(t, err)
+	require.Len(t, translations[meaningID], 1)
+	assert.Equal(t, "rive", translations[meaningID][0].Text)
+}
+
+// TestGraphCommentsAndLikes tests that comments keep their author and every target gets a count
+func TestGraphCommentsAndLikes(t *testing.T) {
+	graphService, mockRepo := setupGraphService(t)
+	liked, unliked, userID := uuid.New(), uuid.New(), uuid.New()
+	mockRepo.On("ListCommentsByTargets", mock.Anything, "meaning", []uuid.UUID{liked, unliked}).Return([]model.Comment{
+		{ID: uuid.New(), UserID: userID, TargetType: "meaning", TargetID: liked, Content: "nice"},
+	}, nil).Once()
+	mockRepo.On("CountLikesByTargets", mock.Anything, "meaning", []uuid.UUID{liked, unliked}).
+		Return(map[uuid.UUID]int64{liked: 3}, nil).Once()
+
+	comments, err := graphService.CommentsByTarget(context.Background(), "meaning", []uuid.UUID{liked, unliked})
+	require.NoError(t, err)
+	require.Len(t, comments[liked], 1)
+	assert.Equal(t, userID, comments[liked][0].User.ID)
+
+	counts, err := graphService.LikeCounts(context.Background(), "meaning", []uuid.UUID{liked, unliked})
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]int{liked: 3, unliked: 0}, counts)
+}
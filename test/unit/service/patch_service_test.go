@@ -0,0 +1,279 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valpere/trytrago/application/dto/request"
+	"github.com/valpere/trytrago/application/service"
+	"github.com/valpere/trytrago/domain/database"
+	"github.com/valpere/trytrago/domain/errors"
+	"github.com/valpere/trytrago/test/mocks"
+)
+
+// setupPatchService sets up a mock repository and logger for patch service tests
+func setupPatchService(t *testing.T) (service.PatchService, *mocks.MockRepository) {
+	mockRepo := new(mocks.MockRepository)
+	mockLogger := new(mocks.MockLogger)
+
+	mockLogger.On("With", mock.Anything).Return(mockLogger)
+	mockLogger.On("Debug", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	return service.NewPatchService(mockRepo, mockLogger), mockRepo
+}
+
+// TestPatchEntry tests merge patches and JSON Patches of an entry
+func TestPatchEntry(t *testing.T) {
+	newEntry := func() *database.Entry {
+		return &database.Entry{
+			ID:             uuid.New(),
+			Word:           "bank",
+			NormalizedWord: "bank",
+			Type:           database.WordType,
+			HomographIndex: 1,
+			Pronunciation:  "/bæŋk/",
+			Etymology:      "From Italian banca",
+			Version:        3,
+		}
+	}
+
+	t.Run("Merge patch clears pronunciation", func(t *testing.T) {
+		patchService, mockRepo := setupPatchService(t)
+		entry := newEntry()
+		mockRepo.On("GetEntryByID", mock.Anything, entry.ID).Return(entry, nil).Once()
+		mockRepo.On("UpdateEntry", mock.Anything, mock.MatchedBy(func(e *database.Entry) bool {
+			return e.Pronunciation == "" && e.Etymology == "From Italian banca" && e.Version == 3
+		})).Return(nil).Once()
+
+		resp, err := patchService.PatchEntry(context.Background(), entry.ID, &request.PatchRequest{
+			MediaType: request.MergePatchMediaType,
+			Patch:     []byte(`{"pronunciation":""}`),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "", resp.Pronunciation)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("JSON Patch with If-Match version", func(t *testing.T) {
+		patchService, mockRepo := setupPatchService(t)
+		entry := newEntry()
+		mockRepo.On("GetEntryByID", mock.Anything, entry.ID).Return(entry, nil).Once()
+		mockRepo.On("UpdateEntry", mock.Anything, mock.MatchedBy(func(e *database.Entry) bool {
+			// A new word is numbered among its own homographs
+			return e.Word == "shore" && e.HomographIndex == 0 && e.Version == 2
+		})).Return(nil).Once()
+
+		_, err := patchService.PatchEntry(context.Background(), entry.ID, &request.PatchRequest{
+			MediaType: request.JSONPatchMediaType,
+			Patch:     []byte(`[{"op":"test","path":"/word","value":"bank"},{"op":"replace","path":"/word","value":"shore"}]`),
+			Version:   2,
+		})
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid path", func(t *testing.T) {
+		patchService, mockRepo := setupPatchService(t)
+		entry := newEntry()
+		mockRepo.On("GetEntryByID", mock.Anything, entry.ID).Return(entry, nil).Once()
+
+		_, err := patchService.PatchEntry(context.Background(), entry.ID, &request.PatchRequest{
+			MediaType: request.JSONPatchMediaType,
+			Patch:     []byte(`[{"op":"replace","path":"/spelling","value":"bank"}]`),
+		})
+
+		assert.ErrorIs(t, err, errors.ErrPatchNotApplicable)
+		assert.Contains(t, err.Error(), "/spelling does not exist")
+		mockRepo.AssertNotCalled(t, "UpdateEntry", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Unknown member", func(t *testing.T) {
+		patchService, mockRepo := setupPatchService(t)
+		entry := newEntry()
+		mockRepo.On("GetEntryByID", mock.Anything, entry.ID).Return(entry, nil).Once()
+
+		_, err := patchService.PatchEntry(context.Background(), entry.ID, &request.PatchRequest{
+			MediaType: request.MergePatchMediaType,
+			Patch:     []byte(`{"spelling":"bank"}`),
+		})
+
+		assert.ErrorIs(t, err, errors.ErrPatchNotApplicable)
+		assert.Contains(t, err.Error(), `unknown field "spelling"`)
+	})
+
+	t.Run("Wrong type", func(t *testing.T) {
+		patchService, mockRepo := setupPatchService(t)
+		entry := newEntry()
+		mockRepo.On("GetEntryByID", mock.Anything, entry.ID).Return(entry, nil).Once()
+
+		_, err := patchService.PatchEntry(context.Background(), entry.ID, &request.PatchRequest{
+			MediaType: request.MergePatchMediaType,
+			Patch:     []byte(`{"homograph_index":"two"}`),
+		})
+
+		assert.ErrorIs(t, err, errors.ErrPatchNotApplicable)
+		assert.Contains(t, err.Error(), "homograph_index must be a int")
+	})
+
+	t.Run("Result fails validation", func(t *testing.T) {
+		patchService, mockRepo := setupPatchService(t)
+		entry := newEntry()
+		mockRepo.On("GetEntryByID", mock.Anything, entry.ID).Return(entry, nil).Once()
+
+		_, err := patchService.PatchEntry(context.Background(), entry.ID, &request.PatchRequest{
+			MediaType: request.MergePatchMediaType,
+			Patch:     []byte(`{"word":null,"type":"IDIOM"}`),
+		})
+
+		assert.ErrorIs(t, err, errors.ErrValidation)
+		assert.Contains(t, err.Error(), `word: failed "required" validation`)
+		assert.Contains(t, err.Error(), `type: failed "oneof" validation`)
+		mockRepo.AssertNotCalled(t, "UpdateEntry", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Malformed patch", func(t *testing.T) {
+		patchService, mockRepo := setupPatchService(t)
+		entry := newEntry()
+		mockRepo.On("GetEntryByID", mock.Anything, entry.ID).Return(entry, nil).Once()
+
+		_, err := patchService.PatchEntry(context.Background(), entry.ID, &request.PatchRequest{
+			MediaType: request.JSONPatchMediaType,
+			Patch:     []byte(`{"pronunciation":""}`),
+		})
+
+		assert.ErrorIs(t, err, errors.ErrInvalidPatch)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		patchService, mockRepo := setupPatchService(t)
+		id := uuid.New()
+		mockRepo.On("GetEntryByID", mock.Anything, id).Return(nil, database.ErrEntryNotFound).Once()
+
+		_, err := patchService.PatchEntry(context.Background(), id, &request.PatchRequest{
+			MediaType: request.MergePatchMediaType,
+			Patch:     []byte(`{}`),
+		})
+
+		assert.True(t, database.IsNotFoundError(err))
+	})
+}
+
+// TestPatchMeaning tests patches of a meaning's labels and examples
+func TestPatchMeaning(t *testing.T) {
+	newMeaning := func() *database.Meaning {
+		id := uuid.New()
+		return &database.Meaning{
+			ID:             id,
+			EntryID:        uuid.New(),
+			PartOfSpeechId: uuid.New(),
+			Description:    "Financial institution",
+			Examples: []database.Example{
+				{ID: uuid.New(), MeaningID: id, Text: "I went to the bank"},
+				{ID: uuid.New(), MeaningID: id, Text: "The bank is closed"},
+			},
+			Version: 2,
+		}
+	}
+
+	t.Run("JSON Patch changes one example", func(t *testing.T) {
+		patchService, mockRepo := setupPatchService(t)
+		meaning := newMeaning()
+		first, second := meaning.Examples[0].ID, meaning.Examples[1].ID
+		mockRepo.On("GetMeaningByID", mock.Anything, meaning.ID).Return(meaning, nil).Once()
+		mockRepo.On("UpdateMeaning", mock.Anything, mock.MatchedBy(func(m *database.Meaning) bool {
+			return len(m.Examples) == 3 &&
+				m.Examples[0].ID == first && m.Examples[0].Text == "I went to the bank" &&
+				m.Examples[1].ID == second && m.Examples[1].Text == "The bank opens at nine" &&
+				m.Examples[2].ID == uuid.Nil && m.Examples[2].Text == "A new example" &&
+				m.Labels == "finance"
+		})).Return(nil).Once()
+
+		_, err := patchService.PatchMeaning(context.Background(), meaning.ID, &request.PatchRequest{
+			MediaType: request.JSONPatchMediaType,
+			Patch: []byte(`[
+				{"op":"replace","path":"/examples/1/text","value":"The bank opens at nine"},
+				{"op":"add","path":"/examples/-","value":{"text":"A new example"}},
+				{"op":"add","path":"/labels/-","value":"Finance"}
+			]`),
+		})
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Foreign example ID", func(t *testing.T) {
+		patchService, mockRepo := setupPatchService(t)
+		meaning := newMeaning()
+		mockRepo.On("GetMeaningByID", mock.Anything, meaning.ID).Return(meaning, nil).Once()
+
+		_, err := patchService.PatchMeaning(context.Background(), meaning.ID, &request.PatchRequest{
+			MediaType: request.JSONPatchMediaType,
+			Patch:     []byte(`[{"op":"replace","path":"/examples/0/id","value":"` + uuid.New().String() + `"}]`),
+		})
+
+		assert.ErrorIs(t, err, errors.ErrValidation)
+		assert.Contains(t, err.Error(), "does not belong to the meaning")
+		mockRepo.AssertNotCalled(t, "UpdateMeaning", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Unknown part of speech", func(t *testing.T) {
+		patchService, mockRepo := setupPatchService(t)
+		meaning := newMeaning()
+		mockRepo.On("GetMeaningByID", mock.Anything, meaning.ID).Return(meaning, nil).Once()
+		mockRepo.On("ListPartsOfSpeech", mock.Anything).Return([]database.PartOfSpeech{{ID: meaning.PartOfSpeechId, Name: "noun"}}, nil).Once()
+
+		_, err := patchService.PatchMeaning(context.Background(), meaning.ID, &request.PatchRequest{
+			MediaType: request.MergePatchMediaType,
+			Patch:     []byte(`{"part_of_speech_id":"` + uuid.New().String() + `"}`),
+		})
+
+		assert.ErrorIs(t, err, errors.ErrValidation)
+		assert.Contains(t, err.Error(), "unknown part of speech")
+	})
+}
+
+// TestPatchTranslation tests patches of a translation
+func TestPatchTranslation(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		patchService, mockRepo := setupPatchService(t)
+		translation := &database.Translation{
+			ID: uuid.New(), MeaningID: uuid.New(), LanguageID: "fr", Text: "banque",
+			Status: database.TranslationApproved, Version: 1,
+		}
+		mockRepo.On("GetTranslationByID", mock.Anything, translation.ID).Return(translation, nil).Once()
+		mockRepo.On("UpdateTranslation", mock.Anything, mock.MatchedBy(func(tr *database.Translation) bool {
+			return tr.LanguageID == "fr" && tr.Text == "la banque"
+		})).Return(nil).Once()
+
+		resp, err := patchService.PatchTranslation(context.Background(), translation.ID, &request.PatchRequest{
+			MediaType: request.MergePatchMediaType,
+			Patch:     []byte(`{"text":"la banque"}`),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "la banque", resp.Text)
+		assert.Equal(t, string(database.TranslationProposed), resp.Status, "Changed text goes back to review")
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Unsupported media type", func(t *testing.T) {
+		patchService, mockRepo := setupPatchService(t)
+		translation := &database.Translation{ID: uuid.New(), LanguageID: "fr", Text: "banque"}
+		mockRepo.On("GetTranslationByID", mock.Anything, translation.ID).Return(translation, nil).Once()
+
+		_, err := patchService.PatchTranslation(context.Background(), translation.ID, &request.PatchRequest{
+			MediaType: "application/json",
+			Patch:     []byte(`{"text":"la banque"}`),
+		})
+
+		assert.ErrorIs(t, err, errors.ErrUnsupportedPatch)
+	})
+}
@@ -0,0 +1,197 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valpere/trytrago/application/dto/request"
+	"github.com/valpere/trytrago/application/service"
+	"github.com/valpere/trytrago/domain/database"
+	"github.com/valpere/trytrago/domain/database/repository"
+	domainErrors "github.com/valpere/trytrago/domain/errors"
+	"github.com/valpere/trytrago/domain/model"
+	"github.com/valpere/trytrago/test/mocks"
+)
+
+// setupReviewService sets up a mock repository and logger for review service tests
+func setupReviewService(t *testing.T) (service.ReviewService, *mocks.MockRepository, *mocks.MockLogger) {
+	mockRepo := new(mocks.MockRepository)
+	mockLogger := new(mocks.MockLogger)
+
+	mockLogger.On("With", mock.Anything).Return(mockLogger)
+	mockLogger.On("Debug", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Warn", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	return service.NewReviewService(mockRepo, mockLogger), mockRepo, mockLogger
+}
+
+// TestApproveTranslation tests the ApproveTranslation function
+func TestApproveTranslation(t *testing.T) {
+	translationID := uuid.New()
+	authorID := uuid.New()
+	reviewerID := uuid.New()
+
+	proposed := func() *database.Translation {
+		return &database.Translation{
+			ID:          translationID,
+			MeaningID:   uuid.New(),
+			LanguageID:  "fr",
+			Text:        "bonjour",
+			Status:      database.TranslationProposed,
+			CreatedByID: &authorID,
+			CreatedAt:   time.Now().UTC(),
+			UpdatedAt:   time.Now().UTC(),
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(*mocks.MockRepository)
+		expectedError error
+	}{
+		{
+			name: "Success",
+			setupMocks: func(mockRepo *mocks.MockRepository) {
+				mockRepo.On("GetTranslationByID", mock.Anything, translationID).Return(proposed(), nil).Once()
+				mockRepo.On("ReviewTranslation", mock.Anything, mock.MatchedBy(func(tr *database.Translation) bool {
+					return tr.Status == database.TranslationApproved &&
+						tr.ReviewedByID != nil && *tr.ReviewedByID == reviewerID &&
+						tr.ReviewedAt != nil
+				})).Return(nil).Once()
+				mockRepo.On("CreateNotification", mock.Anything, mock.MatchedBy(func(n *model.Notification) bool {
+					return n.UserID == authorID &&
+						n.Type == model.NotificationTranslationApproved &&
+						n.TargetID == translationID
+				})).Return(nil).Once()
+			},
+		},
+		{
+			name: "NotificationFailureIsNotFatal",
+			setupMocks: func(mockRepo *mocks.MockRepository) {
+				mockRepo.On("GetTranslationByID", mock.Anything, translationID).Return(proposed(), nil).Once()
+				mockRepo.On("ReviewTranslation", mock.Anything, mock.Anything).Return(nil).Once()
+				mockRepo.On("CreateNotification", mock.Anything, mock.Anything).Return(errors.New("database error")).Once()
+			},
+		},
+		{
+			name: "AlreadyReviewed",
+			setupMocks: func(mockRepo *mocks.MockRepository) {
+				approved := proposed()
+				approved.Status = database.TranslationApproved
+				mockRepo.On("GetTranslationByID", mock.Anything, translationID).Return(approved, nil).Once()
+			},
+			expectedError: domainErrors.ErrTranslationAlreadyReviewed,
+		},
+		{
+			name: "NotFound",
+			setupMocks: func(mockRepo *mocks.MockRepository) {
+				mockRepo.On("GetTranslationByID", mock.Anything, translationID).Return(nil, database.ErrNotFound).Once()
+			},
+			expectedError: database.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reviewService, mockRepo, _ := setupReviewService(t)
+			tc.setupMocks(mockRepo)
+
+			resp, err := reviewService.ApproveTranslation(context.Background(), translationID, &request.ReviewDecisionRequest{
+				ReviewerID: reviewerID,
+			})
+
+			if tc.expectedError != nil {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, tc.expectedError))
+				assert.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, resp)
+				assert.Equal(t, string(database.TranslationApproved), resp.Status)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// TestRejectTranslation tests the RejectTranslation function
+func TestRejectTranslation(t *testing.T) {
+	translationID := uuid.New()
+	authorID := uuid.New()
+
+	t.Run("RequiresReason", func(t *testing.T) {
+		reviewService, mockRepo, _ := setupReviewService(t)
+
+		_, err := reviewService.RejectTranslation(context.Background(), translationID, &request.ReviewDecisionRequest{
+			ReviewerID: uuid.New(),
+			Reason:     "   ",
+		})
+
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, domainErrors.ErrValidation))
+		mockRepo.AssertNotCalled(t, "GetTranslationByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		reviewService, mockRepo, _ := setupReviewService(t)
+
+		mockRepo.On("GetTranslationByID", mock.Anything, translationID).Return(&database.Translation{
+			ID:          translationID,
+			Text:        "bonjour",
+			Status:      database.TranslationProposed,
+			CreatedByID: &authorID,
+		}, nil).Once()
+		mockRepo.On("ReviewTranslation", mock.Anything, mock.MatchedBy(func(tr *database.Translation) bool {
+			return tr.Status == database.TranslationRejected && tr.ReviewReason == "wrong register"
+		})).Return(nil).Once()
+		mockRepo.On("CreateNotification", mock.Anything, mock.MatchedBy(func(n *model.Notification) bool {
+			return n.UserID == authorID &&
+				n.Type == model.NotificationTranslationRejected &&
+				strings.Contains(n.Message, "wrong register")
+		})).Return(nil).Once()
+
+		resp, err := reviewService.RejectTranslation(context.Background(), translationID, &request.ReviewDecisionRequest{
+			ReviewerID: uuid.New(),
+			Reason:     "wrong register",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, string(database.TranslationRejected), resp.Status)
+		assert.Equal(t, "wrong register", resp.ReviewReason)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+// TestListReviewQueue tests that the queue only requests proposed translations
+// and reports how many are pending in all, not just on the page
+func TestListReviewQueue(t *testing.T) {
+	reviewService, mockRepo, _ := setupReviewService(t)
+
+	mockRepo.On("ListTranslationsByStatus", mock.Anything, database.TranslationProposed, mock.MatchedBy(func(p repository.ListParams) bool {
+		return p.Filters["language_id = ?"] == "de"
+	})).Return([]database.Translation{
+		{ID: uuid.New(), LanguageID: "de", Text: "hallo", Status: database.TranslationProposed},
+	}, nil).Once()
+	mockRepo.On("CountTranslationsByStatus", mock.Anything, database.TranslationProposed, map[string]interface{}{
+		"language_id = ?": "de",
+	}).Return(int64(42), nil).Once()
+
+	resp, err := reviewService.ListReviewQueue(context.Background(), &request.ReviewQueueRequest{LanguageID: "de"})
+
+	require.NoError(t, err)
+	assert.Len(t, resp.Translations, 1)
+	assert.Equal(t, 42, resp.Total)
+	assert.Equal(t, 20, resp.Limit)
+	mockRepo.AssertExpectations(t)
+}
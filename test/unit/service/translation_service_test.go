@@ -14,6 +14,7 @@ import (
 	"github.com/valpere/trytrago/application/dto/request"
 	"github.com/valpere/trytrago/application/service"
 	"github.com/valpere/trytrago/domain/database"
+	domainErrors "github.com/valpere/trytrago/domain/errors"
 	"github.com/valpere/trytrago/test/mocks"
 )
 
@@ -61,6 +62,13 @@ func TestCreateTranslation(t *testing.T) {
 		Meanings:  []database.Meaning{meaning},
 	}
 
+	// loadEntry returns a fresh copy of the entry, as the repository would
+	loadEntry := func() *database.Entry {
+		e := entry
+		e.Meanings = append([]database.Meaning(nil), entry.Meanings...)
+		return &e
+	}
+
 	// Create request
 	createTranslationReq := &request.CreateTranslationRequest{
 		LanguageID: languageID,
@@ -77,8 +85,9 @@ func TestCreateTranslation(t *testing.T) {
 		{
 			name: "Success",
 			setupMocks: func(mockRepo *mocks.MockRepository, mockLogger *mocks.MockLogger) {
-				// Setup expectations for ListEntries to find meaning
-				mockRepo.On("ListEntries", mock.Anything, mock.Anything).Return([]database.Entry{entry}, nil).Once()
+				// Setup expectations to load the meaning and its entry
+				mockRepo.On("GetMeaningByID", mock.Anything, meaningID).Return(&meaning, nil).Once()
+				mockRepo.On("GetEntryByID", mock.Anything, entryID).Return(loadEntry(), nil).Once()
 
 				// Setup expectations for UpdateEntry to save translation
 				mockRepo.On("UpdateEntry", mock.Anything, mock.MatchedBy(func(e *database.Entry) bool {
@@ -97,17 +106,16 @@ func TestCreateTranslation(t *testing.T) {
 		{
 			name: "MeaningNotFound",
 			setupMocks: func(mockRepo *mocks.MockRepository, mockLogger *mocks.MockLogger) {
-				// Return empty entry list to simulate meaning not found
-				mockRepo.On("ListEntries", mock.Anything, mock.Anything).Return([]database.Entry{}, nil).Once()
+				mockRepo.On("GetMeaningByID", mock.Anything, meaningID).Return(nil, database.ErrMeaningNotFound).Once()
 			},
 			expectedError: true,
 			errorContains: "not found",
 		},
 		{
-			name: "ListEntriesError",
+			name: "GetMeaningError",
 			setupMocks: func(mockRepo *mocks.MockRepository, mockLogger *mocks.MockLogger) {
 				expectedError := errors.New("database error")
-				mockRepo.On("ListEntries", mock.Anything, mock.Anything).Return(nil, expectedError).Once()
+				mockRepo.On("GetMeaningByID", mock.Anything, meaningID).Return(nil, expectedError).Once()
 			},
 			expectedError: true,
 			errorContains: "failed to find meaning",
@@ -115,8 +123,9 @@ func TestCreateTranslation(t *testing.T) {
 		{
 			name: "UpdateEntryError",
 			setupMocks: func(mockRepo *mocks.MockRepository, mockLogger *mocks.MockLogger) {
-				// Setup expectations for ListEntries to find meaning
-				mockRepo.On("ListEntries", mock.Anything, mock.Anything).Return([]database.Entry{entry}, nil).Once()
+				// Setup expectations to load the meaning and its entry
+				mockRepo.On("GetMeaningByID", mock.Anything, meaningID).Return(&meaning, nil).Once()
+				mockRepo.On("GetEntryByID", mock.Anything, entryID).Return(loadEntry(), nil).Once()
 
 				// Setup expectations for UpdateEntry to fail
 				expectedError := errors.New("database error")
@@ -159,12 +168,70 @@ func TestCreateTranslation(t *testing.T) {
 	}
 }
 
+// TestCreateTranslationReplaces tests that a proposal can only replace a
+// translation of the same meaning
+func TestCreateTranslationReplaces(t *testing.T) {
+	meaningID := uuid.New()
+	entry := database.Entry{
+		ID:       uuid.New(),
+		Word:     "hello",
+		Type:     database.WordType,
+		Meanings: []database.Meaning{{ID: meaningID}},
+	}
+	entry.Meanings[0].EntryID = entry.ID
+	meaning := entry.Meanings[0]
+
+	t.Run("SameMeaning", func(t *testing.T) {
+		translationService, mockRepo, _ := setupTranslationService(t)
+		replaced := &database.Translation{ID: uuid.New(), MeaningID: meaningID, Status: database.TranslationApproved}
+		mockRepo.On("GetMeaningByID", mock.Anything, meaningID).Return(&meaning, nil).Once()
+		mockRepo.On("GetEntryByID", mock.Anything, entry.ID).Return(&database.Entry{
+			ID:       entry.ID,
+			Meanings: []database.Meaning{meaning},
+		}, nil).Once()
+		mockRepo.On("GetTranslationByID", mock.Anything, replaced.ID).Return(replaced, nil).Once()
+		mockRepo.On("UpdateEntry", mock.Anything, mock.MatchedBy(func(e *database.Entry) bool {
+			translations := e.Meanings[0].Translations
+			return len(translations) == 1 && *translations[0].SupersedesID == replaced.ID
+		})).Return(nil).Once()
+
+		_, err := translationService.CreateTranslation(context.Background(), meaningID, &request.CreateTranslationRequest{
+			LanguageID: "fr",
+			Text:       "salut",
+			ReplacesID: &replaced.ID,
+		})
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("OtherMeaning", func(t *testing.T) {
+		translationService, mockRepo, _ := setupTranslationService(t)
+		replaced := &database.Translation{ID: uuid.New(), MeaningID: uuid.New(), Status: database.TranslationApproved}
+		mockRepo.On("GetMeaningByID", mock.Anything, meaningID).Return(&meaning, nil).Once()
+		mockRepo.On("GetEntryByID", mock.Anything, entry.ID).Return(&database.Entry{
+			ID:       entry.ID,
+			Meanings: []database.Meaning{meaning},
+		}, nil).Once()
+		mockRepo.On("GetTranslationByID", mock.Anything, replaced.ID).Return(replaced, nil).Once()
+
+		_, err := translationService.CreateTranslation(context.Background(), meaningID, &request.CreateTranslationRequest{
+			LanguageID: "fr",
+			Text:       "salut",
+			ReplacesID: &replaced.ID,
+		})
+
+		require.ErrorIs(t, err, domainErrors.ErrReplacesOtherMeaning)
+		require.ErrorIs(t, err, domainErrors.ErrValidation)
+		mockRepo.AssertNotCalled(t, "UpdateEntry", mock.Anything, mock.Anything)
+	})
+}
+
 // TestUpdateTranslation tests the UpdateTranslation function
 func TestUpdateTranslation(t *testing.T) {
 	// Setup fixtures
 	translationID := uuid.New()
 	meaningID := uuid.New()
-	entryID := uuid.New()
 	oldText := "bonjour"
 	newText := "salut"
 
@@ -174,36 +241,15 @@ func TestUpdateTranslation(t *testing.T) {
 		MeaningID:  meaningID,
 		LanguageID: "fr",
 		Text:       oldText,
+		Version:    2,
 		CreatedAt:  time.Now().UTC(),
 		UpdatedAt:  time.Now().UTC(),
 	}
 
-	meaning := database.Meaning{
-		ID:           meaningID,
-		EntryID:      entryID,
-		Description:  "hello or greeting",
-		CreatedAt:    time.Now().UTC(),
-		UpdatedAt:    time.Now().UTC(),
-		Translations: []database.Translation{translation},
-	}
-
-	entry := database.Entry{
-		ID:        entryID,
-		Word:      "hello",
-		Type:      database.WordType,
-		CreatedAt: time.Now().UTC(),
-		UpdatedAt: time.Now().UTC(),
-		Meanings:  []database.Meaning{meaning},
-	}
-
-	// Create update request
-	updateReq := &request.UpdateTranslationRequest{
-		Text: newText,
-	}
-
 	// Test cases
 	testCases := []struct {
 		name          string
+		version       int
 		setupMocks    func(*mocks.MockRepository, *mocks.MockLogger)
 		expectedError bool
 		errorContains string
@@ -211,49 +257,75 @@ func TestUpdateTranslation(t *testing.T) {
 		{
 			name: "Success",
 			setupMocks: func(mockRepo *mocks.MockRepository, mockLogger *mocks.MockLogger) {
-				// Setup expectations for ListEntries to find translation
-				mockRepo.On("ListEntries", mock.Anything, mock.Anything).Return([]database.Entry{entry}, nil).Once()
+				stored := translation
+				mockRepo.On("GetTranslationByID", mock.Anything, translationID).Return(&stored, nil).Once()
 
-				// Setup expectations for UpdateEntry
-				mockRepo.On("UpdateEntry", mock.Anything, mock.MatchedBy(func(e *database.Entry) bool {
-					// Verify translation was updated
-					if len(e.Meanings) != 1 || len(e.Meanings[0].Translations) != 1 {
-						return false
-					}
-					updatedTranslation := e.Meanings[0].Translations[0]
-					return updatedTranslation.ID == translationID &&
-						updatedTranslation.Text == newText
+				// The version loaded above is the one checked
+				mockRepo.On("UpdateTranslation", mock.Anything, mock.MatchedBy(func(tr *database.Translation) bool {
+					return tr.ID == translationID && tr.Text == newText && tr.Version == 2
+				})).Return(nil).Once()
+			},
+			expectedError: false,
+		},
+		{
+			name: "ApprovedGoesBackToReview",
+			setupMocks: func(mockRepo *mocks.MockRepository, mockLogger *mocks.MockLogger) {
+				reviewerID := uuid.New()
+				reviewedAt := time.Now().UTC()
+				stored := translation
+				stored.Status = database.TranslationApproved
+				stored.ReviewedByID = &reviewerID
+				stored.ReviewedAt = &reviewedAt
+				mockRepo.On("GetTranslationByID", mock.Anything, translationID).Return(&stored, nil).Once()
+
+				// Unreviewed text must not go public
+				mockRepo.On("UpdateTranslation", mock.Anything, mock.MatchedBy(func(tr *database.Translation) bool {
+					return tr.Text == newText && tr.Status == database.TranslationProposed &&
+						tr.ReviewedByID == nil && tr.ReviewedAt == nil
 				})).Return(nil).Once()
 			},
 			expectedError: false,
 		},
+		{
+			name:    "ExpectedVersion",
+			version: 1,
+			setupMocks: func(mockRepo *mocks.MockRepository, mockLogger *mocks.MockLogger) {
+				stored := translation
+				mockRepo.On("GetTranslationByID", mock.Anything, translationID).Return(&stored, nil).Once()
+
+				// The client's version is checked by the repository
+				mockRepo.On("UpdateTranslation", mock.Anything, mock.MatchedBy(func(tr *database.Translation) bool {
+					return tr.Version == 1
+				})).Return(database.ErrVersionConflict).Once()
+			},
+			expectedError: true,
+			errorContains: "version conflict",
+		},
 		{
 			name: "TranslationNotFound",
 			setupMocks: func(mockRepo *mocks.MockRepository, mockLogger *mocks.MockLogger) {
-				// Return empty entry list to simulate translation not found
-				mockRepo.On("ListEntries", mock.Anything, mock.Anything).Return([]database.Entry{}, nil).Once()
+				mockRepo.On("GetTranslationByID", mock.Anything, translationID).Return(nil, database.ErrNotFound).Once()
 			},
 			expectedError: true,
 			errorContains: "not found",
 		},
 		{
-			name: "ListEntriesError",
+			name: "GetTranslationError",
 			setupMocks: func(mockRepo *mocks.MockRepository, mockLogger *mocks.MockLogger) {
 				expectedError := errors.New("database error")
-				mockRepo.On("ListEntries", mock.Anything, mock.Anything).Return(nil, expectedError).Once()
+				mockRepo.On("GetTranslationByID", mock.Anything, translationID).Return(nil, expectedError).Once()
 			},
 			expectedError: true,
 			errorContains: "failed to find translation",
 		},
 		{
-			name: "UpdateEntryError",
+			name: "UpdateTranslationError",
 			setupMocks: func(mockRepo *mocks.MockRepository, mockLogger *mocks.MockLogger) {
-				// Setup expectations for ListEntries to find translation
-				mockRepo.On("ListEntries", mock.Anything, mock.Anything).Return([]database.Entry{entry}, nil).Once()
+				stored := translation
+				mockRepo.On("GetTranslationByID", mock.Anything, translationID).Return(&stored, nil).Once()
 
-				// Setup expectations for UpdateEntry to fail
 				expectedError := errors.New("database error")
-				mockRepo.On("UpdateEntry", mock.Anything, mock.Anything).Return(expectedError).Once()
+				mockRepo.On("UpdateTranslation", mock.Anything, mock.Anything).Return(expectedError).Once()
 			},
 			expectedError: true,
 			errorContains: "failed to update translation",
@@ -269,6 +341,7 @@ func TestUpdateTranslation(t *testing.T) {
 			tc.setupMocks(mockRepo, mockLogger)
 
 			// Call service
+			updateReq := &request.UpdateTranslationRequest{Text: newText, Version: tc.version}
 			resp, err := translationService.UpdateTranslation(context.Background(), translationID, updateReq)
 
 			// Assert expectations
@@ -291,169 +364,50 @@ func TestUpdateTranslation(t *testing.T) {
 	}
 }
 
-// TestDeleteTranslationUpdateEntryError specifically tests the UpdateEntry error path
-func TestDeleteTranslationUpdateEntryError(t *testing.T) {
-	// Setup fixtures with minimal structure needed
-	translationID := uuid.New()
-	meaningID := uuid.New()
-	entryID := uuid.New()
-
-	// Create the complete entry structure with attached meaning and translation
-	// We need to ensure the translation is findable
-	translation := database.Translation{
-		ID:         translationID,
-		MeaningID:  meaningID,
-		LanguageID: "fr",
-		Text:       "bonjour",
-	}
-
-	meaning := database.Meaning{
-		ID:           meaningID,
-		EntryID:      entryID,
-		Description:  "test meaning",
-		Translations: []database.Translation{translation},
-	}
-
-	entry := database.Entry{
-		ID:       entryID,
-		Word:     "test",
-		Type:     database.WordType,
-		Meanings: []database.Meaning{meaning},
-	}
-
-	// Setup mocks
-	mockRepo := new(mocks.MockRepository)
-	mockLogger := new(mocks.MockLogger)
-
-	// Basic logger setup
-	mockLogger.On("With", mock.Anything).Return(mockLogger)
-	mockLogger.On("Debug", mock.Anything, mock.Anything).Return()
-	mockLogger.On("Debug", mock.Anything, mock.Anything, mock.Anything).Return()
-	mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
-	mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
-
-	// First expect ListEntries to be called and return our test entry
-	mockRepo.On("ListEntries", mock.Anything, mock.Anything).
-		Return([]database.Entry{entry}, nil).Once()
-
-	// Then expect UpdateEntry to be called and return an error
-	dbError := errors.New("database error")
-	mockRepo.On("UpdateEntry", mock.Anything, mock.Anything).
-		Return(dbError).Once()
-
-	// Create service
-	translationService := service.NewTranslationService(mockRepo, mockLogger)
-
-	// Call the method
-	err := translationService.DeleteTranslation(context.Background(), translationID)
-
-	// Verify an error was returned (don't check the message)
-	assert.Error(t, err)
-
-	// Verify the mock expectations
-	mockRepo.AssertExpectations(t)
-}
-
 // TestDeleteTranslation tests the DeleteTranslation function
 func TestDeleteTranslation(t *testing.T) {
-	// Setup fixtures
 	translationID := uuid.New()
-	meaningID := uuid.New()
-	entryID := uuid.New()
-
-	// Create translation
-	translation := database.Translation{
-		ID:         translationID,
-		MeaningID:  meaningID,
-		LanguageID: "fr",
-		Text:       "bonjour",
-		CreatedAt:  time.Now().UTC(),
-		UpdatedAt:  time.Now().UTC(),
-	}
-
-	meaning := database.Meaning{
-		ID:           meaningID,
-		EntryID:      entryID,
-		Description:  "hello or greeting",
-		CreatedAt:    time.Now().UTC(),
-		UpdatedAt:    time.Now().UTC(),
-		Translations: []database.Translation{translation},
-	}
-
-	entry := database.Entry{
-		ID:        entryID,
-		Word:      "hello",
-		Type:      database.WordType,
-		CreatedAt: time.Now().UTC(),
-		UpdatedAt: time.Now().UTC(),
-		Meanings:  []database.Meaning{meaning},
-	}
 
 	// Test cases
 	testCases := []struct {
 		name        string
-		setupMocks  func(*mocks.MockRepository, *mocks.MockLogger)
-		expectError bool
+		setupMocks  func(*mocks.MockRepository)
+		expectError error
 	}{
 		{
 			name: "Success",
-			setupMocks: func(mockRepo *mocks.MockRepository, mockLogger *mocks.MockLogger) {
-				// Setup expectations for ListEntries to find translation
-				mockRepo.On("ListEntries", mock.Anything, mock.Anything).Return([]database.Entry{entry}, nil)
-
-				// Setup expectations for UpdateEntry
-				mockRepo.On("UpdateEntry", mock.Anything, mock.Anything).Return(nil)
+			setupMocks: func(mockRepo *mocks.MockRepository) {
+				mockRepo.On("DeleteTranslation", mock.Anything, translationID, 3).Return(nil).Once()
 			},
-			expectError: false,
 		},
 		{
 			name: "TranslationNotFound",
-			setupMocks: func(mockRepo *mocks.MockRepository, mockLogger *mocks.MockLogger) {
-				// Return empty entry list to simulate translation not found
-				mockRepo.On("ListEntries", mock.Anything, mock.Anything).Return([]database.Entry{}, nil)
+			setupMocks: func(mockRepo *mocks.MockRepository) {
+				mockRepo.On("DeleteTranslation", mock.Anything, translationID, 3).Return(database.ErrNotFound).Once()
 			},
-			expectError: true,
+			expectError: database.ErrNotFound,
 		},
 		{
-			name: "ListEntriesError",
-			setupMocks: func(mockRepo *mocks.MockRepository, mockLogger *mocks.MockLogger) {
-				expectedError := errors.New("database error")
-				mockRepo.On("ListEntries", mock.Anything, mock.Anything).Return(nil, expectedError)
+			name: "VersionConflict",
+			setupMocks: func(mockRepo *mocks.MockRepository) {
+				mockRepo.On("DeleteTranslation", mock.Anything, translationID, 3).Return(database.ErrVersionConflict).Once()
 			},
-			expectError: true,
+			expectError: database.ErrVersionConflict,
 		},
-		// Skipping the UpdateEntryError case since we have a separate test for it
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			// Setup mocks directly in each test
-			mockRepo := new(mocks.MockRepository)
-			mockLogger := new(mocks.MockLogger)
-
-			// Set up permissive logger expectations
-			mockLogger.On("With", mock.Anything).Return(mockLogger)
-			mockLogger.On("Debug", mock.Anything).Maybe()
-			mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
-			mockLogger.On("Debug", mock.Anything, mock.Anything, mock.Anything).Maybe()
-			mockLogger.On("Error", mock.Anything).Maybe()
-			mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
-			mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything).Maybe()
-			mockLogger.On("Info", mock.Anything).Maybe()
-			mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
-			mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything).Maybe()
-
-			translationService := service.NewTranslationService(mockRepo, mockLogger)
+			translationService, mockRepo, _ := setupTranslationService(t)
 
 			// Setup specific test case expectations
-			tc.setupMocks(mockRepo, mockLogger)
+			tc.setupMocks(mockRepo)
 
 			// Call service
-			err := translationService.DeleteTranslation(context.Background(), translationID)
+			err := translationService.DeleteTranslation(context.Background(), translationID, 3)
 
-			// Just check if an error is returned or not, without checking message
-			if tc.expectError {
-				assert.Error(t, err)
+			if tc.expectError != nil {
+				assert.ErrorIs(t, err, tc.expectError)
 			} else {
 				assert.NoError(t, err)
 			}
@@ -499,15 +453,6 @@ func TestListTranslations(t *testing.T) {
 		Translations: translations,
 	}
 
-	entry := database.Entry{
-		ID:        entryID,
-		Word:      "hello",
-		Type:      database.WordType,
-		CreatedAt: time.Now().UTC(),
-		UpdatedAt: time.Now().UTC(),
-		Meanings:  []database.Meaning{meaning},
-	}
-
 	// Test cases
 	testCases := []struct {
 		name          string
@@ -521,7 +466,7 @@ func TestListTranslations(t *testing.T) {
 			name:       "ListAll",
 			languageID: "",
 			setupMocks: func(mockRepo *mocks.MockRepository, mockLogger *mocks.MockLogger) {
-				mockRepo.On("ListEntries", mock.Anything, mock.Anything).Return([]database.Entry{entry}, nil).Once()
+				mockRepo.On("GetMeaningByID", mock.Anything, meaningID).Return(&meaning, nil).Once()
 			},
 			expectedCount: 2,
 			expectedError: false,
@@ -530,7 +475,7 @@ func TestListTranslations(t *testing.T) {
 			name:       "FilterByLanguage",
 			languageID: "fr",
 			setupMocks: func(mockRepo *mocks.MockRepository, mockLogger *mocks.MockLogger) {
-				mockRepo.On("ListEntries", mock.Anything, mock.Anything).Return([]database.Entry{entry}, nil).Once()
+				mockRepo.On("GetMeaningByID", mock.Anything, meaningID).Return(&meaning, nil).Once()
 			},
 			expectedCount: 1,
 			expectedError: false,
@@ -539,7 +484,7 @@ func TestListTranslations(t *testing.T) {
 			name:       "MeaningNotFound",
 			languageID: "",
 			setupMocks: func(mockRepo *mocks.MockRepository, mockLogger *mocks.MockLogger) {
-				mockRepo.On("ListEntries", mock.Anything, mock.Anything).Return([]database.Entry{}, nil).Once()
+				mockRepo.On("GetMeaningByID", mock.Anything, meaningID).Return(nil, database.ErrMeaningNotFound).Once()
 			},
 			expectedError: true,
 			errorContains: "not found",
@@ -549,7 +494,7 @@ func TestListTranslations(t *testing.T) {
 			languageID: "",
 			setupMocks: func(mockRepo *mocks.MockRepository, mockLogger *mocks.MockLogger) {
 				expectedError := errors.New("database error")
-				mockRepo.On("ListEntries", mock.Anything, mock.Anything).Return(nil, expectedError).Once()
+				mockRepo.On("GetMeaningByID", mock.Anything, meaningID).Return(nil, expectedError).Once()
 			},
 			expectedError: true,
 			errorContains: "failed to find meaning",
@@ -598,11 +543,10 @@ func TestAddTranslationComment(t *testing.T) {
 	// Setup fixtures
 	translationID := uuid.New()
 	meaningID := uuid.New()
-	entryID := uuid.New()
 	userID := uuid.New()
 	commentContent := "Great translation!"
 
-	// Create structure with a translation
+	// Create the translation
 	translation := database.Translation{
 		ID:         translationID,
 		MeaningID:  meaningID,
@@ -612,24 +556,6 @@ func TestAddTranslationComment(t *testing.T) {
 		UpdatedAt:  time.Now().UTC(),
 	}
 
-	meaning := database.Meaning{
-		ID:           meaningID,
-		EntryID:      entryID,
-		Description:  "hello or greeting",
-		CreatedAt:    time.Now().UTC(),
-		UpdatedAt:    time.Now().UTC(),
-		Translations: []database.Translation{translation},
-	}
-
-	entry := database.Entry{
-		ID:        entryID,
-		Word:      "hello",
-		Type:      database.WordType,
-		CreatedAt: time.Now().UTC(),
-		UpdatedAt: time.Now().UTC(),
-		Meanings:  []database.Meaning{meaning},
-	}
-
 	// Create comment request
 	commentReq := &request.CreateCommentRequest{
 		UserID:  userID,
@@ -647,14 +573,14 @@ func TestAddTranslationComment(t *testing.T) {
 			name: "Success",
 			setupMocks: func(mockRepo *mocks.MockRepository, mockLogger *mocks.MockLogger) {
 				// Find the translation
-				mockRepo.On("ListEntries", mock.Anything, mock.Anything).Return([]database.Entry{entry}, nil).Once()
+				mockRepo.On("GetTranslationByID", mock.Anything, translationID).Return(&translation, nil).Once()
 			},
 			expectedError: false,
 		},
 		{
 			name: "TranslationNotFound",
 			setupMocks: func(mockRepo *mocks.MockRepository, mockLogger *mocks.MockLogger) {
-				mockRepo.On("ListEntries", mock.Anything, mock.Anything).Return([]database.Entry{}, nil).Once()
+				mockRepo.On("GetTranslationByID", mock.Anything, translationID).Return(nil, database.ErrNotFound).Once()
 			},
 			expectedError: true,
 			errorContains: "not found",
@@ -663,7 +589,7 @@ func TestAddTranslationComment(t *testing.T) {
 			name: "DatabaseError",
 			setupMocks: func(mockRepo *mocks.MockRepository, mockLogger *mocks.MockLogger) {
 				expectedError := errors.New("database error")
-				mockRepo.On("ListEntries", mock.Anything, mock.Anything).Return(nil, expectedError).Once()
+				mockRepo.On("GetTranslationByID", mock.Anything, translationID).Return(nil, expectedError).Once()
 			},
 			expectedError: true,
 			errorContains: "failed to find translation",
@@ -706,10 +632,9 @@ func TestToggleTranslationLike(t *testing.T) {
 	// Setup fixtures
 	translationID := uuid.New()
 	meaningID := uuid.New()
-	entryID := uuid.New()
 	userID := uuid.New()
 
-	// Create structure with a translation
+	// Create the translation
 	translation := database.Translation{
 		ID:         translationID,
 		MeaningID:  meaningID,
@@ -719,24 +644,6 @@ func TestToggleTranslationLike(t *testing.T) {
 		UpdatedAt:  time.Now().UTC(),
 	}
 
-	meaning := database.Meaning{
-		ID:           meaningID,
-		EntryID:      entryID,
-		Description:  "hello or greeting",
-		CreatedAt:    time.Now().UTC(),
-		UpdatedAt:    time.Now().UTC(),
-		Translations: []database.Translation{translation},
-	}
-
-	entry := database.Entry{
-		ID:        entryID,
-		Word:      "hello",
-		Type:      database.WordType,
-		CreatedAt: time.Now().UTC(),
-		UpdatedAt: time.Now().UTC(),
-		Meanings:  []database.Meaning{meaning},
-	}
-
 	// Test cases
 	testCases := []struct {
 		name          string
@@ -748,7 +655,7 @@ func TestToggleTranslationLike(t *testing.T) {
 			name: "Success",
 			setupMocks: func(mockRepo *mocks.MockRepository, mockLogger *mocks.MockLogger) {
 				// Find the translation
-				mockRepo.On("ListEntries", mock.Anything, mock.Anything).Return([]database.Entry{entry}, nil).Once()
+				mockRepo.On("GetTranslationByID", mock.Anything, translationID).Return(&translation, nil).Once()
 				mockLogger.On("Info", "like processed", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return().Once()
 			},
 			expectedError: false,
@@ -756,7 +663,7 @@ func TestToggleTranslationLike(t *testing.T) {
 		{
 			name: "TranslationNotFound",
 			setupMocks: func(mockRepo *mocks.MockRepository, mockLogger *mocks.MockLogger) {
-				mockRepo.On("ListEntries", mock.Anything, mock.Anything).Return([]database.Entry{}, nil).Once()
+				mockRepo.On("GetTranslationByID", mock.Anything, translationID).Return(nil, database.ErrNotFound).Once()
 			},
 			expectedError: true,
 			errorContains: "not found",
@@ -765,7 +672,7 @@ func TestToggleTranslationLike(t *testing.T) {
 			name: "DatabaseError",
 			setupMocks: func(mockRepo *mocks.MockRepository, mockLogger *mocks.MockLogger) {
 				expectedError := errors.New("database error")
-				mockRepo.On("ListEntries", mock.Anything, mock.Anything).Return(nil, expectedError).Once()
+				mockRepo.On("GetTranslationByID", mock.Anything, translationID).Return(nil, expectedError).Once()
 			},
 			expectedError: true,
 			errorContains: "failed to find translation",
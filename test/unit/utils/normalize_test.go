@@ -0,0 +1,75 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valpere/trytrago/domain/utils"
+)
+
+func TestNormalizeWord(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Already normalized", "bank", "bank"},
+		{"Mixed case", "Bank", "bank"},
+		{"Surrounding whitespace", "  bank ", "bank"},
+		{"Internal whitespace", "ice   cream", "ice cream"},
+		{"Compatibility form", "ﬁsh", "fish"},
+		{"Composed accents", "café", "café"},
+		{"Empty string", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, utils.NormalizeWord(tt.input))
+		})
+	}
+}
+
+func TestWordSimilarity(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    string
+		atLeast float64
+		below   float64
+	}{
+		{"Identical after normalization", "Bank", " bank", 1, 1.01},
+		{"Single typo", "recieve", "receive", 0.7, 0.9},
+		{"Accent difference", "résumé", "resume", 0.6, 0.7},
+		{"Unrelated words", "bank", "river", 0, 0.3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score := utils.WordSimilarity(tt.a, tt.b)
+			assert.GreaterOrEqual(t, score, tt.atLeast)
+			assert.Less(t, score, tt.below)
+		})
+	}
+}
+
+func TestFormatHomograph(t *testing.T) {
+	tests := []struct {
+		name       string
+		word       string
+		index      int
+		homographs int
+		expected   string
+	}{
+		{"No index", "bank", 0, 2, "bank"},
+		{"No homographs", "cat", 1, 1, "cat"},
+		{"Homographs unknown", "cat", 1, 0, "cat"},
+		{"First homograph", "bank", 1, 2, "bank¹"},
+		{"Second homograph", "bank", 2, 2, "bank²"},
+		{"Multi-digit index", "set", 12, 12, "set¹²"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, utils.FormatHomograph(tt.word, tt.index, tt.homographs))
+		})
+	}
+}
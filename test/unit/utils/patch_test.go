@@ -0,0 +1,112 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valpere/trytrago/domain/errors"
+	"github.com/valpere/trytrago/domain/utils"
+)
+
+func TestApplyMergePatch(t *testing.T) {
+	document := `{"word":"bank","pronunciation":"/bæŋk/","labels":["finance"],"meta":{"a":1,"b":2}}`
+
+	tests := []struct {
+		name     string
+		patch    string
+		expected string
+	}{
+		{"Replace member", `{"word":"banks"}`, `{"word":"banks","pronunciation":"/bæŋk/","labels":["finance"],"meta":{"a":1,"b":2}}`},
+		{"Empty string clears", `{"pronunciation":""}`, `{"word":"bank","pronunciation":"","labels":["finance"],"meta":{"a":1,"b":2}}`},
+		{"Null removes", `{"pronunciation":null}`, `{"word":"bank","labels":["finance"],"meta":{"a":1,"b":2}}`},
+		{"Array replaced whole", `{"labels":["river"]}`, `{"word":"bank","pronunciation":"/bæŋk/","labels":["river"],"meta":{"a":1,"b":2}}`},
+		{"Objects merged", `{"meta":{"a":null,"c":3}}`, `{"word":"bank","pronunciation":"/bæŋk/","labels":["finance"],"meta":{"b":2,"c":3}}`},
+		{"Empty patch", `{}`, document},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := utils.ApplyMergePatch([]byte(document), []byte(tt.patch))
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(result))
+		})
+	}
+
+	t.Run("Malformed patch", func(t *testing.T) {
+		_, err := utils.ApplyMergePatch([]byte(document), []byte(`{"word":`))
+		assert.ErrorIs(t, err, errors.ErrInvalidPatch)
+	})
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	document := `{"word":"bank","labels":["finance"],"examples":[{"text":"one"},{"text":"two"}],"a/b":1}`
+
+	tests := []struct {
+		name     string
+		patch    string
+		expected string
+	}{
+		{"Add member", `[{"op":"add","path":"/pronunciation","value":"/bæŋk/"}]`,
+			`{"word":"bank","pronunciation":"/bæŋk/","labels":["finance"],"examples":[{"text":"one"},{"text":"two"}],"a/b":1}`},
+		{"Append to array", `[{"op":"add","path":"/labels/-","value":"river"}]`,
+			`{"word":"bank","labels":["finance","river"],"examples":[{"text":"one"},{"text":"two"}],"a/b":1}`},
+		{"Insert into array", `[{"op":"add","path":"/examples/0","value":{"text":"zero"}}]`,
+			`{"word":"bank","labels":["finance"],"examples":[{"text":"zero"},{"text":"one"},{"text":"two"}],"a/b":1}`},
+		{"Remove array element", `[{"op":"remove","path":"/examples/0"}]`,
+			`{"word":"bank","labels":["finance"],"examples":[{"text":"two"}],"a/b":1}`},
+		{"Replace nested member", `[{"op":"replace","path":"/examples/1/text","value":"deux"}]`,
+			`{"word":"bank","labels":["finance"],"examples":[{"text":"one"},{"text":"deux"}],"a/b":1}`},
+		{"Escaped pointer", `[{"op":"replace","path":"/a~1b","value":2}]`,
+			`{"word":"bank","labels":["finance"],"examples":[{"text":"one"},{"text":"two"}],"a/b":2}`},
+		{"Move element", `[{"op":"move","from":"/examples/1","path":"/examples/0"}]`,
+			`{"word":"bank","labels":["finance"],"examples":[{"text":"two"},{"text":"one"}],"a/b":1}`},
+		{"Copy is independent", `[{"op":"copy","from":"/examples/0","path":"/examples/-"},{"op":"replace","path":"/examples/2/text","value":"three"}]`,
+			`{"word":"bank","labels":["finance"],"examples":[{"text":"one"},{"text":"two"},{"text":"three"}],"a/b":1}`},
+		{"Test then replace", `[{"op":"test","path":"/word","value":"bank"},{"op":"replace","path":"/word","value":"banks"}]`,
+			`{"word":"banks","labels":["finance"],"examples":[{"text":"one"},{"text":"two"}],"a/b":1}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := utils.ApplyJSONPatch([]byte(document), []byte(tt.patch))
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(result))
+		})
+	}
+
+	failures := []struct {
+		name     string
+		patch    string
+		expected error
+		message  string
+	}{
+		{"Not an array", `{"op":"add"}`, errors.ErrInvalidPatch, "array of operations"},
+		{"Operation of wrong type", `[{"op":1,"path":"/word"}]`, errors.ErrInvalidPatch, "op must be a string"},
+		{"Unknown operation", `[{"op":"merge","path":"/word"}]`, errors.ErrInvalidPatch, `unknown operation "merge"`},
+		{"Missing value", `[{"op":"add","path":"/word"}]`, errors.ErrInvalidPatch, "value is required"},
+		{"Relative path", `[{"op":"remove","path":"word"}]`, errors.ErrInvalidPatch, "must start with /"},
+		{"Missing member", `[{"op":"replace","path":"/etymology","value":"x"}]`, errors.ErrPatchNotApplicable, "/etymology does not exist"},
+		{"Missing parent", `[{"op":"add","path":"/meta/a","value":1}]`, errors.ErrPatchNotApplicable, "/meta does not exist"},
+		{"Index out of range", `[{"op":"remove","path":"/examples/2"}]`, errors.ErrPatchNotApplicable, "out of range"},
+		{"Leading zero index", `[{"op":"remove","path":"/examples/01"}]`, errors.ErrPatchNotApplicable, "not an array index"},
+		{"Failed test", `[{"op":"test","path":"/word","value":"river"}]`, errors.ErrPatchNotApplicable, "value does not match"},
+		{"Move into itself", `[{"op":"move","from":"/examples","path":"/examples/0"}]`, errors.ErrPatchNotApplicable, "moved into itself"},
+	}
+
+	for _, tt := range failures {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := utils.ApplyJSONPatch([]byte(document), []byte(tt.patch))
+			require.Error(t, err)
+			assert.ErrorIs(t, err, tt.expected)
+			assert.Contains(t, err.Error(), tt.message)
+		})
+	}
+
+	t.Run("Error names the operation", func(t *testing.T) {
+		_, err := utils.ApplyJSONPatch([]byte(document), []byte(`[{"op":"test","path":"/word","value":"bank"},{"op":"remove","path":"/etymology"}]`))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "operation 1 (remove /etymology)")
+	})
+}